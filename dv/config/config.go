@@ -7,6 +7,7 @@ import (
 
 	enc "github.com/named-data/ndnd/std/encoding"
 	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	"github.com/named-data/ndnd/std/utils/metrics"
 )
 
 // CostInfinity is the maximum cost to a router.
@@ -41,6 +42,8 @@ type Config struct {
 	TrustAnchors []string `json:"trust_anchors"`
 	// List of permanent neighbors.
 	Neighbors []Neighbor `json:"neighbors"`
+	// Prometheus metrics HTTP endpoint.
+	Metrics metrics.Config `json:"metrics"`
 
 	// Parsed Global Prefix
 	networkNameN enc.Name
@@ -82,6 +85,10 @@ func DefaultConfig() *Config {
 		AdvertisementSyncInterval_ms: 5000,
 		RouterDeadInterval_ms:        30000,
 		KeyChainUri:                  "undefined",
+		Metrics: metrics.Config{
+			Enabled: false,
+			Address: "127.0.0.1:9102",
+		},
 	}
 }
 
@@ -128,6 +135,11 @@ func (c *Config) Parse() (err error) {
 		return fmt.Errorf("RouterDeadInterval must be at least 2*AdvertisementSyncInterval")
 	}
 
+	// Validate metrics endpoint
+	if c.Metrics.Enabled && c.Metrics.Address == "" {
+		return fmt.Errorf("metrics address must be set if metrics are enabled")
+	}
+
 	// Validate trust anchors
 	c.trustAnchorsN = make([]enc.Name, 0, len(c.TrustAnchors))
 	for _, anchor := range c.TrustAnchors {
@@ -0,0 +1,68 @@
+package dv
+
+import (
+	"github.com/named-data/ndnd/std/log"
+	"github.com/named-data/ndnd/std/utils"
+	"github.com/named-data/ndnd/std/utils/metrics"
+)
+
+// Start the metrics endpoint if enabled in the configuration.
+func (dv *Router) startMetrics() {
+	if !dv.config.Metrics.Enabled {
+		return
+	}
+
+	dv.metrics = metrics.NewServer(dv.config.Metrics.Address, dv.collectMetrics)
+	if err := dv.metrics.Start(); err != nil {
+		log.Error(dv, "Unable to start metrics server", "address", dv.config.Metrics.Address, "err", err)
+		dv.metrics = nil
+		return
+	}
+	log.Info(dv, "Serving metrics", "url", dv.metrics.URL())
+}
+
+// Stop the metrics endpoint.
+func (dv *Router) stopMetrics() {
+	if dv.metrics != nil {
+		dv.metrics.Stop()
+	}
+}
+
+// collectMetrics returns the current router metrics.
+func (dv *Router) collectMetrics() []*metrics.Family {
+	dv.mutex.Lock()
+	defer dv.mutex.Unlock()
+
+	info := metrics.NewGauge("ndnd_dv_info", "DV router version and names.").
+		Add(1, "version", utils.NDNdVersion,
+			"network", dv.config.NetworkName().String(),
+			"router", dv.config.RouterName().String())
+	ribEntries := metrics.NewGauge("ndnd_dv_rib_entries", "Number of routers in the RIB.").
+		Add(float64(dv.rib.Size()))
+	fibEntries := metrics.NewGauge("ndnd_dv_fib_entries", "Number of FIB entries installed by the router.").
+		Add(float64(dv.fib.Size()))
+	neighbors := metrics.NewGauge("ndnd_dv_neighbors", "Number of neighbors.").
+		Add(float64(dv.neighbors.Size()))
+
+	// Neighbor liveness
+	up := metrics.NewGauge("ndnd_dv_neighbor_up", "Whether the neighbor was seen within the router dead interval.")
+	lastSeen := metrics.NewGauge("ndnd_dv_neighbor_last_seen_timestamp_seconds", "Time of the last sync Interest from the neighbor.")
+	face := metrics.NewGauge("ndnd_dv_neighbor_face", "Face ID of the neighbor, or zero if unknown.")
+
+	for _, ns := range dv.neighbors.GetAll() {
+		name := ns.Name.String()
+
+		alive := 0.0
+		if !ns.IsDead() {
+			alive = 1.0
+		}
+		up.Add(alive, "neighbor", name)
+		lastSeen.Add(float64(ns.LastSeen().UnixMilli())/1000, "neighbor", name)
+		face.Add(float64(ns.FaceId()), "neighbor", name)
+	}
+
+	return []*metrics.Family{
+		info, ribEntries, fibEntries, neighbors,
+		up, lastSeen, face,
+	}
+}
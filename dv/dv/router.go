@@ -19,6 +19,7 @@ import (
 	ndn_sync "github.com/named-data/ndnd/std/sync"
 	"github.com/named-data/ndnd/std/types/optional"
 	"github.com/named-data/ndnd/std/utils"
+	"github.com/named-data/ndnd/std/utils/metrics"
 )
 
 const PrefixSnapThreshold = 50
@@ -60,6 +61,9 @@ type Router struct {
 	rib *table.Rib
 	// forwarding table
 	fib *table.Fib
+
+	// metrics endpoint
+	metrics *metrics.Server
 }
 
 // Create a new DV router.
@@ -177,6 +181,10 @@ func (dv *Router) Start() (err error) {
 	// Initialize prefix table
 	dv.pfx.Reset()
 
+	// Start metrics endpoint
+	dv.startMetrics()
+	defer dv.stopMetrics()
+
 	for {
 		select {
 		case <-dv.heartbeat.C:
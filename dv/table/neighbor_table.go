@@ -110,6 +110,16 @@ func (ns *NeighborState) IsDead() bool {
 	return time.Since(ns.lastSeen) > ns.nt.config.RouterDeadInterval()
 }
 
+// LastSeen returns the time of the last sync Interest from the neighbor.
+func (ns *NeighborState) LastSeen() time.Time {
+	return ns.lastSeen
+}
+
+// FaceId returns the latest known face ID of the neighbor, or zero if unknown.
+func (ns *NeighborState) FaceId() uint64 {
+	return ns.faceId
+}
+
 // Call this when a ping is received from a face.
 // This will automatically register the face route with the neighbor
 // and update the last seen time for the neighbor.
@@ -1,9 +1,71 @@
 import json
 import os
+import re
 import shutil
 
 from minindn.apps.application import Application
 
+def _duration_ms(value):
+    # 中文说明：把 2s / 500ms / 1m 这类时长转换为毫秒；纯数字视为毫秒。
+    m = re.fullmatch(r'\s*([0-9.]+)\s*(ms|s|m|h)?\s*', value)
+    if not m:
+        raise ValueError(f'invalid duration: {value}')
+    scale = {None: 1, 'ms': 1, 's': 1000, 'm': 60000, 'h': 3600000}[m.group(2)]
+    return int(float(m.group(1)) * scale)
+
+def _flag(value):
+    return value.strip().lower() in ('1', 'true', 'yes', 'on')
+
+def _cs_audit_config(node, homeDir):
+    # 中文说明：审计与 SEU 通过 yanfd 配置 tables.content_store.audit / seu 开启。
+    # 为了方便实验，仍可在宿主机上用 NDND_CS_* 环境变量指定取值，这里转换成每个节点的配置项。
+    audit = {}
+    repair = {}
+    seu = {}
+    mapping = (
+        ('NDND_CS_AUDIT_INTERVAL', audit, 'interval', _duration_ms),
+        ('NDND_CS_AUDIT_LOG', audit, 'log', _flag),
+        ('NDND_CS_AUDIT_TAG_SCHEME', audit, 'tag_scheme', str),
+        ('NDND_CS_AUDIT_KEYCHAIN', audit, 'keychain', str),
+        ('NDND_CS_AUDIT_KEY_NAME', audit, 'key_name', str),
+        ('NDND_CS_AUDIT_SAMPLE_K', audit, 'sample_k', int),
+        ('NDND_CS_AUDIT_SAMPLE_MODE', audit, 'sample_mode', str),
+        ('NDND_CS_AUDIT_SAMPLE_DEPTH', audit, 'sample_depth', int),
+        ('NDND_CS_AUDIT_BATCH', audit, 'batch', int),
+        ('NDND_CS_AUDIT_RECONCILE_DELAY', audit, 'reconcile_delay', _duration_ms),
+        ('NDND_CS_AUDIT_REPAIR', repair, 'enabled', _flag),
+        ('NDND_CS_AUDIT_REPAIR_SOURCE', repair, 'source', str),
+        ('NDND_CS_AUDIT_REPAIR_TIMEOUT', repair, 'timeout', _duration_ms),
+        ('NDND_CS_SEU_ENABLE', seu, 'enabled', _flag),
+        ('NDND_CS_SEU_RATE_PER_BIT_PER_DAY', seu, 'rate_per_bit_per_day', float),
+        ('NDND_CS_SEU_PREFIX', seu, 'prefix', str),
+        ('NDND_CS_SEU_LOG', seu, 'log', _flag),
+        ('NDND_CS_SEU_MODEL', seu, 'model', str),
+        ('NDND_CS_SEU_REGION', seu, 'region', str),
+        ('NDND_CS_SEU_BITS', seu, 'bits', int),
+        ('NDND_CS_SEU_BURST_SPAN', seu, 'burst_span', int),
+        ('NDND_CS_SEU_SEED', seu, 'seed', int),
+    )
+    for env, section, key, conv in mapping:
+        v = os.environ.get(env)
+        if v is not None:
+            section[key] = conv(v)
+    # 审计日志目录必须按节点区分（各节点共享文件系统），设置任意非空值即启用。
+    if os.environ.get('NDND_CS_AUDIT_JOURNAL'):
+        audit['journal'] = f'{homeDir}/cs-audit-journal'
+    # 故障日志同样按节点区分。
+    if os.environ.get('NDND_CS_SEU_FAULT_LOG'):
+        seu['fault_log'] = f'{homeDir}/cs-seu-faults.jsonl'
+    if repair:
+        audit['repair'] = repair
+    # 每个节点以 /minindn/<node> 作为远程审计挑战前缀（与 DV 路由器名一致）。
+    # 远程挑战要求审计密钥来自 keychain，未配置 keychain 时不开启。
+    if audit.get('keychain'):
+        audit['node_prefix'] = f'/minindn/{node.name}'
+    # 远程挑战默认不验证签名（仍要求 signed Interest），方便实验；可用环境变量指定 keychain。
+    audit['auditors'] = {'keychain': os.environ.get('NDND_CS_AUDIT_AUDITORS_KEYCHAIN', 'insecure')}
+    return {'audit': audit, 'seu': seu}
+
 class NDNd_FW(Application):
     def __init__(self, node, config={}, logLevel='INFO', threads=None):
         Application.__init__(self, node)
@@ -34,12 +96,6 @@ class NDNd_FW(Application):
         self.envDict = {
             'GOMAXPROCS': str(threads),
         }
-        # 中文说明：把审计相关环境变量透传到 Mininet 节点内运行的 ndnd 进程。
-        for k in ('NDND_CS_AUDIT_INTERVAL', 'NDND_CS_AUDIT_LOG'):
-            v = os.environ.get(k)
-            if v is not None:
-                self.envDict[k] = v
-
         # Ensure the unix socket directory exists (shared FS, but required for binding).
         self.node.cmd('mkdir -p /run/nfd')
         self.node.cmd(f'rm -f {self.sockFile}')
@@ -64,6 +120,9 @@ class NDNd_FW(Application):
             'fw': {
                 'threads': threads,
             },
+            'tables': {
+                'content_store': _cs_audit_config(node, self.homeDir),
+            },
         }
 
         # Write YaNFD config file
@@ -33,6 +33,7 @@ func init() {
 // (AI GENERATED DESCRIPTION): Starts a YaNFD instance with the supplied configuration file and runs it until an interrupt or SIGTERM is received, at which point it logs the signal and gracefully stops the daemon.
 func run(cmd *cobra.Command, args []string) {
 	configfile := args[0]
+	config.Core.ConfigFile = configfile
 	config.Core.BaseDir = filepath.Dir(configfile)
 
 	// read configuration file
@@ -43,10 +44,21 @@ func run(cmd *cobra.Command, args []string) {
 	yanfd.Start()
 
 	// set up signal handler channel and wait for interrupt
+	// SIGHUP reloads the configuration file
 	sigChannel := make(chan os.Signal, 1)
-	signal.Notify(sigChannel, os.Interrupt, syscall.SIGTERM)
-	receivedSig := <-sigChannel
-	core.Log.Info(yanfd, "Received signal - exit", "signal", receivedSig)
+	signal.Notify(sigChannel, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	for receivedSig := range sigChannel {
+		if receivedSig == syscall.SIGHUP {
+			core.Log.Info(yanfd, "Received signal - reload", "signal", receivedSig)
+			if err := yanfd.Reload(); err != nil {
+				core.Log.Error(yanfd, "Unable to reload configuration", "err", err)
+			}
+			continue
+		}
+
+		core.Log.Info(yanfd, "Received signal - exit", "signal", receivedSig)
+		break
+	}
 
 	yanfd.Stop()
 }
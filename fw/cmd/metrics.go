@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/dispatch"
+	"github.com/named-data/ndnd/fw/face"
+	"github.com/named-data/ndnd/fw/fw"
+	"github.com/named-data/ndnd/fw/table"
+	"github.com/named-data/ndnd/std/utils"
+	"github.com/named-data/ndnd/std/utils/metrics"
+)
+
+// Metrics serves forwarder counters to Prometheus over HTTP.
+type Metrics struct {
+	config *core.Config
+	server *metrics.Server
+}
+
+// NewMetrics creates the metrics endpoint of the forwarder.
+func NewMetrics(config *core.Config) *Metrics {
+	return &Metrics{config: config}
+}
+
+// Log identifier for the metrics endpoint.
+func (m *Metrics) String() string {
+	return "metrics"
+}
+
+// Start serves metrics if enabled in the configuration.
+func (m *Metrics) Start() {
+	if !m.config.Core.Metrics.Enabled {
+		return
+	}
+
+	m.server = metrics.NewServer(m.config.Core.Metrics.Address, m.collect)
+	if err := m.server.Start(); err != nil {
+		core.Log.Error(m, "Unable to start metrics server", "address", m.config.Core.Metrics.Address, "err", err)
+		m.server = nil
+		return
+	}
+	core.Log.Info(m, "Serving metrics", "url", m.server.URL())
+}
+
+// Stop closes the metrics endpoint.
+func (m *Metrics) Stop() {
+	if m.server != nil {
+		m.server.Stop()
+	}
+}
+
+// collect returns the current forwarder metrics.
+func (m *Metrics) collect() []*metrics.Family {
+	info := metrics.NewGauge("ndnd_fw_info", "Forwarder version.").
+		Add(1, "version", utils.NDNdVersion)
+	uptime := metrics.NewGauge("ndnd_fw_uptime_seconds", "Time since the forwarder was started.").
+		Add(time.Since(core.StartTimestamp).Seconds())
+	fibEntries := metrics.NewGauge("ndnd_fw_fib_entries", "Number of FIB entries.").
+		Add(float64(table.FibStrategyTable.GetNumFIBEntries()))
+	ribEntries := metrics.NewGauge("ndnd_fw_rib_entries", "Number of RIB entries.").
+		Add(float64(len(table.Rib.GetAllEntries())))
+	csCapacity := metrics.NewGauge("ndnd_fw_cs_capacity", "Capacity of the Content Store of each forwarding thread.").
+		Add(float64(table.CfgCsCapacity()))
+
+	// Forwarding thread counters
+	pitEntries := metrics.NewGauge("ndnd_fw_pit_entries", "Number of PIT entries.")
+	csEntries := metrics.NewGauge("ndnd_fw_cs_entries", "Number of Content Store entries.")
+	dnlEntries := metrics.NewGauge("ndnd_fw_dnl_entries", "Number of Dead Nonce List entries.")
+	inInterests := metrics.NewCounter("ndnd_fw_in_interests_total", "Number of incoming Interests.")
+	inData := metrics.NewCounter("ndnd_fw_in_data_total", "Number of incoming Data packets.")
+	inNacks := metrics.NewCounter("ndnd_fw_in_nacks_total", "Number of incoming Nacks.")
+	outInterests := metrics.NewCounter("ndnd_fw_out_interests_total", "Number of outgoing Interests.")
+	outData := metrics.NewCounter("ndnd_fw_out_data_total", "Number of outgoing Data packets.")
+	outNacks := metrics.NewCounter("ndnd_fw_out_nacks_total", "Number of outgoing Nacks.")
+	satisfied := metrics.NewCounter("ndnd_fw_satisfied_interests_total", "Number of satisfied Interests.")
+	unsatisfied := metrics.NewCounter("ndnd_fw_unsatisfied_interests_total", "Number of Interests that expired unsatisfied.")
+	csHits := metrics.NewCounter("ndnd_fw_cs_hits_total", "Number of Content Store hits.")
+	csMisses := metrics.NewCounter("ndnd_fw_cs_misses_total", "Number of Content Store misses.")
+
+	for i := range fw.CfgNumThreads() {
+		thread := dispatch.GetFWThread(i)
+		if thread == nil {
+			continue // not started yet
+		}
+		counters := thread.Counters()
+		id := strconv.Itoa(i)
+
+		pitEntries.Add(float64(counters.NPitEntries), "thread", id)
+		csEntries.Add(float64(counters.NCsEntries), "thread", id)
+		dnlEntries.Add(float64(counters.NDnlEntries), "thread", id)
+		inInterests.Add(float64(counters.NInInterests), "thread", id)
+		inData.Add(float64(counters.NInData), "thread", id)
+		inNacks.Add(float64(counters.NInNacks), "thread", id)
+		outInterests.Add(float64(counters.NOutInterests), "thread", id)
+		outData.Add(float64(counters.NOutData), "thread", id)
+		outNacks.Add(float64(counters.NOutNacks), "thread", id)
+		satisfied.Add(float64(counters.NSatisfiedInterests), "thread", id)
+		unsatisfied.Add(float64(counters.NUnsatisfiedInterests), "thread", id)
+		csHits.Add(float64(counters.NCsHits), "thread", id)
+		csMisses.Add(float64(counters.NCsMisses), "thread", id)
+	}
+
+	// Face counters
+	faces := metrics.NewGauge("ndnd_face_info", "Faces in the face table.")
+	faceInInterests := metrics.NewCounter("ndnd_face_in_interests_total", "Number of Interests received on the face.")
+	faceInData := metrics.NewCounter("ndnd_face_in_data_total", "Number of Data packets received on the face.")
+	faceInNacks := metrics.NewCounter("ndnd_face_in_nacks_total", "Number of Nacks received on the face.")
+	faceInBytes := metrics.NewCounter("ndnd_face_in_bytes_total", "Number of link-layer bytes received on the face.")
+	faceOutInterests := metrics.NewCounter("ndnd_face_out_interests_total", "Number of Interests sent on the face.")
+	faceOutData := metrics.NewCounter("ndnd_face_out_data_total", "Number of Data packets sent on the face.")
+	faceOutNacks := metrics.NewCounter("ndnd_face_out_nacks_total", "Number of Nacks sent on the face.")
+	faceOutBytes := metrics.NewCounter("ndnd_face_out_bytes_total", "Number of link-layer bytes sent on the face.")
+	faceOutDropped := metrics.NewCounter("ndnd_face_out_dropped_total", "Number of packets dropped because the send queue was full.")
+	faceOutShaped := metrics.NewCounter("ndnd_face_out_shaped_total", "Number of frames delayed by the egress rate limit.")
+	faceLpInvalid := metrics.NewCounter("ndnd_face_in_lp_invalid_total", "Number of invalid NDNLPv2 frames received on the face.")
+	faceReassemblyTimeouts := metrics.NewCounter("ndnd_face_reassembly_timeouts_total", "Number of incomplete fragmented packets dropped on timeout.")
+	faceReassemblyEvictions := metrics.NewCounter("ndnd_face_reassembly_evictions_total", "Number of incomplete fragmented packets dropped because the reassembly buffer was full.")
+
+	allFaces := face.FaceTable.GetAll()
+	slices.SortFunc(allFaces, func(a, b face.LinkService) int {
+		return cmp.Compare(a.FaceID(), b.FaceID())
+	})
+	for _, f := range allFaces {
+		id := strconv.FormatUint(f.FaceID(), 10)
+
+		faces.Add(1, "face", id,
+			"remote", f.RemoteURI().String(),
+			"local", f.LocalURI().String(),
+			"persistency", f.Persistency().String())
+		faceInInterests.Add(float64(f.NInInterests()), "face", id)
+		faceInData.Add(float64(f.NInData()), "face", id)
+		faceInNacks.Add(float64(f.NInNacks()), "face", id)
+		faceInBytes.Add(float64(f.NInBytes()), "face", id)
+		faceOutInterests.Add(float64(f.NOutInterests()), "face", id)
+		faceOutData.Add(float64(f.NOutData()), "face", id)
+		faceOutNacks.Add(float64(f.NOutNacks()), "face", id)
+		faceOutBytes.Add(float64(f.NOutBytes()), "face", id)
+
+		if ls, ok := f.(*face.NDNLPLinkService); ok {
+			faceOutDropped.Add(float64(ls.NOutDropped()), "face", id)
+			faceOutShaped.Add(float64(ls.NOutShaped()), "face", id)
+			faceLpInvalid.Add(float64(ls.NInLpInvalid()), "face", id)
+			faceReassemblyTimeouts.Add(float64(ls.NReassemblyTimeouts()), "face", id)
+			faceReassemblyEvictions.Add(float64(ls.NReassemblyEvictions()), "face", id)
+		}
+	}
+
+	return []*metrics.Family{
+		info, uptime, fibEntries, ribEntries, csCapacity,
+		pitEntries, csEntries, dnlEntries,
+		inInterests, inData, inNacks, outInterests, outData, outNacks,
+		satisfied, unsatisfied, csHits, csMisses,
+		faces,
+		faceInInterests, faceInData, faceInNacks, faceInBytes,
+		faceOutInterests, faceOutData, faceOutNacks, faceOutBytes,
+		faceOutDropped, faceOutShaped, faceLpInvalid, faceReassemblyTimeouts,
+		faceReassemblyEvictions,
+	}
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/table"
+	"github.com/named-data/ndnd/std/log"
+	"github.com/named-data/ndnd/std/utils/toolutils"
+)
+
+// Configuration options that can be changed without restarting the forwarder.
+// Changes to any other option are rejected on reload.
+var reloadableOptions = []string{
+	"core.log_level",
+	"faces.udp.enabled_unicast",
+	"faces.tcp.enabled",
+	"faces.unix.enabled",
+	"faces.websocket.enabled",
+	"faces.http3.enabled",
+	"faces.ethernet.enabled",
+	"tables.content_store.capacity",
+	"tables.content_store.admit",
+	"tables.content_store.serve",
+	"tables.dead_nonce_list.lifetime",
+	"tables.network_region.regions",
+}
+
+// Reload re-reads the configuration file and applies the options that changed.
+// The configuration is rejected as a whole if an option that requires
+// a restart has changed, or if an option is invalid.
+func (y *YaNFD) Reload() error {
+	y.reloadMutex.Lock()
+	defer y.reloadMutex.Unlock()
+
+	// Options that are not in the configuration file are kept
+	c := core.DefaultConfig()
+	c.Core.ConfigFile = y.config.Core.ConfigFile
+	c.Core.BaseDir = y.config.Core.BaseDir
+	c.Core.CpuProfile = y.config.Core.CpuProfile
+	c.Core.MemProfile = y.config.Core.MemProfile
+	c.Core.BlockProfile = y.config.Core.BlockProfile
+
+	if c.Core.ConfigFile == "" {
+		return fmt.Errorf("forwarder was not started from a configuration file")
+	}
+	if err := toolutils.ParseYaml(c, c.Core.ConfigFile); err != nil {
+		return err
+	}
+
+	changed := y.config.Diff(c)
+	unsafe := make([]string, 0)
+	for _, option := range changed {
+		if !slices.Contains(reloadableOptions, option) {
+			unsafe = append(unsafe, option)
+		}
+	}
+	if len(unsafe) > 0 {
+		return fmt.Errorf("changing %s requires a restart", strings.Join(unsafe, ", "))
+	}
+	if len(changed) == 0 {
+		core.Log.Info(y, "Configuration is unchanged")
+		return nil
+	}
+
+	// Validate all options before applying any
+	if _, err := log.ParseLevel(c.Core.LogLevel); err != nil {
+		return fmt.Errorf("invalid core.log_level: %w", err)
+	}
+	regions, err := table.ParseNetworkRegions(c.Tables.NetworkRegion.Regions)
+	if err != nil {
+		return fmt.Errorf("invalid tables.network_region.regions: %w", err)
+	}
+	if c.Tables.DeadNonceList.Lifetime <= 0 {
+		return fmt.Errorf("invalid tables.dead_nonce_list.lifetime: must be positive")
+	}
+
+	// Apply changed options
+	for _, option := range changed {
+		switch option {
+		case "core.log_level":
+			core.SetLogLevel(c.Core.LogLevel)
+		case "faces.udp.enabled_unicast":
+			y.stopUDPListeners()
+			y.startUDPListeners(c)
+		case "faces.tcp.enabled":
+			y.stopTCPListeners()
+			y.startTCPListeners(c)
+		case "faces.unix.enabled":
+			y.stopUnixListener()
+			y.startUnixListener(c)
+		case "faces.websocket.enabled":
+			y.stopWebSocketListener()
+			y.startWebSocketListener(c)
+		case "faces.http3.enabled":
+			y.stopHTTP3Listener()
+			y.startHTTP3Listener(c)
+		case "faces.ethernet.enabled":
+			y.stopEthernetListeners()
+			y.startEthernetListeners(c)
+		case "tables.content_store.capacity":
+			table.CfgSetCsCapacity(int(c.Tables.ContentStore.Capacity))
+		case "tables.content_store.admit":
+			table.CfgSetCsAdmit(c.Tables.ContentStore.Admit)
+		case "tables.content_store.serve":
+			table.CfgSetCsServe(c.Tables.ContentStore.Serve)
+		case "tables.dead_nonce_list.lifetime":
+			table.CfgSetDeadNonceListLifetime(time.Duration(c.Tables.DeadNonceList.Lifetime) * time.Millisecond)
+		case "tables.network_region.regions":
+			table.NetworkRegion.Set(regions)
+		}
+	}
+
+	y.config = c
+	core.Log.Info(y, "Reloaded configuration", "changed", strings.Join(changed, ","))
+	return nil
+}
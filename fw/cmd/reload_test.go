@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/table"
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newReloadTestYaNFD creates a forwarder started from a configuration file
+// with the default options, without starting any face.
+func newReloadTestYaNFD(t *testing.T) (*YaNFD, string) {
+	file := filepath.Join(t.TempDir(), "yanfd.yml")
+	require.NoError(t, os.WriteFile(file, []byte("{}\n"), 0o644))
+
+	c := core.DefaultConfig()
+	c.Core.ConfigFile = file
+
+	// Live settings start from the configuration, as after startup
+	resetLive := func() {
+		table.CfgSetCsCapacity(int(c.Tables.ContentStore.Capacity))
+		table.CfgSetCsAdmit(c.Tables.ContentStore.Admit)
+		table.CfgSetCsServe(c.Tables.ContentStore.Serve)
+		table.CfgSetDeadNonceListLifetime(time.Duration(c.Tables.DeadNonceList.Lifetime) * time.Millisecond)
+		table.NetworkRegion.Set(nil)
+	}
+	resetLive()
+
+	level := core.Log.Level()
+	t.Cleanup(func() {
+		core.Log.SetLevel(level)
+		resetLive()
+	})
+
+	return &YaNFD{config: c}, file
+}
+
+// Tests that a changed configuration file is applied to the live settings.
+func TestReloadApply(t *testing.T) {
+	y, file := newReloadTestYaNFD(t)
+	old := y.config
+
+	// Unchanged configuration
+	require.NoError(t, y.Reload())
+	assert.Same(t, old, y.config)
+
+	require.NoError(t, os.WriteFile(file, []byte(`
+core:
+  log_level: ERROR
+tables:
+  content_store:
+    capacity: 2048
+    serve: false
+  dead_nonce_list:
+    lifetime: 1234
+  network_region:
+    regions:
+      - /reload/region
+`), 0o644))
+	require.NoError(t, y.Reload())
+
+	assert.Equal(t, log.LevelError, core.Log.Level())
+	assert.Equal(t, 2048, table.CfgCsCapacity())
+	assert.False(t, table.CfgCsServe())
+	assert.Equal(t, old.Tables.ContentStore.Admit, table.CfgCsAdmit())
+	assert.Equal(t, 1234*time.Millisecond, table.CfgDeadNonceListLifetime())
+	region, _ := enc.NameFromStr("/reload/region/data")
+	assert.True(t, table.NetworkRegion.IsProducer(region))
+
+	// The new configuration is the base of the next reload
+	assert.EqualValues(t, 2048, y.config.Tables.ContentStore.Capacity)
+	assert.Equal(t, file, y.config.Core.ConfigFile)
+	assert.Empty(t, old.Diff(core.DefaultConfig()))
+}
+
+// Tests that a configuration changing options that require a restart, or
+// with invalid options, is rejected without applying any option.
+func TestReloadReject(t *testing.T) {
+	y, file := newReloadTestYaNFD(t)
+	old := y.config
+	capacity := table.CfgCsCapacity()
+
+	require.NoError(t, os.WriteFile(file, []byte(`
+fw:
+  threads: 64
+tables:
+  content_store:
+    capacity: 4096
+`), 0o644))
+	err := y.Reload()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "fw.threads")
+	assert.NotContains(t, err.Error(), "tables.content_store.capacity")
+	assert.Equal(t, capacity, table.CfgCsCapacity())
+	assert.Same(t, old, y.config)
+
+	require.NoError(t, os.WriteFile(file, []byte(`
+tables:
+  content_store:
+    capacity: 4096
+  dead_nonce_list:
+    lifetime: 0
+`), 0o644))
+	err = y.Reload()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "tables.dead_nonce_list.lifetime")
+	assert.Equal(t, capacity, table.CfgCsCapacity())
+	assert.Same(t, old, y.config)
+
+	require.NoError(t, os.WriteFile(file, []byte("core: [\n"), 0o644))
+	require.Error(t, y.Reload())
+	assert.Same(t, old, y.config)
+
+	// A forwarder not started from a configuration file cannot reload
+	y.config.Core.ConfigFile = ""
+	require.Error(t, y.Reload())
+}
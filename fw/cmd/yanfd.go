@@ -5,6 +5,8 @@ import (
 	"net"
 	"os"
 	"runtime"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/named-data/ndnd/fw/core"
@@ -22,12 +24,18 @@ import (
 type YaNFD struct {
 	config   *core.Config
 	profiler *Profiler
+	metrics  *Metrics
+
+	// Serializes configuration reloads
+	reloadMutex sync.Mutex
 
 	unixListener *face.UnixStreamListener
 	wsListener   *face.WebSocketListener
 	h3Listener   *face.HTTP3Listener
 	tcpListeners []*face.TCPListener
 	udpListeners []*face.UDPListener
+
+	etherListeners []*face.EthernetListener
 }
 
 // NewYaNFD creates a YaNFD. Don't call this function twice.
@@ -44,6 +52,7 @@ func NewYaNFD(config *core.Config) *YaNFD {
 	return &YaNFD{
 		config:   config,
 		profiler: NewProfiler(config),
+		metrics:  NewMetrics(config),
 	}
 }
 
@@ -68,14 +77,14 @@ func (y *YaNFD) Start() {
 	// - 后续阶段：可加入淘汰/删除事件、命中时重算对比、或升级为 BLS 聚合标签等。
 	table.StartCsSha256Auditor()
 
-	// 中文说明：可选的“定时挑战”。
-	// - 设置环境变量 NDND_CS_AUDIT_INTERVAL（如 5s）后启用：定时触发转发线程重算 CS 条目的 sha256，并验证是否与 CSNAT 一致。
-	if interval := table.CfgCsSha256ChallengeInterval(); interval > 0 {
-		table.StartCsSha256Challenger(interval)
-		table.StartCsSha256Verifier()
-	}
+	// 中文说明：“定时挑战”。
+	// - tables.content_store.audit.interval > 0 时定时触发转发线程重算 CS 条目的标签，并验证是否与 CSNAT 一致。
+	// - 周期可通过 cs-audit/config 在运行时修改（设为 0 即暂停），因此挑战器与验证器总是启动。
+	table.StartCsSha256Challenger()
+	table.StartCsSha256Verifier()
 
 	// Start management thread
+	mgmt.SetConfigReloader(y.Reload)
 	go mgmt.MakeMgmtThread().Run()
 
 	// Create forwarding threads
@@ -95,30 +104,117 @@ func (y *YaNFD) Start() {
 
 	// Set up listeners for faces
 	listenerCount := 0
+	listenerCount += y.startTCPListeners(core.C)
+	listenerCount += y.startUDPListeners(core.C)
+	listenerCount += y.startUDPMulticastFaces(core.C)
+	listenerCount += y.startEthernetListeners(core.C)
+	listenerCount += y.startUnixListener(core.C)
+	listenerCount += y.startWebSocketListener(core.C)
+	listenerCount += y.startHTTP3Listener(core.C)
 
-	// Create unicast TCP face
-	if core.C.Faces.Tcp.Enabled {
-		tcpAddrs := []*net.TCPAddr{{
-			IP:   net.IPv4zero,
-			Port: face.CfgTCPUnicastPort(),
-		}, {
-			IP:   net.IPv6zero,
-			Port: face.CfgTCPUnicastPort(),
-		}}
-
-		for _, tcpAddr := range tcpAddrs {
-			uri := fmt.Sprintf("tcp://%s", tcpAddr)
-			tcpListener, err := face.MakeTCPListener(defn.DecodeURIString(uri))
-			if err != nil {
-				core.Log.Error(y, "Unable to create TCP listener", "uri", uri, "err", err)
-			} else {
-				listenerCount++
-				go tcpListener.Run()
-				y.tcpListeners = append(y.tcpListeners, tcpListener)
-				core.Log.Info(y, "Created unicast TCP listener", "uri", uri)
-			}
+	// Check if any faces were created
+	if listenerCount <= 0 {
+		core.Log.Fatal(y, "No face or listener is successfully created. Quit.")
+		os.Exit(2)
+	}
+
+	// Start metrics endpoint
+	y.metrics.Start()
+}
+
+// Stop shuts down YaNFD.
+func (y *YaNFD) Stop() {
+	// Close log file last
+	defer core.CloseLogger()
+
+	// Stop the forwarder
+	core.Log.Info(y, "Stopping NDN forwarder")
+	defer core.Log.Info(y, "Stopped NDN forwarder")
+
+	// Break all loops
+	core.ShouldQuit = true
+
+	// Stop profiler
+	y.profiler.Stop()
+
+	// Stop metrics endpoint
+	y.metrics.Stop()
+
+	// Wait for all listeners to quit
+	y.stopUnixListener()
+	y.stopWebSocketListener()
+	y.stopHTTP3Listener()
+	y.stopUDPListeners()
+	y.stopEthernetListeners()
+	y.stopTCPListeners()
+
+	// Tell all faces to quit
+	for _, face := range face.FaceTable.GetAll() {
+		face.Close()
+	}
+
+	// Tell all forwarding threads to quit
+	for _, fw := range fw.Threads {
+		fw.TellToQuit()
+	}
+
+	// Wait for all forwarding threads to have quit
+	for _, fw := range fw.Threads {
+		<-fw.HasQuit
+	}
+
+	// Close the packet capture file
+	if face.RunningCapture() != nil {
+		face.StopCapture()
+	}
+
+	// 中文说明：转发线程退出后再关闭审计日志，保证最后的审计记录落盘。
+	table.StopCsAuditJournal()
+}
+
+// startTCPListeners creates the unicast TCP listeners if enabled.
+// Returns the number of listeners created.
+func (y *YaNFD) startTCPListeners(c *core.Config) int {
+	if !c.Faces.Tcp.Enabled {
+		return 0
+	}
+
+	tcpAddrs := []*net.TCPAddr{{
+		IP:   net.IPv4zero,
+		Port: face.CfgTCPUnicastPort(),
+	}, {
+		IP:   net.IPv6zero,
+		Port: face.CfgTCPUnicastPort(),
+	}}
+
+	for _, tcpAddr := range tcpAddrs {
+		uri := fmt.Sprintf("tcp://%s", tcpAddr)
+		tcpListener, err := face.MakeTCPListener(defn.DecodeURIString(uri))
+		if err != nil {
+			core.Log.Error(y, "Unable to create TCP listener", "uri", uri, "err", err)
+		} else {
+			go tcpListener.Run()
+			y.tcpListeners = append(y.tcpListeners, tcpListener)
+			core.Log.Info(y, "Created unicast TCP listener", "uri", uri)
 		}
 	}
+	return len(y.tcpListeners)
+}
+
+// stopTCPListeners closes the unicast TCP listeners.
+func (y *YaNFD) stopTCPListeners() {
+	for _, tcpListener := range y.tcpListeners {
+		tcpListener.Close()
+	}
+	y.tcpListeners = nil
+}
+
+// startUDPListeners creates the unicast UDP listeners if enabled.
+// Returns the number of listeners created.
+func (y *YaNFD) startUDPListeners(c *core.Config) int {
+	if !c.Faces.Udp.EnabledUnicast {
+		return 0
+	}
 
 	// Utility to create unicast UDP face
 	createUdpFace := func(ipAddr net.IP, zone string) {
@@ -132,7 +228,6 @@ func (y *YaNFD) Start() {
 		if err != nil {
 			core.Log.Error(y, "Unable to create UDP listener", "uri", uri, "err", err)
 		} else {
-			listenerCount++
 			go udpListener.Run()
 			y.udpListeners = append(y.udpListeners, udpListener)
 			core.Log.Info(y, "Created unicast UDP listener", "uri", uri)
@@ -140,172 +235,232 @@ func (y *YaNFD) Start() {
 	}
 
 	// On Linux and Windows, create a single UDP face for all interfaces.
-	// On macOS, create a UDP face for each interface (see below)
 	if runtime.GOOS != "darwin" {
 		createUdpFace(net.IPv4zero, "")
 		createUdpFace(net.IPv6zero, "")
+		return len(y.udpListeners)
+	}
+
+	// On macOS, create a UDP face for each interface.
+	// Do not make a single listener here for all interfaces.
+	// https://github.com/named-data/ndnd/issues/144
+	for _, iface := range y.upInterfaces() {
+		addrs, err := iface.Addrs()
+		if err != nil {
+			core.Log.Error(y, "Unable to access addresses on network interface", "iface", iface.Name, "err", err)
+			continue
+		}
+		for _, addr := range addrs {
+			createUdpFace(addr.(*net.IPNet).IP, iface.Name)
+		}
+	}
+	return len(y.udpListeners)
+}
+
+// stopUDPListeners closes the unicast UDP listeners.
+func (y *YaNFD) stopUDPListeners() {
+	for _, udpListener := range y.udpListeners {
+		udpListener.Close()
 	}
+	y.udpListeners = nil
+}
 
-	// Create UDP faces on each non-loopback interface
-	if core.C.Faces.Udp.EnabledUnicast || core.C.Faces.Udp.EnabledMulticast {
-		ifaces, err := net.Interfaces()
+// startUDPMulticastFaces creates the multicast UDP faces on each non-loopback interface if enabled.
+// Returns the number of faces created.
+func (y *YaNFD) startUDPMulticastFaces(c *core.Config) int {
+	if !c.Faces.Udp.EnabledMulticast {
+		return 0
+	}
+
+	count := 0
+	for _, iface := range y.upInterfaces() {
+		addrs, err := iface.Addrs()
 		if err != nil {
-			core.Log.Error(y, "Unable to access network interfaces", "err", err)
+			core.Log.Error(y, "Unable to access addresses on network interface", "iface", iface.Name, "err", err)
+			continue
 		}
 
-		for _, iface := range ifaces {
-			if iface.Flags&net.FlagUp == 0 {
-				core.Log.Info(y, "Skipping interface because not up", "iface", iface.Name)
+		for _, addr := range addrs {
+			ipAddr := addr.(*net.IPNet)
+			if ipAddr.IP.IsLoopback() {
 				continue
 			}
 
-			addrs, err := iface.Addrs()
+			uri := fmt.Sprintf("udp://%s", &net.UDPAddr{
+				IP:   ipAddr.IP,
+				Port: face.CfgUDPMulticastPort(),
+				Zone: iface.Name,
+			})
+
+			multicastUDPTransport, err := face.MakeMulticastUDPTransport(defn.DecodeURIString(uri))
 			if err != nil {
-				core.Log.Error(y, "Unable to access addresses on network interface", "iface", iface.Name, "err", err)
+				core.Log.Error(y, "Unable to create MulticastUDPTransport", "uri", uri, "err", err)
 				continue
 			}
+			face.MakeNDNLPLinkService(multicastUDPTransport, face.MakeNDNLPLinkServiceOptions()).Run(nil)
 
-			for _, addr := range addrs {
-				ipAddr := addr.(*net.IPNet)
-
-				// Create unicast UDP faces on each interface for macOS.
-				// Do not make a single listener here for all interfaces.
-				// https://github.com/named-data/ndnd/issues/144
-				if runtime.GOOS == "darwin" && core.C.Faces.Udp.EnabledUnicast {
-					createUdpFace(ipAddr.IP, iface.Name)
-				}
-
-				// Create multicast UDP faces
-				if core.C.Faces.Udp.EnabledMulticast {
-					uri := fmt.Sprintf("udp://%s", &net.UDPAddr{
-						IP:   ipAddr.IP,
-						Port: face.CfgUDPMulticastPort(),
-						Zone: iface.Name,
-					})
-
-					if !addr.(*net.IPNet).IP.IsLoopback() {
-						multicastUDPTransport, err := face.MakeMulticastUDPTransport(defn.DecodeURIString(uri))
-						if err != nil {
-							core.Log.Error(y, "Unable to create MulticastUDPTransport", "uri", uri, "err", err)
-							continue
-						}
-						face.MakeNDNLPLinkService(multicastUDPTransport, face.MakeNDNLPLinkServiceOptions()).Run(nil)
-
-						listenerCount++
-						core.Log.Info(y, "Created multicast UDP face", "uri", uri)
-					}
-				}
-			}
+			count++
+			core.Log.Info(y, "Created multicast UDP face", "uri", uri)
 		}
 	}
+	return count
+}
 
-	// Set up Unix stream listener
-	if core.C.Faces.Unix.Enabled {
-		uri := defn.MakeUnixFaceURI(face.CfgUnixSocketPath())
-		unixListener, err := face.MakeUnixStreamListener(uri)
-		if err != nil {
-			core.Log.Error(y, "Unable to create Unix stream listener", "path", face.CfgUnixSocketPath(), "err", err)
-		} else {
-			listenerCount++
-			go unixListener.Run()
-			y.unixListener = unixListener
-			core.Log.Info(y, "Created unix stream listener", "uri", uri)
-		}
+// upInterfaces returns the network interfaces that are up.
+func (y *YaNFD) upInterfaces() []net.Interface {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		core.Log.Error(y, "Unable to access network interfaces", "err", err)
+		return nil
 	}
 
-	// Set up WebSocket listener
-	if core.C.Faces.WebSocket.Enabled {
-		cfg := face.WebSocketListenerConfig{
-			Bind:       core.C.Faces.WebSocket.Bind,
-			Port:       core.C.Faces.WebSocket.Port,
-			TLSEnabled: core.C.Faces.WebSocket.TlsEnabled,
-			TLSCert:    core.C.ResolveRelPath(core.C.Faces.WebSocket.TlsCert),
-			TLSKey:     core.C.ResolveRelPath(core.C.Faces.WebSocket.TlsKey),
+	up := make([]net.Interface, 0, len(ifaces))
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 {
+			core.Log.Info(y, "Skipping interface because not up", "iface", iface.Name)
+			continue
 		}
+		up = append(up, iface)
+	}
+	return up
+}
 
-		wsListener, err := face.NewWebSocketListener(cfg)
-		if err != nil {
-			core.Log.Error(y, "Unable to create WebSocket Listener", "cfg", cfg, "err", err)
-		} else {
-			listenerCount++
-			go wsListener.Run()
-			y.wsListener = wsListener
-			core.Log.Info(y, "Created WebSocket listener", "uri", cfg.URL().String())
-		}
+// startEthernetListeners creates the Ethernet listeners on each configured interface if enabled.
+// Returns the number of listeners created.
+func (y *YaNFD) startEthernetListeners(c *core.Config) int {
+	if !c.Faces.Ethernet.Enabled {
+		return 0
 	}
 
-	// Set up HTTP/3 WebTransport listener
-	if c := core.C.Faces.HTTP3; c.Enabled {
-		cfg := face.HTTP3ListenerConfig{
-			Bind:    c.Bind,
-			Port:    c.Port,
-			TLSCert: c.TlsCert,
-			TLSKey:  c.TlsKey,
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		core.Log.Error(y, "Unable to access network interfaces", "err", err)
+	}
+
+	for _, iface := range ifaces {
+		if len(c.Faces.Ethernet.Interfaces) > 0 && !slices.Contains(c.Faces.Ethernet.Interfaces, iface.Name) {
+			continue
+		}
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 || len(iface.HardwareAddr) != 6 {
+			core.Log.Info(y, "Skipping interface for Ethernet faces", "iface", iface.Name)
+			continue
 		}
 
-		h3Listener, err := face.NewHTTP3Listener(cfg)
+		uri := defn.MakeDevFaceURI(iface.Name)
+		etherListener, err := face.MakeEthernetListener(uri)
 		if err != nil {
-			core.Log.Error(y, "Unable to create HTTP/3 WebTransport Listener", "cfg", cfg, "err", err)
-		} else {
-			listenerCount++
-			go h3Listener.Run()
-			y.h3Listener = h3Listener
-			core.Log.Info(y, "Created HTTP/3 WebTransport listener", "uri", cfg.URL().String())
+			core.Log.Error(y, "Unable to create Ethernet listener", "uri", uri, "err", err)
+			continue
 		}
-	}
 
-	// Check if any faces were created
-	if listenerCount <= 0 {
-		core.Log.Fatal(y, "No face or listener is successfully created. Quit.")
-		os.Exit(2)
+		go etherListener.Run()
+		y.etherListeners = append(y.etherListeners, etherListener)
+		core.Log.Info(y, "Created multicast Ethernet face", "uri", uri)
 	}
+	return len(y.etherListeners)
 }
 
-// Stop shuts down YaNFD.
-func (y *YaNFD) Stop() {
-	// Close log file last
-	defer core.CloseLogger()
+// stopEthernetListeners closes the Ethernet listeners and their faces.
+func (y *YaNFD) stopEthernetListeners() {
+	for _, etherListener := range y.etherListeners {
+		etherListener.Close()
+	}
+	y.etherListeners = nil
+}
 
-	// Stop the forwarder
-	core.Log.Info(y, "Stopping NDN forwarder")
-	defer core.Log.Info(y, "Stopped NDN forwarder")
+// startUnixListener creates the Unix stream listener if enabled.
+// Returns the number of listeners created.
+func (y *YaNFD) startUnixListener(c *core.Config) int {
+	if !c.Faces.Unix.Enabled {
+		return 0
+	}
 
-	// Break all loops
-	core.ShouldQuit = true
+	uri := defn.MakeUnixFaceURI(face.CfgUnixSocketPath())
+	unixListener, err := face.MakeUnixStreamListener(uri)
+	if err != nil {
+		core.Log.Error(y, "Unable to create Unix stream listener", "path", face.CfgUnixSocketPath(), "err", err)
+		return 0
+	}
 
-	// Stop profiler
-	y.profiler.Stop()
+	go unixListener.Run()
+	y.unixListener = unixListener
+	core.Log.Info(y, "Created unix stream listener", "uri", uri)
+	return 1
+}
 
-	// Wait for unix socket listener to quit
+// stopUnixListener closes the Unix stream listener.
+func (y *YaNFD) stopUnixListener() {
 	if y.unixListener != nil {
 		y.unixListener.Close()
+		y.unixListener = nil
 	}
+}
+
+// startWebSocketListener creates the WebSocket listener if enabled.
+// Returns the number of listeners created.
+func (y *YaNFD) startWebSocketListener(c *core.Config) int {
+	if !c.Faces.WebSocket.Enabled {
+		return 0
+	}
+
+	cfg := face.WebSocketListenerConfig{
+		Bind:       c.Faces.WebSocket.Bind,
+		Port:       c.Faces.WebSocket.Port,
+		TLSEnabled: c.Faces.WebSocket.TlsEnabled,
+		TLSCert:    c.ResolveRelPath(c.Faces.WebSocket.TlsCert),
+		TLSKey:     c.ResolveRelPath(c.Faces.WebSocket.TlsKey),
+	}
+
+	wsListener, err := face.NewWebSocketListener(cfg)
+	if err != nil {
+		core.Log.Error(y, "Unable to create WebSocket Listener", "cfg", cfg, "err", err)
+		return 0
+	}
+
+	go wsListener.Run()
+	y.wsListener = wsListener
+	core.Log.Info(y, "Created WebSocket listener", "uri", cfg.URL().String())
+	return 1
+}
+
+// stopWebSocketListener closes the WebSocket listener.
+func (y *YaNFD) stopWebSocketListener() {
 	if y.wsListener != nil {
 		y.wsListener.Close()
+		y.wsListener = nil
 	}
+}
 
-	// Wait for UDP listener to quit
-	for _, udpListener := range y.udpListeners {
-		udpListener.Close()
+// startHTTP3Listener creates the HTTP/3 WebTransport listener if enabled.
+// Returns the number of listeners created.
+func (y *YaNFD) startHTTP3Listener(c *core.Config) int {
+	if !c.Faces.HTTP3.Enabled {
+		return 0
 	}
 
-	// Wait for TCP listeners to quit
-	for _, tcpListener := range y.tcpListeners {
-		tcpListener.Close()
+	cfg := face.HTTP3ListenerConfig{
+		Bind:    c.Faces.HTTP3.Bind,
+		Port:    c.Faces.HTTP3.Port,
+		TLSCert: c.Faces.HTTP3.TlsCert,
+		TLSKey:  c.Faces.HTTP3.TlsKey,
 	}
 
-	// Tell all faces to quit
-	for _, face := range face.FaceTable.GetAll() {
-		face.Close()
+	h3Listener, err := face.NewHTTP3Listener(cfg)
+	if err != nil {
+		core.Log.Error(y, "Unable to create HTTP/3 WebTransport Listener", "cfg", cfg, "err", err)
+		return 0
 	}
 
-	// Tell all forwarding threads to quit
-	for _, fw := range fw.Threads {
-		fw.TellToQuit()
-	}
+	go h3Listener.Run()
+	y.h3Listener = h3Listener
+	core.Log.Info(y, "Created HTTP/3 WebTransport listener", "uri", cfg.URL().String())
+	return 1
+}
 
-	// Wait for all forwarding threads to have quit
-	for _, fw := range fw.Threads {
-		<-fw.HasQuit
+// stopHTTP3Listener closes the HTTP/3 WebTransport listener and its faces.
+func (y *YaNFD) stopHTTP3Listener() {
+	if y.h3Listener != nil {
+		y.h3Listener.Close()
+		y.h3Listener = nil
 	}
 }
@@ -9,11 +9,14 @@ package core
 
 import (
 	"path/filepath"
+	"reflect"
 	"runtime"
+	"strings"
 )
 
 // Global initial configuration of the forwarder.
 // This configuration is IMMUTABLE. Do not modify it.
+// Settings that can be reloaded at runtime are kept by their modules.
 var C = DefaultConfig()
 
 // Config represents the configuration of the forwarder.
@@ -24,6 +27,8 @@ type Config struct {
 		// Output log to file
 		LogFile string `json:"log_file"`
 
+		// Config file path
+		ConfigFile string `json:"-"`
 		// Config file base dir
 		BaseDir string `json:"-"`
 		// Enable CPU profiling
@@ -32,6 +37,14 @@ type Config struct {
 		MemProfile string `json:"-"`
 		// Enable block profiling
 		BlockProfile string `json:"-"`
+
+		// Prometheus metrics HTTP endpoint
+		Metrics struct {
+			// Serve metrics at http://<address>/metrics
+			Enabled bool `json:"enabled"`
+			// TCP address to listen on
+			Address string `json:"address"`
+		} `json:"metrics"`
 	} `json:"core"`
 
 	Faces struct {
@@ -41,6 +54,12 @@ type Config struct {
 		CongestionMarking bool `json:"congestion_marking"`
 		// If true, face threads will be locked to processor cores
 		LockThreadsToCores bool `json:"lock_threads_to_cores"`
+		// Time to wait for the next fragment of a packet (in milliseconds)
+		ReassemblyTimeout uint64 `json:"reassembly_timeout"`
+		// Maximum size of incomplete fragmented packets buffered per face (in bytes)
+		ReassemblyBufferSize int `json:"reassembly_buffer_size"`
+		// Name prefixes of packets sent before other packets on each face
+		PriorityPrefixes []string `json:"priority_prefixes"`
 
 		Udp struct {
 			// Whether to enable unicast UDP listener
@@ -61,6 +80,17 @@ type Config struct {
 			DefaultMtu uint16 `json:"default_mtu"`
 		} `json:"udp"`
 
+		Ethernet struct {
+			// Whether to enable Ethernet faces (Linux only)
+			Enabled bool `json:"enabled"`
+			// Interfaces to create Ethernet faces on (all interfaces if empty)
+			Interfaces []string `json:"interfaces"`
+			// Ethernet address used for multicast Ethernet faces
+			MulticastAddress string `json:"multicast_address"`
+			// Lifetime of on-demand faces (in seconds)
+			Lifetime uint64 `json:"lifetime"`
+		} `json:"ethernet"`
+
 		Tcp struct {
 			// Whether to enable TCP listener
 			Enabled bool `json:"enabled"`
@@ -105,6 +135,11 @@ type Config struct {
 			TlsCert string `json:"tls_cert"`
 			// TLS private key (relative to the config file)
 			TlsKey string `json:"tls_key"`
+			// Trust anchors for outgoing faces (PEM certificate paths relative to the config file).
+			// The system roots are used if empty.
+			TrustAnchors []string `json:"trust_anchors"`
+			// Reconnect interval for permanent faces (in seconds)
+			ReconnectInterval uint64 `json:"reconnect_interval"`
 		} `json:"http3"`
 	} `json:"faces"`
 
@@ -120,6 +155,21 @@ type Config struct {
 	Mgmt struct {
 		// Controls whether management over /localhop is enabled or disabled
 		AllowLocalhop bool `json:"allow_localhop"`
+		// Directory in which packet captures are written (relative to the config file).
+		// Captures are disabled if empty.
+		CaptureDir string `json:"capture_dir"`
+
+		// Validation of signed prefix announcements (rib/announce)
+		PrefixAnnouncement struct {
+			// URI of the KeyChain holding the trust anchors and known certificates.
+			// Empty rejects all announcements; "insecure" accepts them without validation.
+			Keychain string `json:"keychain"`
+			// Path to the compiled LVS trust schema, relative to the config file.
+			// If empty, any key certified by a trust anchor may announce any prefix.
+			TrustSchema string `json:"trust_schema"`
+			// Full names of the trust anchor certificates.
+			TrustAnchors []string `json:"trust_anchors"`
+		} `json:"prefix_announcement"`
 	} `json:"mgmt"`
 
 	Tables struct {
@@ -135,6 +185,111 @@ type Config struct {
 			Serve bool `json:"serve"`
 			// Cache replacement policy to use in each thread's content store.
 			ReplacementPolicy string `json:"replacement_policy"`
+
+			Audit struct {
+				// Interval between periodic audit challenges (milliseconds). 0 disables periodic challenges.
+				// This is the startup configuration value and can be changed at runtime via management.
+				Interval uint64 `json:"interval"`
+				// Whether to log audit challenges and verification results.
+				// This is the startup configuration value and can be changed at runtime via management.
+				Log bool `json:"log"`
+				// Audit tag scheme. Allowed options: hmac-sha256, bls12-381
+				TagScheme string `json:"tag_scheme"`
+				// URI of the keychain holding the audit key (e.g. dir:///etc/ndn/keys).
+				// If empty, a fixed built-in key is used, which is only suitable for testing with hmac-sha256.
+				// Required by the bls12-381 scheme and by remote challenges (node_prefix).
+				Keychain string `json:"keychain"`
+				// Name of the identity or key in the keychain from which the audit key is derived.
+				// If the identity has multiple keys, the first one is used.
+				KeyName string `json:"key_name"`
+				// Trusted producer keys for the bls12-381 scheme. A producer tag embedded in a Data
+				// is used only if the KeyLocator name of the Data is under one of the prefixes
+				// (the longest match wins) and the tag verifies with that key. Other tags are
+				// replaced by tags computed by this node.
+				ProducerKeys []struct {
+					// Prefix of the KeyLocator names of the producer (e.g. /minindn/a).
+					Prefix string `json:"prefix"`
+					// BLS public key of the producer (hex, 96 bytes compressed G2).
+					PublicKey string `json:"public_key"`
+				} `json:"producer_keys"`
+				// Name prefix of this node for remote audit challenges (e.g. /minindn/n1).
+				// If empty, remote challenges are disabled.
+				NodePrefix string `json:"node_prefix"`
+
+				// Validation of signed remote audit requests (challenges)
+				Auditors struct {
+					// URI of the KeyChain holding the trust anchors and known certificates.
+					// Empty rejects all remote requests; "insecure" accepts any signed request without validation.
+					Keychain string `json:"keychain"`
+					// Path to the compiled LVS trust schema, relative to the config file.
+					// If empty, any key certified by a trust anchor may send requests.
+					TrustSchema string `json:"trust_schema"`
+					// Full names of the trust anchor certificates.
+					TrustAnchors []string `json:"trust_anchors"`
+				} `json:"auditors"`
+
+				// Number of entries (or prefixes) sampled per thread in each round. 0 challenges all entries.
+				SampleK int `json:"sample_k"`
+				// Sampling unit of audit rounds. Allowed options: leaf, prefix
+				// Prefixes are drawn with probability proportional to their number of entries.
+				SampleMode string `json:"sample_mode"`
+				// Prefix length (in components) used by the prefix sampling mode.
+				SampleDepth int `json:"sample_depth"`
+				// Maximum number of entries checked in each table update tick.
+				Batch int `json:"batch"`
+				// Directory of the on-disk audit journal (relative to the config file).
+				// If empty, the CSNAT and audit history are not persisted.
+				Journal string `json:"journal"`
+				// Time to wait for the content store to refill after a restart before
+				// reconciling the journal (milliseconds).
+				ReconcileDelay uint64 `json:"reconcile_delay"`
+
+				Repair struct {
+					// Whether to re-fetch corrupted entries instead of erasing them immediately.
+					// The corrupted entry is not served while the repair is pending.
+					Enabled bool `json:"enabled"`
+					// Name of a repair source (e.g. a repo) sent as the forwarding hint of repair Interests.
+					// If empty, repair Interests are forwarded using the FIB.
+					Source string `json:"source"`
+					// Lifetime of repair Interests (milliseconds). The corrupted entry is erased
+					// if no matching Data arrives within this time.
+					Timeout uint64 `json:"timeout"`
+				} `json:"repair"`
+			} `json:"audit"`
+
+			Seu struct {
+				// Whether to inject random faults into cached Data (SEU simulation).
+				// This is the startup configuration value and can be changed at runtime via management.
+				Enabled bool `json:"enabled"`
+				// SEU rate (bit^-1 day^-1).
+				// This is the startup configuration value and can be changed at runtime via management.
+				RatePerBitPerDay float64 `json:"rate_per_bit_per_day"`
+				// Only inject into entries under this prefix. If empty, all entries are eligible.
+				// This is the startup configuration value and can be changed at runtime via management.
+				Prefix string `json:"prefix"`
+				// Whether to log injections (always logged if audit logging is enabled).
+				Log bool `json:"log"`
+				// Fault model of each injected upset. Allowed options:
+				//  - single: flip one bit
+				//  - adjacent: flip `bits` consecutive bits
+				//  - burst: flip `bits` bits within `burst_span` bytes
+				//  - stuck-at: force one bit to a fixed value for the lifetime of the entry
+				//  - metadata: flip one bit of the entry's stale time instead of the wire
+				Model string `json:"model"`
+				// Part of the Data packet that may be corrupted. Allowed options: any, name, content, signature
+				// Not applicable to the metadata model.
+				Region string `json:"region"`
+				// Number of bits flipped by the adjacent and burst models.
+				Bits int `json:"bits"`
+				// Window (in bytes) of the burst model.
+				BurstSpan int `json:"burst_span"`
+				// Seed of the injector. Runs with the same seed, configuration and cache contents
+				// inject the same faults. If 0, a random seed is used (and written to the fault log).
+				Seed uint64 `json:"seed"`
+				// File to which every injected fault is appended as a JSON line (relative to the config file).
+				// If empty, faults are not recorded.
+				FaultLog string `json:"fault_log"`
+			} `json:"seu"`
 		} `json:"content_store"`
 
 		DeadNonceList struct {
@@ -149,6 +304,7 @@ type Config struct {
 
 		Rib struct {
 			// Enables or disables readvertising to the routing daemon
+			// Routes from signed prefix announcements are readvertised with the announcement
 			ReadvertiseNlsr bool `json:"readvertise_nlsr"`
 		} `json:"rib"`
 
@@ -171,14 +327,20 @@ func DefaultConfig() *Config {
 	c.Core.LogLevel = "INFO"
 	c.Core.LogFile = ""
 
+	c.Core.ConfigFile = ""
 	c.Core.BaseDir = ""
 	c.Core.CpuProfile = ""
 	c.Core.MemProfile = ""
 	c.Core.BlockProfile = ""
+	c.Core.Metrics.Enabled = false
+	c.Core.Metrics.Address = "127.0.0.1:9101"
 
 	c.Faces.QueueSize = 1024
 	c.Faces.CongestionMarking = true
 	c.Faces.LockThreadsToCores = false
+	c.Faces.ReassemblyTimeout = 500
+	c.Faces.ReassemblyBufferSize = 262144
+	c.Faces.PriorityPrefixes = []string{"/localhost", "/localhop"}
 
 	c.Faces.Udp.EnabledUnicast = true
 	c.Faces.Udp.EnabledMulticast = true
@@ -189,6 +351,11 @@ func DefaultConfig() *Config {
 	c.Faces.Udp.Lifetime = 600
 	c.Faces.Udp.DefaultMtu = 1420
 
+	c.Faces.Ethernet.Enabled = false
+	c.Faces.Ethernet.Interfaces = []string{}
+	c.Faces.Ethernet.MulticastAddress = "01:00:5e:00:17:aa"
+	c.Faces.Ethernet.Lifetime = 600
+
 	c.Faces.Tcp.Enabled = true
 	c.Faces.Tcp.PortUnicast = 6363
 	c.Faces.Tcp.Lifetime = 600
@@ -212,18 +379,54 @@ func DefaultConfig() *Config {
 	c.Faces.HTTP3.Port = 443
 	c.Faces.HTTP3.TlsCert = ""
 	c.Faces.HTTP3.TlsKey = ""
+	c.Faces.HTTP3.TrustAnchors = []string{}
+	c.Faces.HTTP3.ReconnectInterval = 10
 
 	c.Fw.Threads = 8
 	c.Fw.QueueSize = 1024
 	c.Fw.LockThreadsToCores = false
 
 	c.Mgmt.AllowLocalhop = false
+	c.Mgmt.CaptureDir = ""
+	c.Mgmt.PrefixAnnouncement.Keychain = ""
+	c.Mgmt.PrefixAnnouncement.TrustSchema = ""
+	c.Mgmt.PrefixAnnouncement.TrustAnchors = []string{}
 
 	c.Tables.ContentStore.Capacity = 1024
 	c.Tables.ContentStore.Admit = true
 	c.Tables.ContentStore.Serve = true
 	c.Tables.ContentStore.ReplacementPolicy = "lru"
 
+	c.Tables.ContentStore.Audit.Interval = 0
+	c.Tables.ContentStore.Audit.Log = false
+	c.Tables.ContentStore.Audit.TagScheme = "hmac-sha256"
+	c.Tables.ContentStore.Audit.Keychain = ""
+	c.Tables.ContentStore.Audit.KeyName = ""
+	c.Tables.ContentStore.Audit.NodePrefix = ""
+	c.Tables.ContentStore.Audit.Auditors.Keychain = ""
+	c.Tables.ContentStore.Audit.Auditors.TrustSchema = ""
+	c.Tables.ContentStore.Audit.Auditors.TrustAnchors = []string{}
+	c.Tables.ContentStore.Audit.SampleK = 0
+	c.Tables.ContentStore.Audit.SampleMode = "leaf"
+	c.Tables.ContentStore.Audit.SampleDepth = 2
+	c.Tables.ContentStore.Audit.Batch = 64
+	c.Tables.ContentStore.Audit.Journal = ""
+	c.Tables.ContentStore.Audit.ReconcileDelay = 30000
+	c.Tables.ContentStore.Audit.Repair.Enabled = false
+	c.Tables.ContentStore.Audit.Repair.Source = ""
+	c.Tables.ContentStore.Audit.Repair.Timeout = 4000
+
+	c.Tables.ContentStore.Seu.Enabled = false
+	c.Tables.ContentStore.Seu.RatePerBitPerDay = 1.51e-7
+	c.Tables.ContentStore.Seu.Prefix = "/minindn"
+	c.Tables.ContentStore.Seu.Log = false
+	c.Tables.ContentStore.Seu.Model = "single"
+	c.Tables.ContentStore.Seu.Region = "any"
+	c.Tables.ContentStore.Seu.Bits = 2
+	c.Tables.ContentStore.Seu.BurstSpan = 8
+	c.Tables.ContentStore.Seu.Seed = 0
+	c.Tables.ContentStore.Seu.FaultLog = ""
+
 	c.Tables.DeadNonceList.Lifetime = 6000
 	c.Tables.NetworkRegion.Regions = []string{}
 	c.Tables.Rib.ReadvertiseNlsr = true
@@ -241,3 +444,36 @@ func (c *Config) ResolveRelPath(target string) string {
 	}
 	return filepath.Join(c.Core.BaseDir, target)
 }
+
+// Diff returns the paths of the configuration options that differ between two configurations,
+// e.g. "fw.threads". Options that are not read from the configuration file are ignored.
+func (c *Config) Diff(other *Config) []string {
+	diff := make([]string, 0)
+	diffConfigValue(&diff, "", reflect.ValueOf(c).Elem(), reflect.ValueOf(other).Elem())
+	return diff
+}
+
+// diffConfigValue appends the paths of the differing options of two config structs.
+func diffConfigValue(diff *[]string, path string, a, b reflect.Value) {
+	for i := range a.NumField() {
+		tag, _, _ := strings.Cut(a.Type().Field(i).Tag.Get("json"), ",")
+		if tag == "-" || tag == "" {
+			continue
+		}
+
+		fieldPath := tag
+		if path != "" {
+			fieldPath = path + "." + tag
+		}
+
+		fa, fb := a.Field(i), b.Field(i)
+		switch {
+		case fa.Kind() == reflect.Struct:
+			diffConfigValue(diff, fieldPath, fa, fb)
+		case fa.Kind() == reflect.Slice && fa.Len() == 0 && fb.Len() == 0:
+			// nil and empty lists are the same
+		case !reflect.DeepEqual(fa.Interface(), fb.Interface()):
+			*diff = append(*diff, fieldPath)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigDiff(t *testing.T) {
+	a := core.DefaultConfig()
+	b := core.DefaultConfig()
+	require.Empty(t, a.Diff(b))
+
+	// options not read from the file are ignored
+	b.Core.BaseDir = "/etc/ndn"
+	b.Core.ConfigFile = "/etc/ndn/yanfd.yml"
+	require.Empty(t, a.Diff(b))
+
+	// nil and empty lists are the same
+	a.Tables.NetworkRegion.Regions = nil
+	b.Tables.NetworkRegion.Regions = []string{}
+	require.Empty(t, a.Diff(b))
+
+	b.Core.LogLevel = "DEBUG"
+	b.Fw.Threads = a.Fw.Threads + 1
+	b.Tables.NetworkRegion.Regions = []string{"/example"}
+	require.Equal(t, []string{
+		"core.log_level",
+		"fw.threads",
+		"tables.network_region.regions",
+	}, a.Diff(b))
+}
@@ -33,11 +33,19 @@ func OpenLogger() {
 	Log = log.NewText(logFileObj)
 
 	// set log level
-	level, err := log.ParseLevel(C.Core.LogLevel)
-	if err != nil {
+	if err := SetLogLevel(C.Core.LogLevel); err != nil {
 		panic(err)
 	}
+}
+
+// SetLogLevel changes the logging level, e.g. "DEBUG".
+func SetLogLevel(levelStr string) error {
+	level, err := log.ParseLevel(levelStr)
+	if err != nil {
+		return err
+	}
 	Log.SetLevel(level)
+	return nil
 }
 
 // ShutdownLogger shuts down the logger.
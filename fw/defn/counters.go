@@ -3,10 +3,13 @@ package defn
 type FWThreadCounters struct {
 	NPitEntries           int
 	NCsEntries            int
+	NDnlEntries           int
 	NInInterests          uint64
 	NInData               uint64
+	NInNacks              uint64
 	NOutInterests         uint64
 	NOutData              uint64
+	NOutNacks             uint64
 	NSatisfiedInterests   uint64
 	NUnsatisfiedInterests uint64
 	NCsHits               uint64
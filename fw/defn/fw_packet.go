@@ -2,6 +2,7 @@
 package defn
 
 import (
+	"encoding/binary"
 	"time"
 
 	enc "github.com/named-data/ndnd/std/encoding"
@@ -93,6 +94,14 @@ type FwLpPacket struct {
 	CachePolicy *FwCachePolicy `tlv:"0x0334"`
 	//+field:natural:optional
 	CongestionMark optional.Optional[uint64] `tlv:"0x0340"`
+	//+field:sequence:uint64:fixedUint:uint64
+	Acks []uint64 `tlv:"0x0344"`
+	//+field:fixedUint:uint64:optional
+	TxSequence optional.Optional[uint64] `tlv:"0x0348"`
+	//+field:bool
+	NonDiscovery bool `tlv:"0x034C"`
+	//+field:wire
+	PrefixAnnouncement enc.Wire `tlv:"0x0350"`
 
 	//+field:wire
 	Fragment enc.Wire `tlv:"0x50"`
@@ -117,3 +126,34 @@ func (p *FwInterest) Name() enc.Name {
 func (p *FwInterest) Lifetime() optional.Optional[time.Duration] {
 	return p.InterestLifetimeV
 }
+
+// SetInterestNonce overwrites the Nonce of an encoded Interest in place.
+// Returns false if the wire is not an Interest with a Nonce.
+func SetInterestNonce(wire []byte, nonce uint32) bool {
+	reader := enc.NewBufferView(wire)
+	if typ, err := reader.ReadTLNum(); err != nil || typ != 0x05 {
+		return false
+	}
+	if _, err := reader.ReadTLNum(); err != nil {
+		return false
+	}
+
+	for !reader.IsEOF() {
+		typ, err := reader.ReadTLNum()
+		if err != nil {
+			return false
+		}
+		l, err := reader.ReadTLNum()
+		if err != nil {
+			return false
+		}
+		if typ == 0x0a && l == 4 && reader.Pos()+4 <= len(wire) {
+			binary.BigEndian.PutUint32(wire[reader.Pos():], nonce)
+			return true
+		}
+		if reader.Skip(int(l)) != nil {
+			return false
+		}
+	}
+	return false
+}
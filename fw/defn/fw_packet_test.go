@@ -0,0 +1,68 @@
+package defn_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/named-data/ndnd/fw/defn"
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/ndn"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	"github.com/named-data/ndnd/std/types/optional"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Tests that the Nonce of an encoded Interest is rewritten in place.
+func TestSetInterestNonce(t *testing.T) {
+	name, err := enc.NameFromStr("/ndn/test/nonce")
+	require.NoError(t, err)
+
+	interest, err := spec.Spec{}.MakeInterest(name, &ndn.InterestConfig{
+		CanBePrefix: true,
+		Nonce:       optional.Some(uint32(0x01020304)),
+		Lifetime:    optional.Some(4 * time.Second),
+	}, nil, nil)
+	require.NoError(t, err)
+
+	wire := interest.Wire.Join()
+	assert.True(t, defn.SetInterestNonce(wire, 0xa0b0c0d0))
+
+	pkt, _, err := spec.ReadPacket(enc.NewBufferView(wire))
+	require.NoError(t, err)
+	require.NotNil(t, pkt.Interest)
+	assert.Equal(t, uint32(0xa0b0c0d0), pkt.Interest.NonceV.Unwrap())
+	assert.True(t, pkt.Interest.NameV.Equal(name))
+	assert.Equal(t, 4*time.Second, pkt.Interest.InterestLifetimeV.Unwrap())
+
+	// Interest without Nonce
+	interest, err = spec.Spec{}.MakeInterest(name, &ndn.InterestConfig{}, nil, nil)
+	require.NoError(t, err)
+	assert.False(t, defn.SetInterestNonce(interest.Wire.Join(), 1))
+
+	// not an Interest
+	assert.False(t, defn.SetInterestNonce([]byte{0x06, 0x00}, 1))
+}
+
+// Tests encoding and decoding of the link-layer reliability fields.
+func TestLpReliabilityFields(t *testing.T) {
+	frame := &defn.FwLpPacket{
+		TxSequence: optional.Some(uint64(0x0102030405060708)),
+		Acks:       []uint64{1, 2, 0xffffffffffffffff},
+		Fragment:   enc.Wire{[]byte{0x05, 0x00}},
+	}
+	wire := (&defn.FwPacket{LpPacket: frame}).Encode().Join()
+
+	parsed, err := defn.ParseFwPacket(enc.NewBufferView(wire), false)
+	require.NoError(t, err)
+	require.NotNil(t, parsed.LpPacket)
+	assert.Equal(t, frame.TxSequence, parsed.LpPacket.TxSequence)
+	assert.Equal(t, frame.Acks, parsed.LpPacket.Acks)
+
+	// IDLE frame with only acks
+	wire = (&defn.FwPacket{LpPacket: &defn.FwLpPacket{Acks: []uint64{7}}}).Encode().Join()
+	parsed, err = defn.ParseFwPacket(enc.NewBufferView(wire), false)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{7}, parsed.LpPacket.Acks)
+	assert.Equal(t, 0, len(parsed.LpPacket.Fragment))
+}
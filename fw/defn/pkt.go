@@ -12,6 +12,11 @@ import (
 	"github.com/named-data/ndnd/std/types/optional"
 )
 
+// CachePolicyNoCache is the NDNLPv2 CachePolicyType asking not to cache a Data packet.
+// On an Interest received from a local face, it asks the forwarder not to satisfy
+// the Interest from the Content Store.
+const CachePolicyNoCache = 1
+
 // Pkt represents a pending packet to be sent or recently
 // received on the link, plus any associated metadata.
 type Pkt struct {
@@ -21,7 +26,32 @@ type Pkt struct {
 
 	PitToken       []byte
 	CongestionMark optional.Optional[uint64]
+	CachePolicy    optional.Optional[uint64]
 
 	IncomingFaceID uint64
 	NextHopFaceID  optional.Optional[uint64]
+
+	// NackReason is set if the packet is a Nack of the Interest in L3.
+	NackReason optional.Optional[uint64]
+	// LostFaceID is set if link-layer reliability could not deliver the Interest in L3 to this face.
+	LostFaceID optional.Optional[uint64]
+	// DroppedFaceID is set if the send queue of this face was full and the Interest in L3 was dropped.
+	DroppedFaceID optional.Optional[uint64]
+
+	// NonDiscovery is set if the Interest must not be flooded by self-learning.
+	NonDiscovery bool
+	// PrefixAnnouncement is attached to Data answering a self-learning discovery Interest.
+	PrefixAnnouncement enc.Wire
+}
+
+// MakeNack creates a Nack of the Interest carried by a packet.
+func MakeNack(interest *Pkt, reason uint64) *Pkt {
+	return &Pkt{
+		Name:           interest.Name,
+		L3:             interest.L3,
+		Raw:            interest.Raw,
+		PitToken:       interest.PitToken,
+		IncomingFaceID: interest.IncomingFaceID,
+		NackReason:     optional.Some(reason),
+	}
 }
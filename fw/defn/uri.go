@@ -32,6 +32,7 @@ var ErrNotCanonical = errors.New("URI could not be canonized")
 const (
 	unknownURI URIType = iota
 	devURI
+	etherURI
 	fdURI
 	internalURI
 	nullURI
@@ -62,6 +63,17 @@ func MakeDevFaceURI(ifname string) *URI {
 	return uri
 }
 
+// MakeEthernetFaceURI constructs a URI for an Ethernet address.
+func MakeEthernetFaceURI(mac net.HardwareAddr) *URI {
+	uri := new(URI)
+	uri.uriType = etherURI
+	uri.scheme = "ether"
+	uri.path = mac.String()
+	uri.port = 0
+	uri.Canonize()
+	return uri
+}
+
 // MakeFDFaceURI constructs a file descriptor URI.
 func MakeFDFaceURI(fd int) *URI {
 	uri := new(URI)
@@ -175,6 +187,15 @@ func DecodeURIString(str string) *URI {
 		str = strings.Replace(str, "%"+zone, "", 1)
 	}
 
+	// Ethernet addresses are not valid URL hosts, so parse them first
+	if mac, ok := strings.CutPrefix(str, "ether://"); ok {
+		ret.uriType = etherURI
+		ret.scheme = "ether"
+		ret.path = strings.TrimSuffix(strings.TrimPrefix(mac, "["), "]")
+		ret.Canonize()
+		return ret
+	}
+
 	// parse common URI schemes
 	uri, err := url.Parse(str)
 	if err != nil {
@@ -190,7 +211,7 @@ func DecodeURIString(str string) *URI {
 			port, _ := strconv.ParseUint(uri.Port(), 10, 16)
 			ret.port = uint16(port)
 		} else {
-			ret.port = uint16(6363) // default NDN port
+			ret.port = defaultPort
 		}
 
 		if zone != "" {
@@ -219,6 +240,13 @@ func DecodeURIString(str string) *URI {
 		decodeHostPort(tcpURI, 6363)
 	case "quic":
 		decodeHostPort(quicURI, 443)
+	case "https":
+		// WebTransport endpoints are served at /ndn
+		if uri.User != nil || (uri.Path != "" && uri.Path != "/ndn") || uri.RawQuery != "" || uri.Fragment != "" {
+			return nil
+		}
+		decodeHostPort(quicURI, 443)
+		ret.scheme = "quic"
 	case "unix":
 		ret.uriType = unixURI
 		ret.scheme = uri.Scheme
@@ -285,6 +313,9 @@ func (u *URI) IsCanonical() bool {
 	switch u.uriType {
 	case devURI:
 		return u.scheme == "dev" && u.path != "" && u.port == 0
+	case etherURI:
+		mac, err := net.ParseMAC(u.path)
+		return u.scheme == "ether" && err == nil && len(mac) == 6 && mac.String() == u.path && u.port == 0
 	case fdURI:
 		fd, err := strconv.Atoi(u.path)
 		return u.scheme == "fd" && err == nil && fd >= 0 && u.port == 0
@@ -308,6 +339,9 @@ func (u *URI) IsCanonical() bool {
 		isIPv4 := ip.To4() != nil
 		return ip != nil && u.port > 0 && ((u.scheme == "tcp4" && ip.To4() != nil) ||
 			(u.scheme == "tcp6" && ip.To16() != nil && !isIPv4))
+	case quicURI:
+		ip := net.ParseIP(u.PathHost())
+		return u.scheme == "quic" && ip != nil && u.port > 0
 	case unixURI:
 		// Do not check whether file exists, because it may fail due to lack of privilege in testing environment
 		return u.scheme == "unix" && u.path != "" && u.port == 0
@@ -322,6 +356,12 @@ func (u *URI) Canonize() error {
 	switch u.uriType {
 	case devURI, fdURI:
 		// Nothing to do to canonize these
+	case etherURI:
+		mac, err := net.ParseMAC(u.path)
+		if err != nil || len(mac) != 6 {
+			return ErrNotCanonical
+		}
+		u.path = mac.String()
 	case udpURI, tcpURI:
 		path := u.path
 		zone := ""
@@ -330,17 +370,9 @@ func (u *URI) Canonize() error {
 			path = u.PathHost()
 			zone = "%" + u.PathZone()
 		}
-		ip := net.ParseIP(strings.Trim(path, "[]"))
+		ip := resolveHost(path)
 		if ip == nil {
-			// Resolve DNS
-			resolvedIPs, err := net.LookupHost(path)
-			if err != nil || len(resolvedIPs) == 0 {
-				return ErrNotCanonical
-			}
-			ip = net.ParseIP(resolvedIPs[0])
-			if ip == nil {
-				return ErrNotCanonical
-			}
+			return ErrNotCanonical
 		}
 
 		if ip.To4() != nil {
@@ -360,6 +392,13 @@ func (u *URI) Canonize() error {
 		} else {
 			return ErrNotCanonical
 		}
+	case quicURI:
+		ip := resolveHost(u.PathHost())
+		if ip == nil {
+			return ErrNotCanonical
+		}
+		u.scheme = "quic"
+		u.path = ip.String()
 	case unixURI:
 		u.scheme = "unix"
 		testPath := "/" + u.path
@@ -382,6 +421,20 @@ func (u *URI) Canonize() error {
 	return nil
 }
 
+// resolveHost returns the IP address of a host, resolving DNS names if necessary.
+func resolveHost(host string) net.IP {
+	ip := net.ParseIP(strings.Trim(host, "[]"))
+	if ip != nil {
+		return ip
+	}
+
+	resolvedIPs, err := net.LookupHost(host)
+	if err != nil || len(resolvedIPs) == 0 {
+		return nil
+	}
+	return net.ParseIP(resolvedIPs[0])
+}
+
 // Scope returns the scope of the URI.
 func (u *URI) Scope() Scope {
 	if !u.IsCanonical() {
@@ -389,7 +442,7 @@ func (u *URI) Scope() Scope {
 	}
 
 	switch u.uriType {
-	case devURI:
+	case devURI, etherURI, quicURI:
 		return NonLocal
 	case fdURI:
 		return Local
@@ -413,6 +466,8 @@ func (u *URI) String() string {
 	switch u.uriType {
 	case devURI:
 		return "dev://" + u.path
+	case etherURI:
+		return "ether://[" + u.path + "]"
 	case fdURI:
 		return "fd://" + u.path
 	case internalURI:
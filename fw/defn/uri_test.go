@@ -23,6 +23,16 @@ func TestDecodeUri(t *testing.T) {
 	assert.Equal(t, "dev", uri.Scheme())
 	assert.Equal(t, "eth0", uri.PathHost())
 
+	// Ethernet URI
+	uri = defn.DecodeURIString("ether://[01:00:5E:00:17:AA]")
+	assert.True(t, uri.IsCanonical())
+	assert.Equal(t, "ether", uri.Scheme())
+	assert.Equal(t, "01:00:5e:00:17:aa", uri.PathHost())
+	assert.Equal(t, "ether://[01:00:5e:00:17:aa]", uri.String())
+
+	uri = defn.DecodeURIString("ether://[01:00:5e]")
+	assert.False(t, uri.IsCanonical())
+
 	// FD URI
 	uri = defn.DecodeURIString("fd://3")
 	assert.True(t, uri.IsCanonical())
@@ -87,9 +97,19 @@ func TestDecodeUri(t *testing.T) {
 
 	// QUIC URI
 	uri = defn.DecodeURIString("quic://[::1]:443")
-	assert.False(t, uri.IsCanonical())
+	assert.True(t, uri.IsCanonical())
+	assert.Equal(t, "quic", uri.Scheme())
+	assert.Equal(t, "::1", uri.PathHost())
+	assert.Equal(t, uint16(443), uri.Port())
+	assert.Equal(t, "quic://[::1]:443", uri.String())
+
+	// WebTransport URI
+	uri = defn.DecodeURIString("https://127.0.0.1/ndn")
+	assert.True(t, uri.IsCanonical())
 	assert.Equal(t, "quic", uri.Scheme())
 	assert.Equal(t, uint16(443), uri.Port())
+	assert.Equal(t, "quic://127.0.0.1:443", uri.String())
+	assert.Nil(t, defn.DecodeURIString("https://127.0.0.1/other"))
 
 	// UDP4 with zone
 	uri = defn.DecodeURIString("udp://127.0.0.1%eth0:3000")
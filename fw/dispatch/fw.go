@@ -18,6 +18,9 @@ type FWThread interface {
 
 	QueueData(packet *defn.Pkt)
 	QueueInterest(packet *defn.Pkt)
+	QueueNack(packet *defn.Pkt)
+	QueueLostInterest(packet *defn.Pkt)
+	QueueDroppedInterest(packet *defn.Pkt)
 
 	Counters() defn.FWThreadCounters
 }
@@ -33,7 +36,7 @@ func InitializeFWThreads(faces []FWThread) {
 
 // GetFWThread returns the specified forwarding thread or nil if it does not exist.
 func GetFWThread(id int) FWThread {
-	if id < 0 || id > len(FWDispatch) {
+	if id < 0 || id >= len(FWDispatch) {
 		return nil
 	}
 	return FWDispatch[id]
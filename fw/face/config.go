@@ -12,12 +12,35 @@ import (
 	"time"
 
 	"github.com/named-data/ndnd/fw/core"
+	enc "github.com/named-data/ndnd/std/encoding"
 )
 
+// priorityPrefixes contains the name prefixes of packets sent with priority.
+var priorityPrefixes []enc.Name
+
 // Initialize initializes the face module.
 func Initialize() {
 	FaceTable.nextFaceID.Store(1)
 	go FaceTable.expirationHandler()
+
+	priorityPrefixes = nil
+	for _, prefix := range core.C.Faces.PriorityPrefixes {
+		name, err := enc.NameFromStr(prefix)
+		if err != nil {
+			core.Log.Fatal(nil, "Invalid priority prefix", "prefix", prefix, "err", err)
+		}
+		priorityPrefixes = append(priorityPrefixes, name)
+	}
+}
+
+// isPriorityName returns whether a packet is sent with priority over other packets.
+func isPriorityName(name enc.Name) bool {
+	for _, prefix := range priorityPrefixes {
+		if prefix.IsPrefix(name) {
+			return true
+		}
+	}
+	return false
 }
 
 // CfgFaceQueueSize returns the maximum number of packets that can be buffered
@@ -36,6 +59,16 @@ func CfgLockThreadsToCores() bool {
 	return core.C.Faces.LockThreadsToCores
 }
 
+// CfgReassemblyTimeout returns the time to wait for the next fragment of a packet.
+func CfgReassemblyTimeout() time.Duration {
+	return time.Duration(core.C.Faces.ReassemblyTimeout) * time.Millisecond
+}
+
+// CfgReassemblyBufferSize returns the maximum size of incomplete fragmented packets buffered per face.
+func CfgReassemblyBufferSize() int {
+	return core.C.Faces.ReassemblyBufferSize
+}
+
 // CfgUDPUnicastPort returns the configured unicast UDP port.
 func CfgUDPUnicastPort() int {
 	return int(core.C.Faces.Udp.PortUnicast)
@@ -61,6 +94,16 @@ func CfgUDPLifetime() time.Duration {
 	return time.Duration(core.C.Faces.Udp.Lifetime) * time.Second
 }
 
+// CfgEthernetMulticastAddress returns the configured multicast Ethernet address.
+func CfgEthernetMulticastAddress() string {
+	return core.C.Faces.Ethernet.MulticastAddress
+}
+
+// CfgEthernetLifetime returns the lifetime of on-demand Ethernet faces after they become idle.
+func CfgEthernetLifetime() time.Duration {
+	return time.Duration(core.C.Faces.Ethernet.Lifetime) * time.Second
+}
+
 // CfgTCPUnicastPort returns the configured unicast TCP port.
 func CfgTCPUnicastPort() int {
 	return int(core.C.Faces.Tcp.PortUnicast)
@@ -71,6 +114,20 @@ func CfgTCPLifetime() time.Duration {
 	return time.Duration(core.C.Faces.Tcp.Lifetime) * time.Second
 }
 
+// CfgHTTP3TrustAnchors returns the paths of the trust anchors for outgoing HTTP/3 faces.
+func CfgHTTP3TrustAnchors() []string {
+	paths := make([]string, 0, len(core.C.Faces.HTTP3.TrustAnchors))
+	for _, path := range core.C.Faces.HTTP3.TrustAnchors {
+		paths = append(paths, core.C.ResolveRelPath(path))
+	}
+	return paths
+}
+
+// CfgHTTP3ReconnectInterval returns the interval between reconnection attempts of permanent HTTP/3 faces.
+func CfgHTTP3ReconnectInterval() time.Duration {
+	return time.Duration(core.C.Faces.HTTP3.ReconnectInterval) * time.Second
+}
+
 // CfgUnixSocketPath returns the configured Unix socket file path.
 func CfgUnixSocketPath() string {
 	return os.ExpandEnv(core.C.Faces.Unix.SocketPath)
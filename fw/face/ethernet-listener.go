@@ -0,0 +1,198 @@
+/* YaNFD - Yet another NDN Forwarding Daemon
+ *
+ * This file is licensed under the terms of the MIT License, as found in LICENSE.md.
+ */
+
+package face
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/named-data/ndnd/fw/core"
+	defn "github.com/named-data/ndnd/fw/defn"
+	"github.com/named-data/ndnd/fw/face/impl"
+	spec_mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+)
+
+// ndnEthertype is the ethertype of NDN frames.
+const ndnEthertype = 0x8624
+
+// ethernetListeners contains the running Ethernet listeners by interface name.
+var ethernetListeners sync.Map
+
+// ethernetSocket is the packet socket shared by the Ethernet faces of an interface.
+// It is implemented by impl.EthernetSocket, and replaced in tests.
+type ethernetSocket interface {
+	SendTo(frame []byte, dst net.HardwareAddr) error
+	RecvFrom(buf []byte) (n int, src net.HardwareAddr, multicast bool, err error)
+	SendQueueSize() uint64
+	Close() error
+}
+
+// EthernetListener receives NDN frames on a network interface, and dispatches
+// them to the multicast Ethernet face of the interface or to the unicast
+// Ethernet face of the sender. Unicast faces are created on demand.
+type EthernetListener struct {
+	iface     *net.Interface
+	localURI  *defn.URI
+	sock      ethernetSocket
+	multicast *EthernetTransport
+	unicast   map[string]*EthernetTransport
+	mutex     sync.Mutex
+	stopped   chan bool
+}
+
+// MakeEthernetListener constructs an EthernetListener on a network interface,
+// given by a dev:// URI, along with the multicast Ethernet face of the interface.
+func MakeEthernetListener(localURI *defn.URI) (*EthernetListener, error) {
+	if !localURI.IsCanonical() || localURI.Scheme() != "dev" {
+		return nil, defn.ErrNotCanonical
+	}
+
+	iface, err := net.InterfaceByName(localURI.Path())
+	if err != nil {
+		return nil, err
+	}
+	if len(iface.HardwareAddr) != 6 {
+		return nil, fmt.Errorf("interface %s is not an Ethernet interface", iface.Name)
+	}
+
+	group, err := net.ParseMAC(CfgEthernetMulticastAddress())
+	if err != nil || len(group) != 6 || group[0]&1 == 0 {
+		return nil, fmt.Errorf("invalid Ethernet multicast address %s", CfgEthernetMulticastAddress())
+	}
+
+	sock, err := impl.OpenEthernetSocket(iface, ndnEthertype, group)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open packet socket on %s: %w", iface.Name, err)
+	}
+
+	return makeEthernetListener(iface, localURI, sock, group), nil
+}
+
+// makeEthernetListener creates a listener on an open packet socket,
+// along with the multicast Ethernet face of the given group.
+func makeEthernetListener(
+	iface *net.Interface,
+	localURI *defn.URI,
+	sock ethernetSocket,
+	group net.HardwareAddr,
+) *EthernetListener {
+	l := &EthernetListener{
+		iface:    iface,
+		localURI: localURI,
+		sock:     sock,
+		unicast:  make(map[string]*EthernetTransport),
+		stopped:  make(chan bool, 1),
+	}
+	l.multicast = makeEthernetTransport(l, group, spec_mgmt.PersistencyPermanent, defn.MultiAccess)
+	return l
+}
+
+// EthernetListenerByInterface returns the running Ethernet listener on a network interface.
+func EthernetListenerByInterface(ifname string) *EthernetListener {
+	if l, ok := ethernetListeners.Load(ifname); ok {
+		return l.(*EthernetListener)
+	}
+	return nil
+}
+
+func (l *EthernetListener) String() string {
+	return fmt.Sprintf("ethernet-listener (%s)", l.localURI)
+}
+
+// MakeUnicastTransport creates a unicast Ethernet transport to a peer on the interface.
+func (l *EthernetListener) MakeUnicastTransport(
+	remoteAddr net.HardwareAddr,
+	persistency spec_mgmt.Persistency,
+) (*EthernetTransport, error) {
+	if len(remoteAddr) != 6 || remoteAddr[0]&1 != 0 {
+		return nil, fmt.Errorf("%s is not a unicast Ethernet address", remoteAddr)
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if _, ok := l.unicast[remoteAddr.String()]; ok {
+		return nil, fmt.Errorf("face to %s already exists on %s", remoteAddr, l.iface.Name)
+	}
+
+	t := makeEthernetTransport(l, remoteAddr, persistency, defn.PointToPoint)
+	l.unicast[remoteAddr.String()] = t
+	return t, nil
+}
+
+// removeTransport removes a closed unicast transport from the listener.
+func (l *EthernetListener) removeTransport(t *EthernetTransport) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.unicast[t.remoteAddr.String()] == t {
+		delete(l.unicast, t.remoteAddr.String())
+	}
+}
+
+// Run starts the multicast Ethernet face and the receive loop of the listener.
+func (l *EthernetListener) Run() {
+	defer func() { l.stopped <- true }()
+
+	ethernetListeners.Store(l.iface.Name, l)
+	MakeNDNLPLinkService(l.multicast, MakeNDNLPLinkServiceOptions()).Run(nil)
+
+	recvBuf := make([]byte, defn.MaxNDNPacketSize)
+	for !core.ShouldQuit {
+		readSize, remoteAddr, multicast, err := l.sock.RecvFrom(recvBuf)
+		if err != nil {
+			if errors.Is(err, os.ErrClosed) {
+				return
+			}
+			core.Log.Warn(l, "Unable to read from socket", "err", err)
+			return
+		}
+
+		if multicast {
+			l.multicast.deliver(recvBuf[:readSize])
+			continue
+		}
+
+		l.mutex.Lock()
+		existing := l.unicast[remoteAddr.String()]
+		l.mutex.Unlock()
+		if existing != nil {
+			existing.deliver(recvBuf[:readSize])
+			continue
+		}
+
+		// If frame received here, must be for new remote endpoint
+		newTransport, err := l.MakeUnicastTransport(remoteAddr, spec_mgmt.PersistencyOnDemand)
+		if err != nil {
+			core.Log.Warn(l, "Failed to create new unicast Ethernet transport", "err", err)
+			continue
+		}
+
+		core.Log.Info(l, "Accepting new Ethernet face", "uri", newTransport.RemoteURI())
+		MakeNDNLPLinkService(newTransport, MakeNDNLPLinkServiceOptions()).Run(recvBuf[:readSize])
+	}
+}
+
+// Close closes the socket of the listener and all Ethernet faces on the interface.
+func (l *EthernetListener) Close() {
+	ethernetListeners.CompareAndDelete(l.iface.Name, l)
+	l.sock.Close()
+	<-l.stopped
+
+	l.mutex.Lock()
+	transports := []*EthernetTransport{l.multicast}
+	for _, t := range l.unicast {
+		transports = append(transports, t)
+	}
+	l.mutex.Unlock()
+
+	for _, t := range transports {
+		t.Close()
+	}
+}
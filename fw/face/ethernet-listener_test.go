@@ -0,0 +1,227 @@
+package face
+
+import (
+	"net"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/named-data/ndnd/fw/defn"
+	"github.com/named-data/ndnd/fw/dispatch"
+	spec_mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testEthernetFrame is a frame received or sent on a testEthernetSocket.
+type testEthernetFrame struct {
+	frame     []byte
+	addr      net.HardwareAddr
+	multicast bool
+}
+
+// testEthernetSocket is a packet socket fed by the test.
+type testEthernetSocket struct {
+	recv      chan testEthernetFrame
+	closed    chan struct{}
+	closeOnce sync.Once
+	mutex     sync.Mutex
+	sent      []testEthernetFrame
+}
+
+func newTestEthernetSocket() *testEthernetSocket {
+	return &testEthernetSocket{
+		recv:   make(chan testEthernetFrame, 16),
+		closed: make(chan struct{}),
+	}
+}
+
+func (s *testEthernetSocket) SendTo(frame []byte, dst net.HardwareAddr) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.sent = append(s.sent, testEthernetFrame{frame: append([]byte(nil), frame...), addr: dst})
+	return nil
+}
+
+func (s *testEthernetSocket) RecvFrom(buf []byte) (int, net.HardwareAddr, bool, error) {
+	select {
+	case f := <-s.recv:
+		return copy(buf, f.frame), f.addr, f.multicast, nil
+	case <-s.closed:
+		return 0, nil, false, os.ErrClosed
+	}
+}
+
+func (s *testEthernetSocket) SendQueueSize() uint64 {
+	return 0
+}
+
+func (s *testEthernetSocket) Close() error {
+	s.closeOnce.Do(func() { close(s.closed) })
+	return nil
+}
+
+// chanFwThread is a forwarding thread that passes the Interests queued to it
+// to a channel, so that it can be used with running link services.
+type chanFwThread struct {
+	testFwThread
+	interests chan *defn.Pkt
+}
+
+func (t *chanFwThread) QueueInterest(pkt *defn.Pkt) { t.interests <- pkt }
+
+// newTestEthernetListener creates a running Ethernet listener on a test socket,
+// with a forwarding thread receiving the Interests of its faces.
+func newTestEthernetListener(t *testing.T) (*EthernetListener, *testEthernetSocket, chan *defn.Pkt) {
+	fwThread := &chanFwThread{interests: make(chan *defn.Pkt, 16)}
+	dispatch.InitializeFWThreads([]dispatch.FWThread{fwThread})
+	t.Cleanup(func() { dispatch.InitializeFWThreads(nil) })
+
+	iface := &net.Interface{
+		Index:        1,
+		MTU:          1500,
+		Name:         "test0",
+		HardwareAddr: net.HardwareAddr{0x02, 0, 0, 0, 0, 0x01},
+	}
+	group, _ := net.ParseMAC(CfgEthernetMulticastAddress())
+	sock := newTestEthernetSocket()
+	l := makeEthernetListener(iface, defn.MakeDevFaceURI(iface.Name), sock, group)
+	go l.Run()
+	return l, sock, fwThread.interests
+}
+
+// recvEthernetInterest receives an Interest sent to the listener and returns its incoming face.
+func recvEthernetInterest(t *testing.T, sock *testEthernetSocket, interests chan *defn.Pkt,
+	name string, src net.HardwareAddr, multicast bool) uint64 {
+	sock.recv <- testEthernetFrame{frame: testInterest(name).Pkt.Raw.Join(), addr: src, multicast: multicast}
+	select {
+	case pkt := <-interests:
+		assert.Equal(t, name, pkt.Name.String())
+		return pkt.IncomingFaceID
+	case <-time.After(time.Second):
+		require.FailNow(t, "Interest not received", name)
+		return 0
+	}
+}
+
+// unicastTransport returns the unicast transport to a peer, if any.
+func (l *EthernetListener) unicastTransport(addr net.HardwareAddr) *EthernetTransport {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.unicast[addr.String()]
+}
+
+// Tests that frames are dispatched to the multicast face or to the unicast face
+// of the sender, which is created on demand.
+func TestEthernetListenerDispatch(t *testing.T) {
+	l, sock, interests := newTestEthernetListener(t)
+	defer l.Close()
+
+	peer1 := net.HardwareAddr{0x02, 0, 0, 0, 0, 0x11}
+	peer2 := net.HardwareAddr{0x02, 0, 0, 0, 0, 0x12}
+
+	// Multicast frames go to the multicast face
+	faceID := recvEthernetInterest(t, sock, interests, "/localhost/test/multicast", peer1, true)
+	assert.Equal(t, l.multicast.FaceID(), faceID)
+	assert.Equal(t, defn.MultiAccess, l.multicast.LinkType())
+	assert.Nil(t, l.unicastTransport(peer1))
+
+	// A unicast frame from a new peer creates an on-demand face
+	faceID = recvEthernetInterest(t, sock, interests, "/localhost/test/peer1/a", peer1, false)
+	t1 := l.unicastTransport(peer1)
+	require.NotNil(t, t1)
+	assert.Equal(t, t1.FaceID(), faceID)
+	assert.NotEqual(t, l.multicast.FaceID(), faceID)
+	assert.Equal(t, spec_mgmt.PersistencyOnDemand, t1.Persistency())
+	assert.Equal(t, defn.PointToPoint, t1.LinkType())
+	assert.Equal(t, defn.MakeEthernetFaceURI(peer1).String(), t1.RemoteURI().String())
+	assert.NotNil(t, FaceTable.Get(faceID))
+
+	// Later frames from the same peer use the same face
+	assert.Equal(t, faceID, recvEthernetInterest(t, sock, interests, "/localhost/test/peer1/b", peer1, false))
+
+	// Another peer gets its own face
+	faceID2 := recvEthernetInterest(t, sock, interests, "/localhost/test/peer2/a", peer2, false)
+	assert.NotEqual(t, faceID, faceID2)
+	assert.Equal(t, l.unicastTransport(peer2).FaceID(), faceID2)
+
+	// Frames are sent to the peer of the face
+	t1.sendFrame([]byte{0x01})
+	l.multicast.sendFrame([]byte{0x02})
+	sock.mutex.Lock()
+	require.Len(t, sock.sent, 2)
+	assert.Equal(t, peer1, sock.sent[0].addr)
+	assert.Equal(t, l.multicast.remoteAddr, sock.sent[1].addr)
+	sock.mutex.Unlock()
+}
+
+// Tests the creation of unicast faces to peers on the interface.
+func TestEthernetListenerUnicastTransport(t *testing.T) {
+	l, _, _ := newTestEthernetListener(t)
+	defer l.Close()
+
+	peer := net.HardwareAddr{0x02, 0, 0, 0, 0, 0x21}
+	tr, err := l.MakeUnicastTransport(peer, spec_mgmt.PersistencyPersistent)
+	require.NoError(t, err)
+	assert.Equal(t, spec_mgmt.PersistencyPersistent, tr.Persistency())
+	assert.Equal(t, tr, l.unicastTransport(peer))
+	assert.Equal(t, 1500, tr.MTU())
+	assert.NotNil(t, tr.ExpirationPeriod())
+
+	// Only one face per peer
+	_, err = l.MakeUnicastTransport(peer, spec_mgmt.PersistencyPersistent)
+	assert.Error(t, err)
+
+	// Multicast and malformed addresses are rejected
+	_, err = l.MakeUnicastTransport(l.multicast.remoteAddr, spec_mgmt.PersistencyPersistent)
+	assert.Error(t, err)
+	_, err = l.MakeUnicastTransport(net.HardwareAddr{0x02, 0}, spec_mgmt.PersistencyPersistent)
+	assert.Error(t, err)
+
+	// The multicast face is always permanent
+	assert.False(t, l.multicast.SetPersistency(spec_mgmt.PersistencyOnDemand))
+	assert.True(t, tr.SetPersistency(spec_mgmt.PersistencyPermanent))
+}
+
+// Tests that closed faces are removed from the listener, and that closing
+// the listener closes all of its faces.
+func TestEthernetListenerClose(t *testing.T) {
+	l, sock, interests := newTestEthernetListener(t)
+
+	peer1 := net.HardwareAddr{0x02, 0, 0, 0, 0, 0x31}
+	peer2 := net.HardwareAddr{0x02, 0, 0, 0, 0, 0x32}
+	faceID1 := recvEthernetInterest(t, sock, interests, "/localhost/test/peer1", peer1, false)
+	faceID2 := recvEthernetInterest(t, sock, interests, "/localhost/test/peer2", peer2, false)
+	t1 := l.unicastTransport(peer1)
+	t2 := l.unicastTransport(peer2)
+
+	// Closing a face removes it from the listener and the face table
+	t1.Close()
+	assert.False(t, t1.IsRunning())
+	assert.Nil(t, l.unicastTransport(peer1))
+	assert.Eventually(t, func() bool { return FaceTable.Get(faceID1) == nil }, time.Second, 10*time.Millisecond)
+
+	// The next frame from the peer creates a new face
+	faceID3 := recvEthernetInterest(t, sock, interests, "/localhost/test/peer1", peer1, false)
+	assert.NotEqual(t, faceID1, faceID3)
+	t3 := l.unicastTransport(peer1)
+	require.NotNil(t, t3)
+
+	// A stale transport does not remove the face that replaced it
+	l.removeTransport(t1)
+	assert.Equal(t, t3, l.unicastTransport(peer1))
+
+	// Closing the listener closes all faces
+	assert.Equal(t, l, EthernetListenerByInterface("test0"))
+	l.Close()
+	assert.Nil(t, EthernetListenerByInterface("test0"))
+	for _, tr := range []*EthernetTransport{l.multicast, t2, t3} {
+		assert.False(t, tr.IsRunning())
+	}
+	assert.Nil(t, l.unicastTransport(peer1))
+	assert.Nil(t, l.unicastTransport(peer2))
+	assert.Eventually(t, func() bool {
+		return FaceTable.Get(faceID2) == nil && FaceTable.Get(faceID3) == nil
+	}, time.Second, 10*time.Millisecond)
+}
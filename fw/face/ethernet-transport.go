@@ -0,0 +1,134 @@
+/* YaNFD - Yet another NDN Forwarding Daemon
+ *
+ * This file is licensed under the terms of the MIT License, as found in LICENSE.md.
+ */
+
+package face
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	defn "github.com/named-data/ndnd/fw/defn"
+	spec_mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	"github.com/named-data/ndnd/std/utils"
+)
+
+// EthernetTransport is an Ethernet transport to the NDN multicast group or to a
+// unicast peer on a network interface. It shares the packet socket of the
+// EthernetListener of the interface, which receives frames on its behalf.
+type EthernetTransport struct {
+	listener   *EthernetListener
+	remoteAddr net.HardwareAddr
+	recvQueue  chan []byte
+	closed     chan struct{}
+	transportBase
+}
+
+// makeEthernetTransport creates a new Ethernet transport on the interface of a listener.
+func makeEthernetTransport(
+	listener *EthernetListener,
+	remoteAddr net.HardwareAddr,
+	persistency spec_mgmt.Persistency,
+	linkType defn.LinkType,
+) *EthernetTransport {
+	t := &EthernetTransport{
+		listener:   listener,
+		remoteAddr: remoteAddr,
+		recvQueue:  make(chan []byte, CfgFaceQueueSize()),
+		closed:     make(chan struct{}),
+	}
+	t.makeTransportBase(
+		defn.MakeEthernetFaceURI(remoteAddr),
+		listener.localURI, persistency,
+		defn.NonLocal, linkType,
+		listener.iface.MTU)
+	if linkType == defn.PointToPoint {
+		t.expirationTime = utils.IdPtr(time.Now().Add(CfgEthernetLifetime()))
+	}
+	t.running.Store(true)
+	return t
+}
+
+func (t *EthernetTransport) String() string {
+	return fmt.Sprintf("ethernet-transport (faceid=%d remote=%s local=%s)", t.faceID, t.remoteURI, t.localURI)
+}
+
+// SetPersistency changes the persistency of the face.
+// Multicast Ethernet faces are always permanent.
+func (t *EthernetTransport) SetPersistency(persistency spec_mgmt.Persistency) bool {
+	if persistency == t.persistency {
+		return true
+	}
+
+	if t.linkType == defn.MultiAccess && persistency != spec_mgmt.PersistencyPermanent {
+		return false
+	}
+
+	t.persistency = persistency
+	return true
+}
+
+// GetSendQueueSize returns the current size of the send queue of the interface socket.
+func (t *EthernetTransport) GetSendQueueSize() uint64 {
+	return t.listener.sock.SendQueueSize()
+}
+
+func (t *EthernetTransport) sendFrame(frame []byte) {
+	if !t.running.Load() {
+		return
+	}
+
+	if len(frame) > t.MTU() {
+		core.Log.Warn(t, "Attempted to send frame larger than MTU")
+		return
+	}
+
+	err := t.listener.sock.SendTo(frame, t.remoteAddr)
+	if err != nil {
+		core.Log.Warn(t, "Unable to send on socket", "err", err)
+		return
+	}
+
+	t.nOutBytes += uint64(len(frame))
+	if t.expirationTime != nil {
+		*t.expirationTime = time.Now().Add(CfgEthernetLifetime())
+	}
+}
+
+// deliver queues a frame received by the listener for this transport.
+func (t *EthernetTransport) deliver(frame []byte) {
+	select {
+	case t.recvQueue <- bytes.Clone(frame):
+	default:
+		core.Log.Warn(t, "Receive queue full - DROP")
+	}
+}
+
+func (t *EthernetTransport) runReceive() {
+	defer t.Close()
+
+	for {
+		select {
+		case frame := <-t.recvQueue:
+			t.nInBytes += uint64(len(frame))
+			if t.expirationTime != nil {
+				*t.expirationTime = time.Now().Add(CfgEthernetLifetime())
+			}
+			t.linkService.handleIncomingFrame(frame)
+		case <-t.closed:
+			return
+		}
+	}
+}
+
+// Close stops the transport. The socket is owned by the listener and stays open.
+func (t *EthernetTransport) Close() {
+	if t.running.Swap(false) {
+		close(t.closed)
+		t.listener.removeTransport(t)
+	}
+}
@@ -0,0 +1,32 @@
+/* YaNFD - Yet another NDN Forwarding Daemon
+ *
+ * This file is licensed under the terms of the MIT License, as found in LICENSE.md.
+ */
+
+package face
+
+// Handlers of face events
+var faceEventHandlers = make([]FaceEventHandler, 0)
+
+// FaceEventHandler is notified when a face is created, destroyed, goes up or down.
+type FaceEventHandler interface {
+	// OnFaceEvent is called with the kind of the event (spec_mgmt.FaceEvent*).
+	// This may be called from any goroutine, including the face's own goroutines.
+	OnFaceEvent(kind uint64, face LinkService)
+}
+
+// AddFaceEventHandler registers a handler of face events.
+// Handlers must be registered at startup, before listeners are started.
+func AddFaceEventHandler(h FaceEventHandler) {
+	faceEventHandlers = append(faceEventHandlers, h)
+}
+
+// notifyFaceEvent notifies all handlers of a face event.
+func notifyFaceEvent(kind uint64, face LinkService) {
+	if face == nil {
+		return
+	}
+	for _, h := range faceEventHandlers {
+		h.OnFaceEvent(kind, face)
+	}
+}
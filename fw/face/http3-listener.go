@@ -122,3 +122,9 @@ func (l *HTTP3Listener) handler(rw http.ResponseWriter, r *http.Request) {
 	options.IsFragmentationEnabled = true
 	MakeNDNLPLinkService(newTransport, options).Run(nil)
 }
+
+// Close stops the listener and closes the sessions it accepted.
+func (l *HTTP3Listener) Close() {
+	core.Log.Info(l, "Stopping listener")
+	l.server.Close()
+}
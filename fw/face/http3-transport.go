@@ -3,18 +3,43 @@
 package face
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"net"
 	"net/netip"
+	"net/url"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"time"
 
 	"github.com/named-data/ndnd/fw/core"
 	defn "github.com/named-data/ndnd/fw/defn"
 	spec_mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	"github.com/quic-go/quic-go"
 	"github.com/quic-go/webtransport-go"
 )
 
+// Fake local URI of outgoing HTTP/3 faces before they are connected
+var StubQuicUri = defn.DecodeURIString("quic://127.0.0.1:0")
+
 type HTTP3Transport struct {
 	transportBase
 	c *webtransport.Session
+
+	// Outgoing faces only
+	dialer *webtransport.Dialer
+	url    string
+	qconn  *quic.Conn
+
+	// Permanent face reconnection
+	rechan chan bool
+	closed atomic.Bool // (permanently)
 }
 
 // (AI GENERATED DESCRIPTION): Creates a new HTTP3Transport instance, initializing it with the given remote and local addresses, configuring its transport base parameters, and marking it as running.
@@ -25,14 +50,118 @@ func NewHTTP3Transport(remote, local netip.AddrPort, c *webtransport.Session) (t
 	return
 }
 
+// MakeHTTP3ClientTransport makes an outgoing HTTP/3 WebTransport transport.
+// The server certificate is verified against certHash if given,
+// otherwise against the configured trust anchors with serverName.
+func MakeHTTP3ClientTransport(
+	remoteURI *defn.URI,
+	serverName string,
+	certHash []byte,
+	persistency spec_mgmt.Persistency,
+) (*HTTP3Transport, error) {
+	// Validate URI.
+	if !remoteURI.IsCanonical() || remoteURI.Scheme() != "quic" {
+		return nil, defn.ErrNotCanonical
+	}
+
+	tlsConfig, err := makeHTTP3ClientTLSConfig(serverName, certHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Construct transport
+	t := &HTTP3Transport{rechan: make(chan bool, 2)}
+	t.makeTransportBase(remoteURI, nil, persistency, defn.NonLocal, defn.PointToPoint, 1000)
+	t.url = (&url.URL{
+		Scheme: "https",
+		Host:   net.JoinHostPort(remoteURI.PathHost(), strconv.Itoa(int(remoteURI.Port()))),
+		Path:   "/ndn",
+	}).String()
+
+	t.dialer = &webtransport.Dialer{
+		TLSClientConfig: tlsConfig,
+		QUICConfig: &quic.Config{
+			MaxIdleTimeout:          60 * time.Second,
+			KeepAlivePeriod:         30 * time.Second,
+			DisablePathMTUDiscovery: true,
+			EnableDatagrams:         true,
+		},
+		// Keep the QUIC connection, since closing the session does not close it
+		DialAddr: func(ctx context.Context, addr string, tlsCfg *tls.Config, cfg *quic.Config) (*quic.Conn, error) {
+			conn, err := quic.DialAddrEarly(ctx, addr, tlsCfg, cfg)
+			t.qconn = conn
+			return conn, err
+		},
+	}
+
+	// Do not attempt to connect here, since it blocks the management thread.
+	// We will attempt to connect in the receive loop instead.
+	t.localURI = StubQuicUri
+
+	return t, nil
+}
+
+// makeHTTP3ClientTLSConfig returns the TLS configuration of an outgoing face.
+func makeHTTP3ClientTLSConfig(serverName string, certHash []byte) (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName: serverName,
+		MinVersion: tls.VersionTLS13,
+	}
+
+	// Pinned certificates are accepted regardless of their issuer and name,
+	// which allows self-signed certificates
+	if len(certHash) > 0 {
+		if len(certHash) != sha256.Size {
+			return nil, fmt.Errorf("certificate hash must be a SHA-256 digest")
+		}
+		config.InsecureSkipVerify = true
+		config.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) > 0 {
+				hash := sha256.Sum256(rawCerts[0])
+				if bytes.Equal(hash[:], certHash) {
+					return nil
+				}
+			}
+			return errors.New("server certificate does not match pinned hash")
+		}
+		return config, nil
+	}
+
+	// Use the system roots if there are no trust anchors
+	anchors := CfgHTTP3TrustAnchors()
+	if len(anchors) > 0 {
+		config.RootCAs = x509.NewCertPool()
+		for _, path := range anchors {
+			pem, err := os.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			if !config.RootCAs.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificates found in trust anchor %s", path)
+			}
+		}
+	}
+
+	return config, nil
+}
+
 // (AI GENERATED DESCRIPTION): Returns a human‑readable string summarizing the HTTP/3 transport, including its face ID, remote URI, and local URI.
 func (t *HTTP3Transport) String() string {
 	return fmt.Sprintf("http3-transport (faceid=%d remote=%s local=%s)", t.faceID, t.remoteURI, t.localURI)
 }
 
-// (AI GENERATED DESCRIPTION): Returns true if the given persistency setting is `OnDemand`, indicating the transport should use persistent mode only in that case.
+// SetPersistency changes the persistency of the face.
+// Incoming faces can only be on-demand.
 func (t *HTTP3Transport) SetPersistency(persistency spec_mgmt.Persistency) bool {
-	return persistency == spec_mgmt.PersistencyOnDemand
+	if t.dialer == nil {
+		return persistency == spec_mgmt.PersistencyOnDemand
+	}
+
+	if persistency != spec_mgmt.PersistencyPersistent && persistency != spec_mgmt.PersistencyPermanent {
+		return false
+	}
+	t.persistency = persistency
+	return true
 }
 
 // (AI GENERATED DESCRIPTION): Returns the current size, in bytes, of the outgoing send queue for this HTTP/3 transport.
@@ -40,6 +169,58 @@ func (t *HTTP3Transport) GetSendQueueSize() uint64 {
 	return 0
 }
 
+// dial connects to the remote WebTransport endpoint of an outgoing face.
+func (t *HTTP3Transport) dial() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, c, err := t.dialer.Dial(ctx, t.url, nil)
+	if err != nil {
+		if t.qconn != nil {
+			t.qconn.CloseWithError(0, "")
+		}
+		return err
+	}
+
+	t.c = c
+	if local, err := netip.ParseAddrPort(c.LocalAddr().String()); err == nil {
+		t.localURI = defn.MakeQuicFaceURI(local)
+	}
+	return nil
+}
+
+// Attempt to reconnect to the remote endpoint of an outgoing face.
+func (t *HTTP3Transport) reconnect() {
+	for attempt := 1; ; attempt++ {
+		// If there is no session, this is the initial attempt to connect.
+		// Make only one attempt to connect for non-permanent faces.
+		if t.c != nil || attempt > 1 {
+			if t.Persistency() != spec_mgmt.PersistencyPermanent || t.closed.Load() {
+				t.rechan <- false // do not continue
+				return
+			}
+		}
+
+		err := t.dial()
+		if err != nil {
+			core.Log.Warn(t, "Unable to connect to remote endpoint", "err", err, "attempt", attempt)
+			time.Sleep(CfgHTTP3ReconnectInterval())
+			continue
+		}
+
+		// If the transport was closed while we were trying to reconnect,
+		// close the new session and return without notifying
+		if t.closed.Load() {
+			t.c.CloseWithError(0, "")
+			t.qconn.CloseWithError(0, "")
+			return
+		}
+
+		t.rechan <- true // continue
+		return
+	}
+}
+
 // (AI GENERATED DESCRIPTION): Sends a given frame over the HTTP/3 transport if it is running and the frame size is within the MTU, logs and handles any send errors (closing the transport on failure), and updates the outbound byte counter.
 func (t *HTTP3Transport) sendFrame(frame []byte) {
 	if !t.running.Load() {
@@ -54,7 +235,7 @@ func (t *HTTP3Transport) sendFrame(frame []byte) {
 	e := t.c.SendDatagram(frame)
 	if e != nil {
 		core.Log.Warn(t, "Unable to send on socket - Face DOWN", "err", e)
-		t.Close()
+		t.CloseConn() // receive might reconnect if needed
 		return
 	}
 
@@ -65,11 +246,38 @@ func (t *HTTP3Transport) sendFrame(frame []byte) {
 func (t *HTTP3Transport) runReceive() {
 	defer t.Close()
 
+	for {
+		// The session is nil for outgoing faces until connected
+		if t.c != nil {
+			err := t.receiveDatagrams()
+			if t.closed.Load() || t.dialer == nil {
+				core.Log.Warn(t, "Unable to read from WebTransport - DROP and Face DOWN", "err", err)
+				return
+			}
+
+			core.Log.Warn(t, "Unable to read from WebTransport - Face DOWN", "err", err)
+			t.CloseConn()
+			notifyFaceEvent(spec_mgmt.FaceEventDown, t.linkService)
+		}
+
+		// Outgoing faces will reconnect if permanent, otherwise close
+		go t.reconnect()
+		if !<-t.rechan {
+			return // do not continue
+		}
+
+		core.Log.Info(t, "Connected WebTransport session - Face UP")
+		t.running.Store(true)
+		notifyFaceEvent(spec_mgmt.FaceEventUp, t.linkService)
+	}
+}
+
+// receiveDatagrams reads datagrams from the session until it fails.
+func (t *HTTP3Transport) receiveDatagrams() error {
 	for {
 		message, err := t.c.ReceiveDatagram(t.c.Context())
 		if err != nil {
-			core.Log.Warn(t, "Unable to read from WebTransport - DROP and Face DOWN", "err", err)
-			return
+			return err
 		}
 
 		if len(message) > defn.MaxNDNPacketSize {
@@ -82,8 +290,24 @@ func (t *HTTP3Transport) runReceive() {
 	}
 }
 
-// (AI GENERATED DESCRIPTION): Closes the HTTP/3 transport by marking it stopped and shutting down the underlying connection without error.
+// CloseConn closes the session if running without closing the transport.
+func (t *HTTP3Transport) CloseConn() {
+	if t.running.Swap(false) {
+		t.c.CloseWithError(0, "")
+		if t.qconn != nil {
+			t.qconn.CloseWithError(0, "")
+		}
+	}
+}
+
+// Close the transport permanently - this will not attempt to reconnect.
 func (t *HTTP3Transport) Close() {
-	t.running.Store(false)
-	t.c.CloseWithError(0, "")
+	t.closed.Store(true)
+	if t.rechan != nil {
+		select {
+		case t.rechan <- false:
+		default:
+		}
+	}
+	t.CloseConn()
 }
@@ -0,0 +1,127 @@
+//go:build linux
+
+/* YaNFD - Yet another NDN Forwarding Daemon
+ *
+ * This file is licensed under the terms of the MIT License, as found in LICENSE.md.
+ */
+
+package impl
+
+import (
+	"encoding/binary"
+	"net"
+	"os"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// EthernetSocket is a packet socket bound to an ethertype on a network interface.
+type EthernetSocket struct {
+	file    *os.File
+	conn    syscall.RawConn
+	ifindex int
+	proto   uint16
+}
+
+// OpenEthernetSocket opens a packet socket receiving the frames of an ethertype
+// on a network interface, and joins the given multicast group.
+func OpenEthernetSocket(iface *net.Interface, ethertype uint16, group net.HardwareAddr) (*EthernetSocket, error) {
+	proto := htons(ethertype)
+	fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_DGRAM|unix.SOCK_NONBLOCK|unix.SOCK_CLOEXEC, int(proto))
+	if err != nil {
+		return nil, os.NewSyscallError("socket", err)
+	}
+
+	err = unix.Bind(fd, &unix.SockaddrLinklayer{Protocol: proto, Ifindex: iface.Index})
+	if err != nil {
+		unix.Close(fd)
+		return nil, os.NewSyscallError("bind", err)
+	}
+
+	mreq := &unix.PacketMreq{Ifindex: int32(iface.Index), Type: unix.PACKET_MR_MULTICAST, Alen: uint16(len(group))}
+	copy(mreq.Address[:], group)
+	err = unix.SetsockoptPacketMreq(fd, unix.SOL_PACKET, unix.PACKET_ADD_MEMBERSHIP, mreq)
+	if err != nil {
+		unix.Close(fd)
+		return nil, os.NewSyscallError("setsockopt", err)
+	}
+
+	// The file registers the non-blocking socket with the runtime poller,
+	// so that Close unblocks a pending RecvFrom.
+	s := &EthernetSocket{
+		file:    os.NewFile(uintptr(fd), "ether://"+iface.Name),
+		ifindex: iface.Index,
+		proto:   proto,
+	}
+	s.conn, err = s.file.SyscallConn()
+	if err != nil {
+		s.file.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// SendTo sends a frame to an Ethernet address.
+func (s *EthernetSocket) SendTo(frame []byte, dst net.HardwareAddr) error {
+	addr := &unix.SockaddrLinklayer{Protocol: s.proto, Ifindex: s.ifindex, Halen: uint8(len(dst))}
+	copy(addr.Addr[:], dst)
+
+	var err error
+	werr := s.conn.Write(func(fd uintptr) bool {
+		err = unix.Sendto(int(fd), frame, 0, addr)
+		return err != unix.EAGAIN
+	})
+	if werr != nil {
+		return werr
+	}
+	return err
+}
+
+// RecvFrom receives a frame sent to this host or to a joined multicast group.
+// Returns the size of the frame, its source address and whether it was multicast.
+func (s *EthernetSocket) RecvFrom(buf []byte) (n int, src net.HardwareAddr, multicast bool, err error) {
+	for {
+		var from unix.Sockaddr
+		rerr := s.conn.Read(func(fd uintptr) bool {
+			n, from, err = unix.Recvfrom(int(fd), buf, 0)
+			return err != unix.EAGAIN
+		})
+		if rerr != nil {
+			return 0, nil, false, rerr
+		}
+		if err != nil {
+			return 0, nil, false, err
+		}
+
+		ll, ok := from.(*unix.SockaddrLinklayer)
+		if !ok {
+			continue
+		}
+
+		switch ll.Pkttype {
+		case unix.PACKET_HOST:
+			return n, net.HardwareAddr(ll.Addr[:ll.Halen]), false, nil
+		case unix.PACKET_MULTICAST, unix.PACKET_BROADCAST:
+			return n, net.HardwareAddr(ll.Addr[:ll.Halen]), true, nil
+		}
+		// Ignore outgoing frames and frames for other hosts (promiscuous mode)
+	}
+}
+
+// SendQueueSize returns the current size of the send queue of the socket.
+func (s *EthernetSocket) SendQueueSize() uint64 {
+	return SyscallGetSocketSendQueueSize(s.conn)
+}
+
+// Close closes the socket.
+func (s *EthernetSocket) Close() error {
+	return s.file.Close()
+}
+
+// htons converts a short from host to network byte order.
+func htons(v uint16) uint16 {
+	var b [2]byte
+	binary.BigEndian.PutUint16(b[:], v)
+	return binary.NativeEndian.Uint16(b[:])
+}
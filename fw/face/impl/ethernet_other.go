@@ -0,0 +1,44 @@
+//go:build !linux
+
+/* YaNFD - Yet another NDN Forwarding Daemon
+ *
+ * This file is licensed under the terms of the MIT License, as found in LICENSE.md.
+ */
+
+package impl
+
+import (
+	"errors"
+	"net"
+)
+
+// ErrEthernetUnsupported is returned when Ethernet faces are not supported on the platform.
+var ErrEthernetUnsupported = errors.New("ethernet faces are only supported on Linux")
+
+// EthernetSocket is a packet socket bound to an ethertype on a network interface.
+type EthernetSocket struct{}
+
+// OpenEthernetSocket is not supported on this platform.
+func OpenEthernetSocket(iface *net.Interface, ethertype uint16, group net.HardwareAddr) (*EthernetSocket, error) {
+	return nil, ErrEthernetUnsupported
+}
+
+// SendTo is not supported on this platform.
+func (s *EthernetSocket) SendTo(frame []byte, dst net.HardwareAddr) error {
+	return ErrEthernetUnsupported
+}
+
+// RecvFrom is not supported on this platform.
+func (s *EthernetSocket) RecvFrom(buf []byte) (n int, src net.HardwareAddr, multicast bool, err error) {
+	return 0, nil, false, ErrEthernetUnsupported
+}
+
+// SendQueueSize is not supported on this platform.
+func (s *EthernetSocket) SendQueueSize() uint64 {
+	return 0
+}
+
+// Close is not supported on this platform.
+func (s *EthernetSocket) Close() error {
+	return ErrEthernetUnsupported
+}
@@ -47,6 +47,7 @@ func RegisterInternalTransport() (LinkService, *InternalTransport) {
 	options := MakeNDNLPLinkServiceOptions()
 	options.IsIncomingFaceIndicationEnabled = true
 	options.IsConsumerControlledForwardingEnabled = true
+	options.IsLocalCachePolicyEnabled = true
 	link := MakeNDNLPLinkService(transport, options)
 	link.Run(nil)
 
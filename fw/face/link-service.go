@@ -10,6 +10,7 @@ package face
 import (
 	"encoding/binary"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/named-data/ndnd/fw/core"
@@ -17,6 +18,7 @@ import (
 	"github.com/named-data/ndnd/fw/dispatch"
 	"github.com/named-data/ndnd/fw/fw"
 	spec_mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	"github.com/named-data/ndnd/std/types/optional"
 )
 
 // LinkService is an interface for link service implementations
@@ -53,9 +55,11 @@ type LinkService interface {
 	// Counters
 	NInInterests() uint64
 	NInData() uint64
+	NInNacks() uint64
 	NInBytes() uint64
 	NOutInterests() uint64
 	NOutData() uint64
+	NOutNacks() uint64
 	NOutBytes() uint64
 }
 
@@ -65,12 +69,17 @@ type linkServiceBase struct {
 	transport transport
 	stopped   chan bool
 	sendQueue chan dispatch.OutPkt
+	// prioQueue contains the outgoing packets with priority over the send queue.
+	prioQueue chan dispatch.OutPkt
 
 	// Counters
 	nInInterests  uint64
 	nInData       uint64
+	nInNacks      uint64
 	nOutInterests uint64
 	nOutData      uint64
+	nOutNacks     uint64
+	nOutDropped   atomic.Uint64
 }
 
 // (AI GENERATED DESCRIPTION): Returns a human‑readable string that describes the link service, displaying its transport type if present, otherwise its face ID.
@@ -98,6 +107,7 @@ func (l *linkServiceBase) SetFaceID(faceID uint64) {
 func (l *linkServiceBase) makeLinkServiceBase() {
 	l.stopped = make(chan bool)
 	l.sendQueue = make(chan dispatch.OutPkt, CfgFaceQueueSize())
+	l.prioQueue = make(chan dispatch.OutPkt, CfgFaceQueueSize())
 }
 
 //
@@ -181,6 +191,11 @@ func (l *linkServiceBase) NInData() uint64 {
 	return l.nInData
 }
 
+// NInNacks returns the number of Nacks received on this face.
+func (l *linkServiceBase) NInNacks() uint64 {
+	return l.nInNacks
+}
+
 // NInBytes returns the number of link-layer bytes received on this face.
 func (l *linkServiceBase) NInBytes() uint64 {
 	return l.transport.NInBytes()
@@ -196,6 +211,16 @@ func (l *linkServiceBase) NOutData() uint64 {
 	return l.nOutData
 }
 
+// NOutNacks returns the number of Nacks sent on this face.
+func (l *linkServiceBase) NOutNacks() uint64 {
+	return l.nOutNacks
+}
+
+// NOutDropped returns the number of packets dropped because the send queue was full.
+func (l *linkServiceBase) NOutDropped() uint64 {
+	return l.nOutDropped.Load()
+}
+
 // NOutBytes returns the number of link-layer bytes sent on this face.
 func (l *linkServiceBase) NOutBytes() uint64 {
 	return l.transport.NOutBytes()
@@ -212,15 +237,24 @@ func (l *linkServiceBase) Close() {
 
 // SendPacket adds a packet to the send queue for this link service
 func (l *linkServiceBase) SendPacket(out dispatch.OutPkt) {
+	queue := l.sendQueue
+	if isPriorityName(out.Pkt.Name) {
+		queue = l.prioQueue
+	}
+
 	select {
-	case l.sendQueue <- out:
+	case queue <- out:
 		// Packet queued successfully
 		core.Log.Trace(l, "Queued packet for link service")
 	default:
 		// Drop packet due to congestion
 		core.Log.Debug(l, "Dropped packet due to congestion")
+		l.nOutDropped.Add(1)
 
-		// TODO: Signal congestion
+		// Signal congestion to the downstream of a dropped Interest
+		if out.Pkt.L3.Interest != nil && !out.Pkt.NackReason.IsSet() {
+			l.dispatchDroppedInterest(out)
+		}
 	}
 }
 
@@ -239,6 +273,75 @@ func (l *linkServiceBase) dispatchInterest(pkt *defn.Pkt) {
 	dispatch.GetFWThread(thread).QueueInterest(pkt)
 }
 
+// dispatchNack routes a Nack to the forwarding thread that sent the Interest,
+// using the PIT token if present or the Interest name otherwise.
+func (l *linkServiceBase) dispatchNack(pkt *defn.Pkt) {
+	if pkt.L3.Interest == nil || !pkt.NackReason.IsSet() {
+		panic("dispatchNack called with packet that is not Nack")
+	}
+
+	// Store name for easy access
+	pkt.Name = pkt.L3.Interest.NameV
+
+	thread := fw.HashNameToFwThread(pkt.Name)
+	if len(pkt.PitToken) == 6 {
+		thread = int(binary.BigEndian.Uint16(pkt.PitToken))
+	}
+
+	fwThread := dispatch.GetFWThread(thread)
+	if fwThread == nil {
+		core.Log.Error(l, "Invalid PIT token attached to Nack")
+		return
+	}
+
+	core.Log.Trace(l, "Dispatched Nack", "thread", thread)
+	fwThread.QueueNack(pkt)
+}
+
+// dispatchLostInterest notifies the forwarding thread that sent an Interest
+// that link-layer reliability gave up delivering it on this face.
+func (l *linkServiceBase) dispatchLostInterest(out dispatch.OutPkt) {
+	if len(out.PitToken) != 6 {
+		return // not sent by a forwarding thread
+	}
+
+	thread := int(binary.BigEndian.Uint16(out.PitToken))
+	fwThread := dispatch.GetFWThread(thread)
+	if fwThread == nil {
+		core.Log.Error(l, "Invalid PIT token attached to lost Interest")
+		return
+	}
+
+	lost := *out.Pkt
+	lost.PitToken = out.PitToken
+	lost.LostFaceID = optional.Some(l.faceID)
+
+	core.Log.Trace(l, "Dispatched lost Interest", "thread", thread)
+	fwThread.QueueLostInterest(&lost)
+}
+
+// dispatchDroppedInterest returns an Interest dropped by the send queue to the
+// forwarding thread that sent it, which Nacks the downstream.
+func (l *linkServiceBase) dispatchDroppedInterest(out dispatch.OutPkt) {
+	if len(out.PitToken) != 6 {
+		return // not sent by a forwarding thread
+	}
+
+	thread := int(binary.BigEndian.Uint16(out.PitToken))
+	fwThread := dispatch.GetFWThread(thread)
+	if fwThread == nil {
+		core.Log.Error(l, "Invalid PIT token attached to dropped Interest")
+		return
+	}
+
+	dropped := *out.Pkt
+	dropped.PitToken = out.PitToken
+	dropped.DroppedFaceID = optional.Some(l.faceID)
+
+	core.Log.Trace(l, "Dispatched dropped Interest", "thread", thread)
+	fwThread.QueueDroppedInterest(&dropped)
+}
+
 // (AI GENERATED DESCRIPTION): Routes an incoming Data packet to the appropriate forwarding thread(s) by examining its PIT token or name prefix, handling local producer packets that lack tokens, and logging the dispatch.
 func (l *linkServiceBase) dispatchData(pkt *defn.Pkt) {
 	if pkt.L3.Data == nil {
@@ -0,0 +1,40 @@
+package face
+
+import (
+	"testing"
+
+	"github.com/named-data/ndnd/fw/dispatch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Tests that an Interest dropped by a full send queue is returned to the
+// forwarding thread that sent it.
+func TestSendPacketDropped(t *testing.T) {
+	l, _ := newTestLinkService(1500, MakeNDNLPLinkServiceOptions())
+	l.faceID = 300
+
+	fwThread := &testFwThread{}
+	dispatch.InitializeFWThreads([]dispatch.FWThread{fwThread})
+	defer dispatch.InitializeFWThreads(nil)
+
+	for range cap(l.sendQueue) {
+		l.SendPacket(testInterest("/test/queued"))
+	}
+	assert.Empty(t, fwThread.dropped)
+
+	out := testInterest("/test/dropped")
+	l.SendPacket(out)
+	assert.Equal(t, uint64(1), l.NOutDropped())
+
+	require.Len(t, fwThread.dropped, 1)
+	dropped := fwThread.dropped[0]
+	assert.Equal(t, out.Pkt.Name, dropped.Name)
+	assert.Equal(t, out.PitToken, dropped.PitToken)
+	assert.Equal(t, uint64(300), dropped.DroppedFaceID.Unwrap())
+
+	// Interests not sent by a forwarding thread are not returned
+	out.PitToken = nil
+	l.SendPacket(out)
+	assert.Len(t, fwThread.dropped, 1)
+}
@@ -0,0 +1,231 @@
+/* YaNFD - Yet another NDN Forwarding Daemon
+ *
+ * This file is licensed under the terms of the MIT License, as found in LICENSE.md.
+ */
+
+package face
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	enc "github.com/named-data/ndnd/std/encoding"
+)
+
+// lpMaxFragCount is the maximum number of fragments of a network layer packet.
+// Same as the default of NFD, which allows fragments as small as 22 bytes
+// for packets of MaxNDNPacketSize.
+const lpMaxFragCount = 400
+
+// lpFragmentOverhead is the memory charged against the reassembly buffer
+// for each fragment in addition to its payload, so that floods of tiny
+// fragments are limited by the buffer size as well.
+const lpFragmentOverhead = 64
+
+// lpReassembler reassembles network layer packets from NDNLPv2 fragments.
+// Partial packets are keyed by base sequence and fragment count, so that the
+// fragments of interleaved packets may arrive in any order.
+// Fragments are added by the receive goroutine of the link service, while
+// partial packets that time out are dropped by a timer.
+type lpReassembler struct {
+	mutex sync.Mutex
+
+	partials map[lpReassemblyKey]*list.Element
+	// order holds the partial packets by expiry, oldest first.
+	// Every update pushes the packet back, since all packets share the same timeout.
+	order *list.List
+	// timer drops the partial packets at the front of order when they expire.
+	timer   *time.Timer
+	stopped bool
+
+	// size is the total size of the fragments held in partial packets,
+	// including lpFragmentOverhead per fragment.
+	size int
+	// maxSize is the limit on the total size of the fragments held in partial packets.
+	maxSize int
+	// timeout is the time to wait for the next fragment of a partial packet.
+	timeout time.Duration
+
+	// Counters
+	nInvalid   uint64
+	nTimeouts  uint64
+	nEvictions uint64
+}
+
+// lpReassemblyKey identifies the fragments of a network layer packet.
+type lpReassemblyKey struct {
+	baseSequence uint64
+	fragCount    uint64
+}
+
+// lpPartialPacket is a network layer packet with missing fragments.
+type lpPartialPacket struct {
+	key lpReassemblyKey
+	// fragments holds the received fragments by index.
+	fragments map[uint64][]byte
+	size      int
+	expiry    time.Time
+}
+
+// init creates the reassembler state with the given timeout and memory limit.
+func (r *lpReassembler) init(timeout time.Duration, maxSize int) {
+	r.stop()
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.partials = make(map[lpReassemblyKey]*list.Element)
+	r.order = list.New()
+	r.stopped = false
+	r.size = 0
+	r.maxSize = maxSize
+	r.timeout = timeout
+}
+
+// stop drops all partial packets and stops the expiry timer.
+func (r *lpReassembler) stop() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+	r.stopped = true
+	r.partials = nil
+	r.order = nil
+	r.size = 0
+}
+
+// receive adds a fragment to its partial packet.
+// Returns the fragments of the packet once all of them have been received.
+func (r *lpReassembler) receive(baseSequence, fragIndex, fragCount uint64, fragment []byte) enc.Wire {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.stopped {
+		return nil
+	}
+
+	now := time.Now()
+	r.dropExpired(now)
+
+	if fragIndex >= fragCount || fragCount > lpMaxFragCount || len(fragment) > r.maxSize {
+		r.nInvalid++
+		return nil
+	}
+
+	key := lpReassemblyKey{baseSequence: baseSequence, fragCount: fragCount}
+	var partial *lpPartialPacket
+	if elem := r.partials[key]; elem == nil {
+		partial = &lpPartialPacket{key: key, fragments: make(map[uint64][]byte)}
+		r.partials[key] = r.order.PushBack(partial)
+	} else {
+		partial = elem.Value.(*lpPartialPacket)
+		if _, ok := partial.fragments[fragIndex]; ok {
+			return nil // duplicate
+		}
+		r.order.MoveToBack(elem)
+	}
+	partial.expiry = now.Add(r.timeout)
+
+	// Drop the least recently updated packets if over the memory limit
+	size := len(fragment) + lpFragmentOverhead
+	for r.size+size > r.maxSize {
+		if !r.dropOldest(key) {
+			r.evict(key) // packet does not fit alone
+			return nil
+		}
+	}
+
+	partial.fragments[fragIndex] = fragment
+	partial.size += size
+	r.size += size
+
+	if uint64(len(partial.fragments)) < fragCount {
+		r.schedule(now)
+		return nil // not all fragments received
+	}
+
+	r.remove(key)
+
+	wire := make(enc.Wire, fragCount)
+	for i, frag := range partial.fragments {
+		wire[i] = frag
+	}
+	return wire
+}
+
+// schedule starts the expiry timer for the oldest partial packet if not already running.
+// Must be called with the mutex held.
+func (r *lpReassembler) schedule(now time.Time) {
+	if r.timer != nil || r.order.Len() == 0 {
+		return
+	}
+	// Expiries only move later, so a timer set for an earlier packet fires early at worst
+	expiry := r.order.Front().Value.(*lpPartialPacket).expiry
+	r.timer = time.AfterFunc(expiry.Sub(now), r.expire)
+}
+
+// expire is called by the expiry timer to drop the partial packets that timed out.
+func (r *lpReassembler) expire() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.stopped {
+		return
+	}
+
+	now := time.Now()
+	r.timer = nil
+	r.dropExpired(now)
+	r.schedule(now)
+}
+
+// dropExpired drops the partial packets that timed out.
+// Must be called with the mutex held.
+func (r *lpReassembler) dropExpired(now time.Time) {
+	for front := r.order.Front(); front != nil; front = r.order.Front() {
+		partial := front.Value.(*lpPartialPacket)
+		if !now.After(partial.expiry) {
+			return
+		}
+		r.remove(partial.key)
+		r.nTimeouts++
+	}
+}
+
+// dropOldest drops the least recently updated partial packet other than the given one.
+// Returns false if there is no other partial packet.
+// Must be called with the mutex held.
+func (r *lpReassembler) dropOldest(except lpReassemblyKey) bool {
+	oldest := r.order.Front()
+	if oldest != nil && oldest.Value.(*lpPartialPacket).key == except {
+		oldest = oldest.Next()
+	}
+	if oldest == nil {
+		return false
+	}
+	r.evict(oldest.Value.(*lpPartialPacket).key)
+	return true
+}
+
+// evict drops an incomplete partial packet to stay within the memory limit.
+// Must be called with the mutex held.
+func (r *lpReassembler) evict(key lpReassemblyKey) {
+	r.remove(key)
+	r.nEvictions++
+}
+
+// remove removes a partial packet.
+// Must be called with the mutex held.
+func (r *lpReassembler) remove(key lpReassemblyKey) {
+	elem := r.partials[key]
+	delete(r.partials, key)
+	r.order.Remove(elem)
+	r.size -= elem.Value.(*lpPartialPacket).size
+}
+
+// counters returns the numbers of invalid, timed out and evicted packets.
+func (r *lpReassembler) counters() (nInvalid, nTimeouts, nEvictions uint64) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.nInvalid, r.nTimeouts, r.nEvictions
+}
@@ -0,0 +1,127 @@
+package face
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Tests reassembly of interleaved packets with fragments received out of order.
+func TestLpReassemblerReorder(t *testing.T) {
+	r := lpReassembler{}
+	r.init(time.Second, 1024)
+	defer r.stop()
+
+	// Packet A has base sequence 10 and 3 fragments, packet B has base sequence 13 and 2 fragments
+	assert.Nil(t, r.receive(10, 2, 3, []byte("a2")))
+	assert.Nil(t, r.receive(13, 1, 2, []byte("b1")))
+	assert.Nil(t, r.receive(10, 0, 3, []byte("a0")))
+	assert.Nil(t, r.receive(10, 0, 3, []byte("a0"))) // duplicate
+
+	wire := r.receive(13, 0, 2, []byte("b0"))
+	assert.Equal(t, []byte("b0b1"), wire.Join())
+
+	wire = r.receive(10, 1, 3, []byte("a1"))
+	assert.Equal(t, []byte("a0a1a2"), wire.Join())
+
+	assert.Equal(t, 0, len(r.partials))
+	assert.Equal(t, 0, r.size)
+	assert.Equal(t, uint64(0), r.nTimeouts)
+
+	// Invalid fragment index
+	assert.Nil(t, r.receive(20, 2, 2, []byte("x")))
+	assert.Equal(t, uint64(1), r.nInvalid)
+}
+
+// Tests that partial packets are dropped by the timer when they time out,
+// without waiting for another fragment.
+func TestLpReassemblerTimeout(t *testing.T) {
+	r := lpReassembler{}
+	r.init(200*time.Millisecond, 1024)
+	defer r.stop()
+
+	assert.Nil(t, r.receive(10, 0, 2, []byte("a0")))
+	assert.Nil(t, r.receive(20, 0, 2, []byte("b0")))
+	time.Sleep(100 * time.Millisecond)
+	assert.Nil(t, r.receive(20, 1, 3, []byte("c1")))
+	time.Sleep(150 * time.Millisecond)
+
+	// Packets A and B timed out, packet C is still waiting
+	nPartials, size := r.state()
+	assert.Equal(t, 1, nPartials)
+	assert.Equal(t, 2+lpFragmentOverhead, size)
+	_, nTimeouts, nEvictions := r.counters()
+	assert.Equal(t, uint64(2), nTimeouts)
+	assert.Equal(t, uint64(0), nEvictions)
+
+	time.Sleep(150 * time.Millisecond)
+	nPartials, size = r.state()
+	assert.Equal(t, 0, nPartials)
+	assert.Equal(t, 0, size)
+
+	// The first fragment timed out, so the packet is incomplete
+	assert.Nil(t, r.receive(10, 1, 2, []byte("a1")))
+	nPartials, _ = r.state()
+	assert.Equal(t, 1, nPartials)
+	_, nTimeouts, _ = r.counters()
+	assert.Equal(t, uint64(3), nTimeouts)
+
+	// No timer is left running once stopped
+	r.stop()
+	assert.Nil(t, r.timer)
+	assert.Nil(t, r.receive(10, 0, 2, []byte("a0")))
+}
+
+// Tests that the least recently updated packets are dropped over the memory limit.
+func TestLpReassemblerMemoryLimit(t *testing.T) {
+	r := lpReassembler{}
+	limit := 2 * (4 + lpFragmentOverhead)
+	r.init(time.Second, limit)
+	defer r.stop()
+
+	assert.Nil(t, r.receive(10, 0, 2, []byte("aaaa")))
+	assert.Nil(t, r.receive(20, 0, 2, []byte("bbbb")))
+
+	// Packet A is dropped to make room for packet C
+	assert.Nil(t, r.receive(30, 0, 2, []byte("cccc")))
+	assert.Equal(t, uint64(1), r.nEvictions)
+	assert.Equal(t, uint64(0), r.nTimeouts)
+	assert.Equal(t, limit, r.size)
+	assert.Nil(t, r.receive(10, 1, 2, []byte("aaaa")))
+
+	// A packet larger than the limit is dropped
+	r.init(time.Second, limit)
+	assert.Nil(t, r.receive(40, 0, 2, []byte("dddddddddddd")))
+	assert.Nil(t, r.receive(40, 1, 2, []byte("dddddddddddd")))
+	assert.Equal(t, 0, r.size)
+	assert.Equal(t, 0, len(r.partials))
+}
+
+// Tests that tiny fragments are charged against the memory limit and that the
+// fragment count is bounded.
+func TestLpReassemblerTinyFragments(t *testing.T) {
+	r := lpReassembler{}
+	r.init(time.Second, 4*lpFragmentOverhead)
+	defer r.stop()
+
+	// Too many fragments
+	assert.Nil(t, r.receive(10, 0, lpMaxFragCount+1, []byte("x")))
+	assert.Equal(t, uint64(1), r.nInvalid)
+	assert.Equal(t, 0, len(r.partials))
+
+	// Empty fragments of distinct packets cannot grow the buffer without bound
+	for i := range uint64(100) {
+		assert.Nil(t, r.receive(100+i*lpMaxFragCount, 0, lpMaxFragCount, []byte{}))
+	}
+	assert.LessOrEqual(t, r.size, 4*lpFragmentOverhead)
+	assert.Equal(t, 4, len(r.partials))
+	assert.Equal(t, uint64(96), r.nEvictions)
+}
+
+// state returns the number of partial packets and their total size.
+func (r *lpReassembler) state() (nPartials int, size int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return len(r.partials), r.size
+}
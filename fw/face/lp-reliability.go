@@ -0,0 +1,304 @@
+/* YaNFD - Yet another NDN Forwarding Daemon
+ *
+ * This file is licensed under the terms of the MIT License, as found in LICENSE.md.
+ */
+
+package face
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	defn "github.com/named-data/ndnd/fw/defn"
+	"github.com/named-data/ndnd/fw/dispatch"
+	"github.com/named-data/ndnd/fw/table"
+	"github.com/named-data/ndnd/std/types/optional"
+)
+
+const txSequenceOverhead = 3 + 1 + 8
+const ackOverhead = 3 + 1 + 8
+
+// Link-layer reliability parameters, same as the defaults of NFD.
+const (
+	// lpReliabilityMaxRetx is the number of retransmissions of a frame before giving up.
+	lpReliabilityMaxRetx = 3
+	// lpReliabilityLossThreshold is the number of acks of later frames
+	// after which an unacknowledged frame is considered lost.
+	lpReliabilityLossThreshold = 3
+	// lpReliabilityTickInterval is the interval of retransmission checks and idle acks.
+	lpReliabilityTickInterval = 5 * time.Millisecond
+	// lpReliabilityRecentRxSize is the number of received Sequences
+	// remembered to detect retransmissions whose ack was lost.
+	lpReliabilityRecentRxSize = 1024
+)
+
+// lpReliability is the state of NDNLPv2 link-layer reliability of a link service.
+// Each sent frame carries a TxSequence, which the peer acknowledges with an Ack
+// piggybacked on its outgoing frames or in IDLE frames. Frames that are not
+// acknowledged within the RTO are retransmitted with a new TxSequence and the
+// same Sequence, which the peer uses to detect duplicates as in NFD.
+type lpReliability struct {
+	mutex sync.Mutex
+	// ticker drives retransmissions and idle acks in the send goroutine.
+	// It is stopped while reliability is disabled.
+	ticker *time.Ticker
+
+	// unacked contains the sent frames waiting for an ack, by TxSequence.
+	unacked map[uint64]*lpUnackedFrag
+	// pendingAcks contains the received TxSequences to acknowledge.
+	pendingAcks []uint64
+	// recentRx contains the recently received Sequences, oldest first in recentRxOrder.
+	recentRx      map[uint64]struct{}
+	recentRxOrder []uint64
+	// rtt estimates the retransmission timeout of the link.
+	rtt table.FaceMeasurement
+}
+
+// lpUnackedFrag is a sent frame waiting for an ack.
+type lpUnackedFrag struct {
+	frame     *defn.FwLpPacket
+	netPkt    *lpUnackedPacket
+	sendTime  time.Time
+	rtoExpiry time.Time
+	nRetx     int
+	// nGreaterAcks is the number of acks received for later frames.
+	nGreaterAcks int
+	// pending is set until the frame is sent, which may be delayed by the egress rate limit.
+	pending bool
+}
+
+// lpUnackedPacket is a network layer packet with fragments waiting for an ack.
+type lpUnackedPacket struct {
+	out  dispatch.OutPkt
+	lost bool
+}
+
+// init creates the reliability state with a stopped ticker.
+func (r *lpReliability) init() {
+	r.ticker = time.NewTicker(lpReliabilityTickInterval)
+	r.ticker.Stop()
+	r.reset()
+}
+
+// reset clears all state of the reliability protocol.
+func (r *lpReliability) reset() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.unacked = make(map[uint64]*lpUnackedFrag)
+	r.pendingAcks = nil
+	r.recentRx = make(map[uint64]struct{})
+	r.recentRxOrder = nil
+	r.rtt = table.FaceMeasurement{RTO: time.Second}
+}
+
+// setEnabled starts or stops the reliability protocol.
+func (r *lpReliability) setEnabled(enabled bool) {
+	if enabled {
+		r.ticker.Reset(lpReliabilityTickInterval)
+	} else {
+		r.ticker.Stop()
+		r.reset()
+	}
+}
+
+// track assigns a TxSequence to a frame and adds it to the retransmission queue.
+func (r *lpReliability) track(frame *defn.FwLpPacket, netPkt *lpUnackedPacket, txSequence uint64, nRetx int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := time.Now()
+	frame.TxSequence = optional.Some(txSequence)
+	r.unacked[txSequence] = &lpUnackedFrag{
+		frame:     frame,
+		netPkt:    netPkt,
+		sendTime:  now,
+		rtoExpiry: now.Add(r.rtt.RTO),
+		nRetx:     nRetx,
+		pending:   true,
+	}
+}
+
+// sent starts the retransmission timer of a frame once it is sent.
+func (r *lpReliability) sent(txSequence uint64) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if frag := r.unacked[txSequence]; frag != nil {
+		now := time.Now()
+		frag.sendTime = now
+		frag.rtoExpiry = now.Add(r.rtt.RTO)
+		frag.nGreaterAcks = 0
+		frag.pending = false
+	}
+}
+
+// popAcks removes pending acks that fit in the given number of bytes.
+func (r *lpReliability) popAcks(room int) []uint64 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	n := min(len(r.pendingAcks), room/ackOverhead)
+	if n <= 0 {
+		return nil
+	}
+	acks := slices.Clone(r.pendingAcks[:n])
+	r.pendingAcks = r.pendingAcks[n:]
+	return acks
+}
+
+// receive processes the Acks and TxSequence of a received frame.
+// Returns true if a frame with the same Sequence was already received,
+// in which case it is acknowledged again but must not be delivered.
+func (r *lpReliability) receive(frame *defn.FwLpPacket) (duplicate bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, ack := range frame.Acks {
+		frag := r.unacked[ack]
+		if frag == nil {
+			continue // duplicate or unknown ack
+		}
+		delete(r.unacked, ack)
+
+		// Retransmitted frames are ambiguous samples (Karn's algorithm)
+		if frag.nRetx == 0 {
+			r.rtt.AddRttSample(time.Since(frag.sendTime))
+		}
+
+		// Frames sent before the acknowledged frame are likely lost
+		for txSeq, other := range r.unacked {
+			if txSeq < ack {
+				other.nGreaterAcks++
+				if other.nGreaterAcks >= lpReliabilityLossThreshold {
+					other.rtoExpiry = time.Time{}
+				}
+			}
+		}
+	}
+
+	txSeq, ok := frame.TxSequence.Get()
+	if !ok {
+		return false
+	}
+
+	// The peer retransmits frames whose ack was lost, so always ack
+	r.pendingAcks = append(r.pendingAcks, txSeq)
+
+	// Retransmissions have a new TxSequence but keep the Sequence
+	seq, ok := frame.Sequence.Get()
+	if !ok {
+		return false
+	}
+	if _, ok := r.recentRx[seq]; ok {
+		return true
+	}
+	r.recentRx[seq] = struct{}{}
+	r.recentRxOrder = append(r.recentRxOrder, seq)
+	if len(r.recentRxOrder) > lpReliabilityRecentRxSize {
+		delete(r.recentRx, r.recentRxOrder[0])
+		r.recentRxOrder = r.recentRxOrder[1:]
+	}
+	return false
+}
+
+// expired removes frames that timed out or are considered lost from the
+// retransmission queue. Returns the frames to retransmit in order, and the
+// network layer packets that exceeded the retransmission limit.
+func (r *lpReliability) expired() (retx []*lpUnackedFrag, lost []dispatch.OutPkt) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := time.Now()
+	retxSeqs := make([]uint64, 0)
+	for txSeq, frag := range r.unacked {
+		if frag.pending || frag.netPkt.lost || now.Before(frag.rtoExpiry) {
+			continue
+		}
+		if frag.nRetx >= lpReliabilityMaxRetx {
+			frag.netPkt.lost = true
+			lost = append(lost, frag.netPkt.out)
+			continue
+		}
+		retxSeqs = append(retxSeqs, txSeq)
+	}
+
+	// Remaining fragments of lost packets are useless
+	for txSeq, frag := range r.unacked {
+		if frag.netPkt.lost {
+			delete(r.unacked, txSeq)
+		}
+	}
+
+	slices.Sort(retxSeqs)
+	for _, txSeq := range retxSeqs {
+		retx = append(retx, r.unacked[txSeq])
+		delete(r.unacked, txSeq)
+	}
+	if len(retx) > 0 {
+		r.rtt.AddTimeout() // back off
+	}
+	return retx, lost
+}
+
+// checkReliability retransmits expired frames, reports lost Interests and
+// sends pending acks in IDLE frames. Runs in the send goroutine.
+func (l *NDNLPLinkService) checkReliability() {
+	retx, lost := l.reliability.expired()
+
+	for _, frag := range retx {
+		core.Log.Debug(l, "Retransmitting frame", "txseq", frag.frame.TxSequence.Unwrap(), "retx", frag.nRetx+1)
+		l.sendReliableFrame(frag.frame, frag.netPkt, frag.nRetx+1)
+	}
+
+	for _, out := range lost {
+		core.Log.Debug(l, "Exceeded retransmission limit - DROP", "name", out.Pkt.Name)
+		if out.Pkt.L3.Interest != nil && !out.Pkt.NackReason.IsSet() {
+			l.dispatchLostInterest(out)
+		}
+	}
+
+	// Acks that could not be piggybacked are sent in IDLE frames
+	room := l.transport.MTU() - lpPacketOverhead
+	for acks := l.reliability.popAcks(room); len(acks) > 0; acks = l.reliability.popAcks(room) {
+		l.encodeAndSend(&defn.FwLpPacket{Acks: acks})
+	}
+}
+
+// sendReliableFrame sends a frame with a new TxSequence and piggybacked acks.
+// The Sequence of the frame is kept across retransmissions.
+func (l *NDNLPLinkService) sendReliableFrame(frame *defn.FwLpPacket, netPkt *lpUnackedPacket, nRetx int) {
+	l.nextTxSequence++
+	txSeq := l.nextTxSequence
+	l.reliability.track(frame, netPkt, txSeq, nRetx)
+
+	// Piggyback acks in the space left by the fragment
+	room := l.transport.MTU() - l.headerOverhead - l.optionalOverhead(frame) - int(frame.Fragment.Length())
+	frame.Acks = l.reliability.popAcks(room)
+
+	// Frames that could not be sent are retransmitted like lost ones
+	l.encodeAndSend(frame)
+	l.reliability.sent(txSeq)
+}
+
+// optionalOverhead returns the size of the optional header fields of a frame.
+func (l *NDNLPLinkService) optionalOverhead(frame *defn.FwLpPacket) (size int) {
+	if len(frame.PitToken) > 0 {
+		size += pitTokenOverhead
+	}
+	if frame.CongestionMark.IsSet() {
+		size += congestionMarkOverhead
+	}
+	if frame.Nack != nil {
+		size += nackOverhead
+	}
+	if frame.NonDiscovery {
+		size += nonDiscoveryOverhead
+	}
+	if pa := frame.PrefixAnnouncement; pa != nil {
+		size += prefixAnnOverhead + int(pa.Length())
+	}
+	return size
+}
@@ -0,0 +1,189 @@
+package face
+
+import (
+	"testing"
+	"time"
+
+	"github.com/named-data/ndnd/fw/defn"
+	"github.com/named-data/ndnd/fw/dispatch"
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/types/optional"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newReliableLinkService creates a link service with reliability enabled and
+// a recording forwarding thread 0. The ticker is stopped so that tests drive
+// checkReliability themselves.
+func newReliableLinkService(t *testing.T) (*NDNLPLinkService, *testTransport, *testFwThread) {
+	options := MakeNDNLPLinkServiceOptions()
+	options.IsReliabilityEnabled = true
+	l, tr := newTestLinkService(1500, options)
+	l.reliability.ticker.Stop()
+
+	fwThread := &testFwThread{}
+	dispatch.InitializeFWThreads([]dispatch.FWThread{fwThread})
+	t.Cleanup(func() { dispatch.InitializeFWThreads(nil) })
+	return l, tr, fwThread
+}
+
+// testInterest returns an outgoing Interest sent by forwarding thread 0.
+func testInterest(name string) dispatch.OutPkt {
+	n, _ := enc.NameFromStr(name)
+	l3 := &defn.FwPacket{Interest: &defn.FwInterest{NameV: n}}
+	return dispatch.OutPkt{
+		Pkt:      &defn.Pkt{Name: n, L3: l3, Raw: l3.Encode()},
+		PitToken: []byte{0, 0, 0, 0, 0, 1},
+	}
+}
+
+// expire makes all unacknowledged frames time out.
+func (r *lpReliability) expire() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, frag := range r.unacked {
+		frag.rtoExpiry = time.Time{}
+	}
+}
+
+// Tests that a frame is retransmitted with a new TxSequence after the RTO.
+func TestLpReliabilityRetxOnRto(t *testing.T) {
+	l, tr, _ := newReliableLinkService(t)
+
+	sendPacket(l, testInterest("/localhost/test/rto"))
+	frames := tr.popFrames(t)
+	require.Len(t, frames, 1)
+	txSeq := frames[0].TxSequence.Unwrap()
+
+	// Nothing is retransmitted before the RTO
+	l.checkReliability()
+	assert.Empty(t, tr.popFrames(t))
+
+	l.reliability.expire()
+	l.checkReliability()
+	frames = tr.popFrames(t)
+	require.Len(t, frames, 1)
+	assert.Greater(t, frames[0].TxSequence.Unwrap(), txSeq)
+	assert.Equal(t, 1, l.reliability.unacked[frames[0].TxSequence.Unwrap()].nRetx)
+
+	// The ack of the retransmission completes the frame
+	l.reliability.receive(&defn.FwLpPacket{Acks: []uint64{frames[0].TxSequence.Unwrap()}})
+	assert.Empty(t, l.reliability.unacked)
+}
+
+// Tests that a frame is retransmitted once enough later frames are acknowledged.
+func TestLpReliabilityLossByGreaterAcks(t *testing.T) {
+	l, tr, _ := newReliableLinkService(t)
+
+	for _, name := range []string{"/localhost/a", "/localhost/b", "/localhost/c", "/localhost/d"} {
+		sendPacket(l, testInterest(name))
+	}
+	frames := tr.popFrames(t)
+	require.Len(t, frames, 4)
+	first := frames[0].TxSequence.Unwrap()
+
+	// Two greater acks are not enough
+	for _, frame := range frames[1:3] {
+		l.reliability.receive(&defn.FwLpPacket{Acks: []uint64{frame.TxSequence.Unwrap()}})
+	}
+	l.checkReliability()
+	assert.Empty(t, tr.popFrames(t))
+
+	// The third one marks the first frame as lost before the RTO
+	l.reliability.receive(&defn.FwLpPacket{Acks: []uint64{frames[3].TxSequence.Unwrap()}})
+	l.checkReliability()
+	retx := tr.popFrames(t)
+	require.Len(t, retx, 1)
+	assert.Equal(t, frames[0].Fragment.Join(), retx[0].Fragment.Join())
+	assert.Greater(t, retx[0].TxSequence.Unwrap(), first)
+}
+
+// Tests that reliability gives up after the retransmission limit and notifies
+// the forwarding thread of the lost Interest.
+func TestLpReliabilityGiveUp(t *testing.T) {
+	l, tr, fwThread := newReliableLinkService(t)
+	l.faceID = 300
+
+	out := testInterest("/localhost/test/lost")
+	sendPacket(l, out)
+	require.Len(t, tr.popFrames(t), 1)
+
+	for range lpReliabilityMaxRetx {
+		l.reliability.expire()
+		l.checkReliability()
+		require.Len(t, tr.popFrames(t), 1)
+	}
+	assert.Empty(t, fwThread.lost)
+
+	l.reliability.expire()
+	l.checkReliability()
+	assert.Empty(t, tr.popFrames(t))
+	assert.Empty(t, l.reliability.unacked)
+
+	require.Len(t, fwThread.lost, 1)
+	lost := fwThread.lost[0]
+	assert.Equal(t, out.Pkt.Name, lost.Name)
+	assert.Equal(t, out.PitToken, lost.PitToken)
+	assert.Equal(t, uint64(300), lost.LostFaceID.Unwrap())
+
+	// Nacks are not reported
+	nack := testInterest("/localhost/test/nack")
+	nack.Pkt.NackReason = optional.Some(uint64(150))
+	sendPacket(l, nack)
+	for range lpReliabilityMaxRetx + 1 {
+		l.reliability.expire()
+		l.checkReliability()
+	}
+	assert.Len(t, fwThread.lost, 1)
+}
+
+// Tests that a frame retransmitted because its ack was lost is acked again
+// but delivered only once.
+func TestLpReliabilityDuplicate(t *testing.T) {
+	sender, senderTr, _ := newReliableLinkService(t)
+	receiver, receiverTr, fwThread := newReliableLinkService(t)
+
+	sendPacket(sender, testInterest("/localhost/test/dup"))
+	sendPacket(sender, testInterest("/localhost/test/other"))
+	require.Len(t, senderTr.frames, 2)
+	for _, frame := range senderTr.frames {
+		receiver.handleIncomingFrame(frame)
+	}
+	frames := senderTr.popFrames(t)
+	assert.NotEqual(t, frames[0].Sequence.Unwrap(), frames[1].Sequence.Unwrap())
+	assert.Len(t, fwThread.interests, 2)
+
+	// The acks are lost, so the sender retransmits both frames
+	receiver.checkReliability()
+	require.Len(t, receiverTr.popFrames(t), 1)
+	sender.reliability.expire()
+	sender.checkReliability()
+	require.Len(t, senderTr.frames, 2)
+	for _, frame := range senderTr.frames {
+		receiver.handleIncomingFrame(frame)
+	}
+	retx := senderTr.popFrames(t)
+	for i, frame := range retx {
+		assert.Equal(t, frames[i].Sequence.Unwrap(), frame.Sequence.Unwrap())
+		assert.NotEqual(t, frames[i].TxSequence.Unwrap(), frame.TxSequence.Unwrap())
+	}
+	assert.Len(t, fwThread.interests, 2)
+
+	// The retransmissions are acked, which completes the frames
+	receiver.checkReliability()
+	acks := receiverTr.popFrames(t)
+	require.Len(t, acks, 1)
+	assert.Equal(t, []uint64{retx[0].TxSequence.Unwrap(), retx[1].TxSequence.Unwrap()}, acks[0].Acks)
+	sender.reliability.receive(acks[0])
+	assert.Empty(t, sender.reliability.unacked)
+
+	// Old Sequences are forgotten
+	for i := range uint64(lpReliabilityRecentRxSize) {
+		receiver.reliability.receive(&defn.FwLpPacket{
+			TxSequence: optional.Some(100 + i),
+			Sequence:   optional.Some(100 + i),
+		})
+	}
+	receiver.handleIncomingFrame((&defn.FwPacket{LpPacket: retx[0]}).Encode().Join())
+	assert.Len(t, fwThread.interests, 3)
+}
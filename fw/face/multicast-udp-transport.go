@@ -178,11 +178,13 @@ func (t *MulticastUDPTransport) runReceive() {
 		if err != nil && t.running.Load() {
 			// Re-create the socket if connection is still running
 			core.Log.Warn(t, "Unable to read from socket - Face DOWN", "err", err)
+			notifyFaceEvent(spec_mgmt.FaceEventDown, t.linkService)
 			err = t.connectRecv()
 			if err != nil {
 				core.Log.Error(t, "Unable to re-create receive connection", "err", err)
 				return
 			}
+			notifyFaceEvent(spec_mgmt.FaceEventUp, t.linkService)
 		}
 	}
 }
@@ -17,11 +17,15 @@ import (
 	"github.com/named-data/ndnd/fw/dispatch"
 	enc "github.com/named-data/ndnd/std/encoding"
 	"github.com/named-data/ndnd/std/types/optional"
+	"github.com/named-data/ndnd/std/utils/pcapng"
 )
 
 const lpPacketOverhead = 1 + 3 + 1 + 3 // LpPacket+Fragment
 const pitTokenOverhead = 1 + 1 + 6
 const congestionMarkOverhead = 3 + 1 + 8
+const nackOverhead = 3 + 1 + 3 + 1 + 1 // Nack+NackReason
+const nonDiscoveryOverhead = 3 + 1
+const prefixAnnOverhead = 3 + 3
 
 const (
 	FaceFlagLocalFields = 1 << iota
@@ -42,8 +46,15 @@ type NDNLPLinkServiceOptions struct {
 
 	IsCongestionMarkingEnabled bool
 
+	IsReliabilityEnabled bool
+
 	BaseCongestionMarkingInterval   time.Duration
 	DefaultCongestionThresholdBytes uint64
+
+	// EgressRate is the maximum egress rate in bits per second (0 for unlimited).
+	EgressRate uint64
+	// EgressBurst is the number of bytes that may be sent at once above the egress rate.
+	EgressBurst uint64
 }
 
 // (AI GENERATED DESCRIPTION): Creates and returns the default NDN link‑service options: a 100 ms congestion‑marking interval, a 64 KiB congestion threshold, and both reassembly and fragmentation enabled.
@@ -62,12 +73,11 @@ type NDNLPLinkService struct {
 	options        NDNLPLinkServiceOptions
 	headerOverhead int
 
-	// Fragment reassembly ring buffer
-	reassemblyIndex   int
-	reassemblyBuffers [16]struct {
-		sequence uint64
-		buffer   enc.Wire
-	}
+	// Fragment reassembly state
+	reassembler lpReassembler
+
+	// Counters
+	nInLpInvalid uint64
 
 	// Outgoing packet state
 	nextSequence             uint64
@@ -75,6 +85,18 @@ type NDNLPLinkService struct {
 	lastTimeCongestionMarked time.Time
 	congestionCheck          uint64
 	outFrame                 []byte
+
+	// Link-layer reliability state
+	reliability lpReliability
+
+	// Traffic shaping state
+	shaper     tokenBucket
+	nOutShaped uint64
+	// shaperBusy is set while the send goroutine services reliability
+	// during a shaping wait. Frames sent meanwhile are charged without waiting.
+	shaperBusy bool
+	// sendStopped is set when the face stopped during a shaping wait.
+	sendStopped bool
 }
 
 // MakeNDNLPLinkService creates a new NDNLPv2 link service
@@ -92,6 +114,11 @@ func MakeNDNLPLinkService(transport transport, options NDNLPLinkServiceOptions)
 	l.congestionCheck = 0
 	l.outFrame = make([]byte, defn.MaxNDNPacketSize)
 
+	l.reassembler.init(CfgReassemblyTimeout(), CfgReassemblyBufferSize())
+	l.reliability.init()
+	l.reliability.setEnabled(options.IsReliabilityEnabled)
+	l.shaper.setRate(options.EgressRate, options.EgressBurst)
+
 	return l
 }
 
@@ -102,6 +129,12 @@ func (l *NDNLPLinkService) Options() NDNLPLinkServiceOptions {
 
 // SetOptions changes the settings of the NDNLPLinkService.
 func (l *NDNLPLinkService) SetOptions(options NDNLPLinkServiceOptions) {
+	if options.IsReliabilityEnabled != l.options.IsReliabilityEnabled {
+		l.reliability.setEnabled(options.IsReliabilityEnabled)
+	}
+	if options.EgressRate != l.options.EgressRate || options.EgressBurst != l.options.EgressBurst {
+		l.shaper.setRate(options.EgressRate, options.EgressBurst)
+	}
 	l.options = options
 	l.computeHeaderOverhead()
 }
@@ -114,11 +147,17 @@ func (l *NDNLPLinkService) computeHeaderOverhead() {
 		l.headerOverhead += 1 + 1 + 8 // Sequence
 		l.headerOverhead += 1 + 1 + 2 // FragIndex (max 2^16 fragments)
 		l.headerOverhead += 1 + 1 + 2 // FragCount
+	} else if l.options.IsReliabilityEnabled {
+		l.headerOverhead += 1 + 1 + 8 // Sequence
 	}
 
 	if l.options.IsIncomingFaceIndicationEnabled {
 		l.headerOverhead += 3 + 1 + 8 // IncomingFaceId
 	}
+
+	if l.options.IsReliabilityEnabled {
+		l.headerOverhead += txSequenceOverhead
+	}
 }
 
 // Run starts the face and associated goroutines
@@ -148,6 +187,7 @@ func (l *NDNLPLinkService) runReceive() {
 	}
 
 	l.transport.runReceive()
+	l.reassembler.stop()
 	l.stopped <- true
 }
 
@@ -157,12 +197,33 @@ func (l *NDNLPLinkService) runSend() {
 		runtime.LockOSThread()
 	}
 
+	defer func() {
+		l.reliability.ticker.Stop()
+		FaceTable.Remove(l.transport.FaceID())
+	}()
+
 	for {
+		// Priority packets are always sent first
+		var out dispatch.OutPkt
 		select {
-		case pkt := <-l.sendQueue:
-			sendPacket(l, pkt)
-		case <-l.stopped:
-			FaceTable.Remove(l.transport.FaceID())
+		case out = <-l.prioQueue:
+		default:
+			select {
+			case out = <-l.prioQueue:
+			case out = <-l.sendQueue:
+			case <-l.reliability.ticker.C:
+				l.checkReliability()
+				if l.sendStopped {
+					return
+				}
+				continue
+			case <-l.stopped:
+				return
+			}
+		}
+
+		sendPacket(l, out)
+		if l.sendStopped {
 			return
 		}
 	}
@@ -174,7 +235,9 @@ func sendPacket(l *NDNLPLinkService, out dispatch.OutPkt) {
 	wire := pkt.Raw
 
 	// Counters
-	if pkt.L3.Interest != nil {
+	if pkt.NackReason.IsSet() {
+		l.nOutNacks++
+	} else if pkt.L3.Interest != nil {
 		l.nOutInterests++
 	} else if pkt.L3.Data != nil {
 		l.nOutData++
@@ -198,6 +261,15 @@ func sendPacket(l *NDNLPLinkService, out dispatch.OutPkt) {
 	if congestionMark.IsSet() {
 		effectiveMtu -= congestionMarkOverhead
 	}
+	if pkt.NackReason.IsSet() {
+		effectiveMtu -= nackOverhead
+	}
+	if pkt.NonDiscovery {
+		effectiveMtu -= nonDiscoveryOverhead
+	}
+	if pa := pkt.PrefixAnnouncement; pa != nil {
+		effectiveMtu -= prefixAnnOverhead + int(pa.Length())
+	}
 
 	// Fragment packet if necessary
 	var fragments []*defn.FwLpPacket
@@ -237,6 +309,18 @@ func sendPacket(l *NDNLPLinkService, out dispatch.OutPkt) {
 	} else {
 		// No fragmentation necessary
 		fragments = []*defn.FwLpPacket{{Fragment: wire}}
+
+		// Reliability detects duplicate frames by Sequence
+		if l.options.IsReliabilityEnabled {
+			l.nextSequence++
+			fragments[0].Sequence = optional.Some(l.nextSequence)
+		}
+	}
+
+	// Link-layer reliability tracks the fragments of the packet together
+	var netPkt *lpUnackedPacket
+	if l.options.IsReliabilityEnabled {
+		netPkt = &lpUnackedPacket{out: out}
 	}
 
 	// Send fragment(s)
@@ -256,25 +340,56 @@ func sendPacket(l *NDNLPLinkService, out dispatch.OutPkt) {
 			fragment.CongestionMark = congestionMark
 		}
 
-		// Encode final LP frame
-		pkt := defn.FwPacket{LpPacket: fragment}
-		frameWire := pkt.Encode()
-		if frameWire == nil {
-			core.Log.Error(l, "Unable to encode fragment - DROP")
-			break
+		// Network Nack
+		if reason, ok := pkt.NackReason.Get(); ok {
+			fragment.Nack = &defn.FwNetworkNack{Reason: reason}
 		}
 
-		// Use preallocated buffer for outgoing frame
-		l.outFrame = l.outFrame[:0]
-		for _, b := range frameWire {
-			l.outFrame = append(l.outFrame, b...)
+		// Self-learning
+		fragment.NonDiscovery = pkt.NonDiscovery
+		fragment.PrefixAnnouncement = pkt.PrefixAnnouncement
+
+		if netPkt != nil {
+			l.sendReliableFrame(fragment, netPkt, 0)
+		} else if !l.encodeAndSend(fragment) {
+			break
 		}
-		l.transport.sendFrame(l.outFrame)
 	}
 }
 
+// encodeAndSend encodes an LP frame and sends it on the transport.
+func (l *NDNLPLinkService) encodeAndSend(fragment *defn.FwLpPacket) bool {
+	// Encode final LP frame
+	pkt := defn.FwPacket{LpPacket: fragment}
+	frameWire := pkt.Encode()
+	if frameWire == nil {
+		core.Log.Error(l, "Unable to encode fragment - DROP")
+		return false
+	}
+
+	// Egress rate limit applies to the whole link-layer frame
+	if !l.waitForTokens(int(frameWire.Length())) {
+		return false
+	}
+
+	// Use preallocated buffer for outgoing frame
+	l.outFrame = l.outFrame[:0]
+	for _, b := range frameWire {
+		l.outFrame = append(l.outFrame, b...)
+	}
+	if c := capture.Load(); c != nil {
+		c.write(l, l.outFrame, pcapng.DirOutbound)
+	}
+	l.transport.sendFrame(l.outFrame)
+	return true
+}
+
 // (AI GENERATED DESCRIPTION): Processes an incoming link‑layer frame: it decodes the L2 packet, optionally reassembles fragmented frames, extracts the encapsulated L3 Interest or Data, updates counters, and dispatches the packet to the appropriate handler.
 func (l *NDNLPLinkService) handleIncomingFrame(frame []byte) {
+	if c := capture.Load(); c != nil {
+		c.write(l, frame, pcapng.DirInbound)
+	}
+
 	// We have to copy so receive transport buffer can be reused
 	frameCopy := make([]byte, len(frame))
 	copy(frameCopy, frame)
@@ -289,6 +404,7 @@ func (l *NDNLPLinkService) handleIncomingFrame(frame []byte) {
 	L2, err := defn.ParseFwPacket(enc.NewWireView(wire), false)
 	if err != nil {
 		core.Log.Error(l, "Unable to decode incoming frame", "err", err)
+		l.nInLpInvalid++
 		return
 	}
 
@@ -301,6 +417,12 @@ func (l *NDNLPLinkService) handleIncomingFrame(frame []byte) {
 		LP := L2.LpPacket
 		fragment := LP.Fragment
 
+		// Link-layer reliability (acks may come in IDLE frames)
+		if l.options.IsReliabilityEnabled && l.reliability.receive(LP) {
+			core.Log.Trace(l, "Duplicate frame - DROP", "seq", LP.Sequence.Unwrap())
+			return
+		}
+
 		// If there is no fragment, then IDLE packet, drop.
 		if len(fragment) == 0 {
 			core.Log.Trace(l, "IDLE frame - DROP")
@@ -323,7 +445,7 @@ func (l *NDNLPLinkService) handleIncomingFrame(frame []byte) {
 			if fragIndex == 0 && fragCount == 1 {
 				// Bypass reassembly since only one fragment
 			} else {
-				fragment = l.reassemble(LP, baseSequence, fragIndex, fragCount)
+				fragment = l.reassembler.receive(baseSequence, fragIndex, fragCount, LP.Fragment.Join())
 				if fragment == nil {
 					// Nothing more to be done, so return
 					return
@@ -337,11 +459,25 @@ func (l *NDNLPLinkService) handleIncomingFrame(frame []byte) {
 		// Congestion mark
 		pkt.CongestionMark = LP.CongestionMark
 
+		// Network Nack
+		if LP.Nack != nil {
+			pkt.NackReason = optional.Some(LP.Nack.Reason)
+		}
+
+		// Self-learning
+		pkt.NonDiscovery = LP.NonDiscovery
+		pkt.PrefixAnnouncement = LP.PrefixAnnouncement
+
 		// Consumer-controlled forwarding (NextHopFaceId)
 		if l.options.IsConsumerControlledForwardingEnabled {
 			pkt.NextHopFaceID = LP.NextHopFaceId
 		}
 
+		// Local cache policy
+		if l.options.IsLocalCachePolicyEnabled && LP.CachePolicy != nil {
+			pkt.CachePolicy = optional.Some(LP.CachePolicy.CachePolicyType)
+		}
+
 		// No need to copy the pit token since it's already in its own buffer
 		// See the generated code for defn.FwLpPacket
 		pkt.PitToken = LP.PitToken
@@ -356,7 +492,14 @@ func (l *NDNLPLinkService) handleIncomingFrame(frame []byte) {
 	}
 
 	// Dispatch and update counters
-	if pkt.L3.Interest != nil {
+	if pkt.NackReason.IsSet() {
+		if pkt.L3.Interest == nil {
+			core.Log.Warn(l, "Received Nack without Interest - DROP")
+			return
+		}
+		l.nInNacks++
+		l.dispatchNack(pkt)
+	} else if pkt.L3.Interest != nil {
 		l.nInInterests++
 		l.dispatchInterest(pkt)
 	} else if pkt.L3.Data != nil {
@@ -367,63 +510,25 @@ func (l *NDNLPLinkService) handleIncomingFrame(frame []byte) {
 	}
 }
 
-// (AI GENERATED DESCRIPTION): Reassembles a fragmented FwLpPacket into a complete wire by storing each fragment in a sequence‑indexed buffer, validating fragment count and index, and returning the full packet once all fragments are received, then clearing the buffer.
-func (l *NDNLPLinkService) reassemble(
-	frame *defn.FwLpPacket,
-	baseSequence uint64,
-	fragIndex uint64,
-	fragCount uint64,
-) enc.Wire {
-	var buffer enc.Wire = nil
-	var bufIndex int = 0
-
-	// Check if reassembly buffer already exists
-	for i := range l.reassemblyBuffers {
-		if l.reassemblyBuffers[i].sequence == baseSequence {
-			bufIndex = i
-			buffer = l.reassemblyBuffers[bufIndex].buffer
-			break
-		}
-	}
-
-	// Use the next available buffer if this is a new sequence
-	if buffer == nil {
-		bufIndex = (l.reassemblyIndex + 1) % len(l.reassemblyBuffers)
-		l.reassemblyIndex = bufIndex
-		l.reassemblyBuffers[bufIndex].sequence = baseSequence
-		l.reassemblyBuffers[bufIndex].buffer = make(enc.Wire, fragCount)
-		buffer = l.reassemblyBuffers[bufIndex].buffer
-	}
-
-	// Validate fragCount has not changed
-	if fragCount != uint64(len(buffer)) {
-		core.Log.Warn(l, "Received fragment count does not match expected count",
-			"count", fragCount, "expected", len(buffer), "base", baseSequence)
-		return nil
-	}
-
-	// Validate fragIndex is valid
-	if fragIndex >= uint64(len(buffer)) {
-		core.Log.Warn(l, "Received fragment index out of range",
-			"index", fragIndex, "count", fragCount, "base", baseSequence)
-		return nil
-	}
-
-	// Store fragment in buffer
-	buffer[fragIndex] = frame.Fragment.Join() // should be only one fragment
-
-	// Check if all fragments have been received
-	for _, frag := range buffer {
-		if len(frag) == 0 {
-			return nil // not all fragments received
-		}
-	}
+// NInLpInvalid returns the number of invalid NDNLPv2 frames received on this face.
+func (l *NDNLPLinkService) NInLpInvalid() uint64 {
+	nInvalid, _, _ := l.reassembler.counters()
+	return l.nInLpInvalid + nInvalid
+}
 
-	// All fragments received, free up buffer
-	l.reassemblyBuffers[bufIndex].sequence = 0
-	l.reassemblyBuffers[bufIndex].buffer = nil
+// NReassemblyTimeouts returns the number of packets received on this face that
+// were dropped before all of their fragments were received.
+func (l *NDNLPLinkService) NReassemblyTimeouts() uint64 {
+	_, nTimeouts, _ := l.reassembler.counters()
+	return nTimeouts
+}
 
-	return buffer
+// NReassemblyEvictions returns the number of packets received on this face that
+// were dropped before all of their fragments were received because the
+// reassembly buffer was full.
+func (l *NDNLPLinkService) NReassemblyEvictions() uint64 {
+	_, _, nEvictions := l.reassembler.counters()
+	return nEvictions
 }
 
 // (AI GENERATED DESCRIPTION): Returns true if the packet should be marked for congestion, based on accumulated packet size, send‑queue depth, and time since the last congestion mark.
@@ -457,5 +562,8 @@ func (op *NDNLPLinkServiceOptions) Flags() (ret uint64) {
 	if op.IsCongestionMarkingEnabled {
 		ret |= FaceFlagCongestionMarking
 	}
+	if op.IsReliabilityEnabled {
+		ret |= FaceFlagLpReliabilityEnabled
+	}
 	return
 }
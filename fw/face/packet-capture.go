@@ -0,0 +1,270 @@
+/* YaNFD - Yet another NDN Forwarding Daemon
+ *
+ * This file is licensed under the terms of the MIT License, as found in LICENSE.md.
+ */
+
+package face
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/defn"
+	enc "github.com/named-data/ndnd/std/encoding"
+	spec_mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	"github.com/named-data/ndnd/std/utils"
+	"github.com/named-data/ndnd/std/utils/pcapng"
+)
+
+// Running packet capture, if any
+var capture atomic.Pointer[PacketCapture]
+
+// Ethernet header of captured frames, with zero addresses and the NDN EtherType.
+// This lets Wireshark decode frames of all face types with its NDN dissector.
+var captureEthHeader = []byte{
+	0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0,
+	byte(pcapng.EtherTypeNDN >> 8), byte(pcapng.EtherTypeNDN & 0xff),
+}
+
+const (
+	tlvLpPacket = 0x64
+	tlvInterest = 0x05
+	tlvData     = 0x06
+)
+
+// CaptureFilter selects the frames written to a packet capture.
+type CaptureFilter struct {
+	// FaceID of the captured face, or zero for all faces.
+	FaceID uint64
+	// Prefix of the captured packet names, or nil for all names.
+	Prefix enc.Name
+	// Types of captured packets (spec_mgmt.CapturePkt*), or zero for all types.
+	Types uint64
+}
+
+// PacketCapture writes the frames sent and received by faces to a pcapng file.
+// Each face is an interface of the capture, named by its face ID.
+type PacketCapture struct {
+	path   string
+	filter CaptureFilter
+
+	mutex    sync.Mutex
+	file     *os.File
+	buf      *bufio.Writer
+	writer   *pcapng.Writer
+	ifaces   map[uint64]uint32
+	nPackets uint64
+	closed   bool
+}
+
+// StartCapture starts capturing frames to a new pcapng file.
+// Only one capture can run at a time.
+func StartCapture(path string, filter CaptureFilter) (*PacketCapture, error) {
+	if capture.Load() != nil {
+		return nil, errors.New("a capture is already running")
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(filter.Prefix) == 0 {
+		filter.Prefix = nil
+	}
+	c := &PacketCapture{
+		path:   path,
+		filter: filter,
+		file:   file,
+		buf:    bufio.NewWriter(file),
+		ifaces: make(map[uint64]uint32),
+	}
+
+	c.writer, err = pcapng.NewWriter(c.buf, "ndnd "+utils.NDNdVersion)
+	if err == nil {
+		err = c.buf.Flush()
+	}
+	if err != nil || !capture.CompareAndSwap(nil, c) {
+		file.Close()
+		os.Remove(path)
+		if err == nil {
+			err = errors.New("a capture is already running")
+		}
+		return nil, err
+	}
+
+	core.Log.Info(c, "Started packet capture", "faceid", filter.FaceID, "prefix", filter.Prefix, "types", filter.Types)
+	return c, nil
+}
+
+// StopCapture stops the running capture and returns it.
+func StopCapture() (*PacketCapture, error) {
+	c := capture.Swap(nil)
+	if c == nil {
+		return nil, errors.New("no capture is running")
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.close()
+
+	core.Log.Info(c, "Stopped packet capture", "packets", c.nPackets)
+	return c, nil
+}
+
+// RunningCapture returns the running capture, or nil if there is none.
+func RunningCapture() *PacketCapture {
+	return capture.Load()
+}
+
+func (c *PacketCapture) String() string {
+	return fmt.Sprintf("capture (%s)", c.path)
+}
+
+// Path of the capture file.
+func (c *PacketCapture) Path() string {
+	return c.path
+}
+
+// Filter of the captured frames.
+func (c *PacketCapture) Filter() CaptureFilter {
+	return c.filter
+}
+
+// NPackets returns the number of frames written to the capture.
+func (c *PacketCapture) NPackets() uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.nPackets
+}
+
+// write adds a frame of a face to the capture if it matches the filter.
+func (c *PacketCapture) write(l *NDNLPLinkService, frame []byte, dir pcapng.Direction) {
+	if !c.filter.matches(l.faceID, frame) {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.closed {
+		return
+	}
+
+	iface, ok := c.ifaces[l.faceID]
+	var err error
+	if !ok {
+		name := fmt.Sprintf("face=%d", l.faceID)
+		iface, err = c.writer.AddInterface(pcapng.LinkTypeEthernet, name, l.RemoteURI().String())
+		c.ifaces[l.faceID] = iface
+	}
+	if err == nil {
+		err = c.writer.WritePacket(iface, time.Now(), dir, captureEthHeader, frame)
+	}
+	if err == nil {
+		// flush so the file can be followed while capturing
+		err = c.buf.Flush()
+	}
+	if err != nil {
+		core.Log.Error(c, "Unable to write packet capture - stopping", "err", err)
+		capture.CompareAndSwap(c, nil)
+		c.close()
+		return
+	}
+
+	c.nPackets++
+}
+
+// close flushes and closes the capture file. The mutex must be held.
+func (c *PacketCapture) close() {
+	if c.closed {
+		return
+	}
+	c.closed = true
+	c.buf.Flush()
+	c.file.Close()
+}
+
+// matches checks if a frame of a face is selected by the filter.
+func (f *CaptureFilter) matches(faceID uint64, frame []byte) bool {
+	if f.FaceID != 0 && f.FaceID != faceID {
+		return false
+	}
+	if f.Prefix == nil && f.Types == 0 {
+		return true
+	}
+
+	pktType, name := classifyFrame(frame)
+	if f.Types != 0 && f.Types&pktType == 0 {
+		return false
+	}
+	if f.Prefix != nil && (name == nil || !f.Prefix.IsPrefix(name)) {
+		return false
+	}
+	return true
+}
+
+// classifyFrame returns the packet type (spec_mgmt.CapturePkt*) and name of the packet in a frame.
+// Only the first fragment of a fragmented packet is classified; other fragments
+// and IDLE frames have a zero type and a nil name.
+func classifyFrame(frame []byte) (uint64, enc.Name) {
+	l3 := frame
+	isNack := false
+	if len(frame) > 0 && frame[0] == tlvLpPacket {
+		L2, err := defn.ParseFwPacket(enc.NewBufferView(frame), false)
+		if err != nil || L2.LpPacket == nil || len(L2.LpPacket.Fragment) == 0 {
+			return 0, nil
+		}
+		if L2.LpPacket.FragIndex.GetOr(0) > 0 {
+			return 0, nil
+		}
+		l3 = L2.LpPacket.Fragment.Join()
+		isNack = L2.LpPacket.Nack != nil
+	}
+
+	// The name is read directly since the first fragment is a truncated packet
+	r := enc.NewBufferView(l3)
+	typ, err := r.ReadTLNum()
+	if err != nil {
+		return 0, nil
+	}
+
+	var pktType uint64
+	switch {
+	case typ == tlvInterest && isNack:
+		pktType = spec_mgmt.CapturePktNack
+	case typ == tlvInterest:
+		pktType = spec_mgmt.CapturePktInterest
+	case typ == tlvData:
+		pktType = spec_mgmt.CapturePktData
+	default:
+		return 0, nil
+	}
+
+	if _, err = r.ReadTLNum(); err != nil { // length
+		return pktType, nil
+	}
+	if nameType, err := r.ReadTLNum(); err != nil || nameType != enc.TypeName {
+		return pktType, nil
+	}
+	nameLen, err := r.ReadTLNum()
+	if err != nil {
+		return pktType, nil
+	}
+	nameBuf, err := r.ReadBuf(int(nameLen))
+	if err != nil {
+		return pktType, nil
+	}
+	nameView := enc.NewBufferView(nameBuf)
+	name, err := nameView.ReadName()
+	if err != nil {
+		return pktType, nil
+	}
+	return pktType, name
+}
@@ -0,0 +1,71 @@
+package face
+
+import (
+	"testing"
+
+	"github.com/named-data/ndnd/fw/defn"
+	enc "github.com/named-data/ndnd/std/encoding"
+	spec_mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	"github.com/named-data/ndnd/std/types/optional"
+	"github.com/stretchr/testify/assert"
+)
+
+// Tests classification of bare packets, NDNLPv2 frames and fragments.
+func TestCaptureClassifyFrame(t *testing.T) {
+	name, _ := enc.NameFromStr("/example/data/1")
+	encode := func(pkt *defn.FwPacket) []byte {
+		return pkt.Encode().Join()
+	}
+
+	interest := encode(&defn.FwPacket{Interest: &defn.FwInterest{
+		NameV:        name,
+		CanBePrefixV: true,
+		MustBeFreshV: true,
+	}})
+	data := encode(&defn.FwPacket{Data: &defn.FwData{NameV: name}})
+
+	typ, n := classifyFrame(interest)
+	assert.Equal(t, spec_mgmt.CapturePktInterest, typ)
+	assert.Equal(t, name, n)
+
+	typ, n = classifyFrame(data)
+	assert.Equal(t, spec_mgmt.CapturePktData, typ)
+	assert.Equal(t, name, n)
+
+	// Nack of an Interest
+	typ, n = classifyFrame(encode(&defn.FwPacket{LpPacket: &defn.FwLpPacket{
+		Fragment: enc.Wire{interest},
+		Nack:     &defn.FwNetworkNack{Reason: 150},
+	}}))
+	assert.Equal(t, spec_mgmt.CapturePktNack, typ)
+	assert.Equal(t, name, n)
+
+	// First fragment has the name, later fragments are unknown
+	typ, n = classifyFrame(encode(&defn.FwPacket{LpPacket: &defn.FwLpPacket{
+		Fragment:  enc.Wire{interest[:len(interest)-3]},
+		Sequence:  optional.Some(uint64(10)),
+		FragIndex: optional.Some(uint64(0)),
+		FragCount: optional.Some(uint64(2)),
+	}}))
+	assert.Equal(t, spec_mgmt.CapturePktInterest, typ)
+	assert.Equal(t, name, n)
+
+	typ, n = classifyFrame(encode(&defn.FwPacket{LpPacket: &defn.FwLpPacket{
+		Fragment:  enc.Wire{interest[len(interest)-3:]},
+		Sequence:  optional.Some(uint64(11)),
+		FragIndex: optional.Some(uint64(1)),
+		FragCount: optional.Some(uint64(2)),
+	}}))
+	assert.Equal(t, uint64(0), typ)
+	assert.Nil(t, n)
+
+	// Filters
+	prefix, _ := enc.NameFromStr("/example")
+	other, _ := enc.NameFromStr("/other")
+	assert.True(t, (&CaptureFilter{}).matches(1, data))
+	assert.False(t, (&CaptureFilter{FaceID: 2}).matches(1, data))
+	assert.True(t, (&CaptureFilter{Prefix: prefix}).matches(1, data))
+	assert.False(t, (&CaptureFilter{Prefix: other}).matches(1, data))
+	assert.True(t, (&CaptureFilter{Types: spec_mgmt.CapturePktData}).matches(1, data))
+	assert.False(t, (&CaptureFilter{Types: spec_mgmt.CapturePktData}).matches(1, interest))
+}
@@ -16,6 +16,7 @@ import (
 	defn "github.com/named-data/ndnd/fw/defn"
 	"github.com/named-data/ndnd/fw/dispatch"
 	"github.com/named-data/ndnd/fw/table"
+	spec_mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
 )
 
 // FaceTable is the global face table for this forwarder
@@ -39,6 +40,7 @@ func (t *Table) Add(face LinkService) {
 	t.faces.Store(faceID, face)
 	dispatch.AddFace(faceID, face)
 	core.Log.Debug(t, "Registered face", "faceid", faceID)
+	notifyFaceEvent(spec_mgmt.FaceEventCreated, face)
 }
 
 // Get gets the face with the specified ID (if any) from the face table.
@@ -76,10 +78,13 @@ func (t *Table) GetAll() []LinkService {
 
 // Remove removes a face from the face table.
 func (t *Table) Remove(id uint64) {
-	t.faces.Delete(id)
+	face, ok := t.faces.LoadAndDelete(id)
 	dispatch.RemoveFace(id)
 	table.Rib.CleanUpFace(id)
 	core.Log.Info(t, "Unregistered face", "faceid", id)
+	if ok {
+		notifyFaceEvent(spec_mgmt.FaceEventDestroyed, face.(LinkService))
+	}
 }
 
 // expirationHandler stops the faces that have expired
@@ -0,0 +1,114 @@
+/* YaNFD - Yet another NDN Forwarding Daemon
+ *
+ * This file is licensed under the terms of the MIT License, as found in LICENSE.md.
+ */
+
+package face
+
+import (
+	"sync"
+	"time"
+)
+
+// tokenBucket limits the egress rate of a face.
+// Tokens are bytes, and accumulate at the rate up to the burst size.
+// A packet may be sent whenever the bucket is not in deficit, so that packets
+// larger than the burst size are still sent at the configured rate.
+type tokenBucket struct {
+	mutex sync.Mutex
+	// rate is the egress rate in bytes per second (0 for unlimited).
+	rate float64
+	// burst is the maximum number of tokens in bytes.
+	burst float64
+	// tokens is the current number of tokens, negative if in deficit.
+	tokens float64
+	// last is the time of the last refill.
+	last time.Time
+}
+
+// setRate changes the egress rate (in bits per second) and burst size (in bytes).
+func (b *tokenBucket) setRate(rate uint64, burst uint64) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.rate = float64(rate) / 8
+	b.burst = float64(burst)
+	b.tokens = b.burst
+	b.last = time.Now()
+}
+
+// reserve takes tokens for a packet of the given size.
+// Returns zero if the packet may be sent now, or the time to wait otherwise.
+func (b *tokenBucket) reserve(size int) time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.rate == 0 {
+		return 0
+	}
+
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	if b.tokens < 0 {
+		return max(time.Duration(-b.tokens/b.rate*float64(time.Second)), time.Microsecond)
+	}
+
+	b.tokens -= float64(size)
+	return 0
+}
+
+// charge takes tokens for a packet of the given size without waiting.
+func (b *tokenBucket) charge(size int) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.rate != 0 {
+		b.tokens -= float64(size)
+	}
+}
+
+// waitForTokens delays an outgoing frame of the given size until the egress
+// rate allows sending it. Link-layer reliability keeps running while waiting,
+// so that acks and retransmissions are not held up behind a large packet.
+// Returns false if the face stopped while waiting. Runs in the send goroutine.
+func (l *NDNLPLinkService) waitForTokens(size int) bool {
+	if l.sendStopped {
+		return false
+	}
+	if l.shaperBusy {
+		// Acks and retransmissions sent during a wait go into deficit
+		l.shaper.charge(size)
+		return true
+	}
+
+	for shaped := false; ; shaped = true {
+		wait := l.shaper.reserve(size)
+		if wait == 0 {
+			return true
+		}
+		if !shaped {
+			l.nOutShaped++
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-l.reliability.ticker.C:
+			timer.Stop()
+			l.shaperBusy = true
+			l.checkReliability()
+			l.shaperBusy = false
+		case <-l.stopped:
+			timer.Stop()
+			l.sendStopped = true
+			return false
+		}
+	}
+}
+
+// NOutShaped returns the number of frames delayed by the egress rate limit.
+func (l *NDNLPLinkService) NOutShaped() uint64 {
+	return l.nOutShaped
+}
@@ -0,0 +1,83 @@
+package face
+
+import (
+	"testing"
+	"time"
+
+	"github.com/named-data/ndnd/fw/defn"
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/types/optional"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Tests that the token bucket allows the burst size and then limits to the rate.
+func TestTokenBucket(t *testing.T) {
+	b := tokenBucket{}
+
+	// Unlimited by default
+	assert.Equal(t, time.Duration(0), b.reserve(1000000))
+
+	// 80 kbps is 10000 bytes per second
+	b.setRate(80000, 2000)
+	assert.Equal(t, time.Duration(0), b.reserve(1000))
+	assert.Equal(t, time.Duration(0), b.reserve(1000))
+
+	// Packets are sent while the bucket is not in deficit
+	assert.Equal(t, time.Duration(0), b.reserve(1500))
+	wait := b.reserve(1000)
+	assert.InDelta(t, 150*time.Millisecond, wait, float64(10*time.Millisecond))
+
+	time.Sleep(wait)
+	assert.Equal(t, time.Duration(0), b.reserve(1000))
+
+	// Charged packets add to the deficit without waiting
+	b.charge(1000)
+	wait = b.reserve(1000)
+	assert.InDelta(t, 200*time.Millisecond, wait, float64(10*time.Millisecond))
+
+	// Disabling the limit resets the bucket
+	b.setRate(0, 0)
+	assert.Equal(t, time.Duration(0), b.reserve(1000000))
+}
+
+// Tests that the egress rate limit is charged the link-layer frame size.
+func TestShaperChargesFrames(t *testing.T) {
+	options := MakeNDNLPLinkServiceOptions()
+	options.EgressRate = 80000
+	options.EgressBurst = 1000
+	l, tr := newTestLinkService(1500, options)
+
+	fragment := enc.Wire{make([]byte, 500)}
+	require.True(t, l.encodeAndSend(&defn.FwLpPacket{
+		Fragment: fragment,
+		Sequence: optional.Some(uint64(1)),
+	}))
+	require.Len(t, tr.frames, 1)
+	assert.Greater(t, len(tr.frames[0]), 500)
+	assert.InDelta(t, 1000-len(tr.frames[0]), l.shaper.tokens, 1)
+}
+
+// Tests that acks are still sent while a frame waits for the egress rate limit.
+func TestShaperKeepsReliability(t *testing.T) {
+	options := MakeNDNLPLinkServiceOptions()
+	options.IsReliabilityEnabled = true
+	options.EgressRate = 80000
+	l, tr := newTestLinkService(1500, options)
+	defer l.reliability.ticker.Stop()
+
+	// The bucket is 100ms in deficit while an ack is pending
+	l.shaper.charge(1000)
+	l.reliability.receive(&defn.FwLpPacket{TxSequence: optional.Some(uint64(7))})
+
+	start := time.Now()
+	require.True(t, l.waitForTokens(100))
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+	assert.Equal(t, uint64(1), l.nOutShaped)
+
+	// The ack was sent in an IDLE frame during the wait
+	frames := tr.popFrames(t)
+	require.Len(t, frames, 1)
+	assert.Equal(t, []uint64{7}, frames[0].Acks)
+	assert.Nil(t, frames[0].Fragment)
+}
@@ -0,0 +1,79 @@
+package face
+
+import (
+	"testing"
+
+	"github.com/named-data/ndnd/fw/defn"
+	enc "github.com/named-data/ndnd/std/encoding"
+	spec_mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	"github.com/stretchr/testify/require"
+)
+
+// testTransport is a transport that records the frames sent on it.
+type testTransport struct {
+	transportBase
+	frames [][]byte
+}
+
+func newTestTransport(mtu int) *testTransport {
+	t := &testTransport{}
+	t.makeTransportBase(
+		defn.MakeNullFaceURI(), defn.MakeNullFaceURI(),
+		spec_mgmt.PersistencyPermanent, defn.NonLocal, defn.PointToPoint, mtu)
+	return t
+}
+
+func (t *testTransport) String() string {
+	return "test-transport"
+}
+
+func (t *testTransport) SetPersistency(persistency spec_mgmt.Persistency) bool {
+	t.persistency = persistency
+	return true
+}
+
+func (t *testTransport) GetSendQueueSize() uint64 {
+	return 0
+}
+
+func (t *testTransport) sendFrame(frame []byte) {
+	t.frames = append(t.frames, append([]byte(nil), frame...))
+}
+
+func (t *testTransport) runReceive() {}
+
+func (t *testTransport) Close() {}
+
+// popFrames parses and clears the frames sent on the transport.
+func (t *testTransport) popFrames(tb testing.TB) []*defn.FwLpPacket {
+	frames := make([]*defn.FwLpPacket, 0, len(t.frames))
+	for _, frame := range t.frames {
+		pkt, err := defn.ParseFwPacket(enc.NewBufferView(frame), false)
+		require.NoError(tb, err)
+		require.NotNil(tb, pkt.LpPacket)
+		frames = append(frames, pkt.LpPacket)
+	}
+	t.frames = nil
+	return frames
+}
+
+// newTestLinkService creates an NDNLPv2 link service on a test transport.
+func newTestLinkService(mtu int, options NDNLPLinkServiceOptions) (*NDNLPLinkService, *testTransport) {
+	t := newTestTransport(mtu)
+	return MakeNDNLPLinkService(t, options), t
+}
+
+// testFwThread is a forwarding thread that records the packets queued to it.
+type testFwThread struct {
+	interests []*defn.Pkt
+	lost      []*defn.Pkt
+	dropped   []*defn.Pkt
+}
+
+func (t *testFwThread) String() string                     { return "test-fw-thread" }
+func (t *testFwThread) QueueData(*defn.Pkt)                {}
+func (t *testFwThread) QueueInterest(pkt *defn.Pkt)        { t.interests = append(t.interests, pkt) }
+func (t *testFwThread) QueueNack(*defn.Pkt)                {}
+func (t *testFwThread) QueueLostInterest(pkt *defn.Pkt)    { t.lost = append(t.lost, pkt) }
+func (t *testFwThread) QueueDroppedInterest(pkt *defn.Pkt) { t.dropped = append(t.dropped, pkt) }
+func (t *testFwThread) Counters() defn.FWThreadCounters    { return defn.FWThreadCounters{} }
@@ -256,6 +256,7 @@ func (t *UnicastTCPTransport) runReceive() {
 			}
 
 			core.Log.Warn(t, "Unable to read from socket - Face DOWN", "err", err)
+			notifyFaceEvent(spec_mgmt.FaceEventDown, t.linkService)
 		}
 
 		// Persistent faces will reconnect, otherwise close
@@ -266,6 +267,7 @@ func (t *UnicastTCPTransport) runReceive() {
 
 		core.Log.Info(t, "Connected socket - Face UP")
 		t.running.Store(true)
+		notifyFaceEvent(spec_mgmt.FaceEventUp, t.linkService)
 	}
 }
 
@@ -0,0 +1,344 @@
+package fw
+
+import (
+	"math/rand/v2"
+	"sort"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/defn"
+	"github.com/named-data/ndnd/fw/table"
+	enc "github.com/named-data/ndnd/std/encoding"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	"github.com/named-data/ndnd/std/types/optional"
+)
+
+// AsfSuppressionTime is the time to suppress retransmissions of the same Interest.
+const AsfSuppressionTime = 400 * time.Millisecond
+
+// AsfProbingInterval is the interval between probes of alternative nexthops of a prefix.
+const AsfProbingInterval = 60 * time.Second
+
+// AsfMeasurementsLifetime is how long measurements of an unused prefix are kept.
+const AsfMeasurementsLifetime = 5 * time.Minute
+
+// ASF is an adaptive forwarding strategy that ranks nexthops by their measured
+// smoothed RTT, periodically probes alternative nexthops, and fails over on
+// timeouts and Nacks.
+type ASF struct {
+	StrategyBase
+	suppressionTime time.Duration
+	probingInterval time.Duration
+}
+
+// asfInfo is the per-prefix state of the ASF strategy.
+type asfInfo struct {
+	nextProbe time.Time
+}
+
+func init() {
+	strategyInit["asf"] = func() Strategy { return &ASF{} }
+	StrategyVersions["asf"] = []uint64{1}
+}
+
+// Instantiate initializes the ASF strategy on a forwarding thread.
+// The retx-suppression and probing-interval parameters are in milliseconds.
+func (s *ASF) Instantiate(fwThread *Thread, params StrategyParams) (err error) {
+	if err = params.Check("retx-suppression", "probing-interval"); err != nil {
+		return err
+	}
+	if s.suppressionTime, err = params.Duration("retx-suppression", AsfSuppressionTime); err != nil {
+		return err
+	}
+	if s.probingInterval, err = params.Duration("probing-interval", AsfProbingInterval); err != nil {
+		return err
+	}
+	s.NewStrategyBase(fwThread, "asf", 1, params)
+	return nil
+}
+
+// AfterContentStoreHit sends the cached Data back to the requesting face.
+func (s *ASF) AfterContentStoreHit(
+	packet *defn.Pkt,
+	pitEntry table.PitEntry,
+	inFace uint64,
+) {
+	core.Log.Trace(s, "AfterContentStoreHit", "name", packet.Name, "faceid", inFace)
+	s.SendData(packet, pitEntry, inFace, 0) // 0 indicates ContentStore is source
+}
+
+// AfterReceiveData records the RTT of the upstream and forwards the Data downstream.
+func (s *ASF) AfterReceiveData(
+	packet *defn.Pkt,
+	pitEntry table.PitEntry,
+	inFace uint64,
+) {
+	core.Log.Trace(s, "AfterReceiveData", "name", packet.Name, "inrecords", len(pitEntry.InRecords()))
+	s.recordRtt(pitEntry, inFace)
+
+	for faceID := range pitEntry.InRecords() {
+		core.Log.Trace(s, "Forwarding Data", "name", packet.Name, "faceid", faceID)
+		s.SendData(packet, pitEntry, faceID, inFace)
+	}
+}
+
+// AfterReceiveInterest forwards an Interest to the best ranked nexthop,
+// and to a probed alternative nexthop when probing is due.
+func (s *ASF) AfterReceiveInterest(
+	packet *defn.Pkt,
+	pitEntry table.PitEntry,
+	inFace uint64,
+	nexthops []*table.FibNextHopEntry,
+) {
+	if len(nexthops) == 0 {
+		core.Log.Debug(s, "No nexthop found - NACK", "name", packet.Name)
+		s.RejectInterest(packet, pitEntry, spec.NackReasonNoRoute)
+		return
+	}
+
+	now := time.Now()
+	outRecords := pitEntry.OutRecords()
+	isRetransmission := len(outRecords) > 0
+
+	// Suppress retransmissions of the same Interest within suppression time
+	for _, oR := range outRecords {
+		if oR.LatestTimestamp.Add(s.suppressionTime).After(now) {
+			core.Log.Debug(s, "Suppressed Interest - DROP", "name", packet.Name)
+			return
+		}
+	}
+
+	entry := s.measurements(pitEntry, true)
+	if entry == nil {
+		entry = &table.MeasurementsEntry{} // FIB changed under us
+	}
+	ranked := s.rank(entry, nexthops)
+
+	// Retransmissions go to the best nexthop that was not tried yet,
+	// or the least recently tried one if all of them were.
+	if isRetransmission {
+		sort.SliceStable(ranked, func(i, j int) bool {
+			return s.lastTried(outRecords, ranked[i]).Before(s.lastTried(outRecords, ranked[j]))
+		})
+	}
+
+	var sentTo *table.FibNextHopEntry
+	for _, nh := range ranked {
+		core.Log.Trace(s, "Forwarding Interest", "name", packet.Name, "faceid", nh.Nexthop)
+		if s.SendInterest(packet, pitEntry, nh.Nexthop, inFace) {
+			sentTo = nh
+			break
+		}
+	}
+	if sentTo == nil {
+		core.Log.Debug(s, "No usable nexthop for Interest - NACK", "name", packet.Name)
+		s.RejectInterest(packet, pitEntry, spec.NackReasonNoRoute)
+		return
+	}
+
+	// Probe an alternative nexthop to learn its performance
+	info := s.info(entry)
+	if isRetransmission || len(ranked) < 2 || now.Before(info.nextProbe) {
+		return
+	}
+	info.nextProbe = now.Add(s.probingInterval)
+
+	if probe := s.probeCandidate(ranked, sentTo); probe != nil {
+		core.Log.Debug(s, "Probing nexthop", "name", packet.Name, "faceid", probe.Nexthop)
+		s.SendInterest(packet, pitEntry, probe.Nexthop, inFace)
+	}
+}
+
+// AfterReceiveNack records the Nack as a failure of the upstream and retries the
+// Interest on the next nexthop, or rejects it once all upstreams returned a Nack.
+func (s *ASF) AfterReceiveNack(
+	packet *defn.Pkt,
+	pitEntry table.PitEntry,
+	inFace uint64,
+) {
+	core.Log.Trace(s, "AfterReceiveNack", "name", packet.Name, "faceid", inFace)
+
+	if s.failover(packet, pitEntry, inFace) {
+		return
+	}
+	s.ProcessNack(packet, pitEntry)
+}
+
+// AfterLostInterest records the loss as a failure of the upstream and retries
+// the Interest on the next nexthop.
+func (s *ASF) AfterLostInterest(
+	packet *defn.Pkt,
+	pitEntry table.PitEntry,
+	outFace uint64,
+) {
+	core.Log.Trace(s, "AfterLostInterest", "name", packet.Name, "faceid", outFace)
+	s.failover(packet, pitEntry, outFace)
+}
+
+// BeforeExpirePendingInterest records a timeout for each upstream that did not answer.
+func (s *ASF) BeforeExpirePendingInterest(pitEntry table.PitEntry) {
+	entry := s.measurements(pitEntry, false)
+	if entry == nil {
+		return
+	}
+
+	now := time.Now()
+	for faceID, oR := range pitEntry.OutRecords() {
+		if !oR.NackReason.IsSet() && !oR.ExpirationTime.After(now) {
+			core.Log.Debug(s, "Upstream timed out", "name", pitEntry.EncName(), "faceid", faceID)
+			entry.Face(faceID).AddTimeout()
+		}
+	}
+}
+
+// BeforeSatisfyInterest records the RTT of the upstream.
+func (s *ASF) BeforeSatisfyInterest(pitEntry table.PitEntry, inFace uint64) {
+	s.recordRtt(pitEntry, inFace)
+}
+
+// failover records a failure of an upstream and sends the Interest to the best
+// ranked nexthop that was not tried yet. Returns false if there is no such nexthop.
+func (s *ASF) failover(packet *defn.Pkt, pitEntry table.PitEntry, failedFace uint64) bool {
+	entry := s.measurements(pitEntry, false)
+	if entry == nil {
+		return false
+	}
+	entry.Face(failedFace).AddTimeout()
+
+	// Interests of /localhop are not failed over since the allowed
+	// nexthops depend on the downstream
+	if packet.Name.At(0).Equal(enc.LOCALHOP) {
+		return false
+	}
+
+	inRecords := pitEntry.InRecords()
+	outRecords := pitEntry.OutRecords()
+
+	var downstream uint64
+	for faceID := range inRecords {
+		downstream = faceID
+		break
+	}
+
+	nexthops := table.FibStrategyTable.FindNextHopsEnc(s.lookupName(pitEntry))
+	for _, nh := range s.rank(entry, nexthops) {
+		if inRecords[nh.Nexthop] != nil || outRecords[nh.Nexthop] != nil {
+			continue
+		}
+
+		// Forward the Interest carried by the Nack or lost on the link
+		interest := *packet
+		interest.NackReason = optional.None[uint64]()
+		interest.LostFaceID = optional.None[uint64]()
+		core.Log.Debug(s, "Failing over", "name", packet.Name, "faceid", nh.Nexthop)
+		if s.SendInterest(&interest, pitEntry, nh.Nexthop, downstream) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupName returns the name used to look up the FIB for a PIT entry.
+func (s *ASF) lookupName(pitEntry table.PitEntry) enc.Name {
+	if hint := pitEntry.ForwardingHintNew(); len(hint) > 0 {
+		return hint
+	}
+	return pitEntry.EncName()
+}
+
+// measurements returns the measurements entry of the FIB prefix matching a PIT entry.
+func (s *ASF) measurements(pitEntry table.PitEntry, create bool) *table.MeasurementsEntry {
+	prefix := table.FibStrategyTable.FindNextHopsPrefixEnc(s.lookupName(pitEntry))
+	if prefix == nil {
+		return nil
+	}
+	if create {
+		return s.Measurements().Get(prefix, AsfMeasurementsLifetime)
+	}
+	return s.Measurements().Find(prefix)
+}
+
+// info returns the ASF state of a measurements entry.
+func (s *ASF) info(entry *table.MeasurementsEntry) *asfInfo {
+	info, ok := entry.StrategyInfo.(*asfInfo)
+	if !ok {
+		info = &asfInfo{}
+		entry.StrategyInfo = info
+	}
+	return info
+}
+
+// recordRtt adds an RTT sample for the upstream that satisfied a PIT entry.
+func (s *ASF) recordRtt(pitEntry table.PitEntry, inFace uint64) {
+	oR := pitEntry.OutRecords()[inFace]
+	if oR == nil {
+		return
+	}
+	if entry := s.measurements(pitEntry, true); entry != nil {
+		entry.Face(inFace).AddRttSample(time.Since(oR.LatestTimestamp))
+	}
+}
+
+// rank orders nexthops with working faces first by SRTT, then faces without
+// measurements and finally faces that timed out, each by cost.
+func (s *ASF) rank(entry *table.MeasurementsEntry, nexthops []*table.FibNextHopEntry) []*table.FibNextHopEntry {
+	class := func(nh *table.FibNextHopEntry) (int, time.Duration) {
+		fm := entry.Faces[nh.Nexthop]
+		switch {
+		case fm == nil:
+			return 1, 0
+		case fm.NTimeouts > 0:
+			return 2, 0
+		case fm.SRTT > 0:
+			return 0, fm.SRTT
+		default:
+			return 1, 0
+		}
+	}
+
+	ranked := append([]*table.FibNextHopEntry{}, nexthops...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		ci, ri := class(ranked[i])
+		cj, rj := class(ranked[j])
+		if ci != cj {
+			return ci < cj
+		}
+		if ri != rj {
+			return ri < rj
+		}
+		return ranked[i].Cost < ranked[j].Cost
+	})
+	return ranked
+}
+
+// lastTried returns when the Interest was last sent to a nexthop, zero if never.
+func (s *ASF) lastTried(outRecords map[uint64]*table.PitOutRecord, nh *table.FibNextHopEntry) time.Time {
+	if oR := outRecords[nh.Nexthop]; oR != nil {
+		return oR.LatestTimestamp
+	}
+	return time.Time{}
+}
+
+// probeCandidate picks an alternative nexthop to probe, favoring higher ranked ones.
+// The nexthop at rank i of n is picked with probability 2(n+1-i)/(n(n+1)).
+func (s *ASF) probeCandidate(ranked []*table.FibNextHopEntry, exclude *table.FibNextHopEntry) *table.FibNextHopEntry {
+	candidates := make([]*table.FibNextHopEntry, 0, len(ranked)-1)
+	for _, nh := range ranked {
+		if nh != exclude {
+			candidates = append(candidates, nh)
+		}
+	}
+
+	n := len(candidates)
+	if n == 0 {
+		return nil
+	}
+	pick := rand.IntN(n * (n + 1) / 2)
+	for i, nh := range candidates {
+		pick -= n - i
+		if pick < 0 {
+			return nh
+		}
+	}
+	return candidates[n-1]
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/named-data/ndnd/fw/core"
 	"github.com/named-data/ndnd/fw/defn"
 	"github.com/named-data/ndnd/fw/table"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
 )
 
 // BestRouteSuppressionTime is the time to suppress retransmissions of the same Interest.
@@ -23,17 +24,26 @@ const BestRouteSuppressionTime = 400 * time.Millisecond
 // to the nexthop with the lowest cost.
 type BestRoute struct {
 	StrategyBase
+	suppressionTime time.Duration
 }
 
 // (AI GENERATED DESCRIPTION): Registers the BestRoute strategy with version 1 in the strategy registry by appending its constructor to the init list.
 func init() {
-	strategyInit = append(strategyInit, func() Strategy { return &BestRoute{} })
+	strategyInit["best-route"] = func() Strategy { return &BestRoute{} }
 	StrategyVersions["best-route"] = []uint64{1}
 }
 
-// (AI GENERATED DESCRIPTION): Initializes the *BestRoute strategy by setting up its base with the name “best‑route” and priority 1 on the provided forwarding thread.
-func (s *BestRoute) Instantiate(fwThread *Thread) {
-	s.NewStrategyBase(fwThread, "best-route", 1)
+// Instantiate initializes the best-route strategy on a forwarding thread.
+// The retx-suppression parameter sets the suppression time in milliseconds.
+func (s *BestRoute) Instantiate(fwThread *Thread, params StrategyParams) (err error) {
+	if err = params.Check("retx-suppression"); err != nil {
+		return err
+	}
+	if s.suppressionTime, err = params.Duration("retx-suppression", BestRouteSuppressionTime); err != nil {
+		return err
+	}
+	s.NewStrategyBase(fwThread, "best-route", 1, params)
+	return nil
 }
 
 // (AI GENERATED DESCRIPTION): Sends a cached Data packet (retrieved from the Content Store) back to the requester via the specified PIT entry, using the requesting face and indicating the Content Store as the data source.
@@ -67,7 +77,8 @@ func (s *BestRoute) AfterReceiveInterest(
 	nexthops []*table.FibNextHopEntry,
 ) {
 	if len(nexthops) == 0 {
-		core.Log.Debug(s, "No nexthop found - DROP", "name", packet.Name)
+		core.Log.Debug(s, "No nexthop found - NACK", "name", packet.Name)
+		s.RejectInterest(packet, pitEntry, spec.NackReasonNoRoute)
 		return
 	}
 
@@ -81,7 +92,7 @@ func (s *BestRoute) AfterReceiveInterest(
 			if pass == 0 {
 				if oR := pitEntry.OutRecords()[nh.Nexthop]; oR != nil {
 					// Suppress retransmissions of the same Interest within suppression time
-					if oR.LatestTimestamp.Add(BestRouteSuppressionTime).After(now) {
+					if oR.LatestTimestamp.Add(s.suppressionTime).After(now) {
 						core.Log.Debug(s, "Suppressed Interest - DROP", "name", packet.Name)
 						return
 					}
@@ -102,7 +113,32 @@ func (s *BestRoute) AfterReceiveInterest(
 		}
 	}
 
-	core.Log.Debug(s, "No usable nexthop for Interest - DROP", "name", packet.Name)
+	core.Log.Debug(s, "No usable nexthop for Interest - NACK", "name", packet.Name)
+	s.RejectInterest(packet, pitEntry, spec.NackReasonNoRoute)
+}
+
+// AfterReceiveNack rejects the Interest downstream once all upstreams have returned a Nack.
+func (s *BestRoute) AfterReceiveNack(
+	packet *defn.Pkt,
+	pitEntry table.PitEntry,
+	inFace uint64,
+) {
+	core.Log.Trace(s, "AfterReceiveNack", "name", packet.Name, "faceid", inFace)
+	s.ProcessNack(packet, pitEntry)
+}
+
+// AfterLostInterest does nothing in BestRoute.
+func (s *BestRoute) AfterLostInterest(
+	packet *defn.Pkt,
+	pitEntry table.PitEntry,
+	outFace uint64,
+) {
+	// This does nothing in BestRoute
+}
+
+// BeforeExpirePendingInterest does nothing in BestRoute.
+func (s *BestRoute) BeforeExpirePendingInterest(pitEntry table.PitEntry) {
+	// This does nothing in BestRoute
 }
 
 // (AI GENERATED DESCRIPTION): No‑op; the BestRoute strategy performs no action before satisfying an Interest.
@@ -13,6 +13,7 @@ import (
 	"github.com/named-data/ndnd/fw/core"
 	"github.com/named-data/ndnd/fw/defn"
 	"github.com/named-data/ndnd/fw/table"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
 )
 
 // MulticastSuppressionTime is the time to suppress retransmissions of the same Interest.
@@ -21,17 +22,26 @@ const MulticastSuppressionTime = 500 * time.Millisecond
 // Multicast is a forwarding strategy that forwards Interests to all nexthop faces.
 type Multicast struct {
 	StrategyBase
+	suppressionTime time.Duration
 }
 
 // (AI GENERATED DESCRIPTION): Registers the Multicast strategy by appending its constructor to the global strategy initialization list and associating the version “1” with the “multicast” key.
 func init() {
-	strategyInit = append(strategyInit, func() Strategy { return &Multicast{} })
+	strategyInit["multicast"] = func() Strategy { return &Multicast{} }
 	StrategyVersions["multicast"] = []uint64{1}
 }
 
-// (AI GENERATED DESCRIPTION): Initializes the multicast strategy by setting up its base with the supplied thread, assigning it the name “multicast” and version 1.
-func (s *Multicast) Instantiate(fwThread *Thread) {
-	s.NewStrategyBase(fwThread, "multicast", 1)
+// Instantiate initializes the multicast strategy on a forwarding thread.
+// The retx-suppression parameter sets the suppression time in milliseconds.
+func (s *Multicast) Instantiate(fwThread *Thread, params StrategyParams) (err error) {
+	if err = params.Check("retx-suppression"); err != nil {
+		return err
+	}
+	if s.suppressionTime, err = params.Duration("retx-suppression", MulticastSuppressionTime); err != nil {
+		return err
+	}
+	s.NewStrategyBase(fwThread, "multicast", 1, params)
+	return nil
 }
 
 // (AI GENERATED DESCRIPTION): Sends the cached Data packet to the originating face after a content‑store hit.
@@ -65,7 +75,8 @@ func (s *Multicast) AfterReceiveInterest(
 	nexthops []*table.FibNextHopEntry,
 ) {
 	if len(nexthops) == 0 {
-		core.Log.Debug(s, "No nexthop for Interest", "name", packet.Name)
+		core.Log.Debug(s, "No nexthop for Interest - NACK", "name", packet.Name)
+		s.RejectInterest(packet, pitEntry, spec.NackReasonNoRoute)
 		return
 	}
 
@@ -74,7 +85,7 @@ func (s *Multicast) AfterReceiveInterest(
 	now := time.Now()
 	for _, outRecord := range pitEntry.OutRecords() {
 		if outRecord.LatestNonce != packet.L3.Interest.NonceV.Unwrap() &&
-			outRecord.LatestTimestamp.Add(MulticastSuppressionTime).After(now) {
+			outRecord.LatestTimestamp.Add(s.suppressionTime).After(now) {
 			core.Log.Debug(s, "Suppressed Interest", "name", packet.Name)
 			return
 		}
@@ -87,6 +98,30 @@ func (s *Multicast) AfterReceiveInterest(
 	}
 }
 
+// AfterReceiveNack rejects the Interest downstream once all upstreams have returned a Nack.
+func (s *Multicast) AfterReceiveNack(
+	packet *defn.Pkt,
+	pitEntry table.PitEntry,
+	inFace uint64,
+) {
+	core.Log.Trace(s, "AfterReceiveNack", "name", packet.Name, "faceid", inFace)
+	s.ProcessNack(packet, pitEntry)
+}
+
+// AfterLostInterest does nothing in Multicast.
+func (s *Multicast) AfterLostInterest(
+	packet *defn.Pkt,
+	pitEntry table.PitEntry,
+	outFace uint64,
+) {
+	// This does nothing in Multicast
+}
+
+// BeforeExpirePendingInterest does nothing in Multicast.
+func (s *Multicast) BeforeExpirePendingInterest(pitEntry table.PitEntry) {
+	// This does nothing in Multicast
+}
+
 // (AI GENERATED DESCRIPTION): No‑op hook invoked before satisfying an Interest in the Multicast strategy – it performs no action.
 func (s *Multicast) BeforeSatisfyInterest(pitEntry table.PitEntry, inFace uint64) {
 	// This does nothing in Multicast
@@ -0,0 +1,283 @@
+package fw
+
+import (
+	"sort"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/defn"
+	"github.com/named-data/ndnd/fw/dispatch"
+	"github.com/named-data/ndnd/fw/table"
+	enc "github.com/named-data/ndnd/std/encoding"
+	spec_mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	sec "github.com/named-data/ndnd/std/security"
+	"github.com/named-data/ndnd/std/security/signer"
+)
+
+// SelfLearningSuppressionTime is the time to suppress retransmissions of the same Interest.
+const SelfLearningSuppressionTime = 400 * time.Millisecond
+
+// SelfLearningRouteLifetime is the maximum lifetime of a learned route,
+// and the expiration of announcements made for local producers.
+const SelfLearningRouteLifetime = 10 * time.Minute
+
+// SelfLearning is a forwarding strategy for networks without routing.
+// Interests without a route are flooded as discovery Interests. The producer's
+// forwarder attaches a prefix announcement to the Data, from which the forwarders
+// on the way back learn a route. Later Interests are unicast along learned routes.
+type SelfLearning struct {
+	StrategyBase
+	suppressionTime time.Duration
+	routeLifetime   time.Duration
+}
+
+func init() {
+	strategyInit["self-learning"] = func() Strategy { return &SelfLearning{} }
+	StrategyVersions["self-learning"] = []uint64{1}
+}
+
+// Instantiate initializes the self-learning strategy on a forwarding thread.
+// The retx-suppression and route-lifetime parameters are in milliseconds.
+func (s *SelfLearning) Instantiate(fwThread *Thread, params StrategyParams) (err error) {
+	if err = params.Check("retx-suppression", "route-lifetime"); err != nil {
+		return err
+	}
+	if s.suppressionTime, err = params.Duration("retx-suppression", SelfLearningSuppressionTime); err != nil {
+		return err
+	}
+	if s.routeLifetime, err = params.Duration("route-lifetime", SelfLearningRouteLifetime); err != nil {
+		return err
+	}
+	s.NewStrategyBase(fwThread, "self-learning", 1, params)
+	return nil
+}
+
+// AfterContentStoreHit sends the cached Data back to the requesting face.
+func (s *SelfLearning) AfterContentStoreHit(
+	packet *defn.Pkt,
+	pitEntry table.PitEntry,
+	inFace uint64,
+) {
+	core.Log.Trace(s, "AfterContentStoreHit", "name", packet.Name, "faceid", inFace)
+	s.SendData(packet, pitEntry, inFace, 0) // 0 indicates ContentStore is source
+}
+
+// AfterReceiveData learns a route from the prefix announcement of the Data, or
+// announces the local producer, and forwards the Data downstream. Downstreams
+// that sent a discovery Interest receive the prefix announcement.
+func (s *SelfLearning) AfterReceiveData(
+	packet *defn.Pkt,
+	pitEntry table.PitEntry,
+	inFace uint64,
+) {
+	core.Log.Trace(s, "AfterReceiveData", "name", packet.Name, "inrecords", len(pitEntry.InRecords()))
+
+	announcement := packet.PrefixAnnouncement
+	if face := dispatch.GetFace(inFace); face != nil && face.Scope() == defn.Local {
+		if s.hasDiscoveryDownstream(pitEntry) {
+			announcement = s.announce(packet.Name, inFace)
+		}
+	} else if announcement != nil {
+		s.learn(announcement, inFace)
+	}
+
+	withAnn, withoutAnn := packet, packet
+	if announcement != nil {
+		withAnn = &defn.Pkt{}
+		*withAnn = *packet
+		withAnn.PrefixAnnouncement = announcement
+	}
+	if packet.PrefixAnnouncement != nil {
+		withoutAnn = &defn.Pkt{}
+		*withoutAnn = *packet
+		withoutAnn.PrefixAnnouncement = nil
+	}
+
+	for faceID, inRecord := range pitEntry.InRecords() {
+		out := withoutAnn
+		if !inRecord.NonDiscovery && s.isNonLocal(faceID) {
+			out = withAnn
+		}
+		core.Log.Trace(s, "Forwarding Data", "name", packet.Name, "faceid", faceID)
+		s.SendData(out, pitEntry, faceID, inFace)
+	}
+}
+
+// AfterReceiveInterest unicasts an Interest with a route to the best nexthop,
+// and floods a discovery Interest without a route to all non-local faces.
+func (s *SelfLearning) AfterReceiveInterest(
+	packet *defn.Pkt,
+	pitEntry table.PitEntry,
+	inFace uint64,
+	nexthops []*table.FibNextHopEntry,
+) {
+	// Suppress retransmissions of the same Interest within suppression time
+	now := time.Now()
+	for _, oR := range pitEntry.OutRecords() {
+		if oR.LatestTimestamp.Add(s.suppressionTime).After(now) {
+			core.Log.Debug(s, "Suppressed Interest - DROP", "name", packet.Name)
+			return
+		}
+	}
+
+	if len(nexthops) > 0 {
+		sort.Slice(nexthops, func(i, j int) bool { return nexthops[i].Cost < nexthops[j].Cost })
+
+		packet.NonDiscovery = true
+		for _, nh := range nexthops {
+			core.Log.Trace(s, "Forwarding Interest", "name", packet.Name, "faceid", nh.Nexthop)
+			if s.SendInterest(packet, pitEntry, nh.Nexthop, inFace) {
+				return
+			}
+		}
+
+		core.Log.Debug(s, "No usable nexthop for Interest - NACK", "name", packet.Name)
+		s.RejectInterest(packet, pitEntry, spec.NackReasonNoRoute)
+		return
+	}
+
+	// Only discovery Interests without any route are flooded
+	if packet.NonDiscovery || table.FibStrategyTable.FindNextHopsPrefixEnc(pitEntry.EncName()) != nil {
+		core.Log.Debug(s, "No nexthop for non-discovery Interest - NACK", "name", packet.Name)
+		s.RejectInterest(packet, pitEntry, spec.NackReasonNoRoute)
+		return
+	}
+
+	// Interests of /localhop received from non-local faces stay on this node
+	if packet.Name.At(0).Equal(enc.LOCALHOP) && s.isNonLocal(inFace) {
+		s.RejectInterest(packet, pitEntry, spec.NackReasonNoRoute)
+		return
+	}
+
+	sent := 0
+	inRecords := pitEntry.InRecords()
+	dispatch.FaceDispatch.Range(func(_, value any) bool {
+		face := value.(dispatch.Face)
+		faceID := face.FaceID()
+		if face.Scope() != defn.NonLocal || face.State() != defn.Up ||
+			face.RemoteURI().Scheme() == "null" {
+			return true
+		}
+		if inRecords[faceID] != nil && face.LinkType() != defn.AdHoc {
+			return true
+		}
+
+		core.Log.Trace(s, "Flooding discovery Interest", "name", packet.Name, "faceid", faceID)
+		if s.SendInterest(packet, pitEntry, faceID, inFace) {
+			sent++
+		}
+		return true
+	})
+
+	if sent == 0 {
+		core.Log.Debug(s, "No face to flood discovery Interest - NACK", "name", packet.Name)
+		s.RejectInterest(packet, pitEntry, spec.NackReasonNoRoute)
+	}
+}
+
+// AfterReceiveNack removes a learned route that no longer leads to the producer.
+func (s *SelfLearning) AfterReceiveNack(
+	packet *defn.Pkt,
+	pitEntry table.PitEntry,
+	inFace uint64,
+) {
+	core.Log.Trace(s, "AfterReceiveNack", "name", packet.Name, "faceid", inFace)
+
+	if packet.NackReason.Unwrap() == spec.NackReasonNoRoute {
+		if prefix := table.FibStrategyTable.FindNextHopsPrefixEnc(pitEntry.EncName()); prefix != nil {
+			table.Rib.RemoveRouteEnc(prefix, inFace, uint64(spec_mgmt.RouteOriginSelfLearn))
+		}
+	}
+
+	s.ProcessNack(packet, pitEntry)
+}
+
+// AfterLostInterest does nothing in SelfLearning.
+func (s *SelfLearning) AfterLostInterest(
+	packet *defn.Pkt,
+	pitEntry table.PitEntry,
+	outFace uint64,
+) {
+	// This does nothing in SelfLearning
+}
+
+// BeforeExpirePendingInterest does nothing in SelfLearning.
+func (s *SelfLearning) BeforeExpirePendingInterest(pitEntry table.PitEntry) {
+	// This does nothing in SelfLearning
+}
+
+// BeforeSatisfyInterest does nothing in SelfLearning.
+func (s *SelfLearning) BeforeSatisfyInterest(pitEntry table.PitEntry, inFace uint64) {
+	// This does nothing in SelfLearning
+}
+
+// isNonLocal returns whether a face leads to another forwarder.
+func (s *SelfLearning) isNonLocal(faceID uint64) bool {
+	face := dispatch.GetFace(faceID)
+	return face != nil && face.Scope() == defn.NonLocal
+}
+
+// hasDiscoveryDownstream returns whether a non-local downstream sent a discovery Interest.
+func (s *SelfLearning) hasDiscoveryDownstream(pitEntry table.PitEntry) bool {
+	for faceID, inRecord := range pitEntry.InRecords() {
+		if !inRecord.NonDiscovery && s.isNonLocal(faceID) {
+			return true
+		}
+	}
+	return false
+}
+
+// announce returns a prefix announcement for the local producer of a Data.
+// The announcement the producer registered with is used if there is one,
+// otherwise an announcement of the registered prefix is made.
+func (s *SelfLearning) announce(name enc.Name, producer uint64) enc.Wire {
+	prefix, route := table.Rib.FindRouteToFaceEnc(name, producer)
+	if route == nil || len(prefix) == 0 {
+		return nil
+	}
+	if route.Announcement != nil {
+		return route.Announcement
+	}
+
+	announcement, err := sec.MakePrefixAnn(sec.PrefixAnnArgs{
+		Signer:     signer.NewSha256Signer(),
+		Prefix:     prefix,
+		Expiration: s.routeLifetime,
+	})
+	if err != nil {
+		core.Log.Warn(s, "Unable to make prefix announcement", "prefix", prefix, "err", err)
+		return nil
+	}
+	return announcement
+}
+
+// learn inserts a route to the face that returned Data with a prefix announcement.
+// The signature of the announcement is not validated: self-learning trusts its neighbors.
+func (s *SelfLearning) learn(announcement enc.Wire, faceID uint64) {
+	data, _, err := spec.Spec{}.ReadData(enc.NewWireView(announcement))
+	if err != nil {
+		core.Log.Debug(s, "Unable to decode prefix announcement", "err", err)
+		return
+	}
+	ann, err := sec.ParsePrefixAnn(data)
+	if err != nil {
+		core.Log.Debug(s, "Invalid prefix announcement", "err", err)
+		return
+	}
+
+	lifetime := min(ann.Lifetime(time.Now()), s.routeLifetime)
+	if lifetime <= 0 {
+		core.Log.Debug(s, "Prefix announcement expired", "prefix", ann.Prefix)
+		return
+	}
+
+	core.Log.Debug(s, "Learned route", "prefix", ann.Prefix, "faceid", faceID, "lifetime", lifetime)
+	table.Rib.AddEncRoute(ann.Prefix, &table.Route{
+		FaceID:           faceID,
+		Origin:           uint64(spec_mgmt.RouteOriginSelfLearn),
+		Flags:            uint64(spec_mgmt.RouteFlagChildInherit),
+		ExpirationPeriod: &lifetime,
+		Announcement:     announcement,
+	})
+}
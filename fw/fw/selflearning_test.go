@@ -0,0 +1,193 @@
+package fw
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/named-data/ndnd/fw/defn"
+	"github.com/named-data/ndnd/fw/dispatch"
+	"github.com/named-data/ndnd/fw/table"
+	enc "github.com/named-data/ndnd/std/encoding"
+	spec_mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	sec "github.com/named-data/ndnd/std/security"
+	"github.com/named-data/ndnd/std/security/signer"
+	"github.com/named-data/ndnd/std/types/optional"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var initTablesOnce sync.Once
+
+// testFace is a face that records the packets sent on it.
+type testFace struct {
+	id    uint64
+	scope defn.Scope
+	sent  []dispatch.OutPkt
+}
+
+func (f *testFace) String() string          { return "test-face" }
+func (f *testFace) SetFaceID(faceID uint64) { f.id = faceID }
+func (f *testFace) FaceID() uint64          { return f.id }
+func (f *testFace) LocalURI() *defn.URI     { return defn.MakeUDPFaceURI(4, "192.0.2.1", 6363) }
+func (f *testFace) RemoteURI() *defn.URI    { return defn.MakeUDPFaceURI(4, "192.0.2.2", 6363) }
+func (f *testFace) Scope() defn.Scope       { return f.scope }
+func (f *testFace) LinkType() defn.LinkType { return defn.PointToPoint }
+func (f *testFace) MTU() int                { return 8800 }
+func (f *testFace) State() defn.State       { return defn.Up }
+func (f *testFace) SendPacket(out dispatch.OutPkt) {
+	f.sent = append(f.sent, out)
+}
+
+// pop returns and clears the packets sent on the face.
+func (f *testFace) pop() []dispatch.OutPkt {
+	sent := f.sent
+	f.sent = nil
+	return sent
+}
+
+// newSelfLearningThread creates a forwarding thread using self-learning for
+// the prefix, with a local consumer face and two non-local faces.
+func newSelfLearningThread(t *testing.T, prefix string) (*Thread, *testFace, *testFace, *testFace) {
+	initTablesOnce.Do(table.Initialize)
+
+	name, _ := enc.NameFromStr(prefix)
+	strategy := defn.STRATEGY_PREFIX.
+		Append(enc.NewGenericComponent("self-learning")).
+		Append(enc.NewVersionComponent(1))
+	table.FibStrategyTable.SetStrategyEnc(name, strategy)
+	t.Cleanup(func() { table.FibStrategyTable.UnSetStrategyEnc(name) })
+
+	consumer := &testFace{id: 9001, scope: defn.Local}
+	up1 := &testFace{id: 9002, scope: defn.NonLocal}
+	up2 := &testFace{id: 9003, scope: defn.NonLocal}
+	for _, face := range []*testFace{consumer, up1, up2} {
+		dispatch.AddFace(face.id, face)
+	}
+	t.Cleanup(func() {
+		for _, face := range []*testFace{consumer, up1, up2} {
+			dispatch.RemoveFace(face.id)
+		}
+	})
+
+	return NewThread(0), consumer, up1, up2
+}
+
+func makeTestInterest(name string, nonce uint32, faceID uint64) *defn.Pkt {
+	n, _ := enc.NameFromStr(name)
+	l3 := &defn.FwPacket{Interest: &defn.FwInterest{
+		NameV:             n,
+		NonceV:            optional.Some(nonce),
+		InterestLifetimeV: optional.Some(4 * time.Second),
+	}}
+	return &defn.Pkt{Name: n, L3: l3, Raw: l3.Encode(), IncomingFaceID: faceID}
+}
+
+func makeTestData(name string, faceID uint64, pitToken []byte) *defn.Pkt {
+	n, _ := enc.NameFromStr(name)
+	l3 := &defn.FwPacket{Data: &defn.FwData{NameV: n}}
+	return &defn.Pkt{Name: n, L3: l3, Raw: l3.Encode(), IncomingFaceID: faceID, PitToken: pitToken}
+}
+
+// Tests that Interests without a route are flooded and Interests with a route are unicast.
+func TestSelfLearningDiscovery(t *testing.T) {
+	thread, consumer, up1, up2 := newSelfLearningThread(t, "/sl-discovery")
+
+	thread.processIncomingInterest(makeTestInterest("/sl-discovery/a", 1, consumer.id))
+	for _, face := range []*testFace{up1, up2} {
+		sent := face.pop()
+		require.Len(t, sent, 1)
+		assert.False(t, sent[0].Pkt.NonDiscovery)
+	}
+
+	prefix, _ := enc.NameFromStr("/sl-discovery/route")
+	table.Rib.AddEncRoute(prefix, &table.Route{FaceID: up2.id, Origin: uint64(spec_mgmt.RouteOriginApp)})
+	t.Cleanup(func() { table.Rib.RemoveRouteEnc(prefix, up2.id, uint64(spec_mgmt.RouteOriginApp)) })
+
+	thread.processIncomingInterest(makeTestInterest("/sl-discovery/route/b", 2, consumer.id))
+	assert.Empty(t, up1.pop())
+	sent := up2.pop()
+	require.Len(t, sent, 1)
+	assert.True(t, sent[0].Pkt.NonDiscovery)
+
+	// Non-discovery Interests without a route are not flooded
+	interest := makeTestInterest("/sl-discovery/c", 3, consumer.id)
+	interest.NonDiscovery = true
+	thread.processIncomingInterest(interest)
+	assert.Empty(t, up1.pop())
+	assert.Empty(t, up2.pop())
+	nack := consumer.pop()
+	require.Len(t, nack, 1)
+	assert.Equal(t, uint64(spec.NackReasonNoRoute), nack[0].Pkt.NackReason.Unwrap())
+}
+
+// Tests that a route is learned from the prefix announcement attached to Data.
+func TestSelfLearningLearnRoute(t *testing.T) {
+	thread, consumer, up1, up2 := newSelfLearningThread(t, "/sl-learn")
+
+	thread.processIncomingInterest(makeTestInterest("/sl-learn/obj", 1, consumer.id))
+	sent := up1.pop()
+	require.Len(t, sent, 1)
+	require.Len(t, up2.pop(), 1)
+
+	// The announcement outlives the maximum route lifetime
+	prefix, _ := enc.NameFromStr("/sl-learn")
+	announcement, err := sec.MakePrefixAnn(sec.PrefixAnnArgs{
+		Signer:     signer.NewSha256Signer(),
+		Prefix:     prefix,
+		Expiration: time.Hour,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { table.Rib.RemoveRouteEnc(prefix, up1.id, uint64(spec_mgmt.RouteOriginSelfLearn)) })
+
+	data := makeTestData("/sl-learn/obj", up1.id, sent[0].PitToken)
+	data.PrefixAnnouncement = announcement
+	thread.processIncomingData(data)
+
+	learned, route := table.Rib.FindRouteToFaceEnc(prefix, up1.id)
+	require.NotNil(t, route)
+	assert.Equal(t, prefix, learned)
+	assert.Equal(t, uint64(spec_mgmt.RouteOriginSelfLearn), route.Origin)
+	require.NotNil(t, route.ExpirationPeriod)
+	assert.LessOrEqual(t, *route.ExpirationPeriod, SelfLearningRouteLifetime)
+	assert.Greater(t, *route.ExpirationPeriod, SelfLearningRouteLifetime-time.Minute)
+
+	// The local consumer receives the Data without the announcement
+	out := consumer.pop()
+	require.Len(t, out, 1)
+	assert.NotNil(t, out[0].Pkt.L3.Data)
+	assert.Nil(t, out[0].Pkt.PrefixAnnouncement)
+
+	// Later Interests follow the learned route
+	thread.processIncomingInterest(makeTestInterest("/sl-learn/obj2", 2, consumer.id))
+	assert.Len(t, up1.pop(), 1)
+	assert.Empty(t, up2.pop())
+}
+
+// Tests that a learned route is removed when the upstream answers with a NoRoute Nack.
+func TestSelfLearningNoRouteNack(t *testing.T) {
+	thread, consumer, up1, up2 := newSelfLearningThread(t, "/sl-nack")
+
+	prefix, _ := enc.NameFromStr("/sl-nack")
+	lifetime := time.Minute
+	table.Rib.AddEncRoute(prefix, &table.Route{
+		FaceID:           up1.id,
+		Origin:           uint64(spec_mgmt.RouteOriginSelfLearn),
+		ExpirationPeriod: &lifetime,
+	})
+	t.Cleanup(func() { table.Rib.RemoveRouteEnc(prefix, up1.id, uint64(spec_mgmt.RouteOriginSelfLearn)) })
+
+	interest := makeTestInterest("/sl-nack/obj", 1, consumer.id)
+	thread.processIncomingInterest(interest)
+	sent := up1.pop()
+	require.Len(t, sent, 1)
+	assert.Empty(t, up2.pop())
+
+	nack := defn.MakeNack(makeTestInterest("/sl-nack/obj", 1, up1.id), spec.NackReasonNoRoute)
+	nack.PitToken = sent[0].PitToken
+	thread.processIncomingNack(nack)
+
+	_, route := table.Rib.FindRouteToFaceEnc(prefix, up1.id)
+	assert.Nil(t, route)
+}
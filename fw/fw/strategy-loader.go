@@ -8,26 +8,146 @@
 package fw
 
 import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/defn"
+	enc "github.com/named-data/ndnd/std/encoding"
 )
 
 // Strategy implementations should register the instatiation function using init().
-// Each thread has a separate instance of each strategy.
-var strategyInit []func() Strategy
+// Each thread has a separate instance of each strategy and parameter set.
+var strategyInit = make(map[string]func() Strategy)
 
 // StrategyVersions contains a list of strategies mapping to a list of their versions
 var StrategyVersions = make(map[string][]uint64)
 
-// InstantiateStrategies instantiates all strategies for a forwarding thread.
+// StrategyParams are the parameters of a strategy instance, given as key~value
+// components after the version in the strategy name, for example
+// /localhost/nfd/strategy/best-route/v=1/retx-suppression~200
+// Durations are integer milliseconds; NFD's retx-suppression~exponential is not supported.
+type StrategyParams map[string]string
+
+// InstantiateStrategies instantiates all strategies without parameters for a forwarding thread.
 func InstantiateStrategies(fwThread *Thread) map[uint64]Strategy {
 	strategies := make(map[uint64]Strategy, len(strategyInit))
 
-	for _, initFun := range strategyInit {
-		strategy := initFun()
-		strategy.Instantiate(fwThread)
+	for name, versions := range StrategyVersions {
+		strategyName := defn.STRATEGY_PREFIX.
+			Append(enc.NewGenericComponent(name)).
+			Append(enc.NewVersionComponent(slices.Max(versions)))
+
+		strategy, err := NewStrategy(fwThread, strategyName)
+		if err != nil {
+			core.Log.Fatal(nil, "Unable to instantiate strategy", "strategy", strategyName, "err", err)
+		}
 		strategies[strategy.GetName().Hash()] = strategy
 		core.Log.Debug(nil, "Instantiated Strategy", "strategy", strategy.GetName(), "thread", fwThread.GetID())
 	}
 
 	return strategies
 }
+
+// NewStrategy instantiates a strategy for a forwarding thread from a strategy choice name.
+// The name must contain the version, which may be followed by parameters.
+func NewStrategy(fwThread *Thread, name enc.Name) (Strategy, error) {
+	strategyName, version, params, err := parseStrategyName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	initFun, ok := strategyInit[strategyName]
+	if !ok {
+		return nil, fmt.Errorf("unknown strategy %s", strategyName)
+	}
+	if !slices.Contains(StrategyVersions[strategyName], version) {
+		return nil, fmt.Errorf("unknown version %d of strategy %s", version, strategyName)
+	}
+
+	strategy := initFun()
+	if err := strategy.Instantiate(fwThread, params); err != nil {
+		return nil, err
+	}
+	return strategy, nil
+}
+
+// ValidateStrategy checks that a strategy choice name can be instantiated
+// and returns its canonical name, with parameters in sorted order.
+func ValidateStrategy(name enc.Name) (enc.Name, error) {
+	strategy, err := NewStrategy(&Thread{threadID: -1}, name)
+	if err != nil {
+		return nil, err
+	}
+	return strategy.GetName(), nil
+}
+
+// parseStrategyName splits a strategy choice name into strategy name, version and parameters.
+func parseStrategyName(name enc.Name) (string, uint64, StrategyParams, error) {
+	if !defn.STRATEGY_PREFIX.IsPrefix(name) || len(name) < len(defn.STRATEGY_PREFIX)+2 {
+		return "", 0, nil, fmt.Errorf("invalid strategy name %s", name)
+	}
+
+	strategyName := name[len(defn.STRATEGY_PREFIX)].String()
+	versionComp := name[len(defn.STRATEGY_PREFIX)+1]
+	if !versionComp.IsVersion() {
+		return "", 0, nil, fmt.Errorf("missing version in strategy name %s", name)
+	}
+
+	params := make(StrategyParams)
+	for _, c := range name[len(defn.STRATEGY_PREFIX)+2:] {
+		key, value, ok := strings.Cut(string(c.Val), "~")
+		if c.Typ != enc.TypeGenericNameComponent || !ok || key == "" {
+			return "", 0, nil, fmt.Errorf("invalid strategy parameter %s", c)
+		}
+		if _, dup := params[key]; dup {
+			return "", 0, nil, fmt.Errorf("duplicate strategy parameter %s", key)
+		}
+		params[key] = value
+	}
+
+	return strategyName, versionComp.NumberVal(), params, nil
+}
+
+// components returns the parameters as name components in sorted order.
+func (p StrategyParams) components() enc.Name {
+	keys := make([]string, 0, len(p))
+	for key := range p {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	comps := make(enc.Name, 0, len(keys))
+	for _, key := range keys {
+		comps = append(comps, enc.NewGenericComponent(key+"~"+p[key]))
+	}
+	return comps
+}
+
+// Check returns an error if there is a parameter not in the list of known keys.
+func (p StrategyParams) Check(known ...string) error {
+	for key := range p {
+		if !slices.Contains(known, key) {
+			return fmt.Errorf("unknown strategy parameter %s", key)
+		}
+	}
+	return nil
+}
+
+// Duration returns a parameter given as an integer number of milliseconds,
+// or the default if it is not set.
+func (p StrategyParams) Duration(key string, def time.Duration) (time.Duration, error) {
+	value, ok := p[key]
+	if !ok {
+		return def, nil
+	}
+	ms, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q of strategy parameter %s, expected milliseconds", value, key)
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
@@ -0,0 +1,124 @@
+package fw
+
+import (
+	"testing"
+	"time"
+
+	"github.com/named-data/ndnd/fw/defn"
+	"github.com/named-data/ndnd/fw/table"
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strategyName(t *testing.T, uri string) enc.Name {
+	name, err := enc.NameFromStr(uri)
+	require.NoError(t, err)
+	return name
+}
+
+func TestParseStrategyName(t *testing.T) {
+	name, version, params, err := parseStrategyName(strategyName(t,
+		"/localhost/nfd/strategy/asf/v=1/retx-suppression~200/probing-interval~5000"))
+	require.NoError(t, err)
+	assert.Equal(t, "asf", name)
+	assert.Equal(t, uint64(1), version)
+	assert.Equal(t, StrategyParams{"retx-suppression": "200", "probing-interval": "5000"}, params)
+
+	// No parameters
+	_, _, params, err = parseStrategyName(strategyName(t, "/localhost/nfd/strategy/best-route/v=1"))
+	require.NoError(t, err)
+	assert.Empty(t, params)
+
+	for _, uri := range []string{
+		"/localhost/nfd/strategy/best-route",                      // missing version
+		"/localhost/nfd/strategy/best-route/retx-suppression~200", // parameter instead of version
+		"/localhost/other/best-route/v=1",                         // not a strategy
+		"/localhost/nfd/strategy/best-route/v=1/retx-suppression", // missing ~
+		"/localhost/nfd/strategy/best-route/v=1/~200",             // empty key
+		"/localhost/nfd/strategy/best-route/v=1/a~1/a~2",          // duplicate key
+		"/localhost/nfd/strategy/best-route/v=1/v=2",              // not a generic component
+	} {
+		_, _, _, err := parseStrategyName(strategyName(t, uri))
+		assert.Error(t, err, uri)
+	}
+}
+
+func TestStrategyParamsDuration(t *testing.T) {
+	params := StrategyParams{"a": "250", "b": "exponential", "c": "-1", "d": "1.5"}
+
+	d, err := params.Duration("a", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 250*time.Millisecond, d)
+
+	d, err = params.Duration("unset", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, d)
+
+	for _, key := range []string{"b", "c", "d"} {
+		_, err = params.Duration(key, time.Second)
+		assert.Error(t, err, key)
+	}
+}
+
+func TestValidateStrategy(t *testing.T) {
+	// Parameters are put in canonical order
+	canonical, err := ValidateStrategy(strategyName(t,
+		"/localhost/nfd/strategy/asf/v=1/retx-suppression~200/probing-interval~5000"))
+	require.NoError(t, err)
+	assert.Equal(t,
+		strategyName(t, "/localhost/nfd/strategy/asf/v=1/probing-interval~5000/retx-suppression~200"),
+		canonical)
+
+	// The canonical name is stable
+	again, err := ValidateStrategy(canonical)
+	require.NoError(t, err)
+	assert.Equal(t, canonical, again)
+
+	for _, uri := range []string{
+		"/localhost/nfd/strategy/unknown/v=1",                                   // unknown strategy
+		"/localhost/nfd/strategy/best-route/v=99",                               // unknown version
+		"/localhost/nfd/strategy/best-route/v=1/probing-interval~5000",          // unknown key
+		"/localhost/nfd/strategy/best-route/v=1/retx-suppression~exponential",   // not milliseconds
+		"/localhost/nfd/strategy/asf/v=1/retx-suppression~1/retx-suppression~2", // duplicate key
+	} {
+		_, err := ValidateStrategy(strategyName(t, uri))
+		assert.Error(t, err, uri)
+	}
+}
+
+// Tests that a strategy choice with parameters is instantiated on a thread
+// with its canonical name, and released when the choice is unset.
+func TestStrategyChoiceRoundTrip(t *testing.T) {
+	initTablesOnce.Do(table.Initialize)
+	thread := NewThread(0)
+
+	prefix := strategyName(t, "/strategy-choice")
+	choice, err := ValidateStrategy(strategyName(t, "/localhost/nfd/strategy/best-route/v=1/retx-suppression~50"))
+	require.NoError(t, err)
+	table.FibStrategyTable.SetStrategyEnc(prefix, choice)
+
+	found := table.FibStrategyTable.FindStrategyEnc(strategyName(t, "/strategy-choice/obj"))
+	assert.Equal(t, choice, found)
+
+	strategy := thread.strategy(found)
+	assert.Equal(t, choice, strategy.GetName())
+	require.IsType(t, &BestRoute{}, strategy)
+	assert.Equal(t, 50*time.Millisecond, strategy.(*BestRoute).suppressionTime)
+	assert.Same(t, strategy, thread.strategy(found))
+
+	// The instance is kept while the choice is set
+	thread.evictStrategies()
+	assert.Contains(t, thread.strategies, choice.Hash())
+
+	table.FibStrategyTable.UnSetStrategyEnc(prefix)
+	thread.QueueStrategyEviction()
+	thread.QueueStrategyEviction() // coalesced
+	<-thread.strategyGc
+	thread.evictStrategies()
+	assert.NotContains(t, thread.strategies, choice.Hash())
+
+	// Instances without parameters are never evicted
+	assert.Contains(t, thread.strategies, defn.DEFAULT_STRATEGY.Hash())
+	assert.Len(t, thread.strategies, len(StrategyVersions))
+}
@@ -9,7 +9,9 @@ package fw
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/named-data/ndnd/fw/core"
 	"github.com/named-data/ndnd/fw/defn"
 	"github.com/named-data/ndnd/fw/table"
 	enc "github.com/named-data/ndnd/std/encoding"
@@ -17,7 +19,7 @@ import (
 
 // Strategy represents a forwarding strategy.
 type Strategy interface {
-	Instantiate(fwThread *Thread)
+	Instantiate(fwThread *Thread, params StrategyParams) error
 	String() string
 	GetName() enc.Name
 
@@ -34,6 +36,16 @@ type Strategy interface {
 		pitEntry table.PitEntry,
 		inFace uint64,
 		nexthops []*table.FibNextHopEntry)
+	AfterReceiveNack(
+		packet *defn.Pkt,
+		pitEntry table.PitEntry,
+		inFace uint64)
+	AfterLostInterest(
+		packet *defn.Pkt,
+		pitEntry table.PitEntry,
+		outFace uint64)
+	BeforeExpirePendingInterest(
+		pitEntry table.PitEntry)
 	BeforeSatisfyInterest(
 		pitEntry table.PitEntry,
 		inFace uint64)
@@ -53,12 +65,14 @@ func (s *StrategyBase) NewStrategyBase(
 	fwThread *Thread,
 	name string,
 	version uint64,
+	params StrategyParams,
 ) {
 	s.thread = fwThread
 	s.threadID = s.thread.threadID
 	s.name = defn.STRATEGY_PREFIX.
 		Append(enc.NewGenericComponent(name)).
-		Append(enc.NewVersionComponent(version))
+		Append(enc.NewVersionComponent(version)).
+		Append(params.components()...)
 	s.version = version
 	s.logName = name
 }
@@ -68,11 +82,16 @@ func (s *StrategyBase) String() string {
 	return fmt.Sprintf("%s (v=%d t=%d)", s.logName, s.version, s.threadID)
 }
 
-// GetName returns the name of strategy, including version information.
+// GetName returns the name of strategy, including version information and parameters.
 func (s *StrategyBase) GetName() enc.Name {
 	return s.name
 }
 
+// Measurements returns the measurements table of the forwarding thread.
+func (s *StrategyBase) Measurements() *table.Measurements {
+	return s.thread.measurements
+}
+
 // SendInterest sends an Interest on the specified face.
 func (s *StrategyBase) SendInterest(
 	packet *defn.Pkt,
@@ -97,3 +116,50 @@ func (s *StrategyBase) SendData(
 	}
 	s.thread.processOutgoingData(packet, nexthop, pitToken, inFace)
 }
+
+// SendNack sends a Nack to the specified downstream face.
+func (s *StrategyBase) SendNack(
+	packet *defn.Pkt,
+	pitEntry table.PitEntry,
+	nexthop uint64,
+	reason uint64,
+) {
+	s.thread.processOutgoingNack(packet, pitEntry, nexthop, reason)
+}
+
+// RejectInterest sends a Nack to all downstream faces of the PIT entry
+// and lets the entry expire immediately.
+func (s *StrategyBase) RejectInterest(
+	packet *defn.Pkt,
+	pitEntry table.PitEntry,
+	reason uint64,
+) {
+	for faceID := range pitEntry.InRecords() {
+		s.SendNack(packet, pitEntry, faceID, reason)
+	}
+	table.UpdateExpirationTimer(pitEntry, time.Now())
+}
+
+// ProcessNack is the default handling of a Nack received from upstream.
+// Once every pending upstream has returned a Nack, the Interest is rejected
+// downstream with the least severe of the received reasons.
+func (s *StrategyBase) ProcessNack(
+	packet *defn.Pkt,
+	pitEntry table.PitEntry,
+) {
+	now := time.Now()
+	reason := packet.NackReason.Unwrap()
+	for _, outRecord := range pitEntry.OutRecords() {
+		outReason, ok := outRecord.NackReason.Get()
+		if !ok {
+			if outRecord.ExpirationTime.After(now) {
+				return // still waiting for this upstream
+			}
+			continue
+		}
+		reason = min(reason, outReason)
+	}
+
+	core.Log.Debug(s, "All upstreams returned Nack", "name", packet.Name, "reason", reason)
+	s.RejectInterest(packet, pitEntry, reason)
+}
@@ -8,6 +8,7 @@
 package fw
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"runtime"
@@ -19,12 +20,18 @@ import (
 	"github.com/named-data/ndnd/fw/dispatch"
 	"github.com/named-data/ndnd/fw/table"
 	enc "github.com/named-data/ndnd/std/encoding"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	"github.com/named-data/ndnd/std/types/optional"
 	"github.com/named-data/ndnd/std/utils"
 )
 
 // MaxFwThreads Maximum number of forwarding threads
 const MaxFwThreads = 32
 
+// congestedQueueSize is the number of Interests that did not fit in the
+// queue of a forwarding thread and wait to be Nacked by the thread.
+const congestedQueueSize = 256
+
 // Threads contains all forwarding threads
 var Threads []*Thread
 
@@ -63,17 +70,22 @@ func HashNameToAllPrefixFwThreads(name enc.Name) []bool {
 type Thread struct {
 	threadID      int
 	pending       chan *defn.Pkt
+	congested     chan *defn.Pkt
 	pitCS         table.PitCsTable
 	strategies    map[uint64]Strategy
+	strategyGc    chan struct{}
 	deadNonceList *table.DeadNonceList
+	measurements  *table.Measurements
 	shouldQuit    chan interface{}
 	HasQuit       chan interface{}
 
 	// Counters
 	nInInterests          atomic.Uint64
 	nInData               atomic.Uint64
+	nInNacks              atomic.Uint64
 	nOutInterests         atomic.Uint64
 	nOutData              atomic.Uint64
+	nOutNacks             atomic.Uint64
 	nSatisfiedInterests   atomic.Uint64
 	nUnsatisfiedInterests atomic.Uint64
 	nCsHits               atomic.Uint64
@@ -85,9 +97,12 @@ func NewThread(id int) *Thread {
 	t := new(Thread)
 	t.threadID = id
 	t.pending = make(chan *defn.Pkt, CfgFwQueueSize())
+	t.congested = make(chan *defn.Pkt, congestedQueueSize)
 	t.pitCS = table.NewPitCS(t.finalizeInterest)
 	t.strategies = InstantiateStrategies(t)
+	t.strategyGc = make(chan struct{}, 1)
 	t.deadNonceList = table.NewDeadNonceList()
+	t.measurements = table.NewMeasurements()
 	t.shouldQuit = make(chan interface{}, 1)
 	t.HasQuit = make(chan interface{})
 	return t
@@ -108,10 +123,13 @@ func (t *Thread) Counters() defn.FWThreadCounters {
 	return defn.FWThreadCounters{
 		NPitEntries:           t.pitCS.PitSize(),
 		NCsEntries:            t.pitCS.CsSize(),
+		NDnlEntries:           t.deadNonceList.Size(),
 		NInInterests:          t.nInInterests.Load(),
 		NInData:               t.nInData.Load(),
+		NInNacks:              t.nInNacks.Load(),
 		NOutInterests:         t.nOutInterests.Load(),
 		NOutData:              t.nOutData.Load(),
+		NOutNacks:             t.nOutNacks.Load(),
 		NSatisfiedInterests:   t.nSatisfiedInterests.Load(),
 		NUnsatisfiedInterests: t.nUnsatisfiedInterests.Load(),
 		NCsHits:               t.nCsHits.Load(),
@@ -134,32 +152,50 @@ func (t *Thread) Run() {
 	for !core.ShouldQuit {
 		select {
 		case pkt := <-t.pending:
-			if pkt.L3.Interest != nil {
+			if pkt.LostFaceID.IsSet() {
+				t.processLostInterest(pkt)
+			} else if pkt.DroppedFaceID.IsSet() {
+				t.processDroppedInterest(pkt)
+			} else if pkt.NackReason.IsSet() {
+				t.processIncomingNack(pkt)
+			} else if pkt.L3.Interest != nil {
 				t.processIncomingInterest(pkt)
 			} else if pkt.L3.Data != nil {
 				t.processIncomingData(pkt)
 			}
+		case pkt := <-t.congested:
+			t.rejectCongestedInterest(pkt)
 		case <-t.deadNonceList.Ticker.C:
 			t.deadNonceList.RemoveExpiredEntries()
+		case <-t.measurements.Ticker.C:
+			t.measurements.RemoveExpiredEntries()
 		case <-t.pitCS.UpdateTicker():
 			t.pitCS.Update()
+		case <-t.strategyGc:
+			t.evictStrategies()
 		case <-t.shouldQuit:
 			continue
 		}
 	}
 
 	t.deadNonceList.Ticker.Stop()
+	t.measurements.Ticker.Stop()
 
 	core.Log.Info(t, "Stopping thread")
 	t.HasQuit <- true
 }
 
 // QueueInterest queues an Interest for processing by this forwarding thread.
+// If the queue is full, the thread Nacks the Interest instead.
 func (t *Thread) QueueInterest(interest *defn.Pkt) {
 	select {
 	case t.pending <- interest:
 	default:
-		core.Log.Error(t, "Interest dropped due to full queue")
+		select {
+		case t.congested <- interest:
+		default:
+			core.Log.Error(t, "Interest dropped due to full queue")
+		}
 	}
 }
 
@@ -172,6 +208,75 @@ func (t *Thread) QueueData(data *defn.Pkt) {
 	}
 }
 
+// QueueNack queues a Nack for processing by this forwarding thread.
+func (t *Thread) QueueNack(nack *defn.Pkt) {
+	select {
+	case t.pending <- nack:
+	default:
+		core.Log.Error(t, "Nack dropped due to full queue")
+	}
+}
+
+// QueueLostInterest queues an Interest that could not be delivered to an upstream.
+func (t *Thread) QueueLostInterest(interest *defn.Pkt) {
+	select {
+	case t.pending <- interest:
+	default:
+		core.Log.Error(t, "Lost Interest dropped due to full queue")
+	}
+}
+
+// QueueDroppedInterest queues an Interest that was dropped by the send queue of an upstream.
+func (t *Thread) QueueDroppedInterest(interest *defn.Pkt) {
+	select {
+	case t.pending <- interest:
+	default:
+		core.Log.Error(t, "Dropped Interest dropped due to full queue")
+	}
+}
+
+// QueueStrategyEviction asks the thread to release the strategy instances
+// with parameters that are no longer used by any strategy choice.
+func (t *Thread) QueueStrategyEviction() {
+	select {
+	case t.strategyGc <- struct{}{}:
+	default: // already queued
+	}
+}
+
+// evictStrategies removes the strategy instances with parameters whose
+// strategy choice was unset or replaced.
+func (t *Thread) evictStrategies() {
+	inUse := make(map[uint64]bool)
+	for _, choice := range table.FibStrategyTable.GetAllForwardingStrategies() {
+		inUse[choice.GetStrategy().Hash()] = true
+	}
+
+	for hash, strategy := range t.strategies {
+		if len(strategy.GetName()) > len(defn.STRATEGY_PREFIX)+2 && !inUse[hash] {
+			core.Log.Debug(t, "Evicted strategy", "strategy", strategy.GetName())
+			delete(t.strategies, hash)
+		}
+	}
+}
+
+// strategy returns the instance of a strategy choice on this thread.
+// Instances with parameters are created on first use.
+func (t *Thread) strategy(name enc.Name) Strategy {
+	hash := name.Hash()
+	if strategy, ok := t.strategies[hash]; ok {
+		return strategy
+	}
+
+	strategy, err := NewStrategy(t, name)
+	if err != nil {
+		core.Log.Warn(t, "Unable to instantiate strategy, using default", "strategy", name, "err", err)
+		return t.strategies[defn.DEFAULT_STRATEGY.Hash()]
+	}
+	t.strategies[hash] = strategy
+	return strategy
+}
+
 // (AI GENERATED DESCRIPTION): Processes an incoming Interest packet: verifies its validity, enforces hop limits and scope, checks for nonces and dead‑nonce loops, updates the PIT and content store, selects and filters next‑hops via the FIB, and forwards the Interest according to the chosen forwarding strategy.
 func (t *Thread) processIncomingInterest(packet *defn.Pkt) {
 	interest := packet.L3.Interest
@@ -237,6 +342,7 @@ func (t *Thread) processIncomingInterest(packet *defn.Pkt) {
 	// Check if packet is in dead nonce list
 	if exists := t.deadNonceList.Find(interest.NameV, interest.NonceV.Unwrap()); exists {
 		core.Log.Debug(t, "Interest is looping (DNL)", "name", packet.Name, "nonce", interest.NonceV.Unwrap())
+		t.sendNack(packet, incomingFace.FaceID(), packet.PitToken, spec.NackReasonDuplicate)
 		return
 	}
 
@@ -244,25 +350,26 @@ func (t *Thread) processIncomingInterest(packet *defn.Pkt) {
 	// read into this, looks like this one will have to be manually changed
 	pitEntry, isDuplicate := t.pitCS.InsertInterest(interest, fhName, incomingFace.FaceID())
 	if isDuplicate {
-		// Interest loop - since we don't use Nacks, just drop
+		// Interest loop - tell the downstream not to wait for this one
 		core.Log.Debug(t, "Interest is looping (PIT)", "name", packet.Name)
+		t.sendNack(packet, incomingFace.FaceID(), packet.PitToken, spec.NackReasonDuplicate)
 		return
 	}
 
 	// Get strategy for name
-	strategyName := table.FibStrategyTable.FindStrategyEnc(interest.Name())
-	strategy := t.strategies[strategyName.Hash()]
+	strategy := t.strategy(table.FibStrategyTable.FindStrategyEnc(interest.Name()))
 
 	// Add in-record and determine if already pending
 	// this looks like custom interest again, but again can be changed without much issue?
 	inRecord, isAlreadyPending, prevNonce := pitEntry.InsertInRecord(
 		interest, incomingFace.FaceID(), packet.PitToken)
+	inRecord.NonDiscovery = packet.NonDiscovery
 
 	if !isAlreadyPending {
 		core.Log.Trace(t, "Interest is not pending", "name", packet.Name)
 
-		// Check CS for matching entry
-		if t.pitCS.IsCsServing() {
+		// Check CS for matching entry (unless a local consumer asked to bypass it)
+		if t.pitCS.IsCsServing() && packet.CachePolicy.GetOr(0) != defn.CachePolicyNoCache {
 			csEntry := t.pitCS.FindMatchingDataFromCS(interest)
 			if csEntry != nil {
 				// Update counters
@@ -410,6 +517,177 @@ func (t *Thread) processOutgoingInterest(
 	return true
 }
 
+// processIncomingNack processes a Nack received for an Interest sent by this thread.
+// The Nack is only accepted if it matches the latest Interest sent to the face.
+func (t *Thread) processIncomingNack(packet *defn.Pkt) {
+	interest := packet.L3.Interest
+	if interest == nil {
+		panic("processIncomingNack called with non-Interest packet")
+	}
+
+	incomingFace := dispatch.GetFace(packet.IncomingFaceID)
+	if incomingFace == nil {
+		core.Log.Error(t, "Nack has non-existent incoming face", "faceid", packet.IncomingFaceID, "name", packet.Name)
+		return
+	}
+
+	// Update counters
+	t.nInNacks.Add(1)
+
+	core.Log.Trace(t, "OnIncomingNack", "name", packet.Name, "faceid", packet.IncomingFaceID,
+		"reason", packet.NackReason.Unwrap())
+
+	// Nacks are only meaningful on point-to-point links
+	if incomingFace.LinkType() != defn.PointToPoint {
+		core.Log.Debug(t, "Nack received on non point-to-point face - DROP", "name", packet.Name)
+		return
+	}
+
+	pitEntry := t.pitCS.FindInterestExactMatchEnc(interest)
+	if pitEntry == nil {
+		core.Log.Debug(t, "Nack does not match any PIT entry - DROP", "name", packet.Name)
+		return
+	}
+
+	// The Nack must be for the latest Interest sent to this face
+	outRecord := pitEntry.OutRecords()[packet.IncomingFaceID]
+	if outRecord == nil {
+		core.Log.Debug(t, "Nack does not match any out-record - DROP", "name", packet.Name)
+		return
+	}
+	if !interest.NonceV.IsSet() || outRecord.LatestNonce != interest.NonceV.Unwrap() {
+		core.Log.Debug(t, "Nack does not match latest Nonce - DROP", "name", packet.Name)
+		return
+	}
+	outRecord.NackReason = packet.NackReason
+
+	// Get strategy for name
+	strategy := t.strategy(table.FibStrategyTable.FindStrategyEnc(pitEntry.EncName()))
+	strategy.AfterReceiveNack(packet, pitEntry, packet.IncomingFaceID)
+}
+
+// processLostInterest notifies the strategy that link-layer reliability gave up
+// delivering an Interest to an upstream face.
+func (t *Thread) processLostInterest(packet *defn.Pkt) {
+	interest := packet.L3.Interest
+	if interest == nil {
+		panic("processLostInterest called with non-Interest packet")
+	}
+	faceID := packet.LostFaceID.Unwrap()
+
+	core.Log.Debug(t, "OnLostInterest", "name", packet.Name, "faceid", faceID)
+
+	pitEntry := t.pitCS.FindInterestExactMatchEnc(interest)
+	if pitEntry == nil {
+		return // already satisfied or expired
+	}
+
+	// Ignore if the Interest was retransmitted to the face since
+	outRecord := pitEntry.OutRecords()[faceID]
+	if outRecord == nil || !interest.NonceV.IsSet() || outRecord.LatestNonce != interest.NonceV.Unwrap() {
+		return
+	}
+
+	strategy := t.strategy(table.FibStrategyTable.FindStrategyEnc(pitEntry.EncName()))
+	strategy.AfterLostInterest(packet, pitEntry, faceID)
+}
+
+// rejectCongestedInterest Nacks an Interest that did not fit in the queue of the thread.
+func (t *Thread) rejectCongestedInterest(packet *defn.Pkt) {
+	core.Log.Debug(t, "Interest rejected due to full queue", "name", packet.Name, "faceid", packet.IncomingFaceID)
+	t.sendNack(packet, packet.IncomingFaceID, packet.PitToken, spec.NackReasonCongestion)
+}
+
+// processDroppedInterest passes an Interest that an upstream face dropped
+// because its send queue was full to the strategy as a Congestion Nack.
+func (t *Thread) processDroppedInterest(packet *defn.Pkt) {
+	interest := packet.L3.Interest
+	if interest == nil {
+		panic("processDroppedInterest called with non-Interest packet")
+	}
+	faceID := packet.DroppedFaceID.Unwrap()
+
+	core.Log.Debug(t, "OnDroppedInterest", "name", packet.Name, "faceid", faceID)
+
+	pitEntry := t.pitCS.FindInterestExactMatchEnc(interest)
+	if pitEntry == nil {
+		return // already satisfied or expired
+	}
+
+	// Ignore if the Interest was retransmitted to the face since
+	outRecord := pitEntry.OutRecords()[faceID]
+	if outRecord == nil || !interest.NonceV.IsSet() || outRecord.LatestNonce != interest.NonceV.Unwrap() {
+		return
+	}
+
+	nack := *packet
+	nack.IncomingFaceID = faceID
+	nack.DroppedFaceID = optional.None[uint64]()
+	nack.NackReason = optional.Some(uint64(spec.NackReasonCongestion))
+	outRecord.NackReason = nack.NackReason
+
+	strategy := t.strategy(table.FibStrategyTable.FindStrategyEnc(pitEntry.EncName()))
+	strategy.AfterReceiveNack(&nack, pitEntry, faceID)
+}
+
+// processOutgoingNack sends a Nack to a downstream face with an in-record in the PIT entry.
+// The Nack carries the latest Interest received from that downstream.
+func (t *Thread) processOutgoingNack(
+	packet *defn.Pkt,
+	pitEntry table.PitEntry,
+	nexthop uint64,
+	reason uint64,
+) {
+	inRecord := pitEntry.InRecords()[nexthop]
+	if inRecord == nil {
+		core.Log.Debug(t, "No in-record for outgoing Nack", "name", packet.Name, "faceid", nexthop)
+		return
+	}
+
+	outgoingFace := dispatch.GetFace(nexthop)
+	if outgoingFace == nil {
+		core.Log.Error(t, "Non-existent nexthop for Nack", "name", packet.Name, "faceid", nexthop)
+		return
+	}
+	if outgoingFace.LinkType() != defn.PointToPoint {
+		return
+	}
+
+	// The Nack must carry the Nonce the downstream sent
+	wire := bytes.Clone(packet.Raw.Join())
+	if !defn.SetInterestNonce(wire, inRecord.LatestNonce) {
+		core.Log.Error(t, "Unable to set Nonce of outgoing Nack", "name", packet.Name)
+		return
+	}
+	downstream := &defn.Pkt{
+		Name: packet.Name,
+		L3:   packet.L3,
+		Raw:  enc.Wire{wire},
+	}
+	pitToken := inRecord.PitToken
+	pitEntry.RemoveInRecord(nexthop)
+
+	t.sendNack(downstream, nexthop, pitToken, reason)
+}
+
+// sendNack sends a Nack of an Interest to a downstream face.
+func (t *Thread) sendNack(packet *defn.Pkt, faceID uint64, pitToken []byte, reason uint64) {
+	face := dispatch.GetFace(faceID)
+	if face == nil || face.LinkType() != defn.PointToPoint {
+		return
+	}
+
+	core.Log.Trace(t, "OnOutgoingNack", "name", packet.Name, "faceid", faceID, "reason", reason)
+
+	// Update counters
+	t.nOutNacks.Add(1)
+
+	face.SendPacket(dispatch.OutPkt{
+		Pkt:      defn.MakeNack(packet, reason),
+		PitToken: pitToken,
+	})
+}
+
 // (AI GENERATED DESCRIPTION): Finalizes an Interest by recording its nonces into the dead‑nonce list and, if the Interest was unsatisfied, incrementing the counter of unsatisfied Interests.
 func (t *Thread) finalizeInterest(pitEntry table.PitEntry) {
 	// Check for nonces to insert into dead nonce list
@@ -420,6 +698,10 @@ func (t *Thread) finalizeInterest(pitEntry table.PitEntry) {
 	// Update counters
 	if !pitEntry.Satisfied() {
 		t.nUnsatisfiedInterests.Add(uint64(len(pitEntry.InRecords())))
+
+		// Let the strategy account for upstreams that did not answer
+		strategy := t.strategy(table.FibStrategyTable.FindStrategyEnc(pitEntry.EncName()))
+		strategy.BeforeExpirePendingInterest(pitEntry)
 	}
 }
 
@@ -467,8 +749,7 @@ func (t *Thread) processIncomingData(packet *defn.Pkt) {
 	}
 
 	// Get strategy for name
-	strategyName := table.FibStrategyTable.FindStrategyEnc(data.NameV)
-	strategy := t.strategies[strategyName.Hash()]
+	strategy := t.strategy(table.FibStrategyTable.FindStrategyEnc(data.NameV))
 
 	if len(pitEntries) == 1 {
 		// When a single PIT entry matches, we pass the data to the strategy.
@@ -479,7 +760,7 @@ func (t *Thread) processIncomingData(packet *defn.Pkt) {
 		table.UpdateExpirationTimer(pitEntry, time.Now())
 
 		// Invoke strategy's AfterReceiveData
-		core.Log.Trace(t, "Sending Data", "name", packet.Name, "strategy", strategyName)
+		core.Log.Trace(t, "Sending Data", "name", packet.Name, "strategy", strategy.GetName())
 		strategy.AfterReceiveData(packet, pitEntry, packet.IncomingFaceID)
 
 		// Mark PIT entry as satisfied
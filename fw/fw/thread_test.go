@@ -0,0 +1,98 @@
+package fw
+
+import (
+	"testing"
+
+	"github.com/named-data/ndnd/fw/defn"
+	"github.com/named-data/ndnd/fw/dispatch"
+	"github.com/named-data/ndnd/fw/table"
+	enc "github.com/named-data/ndnd/std/encoding"
+	spec_mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	"github.com/named-data/ndnd/std/types/optional"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// addTestFaces registers non-local faces with the given IDs.
+func addTestFaces(t *testing.T, ids ...uint64) []*testFace {
+	faces := make([]*testFace, 0, len(ids))
+	for _, id := range ids {
+		face := &testFace{id: id, scope: defn.NonLocal}
+		dispatch.AddFace(id, face)
+		faces = append(faces, face)
+	}
+	t.Cleanup(func() {
+		for _, id := range ids {
+			dispatch.RemoveFace(id)
+		}
+	})
+	return faces
+}
+
+// dropInterest returns the last Interest sent on a face as dropped by its send queue.
+func dropInterest(t *testing.T, face *testFace) *defn.Pkt {
+	sent := face.pop()
+	require.NotEmpty(t, sent)
+	out := sent[len(sent)-1]
+	dropped := *out.Pkt
+	dropped.PitToken = out.PitToken
+	dropped.DroppedFaceID = optional.Some(face.id)
+	return &dropped
+}
+
+// Tests that an Interest dropped by an upstream is a Congestion Nack for the strategy,
+// which Nacks all downstreams once no upstream is pending.
+func TestDroppedInterest(t *testing.T) {
+	initTablesOnce.Do(table.Initialize)
+	faces := addTestFaces(t, 9101, 9102, 9103, 9104)
+	down1, down2, up1, up2 := faces[0], faces[1], faces[2], faces[3]
+
+	prefix, _ := enc.NameFromStr("/dropped")
+	table.FibStrategyTable.SetStrategyEnc(prefix, defn.STRATEGY_PREFIX.
+		Append(enc.NewGenericComponent("multicast")).
+		Append(enc.NewVersionComponent(1)))
+	t.Cleanup(func() { table.FibStrategyTable.UnSetStrategyEnc(prefix) })
+	for _, up := range []*testFace{up1, up2} {
+		table.Rib.AddEncRoute(prefix, &table.Route{FaceID: up.id, Origin: uint64(spec_mgmt.RouteOriginApp)})
+		t.Cleanup(func() { table.Rib.RemoveRouteEnc(prefix, up.id, uint64(spec_mgmt.RouteOriginApp)) })
+	}
+
+	thread := NewThread(0)
+	thread.processIncomingInterest(makeTestInterest("/dropped/obj", 1, down1.id))
+	thread.processIncomingInterest(makeTestInterest("/dropped/obj", 2, down2.id))
+
+	// The other upstream may still answer
+	thread.processDroppedInterest(dropInterest(t, up1))
+	assert.Empty(t, down1.pop())
+	assert.Empty(t, down2.pop())
+
+	thread.processDroppedInterest(dropInterest(t, up2))
+	for i, down := range []*testFace{down1, down2} {
+		nack := down.pop()
+		require.Len(t, nack, 1)
+		assert.Equal(t, uint64(spec.NackReasonCongestion), nack[0].Pkt.NackReason.Unwrap())
+
+		// The Nack carries the Nonce of the downstream
+		interest, err := defn.ParseFwPacket(enc.NewWireView(nack[0].Pkt.Raw), false)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(i+1), interest.Interest.NonceV.Unwrap())
+	}
+}
+
+// Tests that an Interest that does not fit in the queue is Nacked by the thread.
+func TestQueueInterestCongested(t *testing.T) {
+	consumer := addTestFaces(t, 9105)[0]
+	thread := NewThread(0)
+	for len(thread.pending) < cap(thread.pending) {
+		thread.pending <- makeTestInterest("/congested/queued", 1, consumer.id)
+	}
+
+	thread.QueueInterest(makeTestInterest("/congested/obj", 2, consumer.id))
+	assert.Empty(t, consumer.pop())
+
+	thread.rejectCongestedInterest(<-thread.congested)
+	nack := consumer.pop()
+	require.Len(t, nack, 1)
+	assert.Equal(t, uint64(spec.NackReasonCongestion), nack[0].Pkt.NackReason.Unwrap())
+}
@@ -0,0 +1,171 @@
+/* YaNFD - Yet another NDN Forwarding Daemon
+ *
+ * This file is licensed under the terms of the MIT License, as found in LICENSE.md.
+ */
+
+package mgmt
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/face"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	"github.com/named-data/ndnd/std/types/optional"
+)
+
+// All packet types that can be selected by a capture filter
+const capturePktAll = mgmt.CapturePktInterest | mgmt.CapturePktData | mgmt.CapturePktNack
+
+// CaptureModule is the module that handles packet captures.
+type CaptureModule struct {
+	manager *Thread
+}
+
+func (c *CaptureModule) String() string {
+	return "mgmt-capture"
+}
+
+func (c *CaptureModule) registerManager(manager *Thread) {
+	c.manager = manager
+}
+
+func (c *CaptureModule) getManager() *Thread {
+	return c.manager
+}
+
+func (c *CaptureModule) handleIncomingInterest(interest *Interest) {
+	// Only allow from /localhost
+	if !LOCAL_PREFIX.IsPrefix(interest.Name()) {
+		core.Log.Warn(c, "Received capture management Interest from non-local source")
+		return
+	}
+
+	// Dispatch by verb
+	verb := interest.Name()[len(LOCAL_PREFIX)+1].String()
+	switch verb {
+	case "start":
+		c.start(interest)
+	case "stop":
+		c.stop(interest)
+	case "status":
+		c.status(interest)
+	default:
+		core.Log.Warn(c, "Received Interest for non-existent verb", "verb", verb)
+		c.manager.sendCtrlResp(interest, 501, "Unknown verb", nil)
+		return
+	}
+}
+
+// start starts a packet capture to the file given in the Uri field.
+func (c *CaptureModule) start(interest *Interest) {
+	if len(interest.Name()) < len(LOCAL_PREFIX)+3 {
+		// Name not long enough to contain ControlParameters
+		core.Log.Warn(c, "Missing ControlParameters", "name", interest.Name())
+		c.manager.sendCtrlResp(interest, 400, "ControlParameters is incorrect", nil)
+		return
+	}
+
+	params := decodeControlParameters(c, interest)
+	if params == nil {
+		c.manager.sendCtrlResp(interest, 400, "ControlParameters is incorrect", nil)
+		return
+	}
+
+	if core.C.Mgmt.CaptureDir == "" {
+		c.manager.sendCtrlResp(interest, 403, "Packet capture is disabled", nil)
+		return
+	}
+	file, ok := params.Uri.Get()
+	if !ok {
+		c.manager.sendCtrlResp(interest, 400, "Missing capture file", nil)
+		return
+	}
+	path, err := capturePath(core.C.ResolveRelPath(core.C.Mgmt.CaptureDir), file)
+	if err != nil {
+		core.Log.Warn(c, "Rejected capture file", "file", file, "err", err)
+		c.manager.sendCtrlResp(interest, 403, err.Error(), nil)
+		return
+	}
+
+	filter := face.CaptureFilter{
+		FaceID: params.FaceId.GetOr(0),
+		Prefix: params.Name,
+		Types:  params.PacketTypes.GetOr(0),
+	}
+	if filter.FaceID != 0 && face.FaceTable.Get(filter.FaceID) == nil {
+		c.manager.sendCtrlResp(interest, 404, "Face does not exist", &mgmt.ControlArgs{FaceId: params.FaceId})
+		return
+	}
+	if filter.Types&^capturePktAll != 0 {
+		c.manager.sendCtrlResp(interest, 400, "Unknown packet type", nil)
+		return
+	}
+
+	capture, err := face.StartCapture(path, filter)
+	if err != nil {
+		core.Log.Warn(c, "Unable to start capture", "path", path, "err", err)
+		c.manager.sendCtrlResp(interest, 409, err.Error(), nil)
+		return
+	}
+
+	c.manager.sendCtrlResp(interest, 200, "OK", captureArgs(capture))
+}
+
+// capturePath returns the path of a capture file, which must be directly in
+// the capture directory. A file name without directory is put in the capture directory.
+func capturePath(dir string, file string) (string, error) {
+	dir = filepath.Clean(dir)
+	path := filepath.Clean(file)
+	if !filepath.IsAbs(path) {
+		if file == "" || filepath.Base(path) != path {
+			return "", errors.New("Capture file must be a file name or an absolute path")
+		}
+		path = filepath.Join(dir, path)
+	}
+	if filepath.Dir(path) != dir || filepath.Base(path) == ".." {
+		return "", fmt.Errorf("Capture file must be in the capture directory %s", dir)
+	}
+	return path, nil
+}
+
+// stop stops the running packet capture.
+func (c *CaptureModule) stop(interest *Interest) {
+	capture, err := face.StopCapture()
+	if err != nil {
+		c.manager.sendCtrlResp(interest, 409, err.Error(), nil)
+		return
+	}
+
+	c.manager.sendCtrlResp(interest, 200, "OK", captureArgs(capture))
+}
+
+// status replies with the running packet capture.
+func (c *CaptureModule) status(interest *Interest) {
+	capture := face.RunningCapture()
+	if capture == nil {
+		c.manager.sendCtrlResp(interest, 404, "No capture is running", nil)
+		return
+	}
+
+	c.manager.sendCtrlResp(interest, 200, "OK", captureArgs(capture))
+}
+
+// captureArgs returns the parameters and packet count of a capture.
+func captureArgs(capture *face.PacketCapture) *mgmt.ControlArgs {
+	filter := capture.Filter()
+	args := &mgmt.ControlArgs{
+		Name:  filter.Prefix,
+		Uri:   optional.Some(capture.Path()),
+		Count: optional.Some(capture.NPackets()),
+	}
+	if filter.FaceID != 0 {
+		args.FaceId = optional.Some(filter.FaceID)
+	}
+	if filter.Types != 0 {
+		args.PacketTypes = optional.Some(filter.Types)
+	}
+	return args
+}
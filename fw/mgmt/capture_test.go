@@ -0,0 +1,32 @@
+package mgmt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapturePath(t *testing.T) {
+	for _, file := range []string{"test.pcapng", "/var/capture/test.pcapng", "/var/capture/../capture/test.pcapng"} {
+		path, err := capturePath("/var/capture/", file)
+		require.NoError(t, err, file)
+		assert.Equal(t, "/var/capture/test.pcapng", path, file)
+	}
+
+	for _, file := range []string{
+		"",
+		".",
+		"..",
+		"../test.pcapng",
+		"sub/test.pcapng",
+		"/etc/passwd",
+		"/var/capture",
+		"/var/capture/..",
+		"/var/capture/sub/test.pcapng",
+		"/var/capture/../test.pcapng",
+	} {
+		_, err := capturePath("/var/capture", file)
+		assert.Error(t, err, file)
+	}
+}
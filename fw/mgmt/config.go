@@ -0,0 +1,66 @@
+package mgmt
+
+import (
+	"github.com/named-data/ndnd/fw/core"
+)
+
+// reloadConfig reloads the forwarder configuration file.
+var reloadConfig func() error
+
+// SetConfigReloader sets the function called by the config/reload command.
+func SetConfigReloader(f func() error) {
+	reloadConfig = f
+}
+
+// ConfigModule is the module that handles forwarder configuration.
+type ConfigModule struct {
+	manager *Thread
+}
+
+func (c *ConfigModule) String() string {
+	return "mgmt-config"
+}
+
+func (c *ConfigModule) registerManager(manager *Thread) {
+	c.manager = manager
+}
+
+func (c *ConfigModule) getManager() *Thread {
+	return c.manager
+}
+
+func (c *ConfigModule) handleIncomingInterest(interest *Interest) {
+	// Only allow from /localhost
+	if !LOCAL_PREFIX.IsPrefix(interest.Name()) {
+		core.Log.Warn(c, "Received config management Interest from non-local source")
+		return
+	}
+
+	// Dispatch by verb
+	verb := interest.Name()[len(LOCAL_PREFIX)+1].String()
+	switch verb {
+	case "reload":
+		c.reload(interest)
+	default:
+		core.Log.Warn(c, "Received Interest for non-existent verb", "verb", verb)
+		c.manager.sendCtrlResp(interest, 501, "Unknown verb", nil)
+		return
+	}
+}
+
+// reload re-reads the configuration file and applies the changes.
+func (c *ConfigModule) reload(interest *Interest) {
+	if reloadConfig == nil {
+		c.manager.sendCtrlResp(interest, 501, "Configuration reload is not supported", nil)
+		return
+	}
+
+	core.Log.Info(c, "Reloading configuration")
+	if err := reloadConfig(); err != nil {
+		core.Log.Warn(c, "Unable to reload configuration", "err", err)
+		c.manager.sendCtrlResp(interest, 409, err.Error(), nil)
+		return
+	}
+
+	c.manager.sendCtrlResp(interest, 200, "OK", nil)
+}
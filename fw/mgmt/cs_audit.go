@@ -2,30 +2,63 @@ package mgmt
 
 import (
 	"fmt"
+	"math"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/named-data/ndnd/fw/core"
 	"github.com/named-data/ndnd/fw/table"
 	enc "github.com/named-data/ndnd/std/encoding"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	"github.com/named-data/ndnd/std/types/optional"
 )
 
+// csAuditRoundTimeout 是 round 请求等待转发线程完成本轮挑战的超时。
+const csAuditRoundTimeout = 800 * time.Millisecond
+
 // CsAuditModule 提供本机（/localhost）上的缓存审计查询接口。
 //
 // 中文说明：
 // - 本模块不直接读取转发线程的 CS，而是查询 table 包里由审计者 goroutine 维护的 CSNAT（前缀聚合树）。
-// - 目前叶子标签为 BLSTag(Name, Wire)（占位实现：HMAC-SHA256），聚合值为 SHA-256 Merkle 化聚合。
+// - 叶子标签为 BLSTag(Name, Wire)（方案可插拔：hmac-sha256 / bls12-381）。
+// - agg 为 SHA-256 Merkle 化聚合，sig 为方案相关的同态聚合（BLS 下即聚合签名）。
 //
 // 接口约定：
 // - /localhost/nfd/cs-audit/agg[/<prefix...>]  -> 返回 prefix 子树的聚合 tag（32 bytes）
-// - /localhost/nfd/cs-audit/leaf/<name...>    -> 返回精确 name 的叶子 tag（32 bytes）
+// - /localhost/nfd/cs-audit/sig[/<prefix...>]  -> 返回 prefix 子树的同态聚合标签（hmac 32 bytes / bls 48 bytes）
+// - /localhost/nfd/cs-audit/pubkey             -> 返回本节点审计公钥（bls 96 bytes；hmac 方案返回 404）
+// - /localhost/nfd/cs-audit/leaf/<name...>    -> 返回精确 name 的叶子 tag（hmac 32 bytes / bls 48 bytes）
 // - /localhost/nfd/cs-audit/flip/<name...>    -> 对指定 name 的缓存条目进行随机 1-bit 翻转（用于验证审计）
+// - /localhost/nfd/cs-audit/round/<k>/<seed>  -> 用审计者提供的 32 字节种子发起一轮抽样挑战（k=0 为全表），返回本轮结果（CsAuditRoundResult）
+// - /localhost/nfd/cs-audit/status            -> 返回审计运行状态计数器与最近一轮的检测概率（CsAuditStatus）
+// - /localhost/nfd/cs-audit/history           -> 返回最近的审计结果记录（CsAuditHistoryMsg，可跨重启持久化）
+// - /localhost/nfd/cs-audit/config[/<cfg>]     -> 修改 cfg（CsAuditConfig TLV）中出现的字段，返回当前运行时配置（CsAuditConfig）
+// - /localhost/nfd/cs-audit/aggregates/<depth>[/<prefix...>] -> 返回前缀聚合数据集（CsNatAggregateMsg），见 cs_audit_aggregates.go
+// - 远程挑战 /<node>/cs-audit/challenge/... 见 cs_audit_challenge.go
+// - 损坏条目的修复 Interest 由 runRepairs 发出，见 cs_audit_repair.go
 type CsAuditModule struct {
 	manager *Thread
+
+	// 中文说明：远程请求签名的验证器（tables.content_store.audit.auditors；nil 表示拒绝所有远程请求）。
+	auditors *trustValidator
+
+	// 中文说明：远程挑战 nonce -> 首次出现时间（重放保护）。
+	// 签名验证的回调可能在其它 goroutine 中执行，因此需要加锁。
+	challengeNonces map[string]time.Time
+	challengeMutex  sync.Mutex
 }
 
 func (m *CsAuditModule) String() string { return "mgmt-cs-audit" }
 func (m *CsAuditModule) registerManager(manager *Thread) {
 	m.manager = manager
+
+	cfg := &core.C.Tables.ContentStore.Audit.Auditors
+	auditors, err := newTrustValidator(manager, "mgmt-cs-audit-auditors", cfg.Keychain, cfg.TrustSchema, cfg.TrustAnchors)
+	if err != nil {
+		core.Log.Fatal(m, "Unable to set up CS audit request validation", "err", err)
+	}
+	m.auditors = auditors
 }
 func (m *CsAuditModule) getManager() *Thread { return m.manager }
 
@@ -46,10 +79,24 @@ func (m *CsAuditModule) handleIncomingInterest(interest *Interest) {
 	switch verb {
 	case "agg":
 		m.agg(interest)
+	case "sig":
+		m.sig(interest)
+	case "pubkey":
+		m.pubkey(interest)
 	case "leaf":
 		m.leaf(interest)
 	case "flip":
 		m.flip(interest)
+	case "round":
+		m.round(interest)
+	case "status":
+		m.status(interest)
+	case "history":
+		m.history(interest)
+	case "config":
+		m.config(interest)
+	case "aggregates":
+		m.aggregates(interest, LOCAL_PREFIX.Append(enc.NewGenericComponent("cs-audit")))
 	default:
 		core.Log.Warn(m, "Received Interest for non-existent verb", "verb", verb)
 		m.manager.sendCtrlResp(interest, 501, "Unknown verb", nil)
@@ -89,6 +136,47 @@ func (m *CsAuditModule) agg(interest *Interest) {
 	m.manager.sendStatusDataset(interest, name, enc.Wire{buf})
 }
 
+func (m *CsAuditModule) sig(interest *Interest) {
+	// 解析 prefix：/localhost/nfd/cs-audit/sig[/<prefix...>]
+	var prefix enc.Name
+	if len(interest.Name()) > len(LOCAL_PREFIX)+2 {
+		prefix = interest.Name()[len(LOCAL_PREFIX)+2:]
+	} else {
+		prefix = enc.Name{}
+	}
+	// 中文说明：与 agg 相同，nfdc 会在末尾追加 "_"。
+	if len(prefix) > 0 && prefix.At(-1).IsGeneric("_") {
+		prefix = prefix.Prefix(-1)
+	}
+
+	tag, ok := table.GetCsNatTagAgg(prefix)
+	if !ok {
+		m.manager.sendCtrlResp(interest, 404, "Prefix not found", nil)
+		return
+	}
+
+	name := LOCAL_PREFIX.
+		Append(enc.NewGenericComponent("cs-audit")).
+		Append(enc.NewGenericComponent("sig")).
+		Append(prefix...).
+		Append(enc.NewGenericComponent("_"))
+	m.manager.sendStatusDataset(interest, name, enc.Wire{tag})
+}
+
+func (m *CsAuditModule) pubkey(interest *Interest) {
+	// 中文说明：对称方案（hmac-sha256）没有公钥，第三方无法验证。
+	pk := table.CsAuditScheme().PublicKey()
+	if pk == nil {
+		m.manager.sendCtrlResp(interest, 404, "Audit scheme has no public key", nil)
+		return
+	}
+
+	name := LOCAL_PREFIX.
+		Append(enc.NewGenericComponent("cs-audit")).
+		Append(enc.NewGenericComponent("pubkey"))
+	m.manager.sendStatusDataset(interest, name, enc.Wire{pk})
+}
+
 func (m *CsAuditModule) flip(interest *Interest) {
 	// 解析 name：/localhost/nfd/cs-audit/flip/<name...>
 	if len(interest.Name()) <= len(LOCAL_PREFIX)+2 {
@@ -155,9 +243,6 @@ func (m *CsAuditModule) leaf(interest *Interest) {
 		return
 	}
 
-	buf := make([]byte, 32)
-	copy(buf, sum[:])
-
 	name := LOCAL_PREFIX.
 		Append(enc.NewGenericComponent("cs-audit")).
 		Append(enc.NewGenericComponent("leaf")).
@@ -165,5 +250,204 @@ func (m *CsAuditModule) leaf(interest *Interest) {
 		// 中文说明：追加 "_"，保证 dataset 的版本组件不会覆盖目标 name 自带的版本组件。
 		Append(enc.NewGenericComponent("_"))
 
-	m.manager.sendStatusDataset(interest, name, enc.Wire{buf})
+	m.manager.sendStatusDataset(interest, name, enc.Wire{sum})
+}
+
+func (m *CsAuditModule) round(interest *Interest) {
+	// 解析参数：/localhost/nfd/cs-audit/round/<k>/<seed>
+	if len(interest.Name()) < len(LOCAL_PREFIX)+4 {
+		m.manager.sendCtrlResp(interest, 400, "Missing sample size or seed", nil)
+		return
+	}
+	kComp := interest.Name()[len(LOCAL_PREFIX)+2]
+	seedComp := interest.Name()[len(LOCAL_PREFIX)+3]
+	k, err := strconv.ParseUint(kComp.String(), 10, 32)
+	if err != nil {
+		m.manager.sendCtrlResp(interest, 400, "Invalid sample size", nil)
+		return
+	}
+	var seed [32]byte
+	if len(seedComp.Val) != len(seed) {
+		m.manager.sendCtrlResp(interest, 400, "Seed must be 32 bytes", nil)
+		return
+	}
+	copy(seed[:], seedComp.Val)
+
+	// 中文说明：等待各转发线程完成本轮（最多 csAuditRoundTimeout），返回的是“这个种子”对应的结果；
+	// detectProb 为本轮抽中单个损坏条目的概率。
+	mode := table.CfgCsAuditSampleMode()
+	res := table.RunCsAuditRound(seed, int(k), mode, table.CfgCsAuditSampleDepth(), csAuditRoundTimeout)
+	dataset := &mgmt.CsAuditRoundResult{
+		Seed:       seed[:],
+		SampleK:    k,
+		SampleMode: mode,
+		NThreads:   res.NThreads,
+		NFinished:  res.NFinished,
+		NOverruns:  res.NOverruns,
+		Total:      res.Total,
+		Sampled:    res.Sampled,
+		Mismatched: res.Mismatched,
+		DetectProb: formatCsAuditProb(table.CsAuditDetectProb(res.Total, res.Sampled, 1)),
+		Checked:    res.Checked,
+	}
+
+	name := LOCAL_PREFIX.
+		Append(enc.NewGenericComponent("cs-audit")).
+		Append(enc.NewGenericComponent("round")).
+		Append(kComp, seedComp)
+	m.manager.sendStatusDataset(interest, name, dataset.Encode())
+}
+
+// formatCsAuditProb 把概率编码为 TLV 中的字符串（与 SeuRate 相同的格式）。
+func formatCsAuditProb(p float64) string {
+	return strconv.FormatFloat(p, 'g', -1, 64)
+}
+
+func (m *CsAuditModule) history(interest *Interest) {
+	// 中文说明：记录由 table 包维护（配置了审计日志时包含重启前的记录）。
+	hist := table.GetCsAuditHistory()
+	dataset := &mgmt.CsAuditHistoryMsg{Entries: make([]*mgmt.CsAuditHistoryEntry, 0, len(hist))}
+	for _, e := range hist {
+		dataset.Entries = append(dataset.Entries, &mgmt.CsAuditHistoryEntry{
+			Timestamp:  uint64(e.Time.UnixMilli()),
+			Event:      e.Event,
+			Name:       e.Name,
+			Checked:    e.Checked,
+			Mismatched: e.Mismatched,
+		})
+	}
+
+	name := LOCAL_PREFIX.
+		Append(enc.NewGenericComponent("cs-audit")).
+		Append(enc.NewGenericComponent("history"))
+	m.manager.sendStatusDataset(interest, name, dataset.Encode())
+}
+
+func (m *CsAuditModule) status(interest *Interest) {
+	st := table.GetCsAuditStatus()
+	dataset := &mgmt.CsAuditStatus{
+		Scheme:                st.Scheme,
+		NCsNatNodes:           st.NCsNatNodes,
+		NCsNatLeaves:          st.NCsNatLeaves,
+		NRounds:               st.NRounds,
+		NRoundOverruns:        st.NRoundOverruns,
+		NRemoteChallenges:     st.NRemoteChallenges,
+		NChecked:              st.NChecked,
+		NProofsVerified:       st.NProofsVerified,
+		NMismatches:           st.NMismatches,
+		NUnknownProofs:        st.NUnknownProofs,
+		NCorruptEvicted:       st.NCorruptEvicted,
+		NSeuInjections:        st.NSeuInjections,
+		NFlips:                st.NFlips,
+		NEventsDropped:        st.NEventsDropped,
+		NProofsDropped:        st.NProofsDropped,
+		NProducerTagsRejected: st.NProducerTagsRejected,
+		NRepairs:              st.NRepairs,
+		NRepairsSucceeded:     st.NRepairsSucceeded,
+		NRepairsRejected:      st.NRepairsRejected,
+		NRepairsTimedOut:      st.NRepairsTimedOut,
+		LastTotal:             st.LastTotal,
+		LastSampled:           st.LastSampled,
+		LastMismatched:        st.LastMismatched,
+		DetectProb:            formatCsAuditProb(st.DetectProb),
+	}
+
+	name := LOCAL_PREFIX.
+		Append(enc.NewGenericComponent("cs-audit")).
+		Append(enc.NewGenericComponent("status"))
+	m.manager.sendStatusDataset(interest, name, dataset.Encode())
+}
+
+func (m *CsAuditModule) config(interest *Interest) {
+	name := LOCAL_PREFIX.
+		Append(enc.NewGenericComponent("cs-audit")).
+		Append(enc.NewGenericComponent("config"))
+
+	// 中文说明：不带参数时只返回当前配置；参数与响应使用同一个 CsAuditConfig 结构。
+	if len(interest.Name()) > len(LOCAL_PREFIX)+2 {
+		paramComp := interest.Name()[len(LOCAL_PREFIX)+2]
+		params, err := mgmt.ParseCsAuditConfig(enc.NewBufferView(paramComp.Val), true)
+		if err != nil || params == nil {
+			core.Log.Warn(m, "Invalid CsAuditConfig", "name", interest.Name(), "err", err)
+			m.manager.sendCtrlResp(interest, 400, "CsAuditConfig is incorrect", nil)
+			return
+		}
+		if code, msg := m.applyConfig(params); code != 200 {
+			m.manager.sendCtrlResp(interest, code, msg, nil)
+			return
+		}
+		name = name.Append(paramComp)
+	}
+
+	m.manager.sendStatusDataset(interest, name, m.currentConfig().Encode())
+}
+
+// applyConfig 校验并应用 CsAuditConfig 中出现的字段；全部校验通过后才修改配置。
+func (m *CsAuditModule) applyConfig(params *mgmt.CsAuditConfig) (uint64, string) {
+	if params.Flags.IsSet() != params.Mask.IsSet() {
+		return 409, "Flags and Mask fields must either both be present or both be not present"
+	}
+
+	var seuRate float64
+	if rateStr, ok := params.SeuRate.Get(); ok {
+		rate, err := strconv.ParseFloat(rateStr, 64)
+		if err != nil || !(rate > 0) || math.IsInf(rate, 0) {
+			return 400, "Invalid SEU rate"
+		}
+		seuRate = rate
+	}
+	if prefix, ok := params.SeuPrefix.Get(); ok && prefix != "" {
+		if _, err := enc.NameFromStr(prefix); err != nil {
+			return 400, "Invalid SEU prefix"
+		}
+	}
+
+	if interval, ok := params.Interval.Get(); ok {
+		core.Log.Info(m, "Setting CS audit interval", "interval", interval)
+		table.CfgSetCsSha256ChallengeInterval(time.Duration(interval) * time.Millisecond)
+	}
+	if params.SeuRate.IsSet() {
+		core.Log.Info(m, "Setting SEU rate", "rate", seuRate)
+		table.CfgSetCsSeuRatePerBitPerDay(seuRate)
+	}
+	if prefix, ok := params.SeuPrefix.Get(); ok {
+		core.Log.Info(m, "Setting SEU prefix", "prefix", prefix)
+		table.CfgSetCsSeuPrefix(prefix)
+	}
+
+	if params.Mask.IsSet() && params.Flags.IsSet() {
+		mask := params.Mask.Unwrap()
+		flags := params.Flags.Unwrap()
+
+		if mask&mgmt.CsAuditEnableLog > 0 {
+			val := flags&mgmt.CsAuditEnableLog > 0
+			core.Log.Info(m, "Setting CS audit log flag", "value", val)
+			table.CfgSetCsAuditLog(val)
+		}
+
+		if mask&mgmt.CsAuditEnableSeu > 0 {
+			val := flags&mgmt.CsAuditEnableSeu > 0
+			core.Log.Info(m, "Setting SEU injector flag", "value", val)
+			table.CfgSetCsSeuEnabled(val)
+		}
+	}
+
+	return 200, "OK"
+}
+
+// currentConfig 返回当前运行时配置。
+func (m *CsAuditModule) currentConfig() *mgmt.CsAuditConfig {
+	flags := uint64(0)
+	if table.CfgCsAuditLogEnabled() {
+		flags |= mgmt.CsAuditEnableLog
+	}
+	if table.CfgCsSeuEnabled() {
+		flags |= mgmt.CsAuditEnableSeu
+	}
+	return &mgmt.CsAuditConfig{
+		Interval:  optional.Some(uint64(table.CfgCsSha256ChallengeInterval().Milliseconds())),
+		SeuRate:   optional.Some(strconv.FormatFloat(table.CfgCsSeuRatePerBitPerDay(), 'g', -1, 64)),
+		SeuPrefix: optional.Some(table.CfgCsSeuPrefix().String()),
+		Flags:     optional.Some(flags),
+	}
 }
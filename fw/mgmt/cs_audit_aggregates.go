@@ -0,0 +1,112 @@
+package mgmt
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/named-data/ndnd/fw/table"
+	enc "github.com/named-data/ndnd/std/encoding"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+)
+
+// 中文说明：CSNAT 前缀聚合数据集（多节点路径审计）。
+//
+// 接口约定：
+// - /localhost/nfd/cs-audit/aggregates/<depth>[/<prefix...>]/_
+//   -> 以状态数据集（分段）返回 CsNatAggregateMsg：prefix 子树及其下 depth 层以内各前缀的 agg、同态聚合标签与叶子数。
+// - /<node>/cs-audit/aggregates/<depth>[/<prefix...>]/<nonce>   （相邻转发器或审计者远程获取，需配置 node_prefix）
+//   -> 必须是通过 auditors 信任模式验证的 signed Interest（见 authorizeRemote）；
+//      以单个 Data 返回 CsNatAggregateMsg，携带请求 nonce，并用本节点密钥签名。
+//
+// 说明：
+// - 列表按 CSNAT 的规范顺序排列，同一前缀在路径上各节点的聚合值可以逐项比较；
+//   同态聚合标签可以跨节点相加，审计者用一个组合证明验证整条路径上的缓存（见 nfdc cs-audit-compare）。
+// - prefix 不在 CSNAT 中时返回空列表（而不是错误），表示该节点没有缓存该前缀下的内容。
+// - 数据集只包含聚合值，不包含缓存内容本身。
+// - 远程响应最多 csAuditAggregatesMaxSize 字节，超出部分被截断并设置 Truncated。
+
+// csAuditAggregatesMaxDepth 限制一次请求展开的层数，避免远程请求生成过大的数据集。
+const csAuditAggregatesMaxDepth = 8
+
+// csAuditAggregatesMaxSize 限制远程响应中聚合列表的编码大小，使响应能放进一个 Data 包。
+const csAuditAggregatesMaxSize = 6000
+
+// aggregates 处理 base/aggregates/... 请求；base 为 /localhost/nfd/cs-audit 或 /<node>/cs-audit。
+func (m *CsAuditModule) aggregates(interest *Interest, base enc.Name) {
+	name := interest.Name()
+	remote := !LOCAL_PREFIX.IsPrefix(base)
+	if remote && len(name) > 0 && name.At(-1).Typ == enc.TypeParametersSha256DigestComponent {
+		name = name.Prefix(-1)
+	}
+	if len(name) < len(base)+2 {
+		m.manager.sendCtrlResp(interest, 400, "Missing aggregate depth", nil)
+		return
+	}
+	depthComp := name[len(base)+1]
+	depth, err := strconv.ParseUint(depthComp.String(), 10, 32)
+	if err != nil || depth > csAuditAggregatesMaxDepth {
+		m.manager.sendCtrlResp(interest, 400, "Invalid aggregate depth", nil)
+		return
+	}
+
+	prefix := name[len(base)+2:]
+	if remote {
+		// 远程请求末尾为 nonce
+		if len(prefix) == 0 || len(prefix.At(-1).Val) < csAuditChallengeMinNonce {
+			m.manager.sendCtrlResp(interest, 400, "Missing or short request nonce", nil)
+			return
+		}
+		nonce := prefix.At(-1).Val
+		prefix = prefix.Prefix(-1)
+		m.authorizeRemote(interest, nonce, func() {
+			dataset := m.aggregatesMsg(prefix, int(depth), csAuditAggregatesMaxSize)
+			dataset.Nonce = nonce
+			m.manager.sendSignedData(interest, interest.Name(), dataset.Encode(), m.nodeSigner())
+		})
+		return
+	}
+
+	// 中文说明：与 agg 相同，nfdc 会在末尾追加 "_"。
+	if len(prefix) > 0 && prefix.At(-1).IsGeneric("_") {
+		prefix = prefix.Prefix(-1)
+	}
+
+	dsName := base.
+		Append(enc.NewGenericComponent("aggregates")).
+		Append(depthComp).
+		Append(prefix...).
+		Append(enc.NewGenericComponent("_"))
+	m.manager.sendStatusDataset(interest, dsName, m.aggregatesMsg(prefix, int(depth), 0).Encode())
+}
+
+// aggregatesMsg 生成 prefix 的聚合数据集；maxSize>0 时聚合列表的编码大小不超过 maxSize。
+func (m *CsAuditModule) aggregatesMsg(prefix enc.Name, depth int, maxSize int) *mgmt.CsNatAggregateMsg {
+	scheme := table.CsAuditScheme()
+	dataset := &mgmt.CsNatAggregateMsg{
+		Scheme:     scheme.String(),
+		PublicKey:  scheme.PublicKey(),
+		Timestamp:  uint64(time.Now().UnixMilli()),
+		Aggregates: []*mgmt.CsNatAggregate{},
+	}
+
+	aggs, _ := table.GetCsNatPrefixAggregates(prefix, depth)
+	size := 0
+	for _, a := range aggs {
+		agg := &mgmt.CsNatAggregate{
+			Name:      a.Name,
+			LeafCount: a.LeafCount,
+			Cached:    a.Cached,
+			Agg:       a.Agg[:],
+			TagAgg:    a.TagAgg,
+		}
+		if maxSize > 0 {
+			size += int(agg.Encode().Length())
+			if size > maxSize {
+				dataset.Truncated = true
+				break
+			}
+		}
+		dataset.Aggregates = append(dataset.Aggregates, agg)
+	}
+	return dataset
+}
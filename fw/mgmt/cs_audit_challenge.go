@@ -0,0 +1,210 @@
+package mgmt
+
+import (
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/table"
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/ndn"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	"github.com/named-data/ndnd/std/security/audit"
+)
+
+// 中文说明：远程缓存审计挑战。
+//
+// 接口约定（需配置 tables.content_store.audit.node_prefix，例如 /minindn/n1）：
+// - /<node>/cs-audit/aggregates/... 见 cs_audit_aggregates.go
+// - /<node>/cs-audit/challenge[/<prefix...>]/<nonce>  （必须是通过 auditors 信任模式验证的 signed Interest，见 authorizeRemote）
+//   -> 返回 CsAuditProof（TLV），其中 Aggregate 由实时缓存 wire 重算得到，
+//      ProofSignature 为本节点审计密钥对 audit.ProofDigest(证明编码) 的签名。
+//
+// 重放/新鲜度保护：
+// - nonce 在 csAuditChallengeWindow 内只能使用一次；
+// - signed Interest 必须携带 SignatureTime 或 SignatureNonce；
+// - 若携带 SignatureTime，其与本地时间之差不得超过 csAuditChallengeWindow；
+// - 证明中包含 nonce 与时间戳，且响应 Data 的 FreshnessPeriod 为 0，不会被缓存复用。
+
+// csAuditChallengeWindow 是挑战 nonce 的重放窗口与签名时间的允许偏差。
+const csAuditChallengeWindow = 60 * time.Second
+
+// csAuditChallengeMinNonce 是挑战 nonce 的最小长度（字节）。
+const csAuditChallengeMinNonce = 8
+
+// csAuditChallengeTimeout 是等待转发线程返回证明的超时。
+const csAuditChallengeTimeout = 800 * time.Millisecond
+
+// remotePrefix 返回远程审计接口的名字前缀 /<node>/cs-audit；未配置 node_prefix 则返回 nil。
+func (m *CsAuditModule) remotePrefix() enc.Name {
+	node := table.CfgCsAuditNodePrefix()
+	if node == nil {
+		return nil
+	}
+	return node.Append(enc.NewGenericComponent("cs-audit"))
+}
+
+// challengePrefix 返回远程挑战的名字前缀 /<node>/cs-audit/challenge；未启用则返回 nil。
+func (m *CsAuditModule) challengePrefix() enc.Name {
+	base := m.remotePrefix()
+	if base == nil {
+		return nil
+	}
+	return base.Append(enc.NewGenericComponent("challenge"))
+}
+
+// handleRemoteInterest 处理 /<node>/cs-audit 下的远程请求（挑战与聚合数据集）。
+func (m *CsAuditModule) handleRemoteInterest(interest *Interest) {
+	base := m.remotePrefix()
+	if len(interest.Name()) <= len(base) {
+		m.manager.sendCtrlResp(interest, 400, "Bad request", nil)
+		return
+	}
+
+	switch verb := interest.Name()[len(base)].String(); verb {
+	case "challenge":
+		m.challenge(interest)
+	case "aggregates":
+		m.aggregates(interest, base)
+	default:
+		core.Log.Warn(m, "Received remote CS audit Interest for non-existent verb", "verb", verb)
+		m.manager.sendCtrlResp(interest, 501, "Unknown verb", nil)
+	}
+}
+
+func (m *CsAuditModule) challenge(interest *Interest) {
+	prefix := m.challengePrefix()
+	name := interest.Name()
+
+	// 中文说明：signed Interest 的名字末尾是 ParametersSha256Digest 组件，需要先去掉。
+	if len(name) > 0 && name.At(-1).Typ == enc.TypeParametersSha256DigestComponent {
+		name = name.Prefix(-1)
+	}
+	if len(name) <= len(prefix) {
+		m.manager.sendCtrlResp(interest, 400, "Missing challenge nonce", nil)
+		return
+	}
+
+	nonce := name.At(-1).Val
+	target := name[len(prefix) : len(name)-1]
+	if len(nonce) < csAuditChallengeMinNonce {
+		m.manager.sendCtrlResp(interest, 400, "Challenge nonce too short", nil)
+		return
+	}
+
+	// 挑战需要等待所有转发线程，放到独立 goroutine 中以免阻塞管理线程
+	m.authorizeRemote(interest, nonce, func() {
+		go m.respondChallenge(interest, target, nonce)
+	})
+}
+
+// authorizeRemote 检查远程请求（挑战与聚合数据集）的签名与新鲜度，通过后调用 then。
+//
+// 中文说明：请求必须是 signed Interest，签名信息中的时间戳或 nonce 用于新鲜度检查；
+// 签名按 auditors 信任模式验证；名字中的 nonce 与 SignatureNonce 在窗口内只能使用一次。
+// 验证可能需要获取证书，then 可能在其他 goroutine 中被调用。
+func (m *CsAuditModule) authorizeRemote(interest *Interest, nonce []byte, then func()) {
+	if m.auditors == nil {
+		m.manager.sendCtrlResp(interest, 403, "Remote requests are not accepted", nil)
+		return
+	}
+	sig := interest.Signature()
+	if sig.SigType() == ndn.SignatureNone {
+		m.manager.sendCtrlResp(interest, 401, "Request must be signed", nil)
+		return
+	}
+	if sig.SigTime() == nil && len(sig.SigNonce()) == 0 {
+		m.manager.sendCtrlResp(interest, 401, "Request must carry SignatureTime or SignatureNonce", nil)
+		return
+	}
+	if sigTime := sig.SigTime(); sigTime != nil {
+		if d := time.Since(*sigTime); d > csAuditChallengeWindow || d < -csAuditChallengeWindow {
+			m.manager.sendCtrlResp(interest, 403, "Stale request", nil)
+			return
+		}
+	}
+
+	m.auditors.validateInterest(interest, func(valid bool, err error) {
+		if !valid || err != nil {
+			core.Log.Warn(m, "Rejected remote CS audit request", "name", interest.Name(), "valid", valid, "err", err)
+			m.manager.sendCtrlResp(interest, 403, "Request signature is not valid", nil)
+			return
+		}
+
+		// 重放保护：窗口内重复的 nonce 直接拒绝
+		if !m.useChallengeNonces(time.Now(), nonce, sig.SigNonce()) {
+			core.Log.Warn(m, "Replayed remote CS audit request", "name", interest.Name())
+			m.manager.sendCtrlResp(interest, 403, "Replayed request nonce", nil)
+			return
+		}
+
+		then()
+	})
+}
+
+// nodeSigner 返回签名远程审计响应的密钥：本节点密钥，未配置 keychain 时退回摘要签名。
+func (m *CsAuditModule) nodeSigner() ndn.Signer {
+	if signer := table.CsAuditSigner(); signer != nil {
+		return signer
+	}
+	return m.manager.signer
+}
+
+// respondChallenge 在所有转发线程上完成挑战，并返回以本节点密钥签名的证明。
+func (m *CsAuditModule) respondChallenge(interest *Interest, target enc.Name, nonce []byte) {
+	res, ok := table.RequestCsAuditChallenge(target, nonce, csAuditChallengeTimeout)
+	if !ok {
+		m.manager.sendCtrlResp(interest, 503, "Challenge not completed", nil)
+		return
+	}
+
+	scheme := table.CsAuditScheme()
+	proof := &mgmt.CsAuditProof{
+		Prefix:       target,
+		Nonce:        nonce,
+		Timestamp:    uint64(res.Time.UnixMilli()),
+		Scheme:       scheme.String(),
+		LeafCount:    res.LeafCount,
+		InvalidCount: res.InvalidCount,
+		Aggregate:    res.Aggregate,
+	}
+	proof.ProofSignature = scheme.Sign(audit.ProofDigest(proof.Encode().Join()))
+
+	if table.CfgCsAuditLogEnabled() {
+		core.Log.Info(m, "【审计】响应远程挑战",
+			"prefix", target,
+			"leafCount", res.LeafCount,
+			"invalidCount", res.InvalidCount,
+		)
+	}
+
+	// 中文说明：未配置本节点密钥时只能退回摘要签名，审计方无法确认应答来自该节点。
+	m.manager.sendSignedData(interest, interest.Name(), proof.Encode(), m.nodeSigner())
+}
+
+// useChallengeNonces 记录挑战使用的 nonce；若其中任何一个已在重放窗口内出现过，返回 false。
+// 空 nonce 被忽略。
+func (m *CsAuditModule) useChallengeNonces(now time.Time, nonces ...[]byte) bool {
+	m.challengeMutex.Lock()
+	defer m.challengeMutex.Unlock()
+
+	if m.challengeNonces == nil {
+		m.challengeNonces = make(map[string]time.Time)
+	}
+	for nonce, t := range m.challengeNonces {
+		if now.Sub(t) > csAuditChallengeWindow {
+			delete(m.challengeNonces, nonce)
+		}
+	}
+
+	for _, nonce := range nonces {
+		if _, ok := m.challengeNonces[string(nonce)]; ok {
+			return false
+		}
+	}
+	for _, nonce := range nonces {
+		if len(nonce) > 0 {
+			m.challengeNonces[string(nonce)] = now
+		}
+	}
+	return true
+}
@@ -0,0 +1,50 @@
+package mgmt
+
+import (
+	"math/rand"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/defn"
+	"github.com/named-data/ndnd/fw/table"
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/ndn"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	"github.com/named-data/ndnd/std/types/optional"
+)
+
+// 中文说明：损坏条目的修复 Interest。
+//
+// - 转发线程发现损坏条目后通过 table.CsAuditRepairs 提交修复请求（见 fw/table/cs_audit_repair.go）；
+//   转发线程无法直接发包，因此由管理线程经内部 face 发出 Interest。
+// - Interest 的 LpPacket 携带 CachePolicy=NoCache，转发线程不会用本地 CS（正在修复的条目）响应。
+// - 取回的 Data 在转发线程写入 CS 时校验标签；随后送回内部 face 的 Data 会被管理线程直接丢弃。
+
+// runRepairs 持续发送修复 Interest（在单独的 goroutine 中运行，内部 transport 的 Send 可并发调用）。
+func (m *CsAuditModule) runRepairs() {
+	for req := range table.CsAuditRepairs {
+		m.sendRepairInterest(req.Name)
+	}
+}
+
+// sendRepairInterest 为 name 发出一个绕过本地 CS 的修复 Interest。
+func (m *CsAuditModule) sendRepairInterest(name enc.Name) {
+	config := ndn.InterestConfig{
+		Nonce:    optional.Some(rand.Uint32()),
+		Lifetime: optional.Some(table.CfgCsAuditRepairTimeout()),
+	}
+	if source := table.CfgCsAuditRepairSource(); source != nil {
+		config.ForwardingHint = []enc.Name{source}
+	}
+
+	interest, err := spec.Spec{}.MakeInterest(name, &config, nil, nil)
+	if err != nil {
+		core.Log.Warn(m, "Unable to encode repair Interest", "name", name, "err", err)
+		return
+	}
+
+	m.manager.transport.Send(&spec.LpPacket{
+		Fragment:    interest.Wire,
+		CachePolicy: &spec.CachePolicy{CachePolicyType: defn.CachePolicyNoCache},
+	})
+	core.Log.Debug(m, "Sent CS audit repair Interest", "name", name)
+}
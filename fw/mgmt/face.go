@@ -10,6 +10,7 @@ package mgmt
 import (
 	"math"
 	"net"
+	"net/url"
 	"sort"
 	"time"
 
@@ -62,6 +63,10 @@ func (f *FaceModule) handleIncomingInterest(interest *Interest) {
 		f.list(interest)
 	case "query":
 		f.query(interest)
+	case "events":
+		// Notifications are published when face events occur,
+		// so the Interest stays pending until then.
+		return
 	default:
 		core.Log.Warn(f, "Received Interest for non-existent verb", "verb", verb)
 		f.manager.sendCtrlResp(interest, 501, "Unknown verb", nil)
@@ -69,7 +74,7 @@ func (f *FaceModule) handleIncomingInterest(interest *Interest) {
 	}
 }
 
-// (AI GENERATED DESCRIPTION): Creates a new unicast UDP or TCP face from the supplied ControlParameters, performing validation, configuring the transport and NDNLP link service, and replying with the face properties or an error status.
+// (AI GENERATED DESCRIPTION): Creates a new unicast UDP, TCP, Ethernet or HTTP/3 WebTransport face from the supplied ControlParameters, performing validation, configuring the transport and NDNLP link service, and replying with the face properties or an error status.
 func (f *FaceModule) create(interest *Interest) {
 	if len(interest.Name()) < len(LOCAL_PREFIX)+3 {
 		f.manager.sendCtrlResp(interest, 400, "ControlParameters is incorrect", nil)
@@ -134,17 +139,6 @@ func (f *FaceModule) create(interest *Interest) {
 			return
 		}
 
-		// Check congestion control
-		baseCongestionMarkingInterval := 100 * time.Millisecond
-		if bcmi, ok := params.BaseCongestionMarkInterval.Get(); ok {
-			baseCongestionMarkingInterval = time.Duration(bcmi) * time.Nanosecond
-		}
-
-		defaultCongestionThresholdBytes := uint64(math.Pow(2, 16))
-		if dct, ok := params.DefaultCongestionThreshold.Get(); ok {
-			defaultCongestionThresholdBytes = dct
-		}
-
 		// Create new UDP face
 		transport, err := face.MakeUnicastUDPTransport(URI, nil, persistency)
 		if err != nil {
@@ -158,33 +152,7 @@ func (f *FaceModule) create(interest *Interest) {
 		}
 
 		// NDNLP link service parameters
-		options := face.MakeNDNLPLinkServiceOptions()
-		if params.Flags.IsSet() && params.Mask.IsSet() {
-			// Mask already guaranteed to be present if Flags is above
-			flags := params.Flags.Unwrap()
-			mask := params.Mask.Unwrap()
-
-			if mask&face.FaceFlagLocalFields > 0 {
-				// LocalFieldsEnabled
-				if flags&face.FaceFlagLocalFields > 0 {
-					options.IsConsumerControlledForwardingEnabled = true
-					options.IsIncomingFaceIndicationEnabled = true
-					options.IsLocalCachePolicyEnabled = true
-				} else {
-					options.IsConsumerControlledForwardingEnabled = false
-					options.IsIncomingFaceIndicationEnabled = false
-					options.IsLocalCachePolicyEnabled = false
-				}
-			}
-
-			// Congestion control
-			if mask&face.FaceFlagCongestionMarking > 0 {
-				// CongestionMarkingEnabled
-				options.IsCongestionMarkingEnabled = flags&face.FaceFlagCongestionMarking > 0
-			}
-			options.BaseCongestionMarkingInterval = baseCongestionMarkingInterval
-			options.DefaultCongestionThresholdBytes = defaultCongestionThresholdBytes
-		}
+		options := makeLinkServiceOptions(params)
 
 		linkService = face.MakeNDNLPLinkService(transport, options)
 		linkService.Run(nil)
@@ -211,17 +179,6 @@ func (f *FaceModule) create(interest *Interest) {
 			return
 		}
 
-		// Check congestion control
-		baseCongestionMarkingInterval := 100 * time.Millisecond
-		if bcmi, ok := params.BaseCongestionMarkInterval.Get(); ok {
-			baseCongestionMarkingInterval = time.Duration(bcmi) * time.Nanosecond
-		}
-
-		defaultCongestionThresholdBytes := uint64(math.Pow(2, 16))
-		if dct, ok := params.DefaultCongestionThreshold.Get(); ok {
-			defaultCongestionThresholdBytes = dct
-		}
-
 		// Create new TCP face
 		transport, err := face.MakeUnicastTCPTransport(URI, nil, persistency)
 		if err != nil {
@@ -235,35 +192,84 @@ func (f *FaceModule) create(interest *Interest) {
 		}
 
 		// NDNLP link service parameters
-		options := face.MakeNDNLPLinkServiceOptions()
+		options := makeLinkServiceOptions(params)
 		options.IsFragmentationEnabled = false // reliable stream
-		if params.Flags.IsSet() && params.Mask.IsSet() {
-			// Mask already guaranteed to be present if Flags is above
-			flags := params.Flags.Unwrap()
-			mask := params.Mask.Unwrap()
 
-			if mask&face.FaceFlagLocalFields > 0 {
-				// LocalFieldsEnabled
-				if flags&face.FaceFlagLocalFields > 0 {
-					options.IsConsumerControlledForwardingEnabled = true
-					options.IsIncomingFaceIndicationEnabled = true
-					options.IsLocalCachePolicyEnabled = true
-				} else {
-					options.IsConsumerControlledForwardingEnabled = false
-					options.IsIncomingFaceIndicationEnabled = false
-					options.IsLocalCachePolicyEnabled = false
-				}
-			}
+		linkService = face.MakeNDNLPLinkService(transport, options)
+		linkService.Run(nil)
+	} else if URI.Scheme() == "ether" {
+		// Ethernet faces are created on the listener of the local interface
+		if !params.LocalUri.IsSet() {
+			f.manager.sendCtrlResp(interest, 406, "Local URI required for Ethernet face", nil)
+			return
+		}
+		localURI := defn.DecodeURIString(params.LocalUri.Unwrap())
+		if localURI == nil || !localURI.IsCanonical() || localURI.Scheme() != "dev" {
+			f.manager.sendCtrlResp(interest, 406, "Local URI must be a dev:// URI", nil)
+			return
+		}
+		listener := face.EthernetListenerByInterface(localURI.Path())
+		if listener == nil {
+			f.manager.sendCtrlResp(interest, 406, "No Ethernet listener on interface "+localURI.Path(), nil)
+			return
+		}
 
-			// Congestion control
-			if mask&face.FaceFlagCongestionMarking > 0 {
-				// CongestionMarkingEnabled
-				options.IsCongestionMarkingEnabled = flags&face.FaceFlagCongestionMarking > 0
-			}
-			options.BaseCongestionMarkingInterval = baseCongestionMarkingInterval
-			options.DefaultCongestionThresholdBytes = defaultCongestionThresholdBytes
+		// Check face persistency
+		persistency := mgmt.PersistencyPersistent
+		if pers, ok := params.FacePersistency.Get(); ok && (pers == uint64(mgmt.PersistencyPersistent) || pers == uint64(mgmt.PersistencyPermanent)) {
+			persistency = mgmt.Persistency(pers)
+		} else if params.FacePersistency.IsSet() {
+			f.manager.sendCtrlResp(interest, 406, "Unacceptable persistency", nil)
+			return
+		}
+
+		// Create new Ethernet face
+		remoteAddr, _ := net.ParseMAC(URI.Path())
+		transport, err := listener.MakeUnicastTransport(remoteAddr, persistency)
+		if err != nil {
+			core.Log.Warn(f, "Unable to create unicast Ethernet face", "uri", URI, "err", err)
+			f.manager.sendCtrlResp(interest, 406, "Transport error", nil)
+			return
+		}
+
+		if mtu, ok := params.Mtu.Get(); ok {
+			transport.SetMTU(min(int(mtu), transport.MTU()))
+		}
+
+		linkService = face.MakeNDNLPLinkService(transport, makeLinkServiceOptions(params))
+		linkService.Run(nil)
+	} else if URI.Scheme() == "quic" {
+		// Check face persistency
+		persistency := mgmt.PersistencyPersistent
+		if pers, ok := params.FacePersistency.Get(); ok && (pers == uint64(mgmt.PersistencyPersistent) || pers == uint64(mgmt.PersistencyPermanent)) {
+			persistency = mgmt.Persistency(pers)
+		} else if params.FacePersistency.IsSet() {
+			f.manager.sendCtrlResp(interest, 406, "Unacceptable persistency", nil)
+			return
+		}
+
+		// The server certificate is verified with the name given in the request
+		serverName := ""
+		if uri, err := url.Parse(params.Uri.Unwrap()); err == nil {
+			serverName = uri.Hostname()
+		}
+
+		// Create new HTTP/3 WebTransport face
+		transport, err := face.MakeHTTP3ClientTransport(URI, serverName, params.CertHash, persistency)
+		if err != nil {
+			core.Log.Warn(f, "Unable to create HTTP/3 face", "uri", URI, "err", err)
+			f.manager.sendCtrlResp(interest, 406, "Transport error", nil)
+			return
 		}
 
+		if mtu, ok := params.Mtu.Get(); ok {
+			transport.SetMTU(min(int(mtu), transport.MTU()))
+		}
+
+		// NDNLP link service parameters
+		options := makeLinkServiceOptions(params)
+		options.IsFragmentationEnabled = true // datagrams
+
 		linkService = face.MakeNDNLPLinkService(transport, options)
 		linkService.Run(nil)
 	} else {
@@ -284,6 +290,60 @@ func (f *FaceModule) create(interest *Interest) {
 	core.Log.Info(f, "Created face", "uri", URI)
 }
 
+// makeLinkServiceOptions returns the NDNLP link service options requested in ControlParameters.
+func makeLinkServiceOptions(params *mgmt.ControlArgs) face.NDNLPLinkServiceOptions {
+	// Check congestion control
+	baseCongestionMarkingInterval := 100 * time.Millisecond
+	if bcmi, ok := params.BaseCongestionMarkInterval.Get(); ok {
+		baseCongestionMarkingInterval = time.Duration(bcmi) * time.Nanosecond
+	}
+
+	defaultCongestionThresholdBytes := uint64(math.Pow(2, 16))
+	if dct, ok := params.DefaultCongestionThreshold.Get(); ok {
+		defaultCongestionThresholdBytes = dct
+	}
+
+	// NDNLP link service parameters
+	options := face.MakeNDNLPLinkServiceOptions()
+	if params.Flags.IsSet() && params.Mask.IsSet() {
+		// Mask already guaranteed to be present if Flags is above
+		flags := params.Flags.Unwrap()
+		mask := params.Mask.Unwrap()
+
+		if mask&face.FaceFlagLocalFields > 0 {
+			// LocalFieldsEnabled
+			if flags&face.FaceFlagLocalFields > 0 {
+				options.IsConsumerControlledForwardingEnabled = true
+				options.IsIncomingFaceIndicationEnabled = true
+				options.IsLocalCachePolicyEnabled = true
+			} else {
+				options.IsConsumerControlledForwardingEnabled = false
+				options.IsIncomingFaceIndicationEnabled = false
+				options.IsLocalCachePolicyEnabled = false
+			}
+		}
+
+		// Congestion control
+		if mask&face.FaceFlagCongestionMarking > 0 {
+			// CongestionMarkingEnabled
+			options.IsCongestionMarkingEnabled = flags&face.FaceFlagCongestionMarking > 0
+		}
+
+		// Link-layer reliability
+		if mask&face.FaceFlagLpReliabilityEnabled > 0 {
+			options.IsReliabilityEnabled = flags&face.FaceFlagLpReliabilityEnabled > 0
+		}
+		options.BaseCongestionMarkingInterval = baseCongestionMarkingInterval
+		options.DefaultCongestionThresholdBytes = defaultCongestionThresholdBytes
+	}
+
+	// Traffic shaping
+	options.EgressRate = params.EgressRate.GetOr(0)
+	options.EgressBurst = params.EgressBurst.GetOr(0)
+
+	return options
+}
+
 // (AI GENERATED DESCRIPTION): Updates a specified NDN face according to the ControlParameters carried in an incoming Interest, validating and applying changes such as persistency, MTU, congestion options, and flag settings, then responds with the updated face properties.
 func (f *FaceModule) update(interest *Interest) {
 	if len(interest.Name()) < len(LOCAL_PREFIX)+3 {
@@ -320,16 +380,23 @@ func (f *FaceModule) update(interest *Interest) {
 	}
 
 	if pers, ok := params.FacePersistency.Get(); ok {
-		if selectedFace.RemoteURI().Scheme() == "ether" && pers != uint64(mgmt.PersistencyPermanent) {
+		if selectedFace.RemoteURI().Scheme() == "ether" && selectedFace.LinkType() == defn.MultiAccess &&
+			pers != uint64(mgmt.PersistencyPermanent) {
 			responseParams.FacePersistency = params.FacePersistency
 			areParamsValid = false
-		} else if (selectedFace.RemoteURI().Scheme() == "udp4" || selectedFace.RemoteURI().Scheme() == "udp6") &&
+		} else if (selectedFace.RemoteURI().Scheme() == "udp4" || selectedFace.RemoteURI().Scheme() == "udp6" ||
+			selectedFace.RemoteURI().Scheme() == "ether") &&
 			pers != uint64(mgmt.PersistencyPersistent) && pers != uint64(mgmt.PersistencyPermanent) {
 			responseParams.FacePersistency = params.FacePersistency
 			areParamsValid = false
 		} else if selectedFace.LocalURI().Scheme() == "unix" && pers != uint64(mgmt.PersistencyPersistent) {
 			responseParams.FacePersistency = params.FacePersistency
 			areParamsValid = false
+		} else if selectedFace.RemoteURI().Scheme() == "quic" &&
+			(selectedFace.Persistency() == mgmt.PersistencyOnDemand) != (pers == uint64(mgmt.PersistencyOnDemand)) {
+			// Incoming HTTP/3 faces are on-demand, outgoing faces are persistent or permanent
+			responseParams.FacePersistency = params.FacePersistency
+			areParamsValid = false
 		}
 	}
 
@@ -371,6 +438,17 @@ func (f *FaceModule) update(interest *Interest) {
 			core.Log.Info(f, "Set DefaultCongestionThreshold", "faceid", faceID, "value", options.DefaultCongestionThresholdBytes)
 		}
 
+		// Traffic shaping
+		if rate, ok := params.EgressRate.Get(); ok && rate != options.EgressRate {
+			options.EgressRate = rate
+			core.Log.Info(f, "Set EgressRate", "faceid", faceID, "value", options.EgressRate)
+		}
+
+		if burst, ok := params.EgressBurst.Get(); ok && burst != options.EgressBurst {
+			options.EgressBurst = burst
+			core.Log.Info(f, "Set EgressBurst", "faceid", faceID, "value", options.EgressBurst)
+		}
+
 		// MTU
 		if mtu, ok := params.Mtu.Get(); ok {
 			oldMTU := selectedFace.MTU()
@@ -406,6 +484,15 @@ func (f *FaceModule) update(interest *Interest) {
 					core.Log.Info(f, "Disable congestion marking", "faceid", faceID)
 				}
 			}
+
+			if mask&face.FaceFlagLpReliabilityEnabled > 0 {
+				options.IsReliabilityEnabled = flags&face.FaceFlagLpReliabilityEnabled > 0
+				if flags&face.FaceFlagLpReliabilityEnabled > 0 {
+					core.Log.Info(f, "Enable link-layer reliability", "faceid", faceID)
+				} else {
+					core.Log.Info(f, "Disable link-layer reliability", "faceid", faceID)
+				}
+			}
 		}
 
 		lpLinkService.SetOptions(options)
@@ -560,10 +647,10 @@ func (f *FaceModule) createDataset(selectedFace face.LinkService) *mgmt.FaceStat
 		Mtu:             optional.Some(uint64(selectedFace.MTU())),
 		NInInterests:    selectedFace.NInInterests(),
 		NInData:         selectedFace.NInData(),
-		NInNacks:        0,
+		NInNacks:        selectedFace.NInNacks(),
 		NOutInterests:   selectedFace.NOutInterests(),
 		NOutData:        selectedFace.NOutData(),
-		NOutNacks:       0,
+		NOutNacks:       selectedFace.NOutNacks(),
 		NInBytes:        selectedFace.NInBytes(),
 		NOutBytes:       selectedFace.NInBytes(),
 	}
@@ -577,6 +664,15 @@ func (f *FaceModule) createDataset(selectedFace face.LinkService) *mgmt.FaceStat
 		faceDataset.BaseCongestionMarkInterval = optional.Some(uint64(options.BaseCongestionMarkingInterval.Nanoseconds()))
 		faceDataset.DefaultCongestionThreshold = optional.Some(options.DefaultCongestionThresholdBytes)
 		faceDataset.Flags = options.Flags()
+		faceDataset.NInLpInvalid = optional.Some(linkService.NInLpInvalid())
+		faceDataset.NReassemblyTimeouts = optional.Some(linkService.NReassemblyTimeouts())
+		faceDataset.NReassemblyEvictions = optional.Some(linkService.NReassemblyEvictions())
+		faceDataset.NOutShaped = optional.Some(linkService.NOutShaped())
+		faceDataset.NOutDropped = optional.Some(linkService.NOutDropped())
+		if options.EgressRate > 0 {
+			faceDataset.EgressRate = optional.Some(options.EgressRate)
+			faceDataset.EgressBurst = optional.Some(options.EgressBurst)
+		}
 		if options.IsConsumerControlledForwardingEnabled {
 			// This one will only be enabled if the other two local fields are enabled (and vice versa)
 			faceDataset.Flags |= face.FaceFlagLocalFields
@@ -603,5 +699,9 @@ func (f *FaceModule) fillFaceProperties(params *mgmt.ControlArgs, selectedFace f
 		params.BaseCongestionMarkInterval = optional.Some(uint64(options.BaseCongestionMarkingInterval.Nanoseconds()))
 		params.DefaultCongestionThreshold = optional.Some(options.DefaultCongestionThresholdBytes)
 		params.Flags = optional.Some(uint64(options.Flags()))
+		if options.EgressRate > 0 {
+			params.EgressRate = optional.Some(options.EgressRate)
+			params.EgressBurst = optional.Some(options.EgressBurst)
+		}
 	}
 }
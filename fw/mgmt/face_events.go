@@ -0,0 +1,131 @@
+package mgmt
+
+import (
+	"sync"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	"github.com/named-data/ndnd/fw/face"
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/ndn"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	"github.com/named-data/ndnd/std/types/optional"
+)
+
+// Number of face event notifications kept for consumers that fall behind.
+const faceEventHistorySize = 64
+
+// FaceEventStream publishes the face event notification stream.
+// Notifications are Data packets named /localhost/nfd/faces/events/<seq>.
+// Consumers fetch the next notification by sequence number; the Interest
+// stays pending in the PIT until the notification is published.
+type FaceEventStream struct {
+	m *Thread
+	// Face events are received from any goroutine
+	mutex sync.Mutex
+	// sendMutex is held while a notification is recorded and sent,
+	// so that notifications are sent in sequence order
+	sendMutex sync.Mutex
+	// Sequence number of the next notification
+	nextSeq uint64
+	// Whether the management thread is ready to send notifications
+	running bool
+}
+
+// (AI GENERATED DESCRIPTION): Returns the identifier string for the face event stream used in logging.
+func (s *FaceEventStream) String() string {
+	return "mgmt-face-events"
+}
+
+// NewFaceEventStream creates a face event stream for the management thread.
+func NewFaceEventStream(m *Thread) *FaceEventStream {
+	return &FaceEventStream{m: m}
+}
+
+// prefix returns the name prefix of the notification stream.
+func (s *FaceEventStream) prefix() enc.Name {
+	return LOCAL_PREFIX.
+		Append(enc.NewGenericComponent("faces")).
+		Append(enc.NewGenericComponent("events"))
+}
+
+// start begins sending notifications to the internal transport.
+// Notifications published before are only available from the store.
+func (s *FaceEventStream) start() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.running = true
+}
+
+// OnFaceEvent publishes a notification for a face event.
+func (s *FaceEventStream) OnFaceEvent(kind uint64, ls face.LinkService) {
+	notif := &mgmt.FaceEventNotification{
+		Val: &mgmt.FaceEventNotificationValue{
+			FaceEventKind:   kind,
+			FaceId:          ls.FaceID(),
+			Uri:             ls.RemoteURI().String(),
+			LocalUri:        ls.LocalURI().String(),
+			FaceScope:       uint64(ls.Scope()),
+			FacePersistency: uint64(ls.Persistency()),
+			LinkType:        uint64(ls.LinkType()),
+		},
+	}
+	if linkService, ok := ls.(*face.NDNLPLinkService); ok {
+		options := linkService.Options()
+		notif.Val.Flags = options.Flags()
+	}
+
+	s.sendMutex.Lock()
+	defer s.sendMutex.Unlock()
+
+	wire, seq, running := s.record(notif)
+	if wire == nil {
+		return
+	}
+	core.Log.Debug(s, "Published face event", "seq", seq, "kind", kind, "faceid", ls.FaceID())
+
+	// Satisfy pending Interests for this notification. The send may block,
+	// so it is done without holding the state lock, but still under the send
+	// lock so that a later notification cannot overtake this one.
+	if running && s.m.transport.IsRunning() {
+		s.m.transport.Send(&spec.LpPacket{Fragment: wire})
+	}
+}
+
+// record assigns the next sequence number to a notification and keeps it in the store.
+// Returns the encoded Data, and whether notifications can be sent.
+func (s *FaceEventStream) record(notif *mgmt.FaceEventNotification) (enc.Wire, uint64, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	seq := s.nextSeq
+	s.nextSeq++
+
+	name := s.prefix().Append(enc.NewSequenceNumComponent(seq))
+	data, err := spec.Spec{}.MakeData(name,
+		&ndn.DataConfig{
+			ContentType: optional.Some(ndn.ContentTypeBlob),
+			Freshness:   optional.Some(time.Second),
+		},
+		notif.Encode(),
+		s.m.signer,
+	)
+	if err != nil {
+		core.Log.Warn(s, "Unable to encode face event notification", "err", err)
+		return nil, seq, false
+	}
+
+	// Keep recent notifications for consumers that fall behind
+	if err := s.m.store.Put(name, data.Wire.Join()); err != nil {
+		core.Log.Warn(s, "Unable to store face event notification", "err", err)
+	}
+	if seq >= faceEventHistorySize {
+		old := enc.NewSequenceNumComponent(seq - faceEventHistorySize)
+		if err := s.m.store.RemoveFlatRange(s.prefix(), old, old); err != nil {
+			core.Log.Warn(s, "Unable to clean up old face event notification", "err", err)
+		}
+	}
+
+	return data.Wire, seq, s.running
+}
@@ -0,0 +1,168 @@
+package mgmt
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/named-data/ndnd/fw/defn"
+	"github.com/named-data/ndnd/fw/dispatch"
+	"github.com/named-data/ndnd/fw/face"
+	"github.com/named-data/ndnd/fw/fw"
+	enc "github.com/named-data/ndnd/std/encoding"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	"github.com/named-data/ndnd/std/object/storage"
+	"github.com/named-data/ndnd/std/security/signer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dataFwThread is a forwarding thread that receives the Data sent by management.
+type dataFwThread struct {
+	data chan *defn.Pkt
+}
+
+func (t *dataFwThread) String() string                  { return "data-fw-thread" }
+func (t *dataFwThread) QueueData(pkt *defn.Pkt)         { t.data <- pkt }
+func (t *dataFwThread) QueueInterest(*defn.Pkt)         {}
+func (t *dataFwThread) QueueNack(*defn.Pkt)             {}
+func (t *dataFwThread) QueueLostInterest(*defn.Pkt)     {}
+func (t *dataFwThread) QueueDroppedInterest(*defn.Pkt)  {}
+func (t *dataFwThread) Counters() defn.FWThreadCounters { return defn.FWThreadCounters{} }
+
+// newFaceEventStream creates a face event stream of a management thread with
+// an internal transport connected to a single recording forwarding thread.
+func newFaceEventStream(t *testing.T) (*FaceEventStream, *dataFwThread) {
+	fwThread := &dataFwThread{data: make(chan *defn.Pkt, 2*faceEventHistorySize)}
+	fw.Threads = make([]*fw.Thread, 1)
+	dispatch.InitializeFWThreads([]dispatch.FWThread{fwThread})
+
+	m := &Thread{
+		store:  storage.NewMemoryStore(),
+		signer: signer.NewSha256Signer(),
+	}
+	m.face, m.transport = face.RegisterInternalTransport()
+	t.Cleanup(func() {
+		m.face.Close()
+		dispatch.InitializeFWThreads(nil)
+		fw.Threads = nil
+	})
+
+	return NewFaceEventStream(m), fwThread
+}
+
+// eventName returns the name of a face event notification.
+func (s *FaceEventStream) eventName(seq uint64) enc.Name {
+	return s.prefix().Append(enc.NewSequenceNumComponent(seq))
+}
+
+// storedEvent returns the notification kept in the store, or nil.
+func (s *FaceEventStream) storedEvent(t *testing.T, seq uint64) *mgmt.FaceEventNotificationValue {
+	wire, err := s.m.store.Get(s.eventName(seq), false)
+	require.NoError(t, err)
+	if wire == nil {
+		return nil
+	}
+	data, _, err := spec.Spec{}.ReadData(enc.NewBufferView(wire))
+	require.NoError(t, err)
+	notif, err := mgmt.ParseFaceEventNotification(enc.NewWireView(data.Content()), false)
+	require.NoError(t, err)
+	return notif.Val
+}
+
+func receiveData(t *testing.T, fwThread *dataFwThread) *defn.Pkt {
+	select {
+	case pkt := <-fwThread.data:
+		return pkt
+	case <-time.After(time.Second):
+		require.FailNow(t, "notification was not sent")
+		return nil
+	}
+}
+
+// Tests that notifications are sent to the forwarder to satisfy pending Interests.
+func TestFaceEventPublish(t *testing.T) {
+	s, fwThread := newFaceEventStream(t)
+
+	// Notifications before the start are only stored
+	s.OnFaceEvent(mgmt.FaceEventCreated, s.m.face)
+	assert.NotNil(t, s.storedEvent(t, 0))
+	assert.Empty(t, fwThread.data)
+
+	s.start()
+	s.OnFaceEvent(mgmt.FaceEventUp, s.m.face)
+	pkt := receiveData(t, fwThread)
+	assert.Equal(t, s.eventName(1), pkt.Name)
+
+	data, _, err := spec.Spec{}.ReadData(enc.NewWireView(pkt.Raw))
+	require.NoError(t, err)
+	notif, err := mgmt.ParseFaceEventNotification(enc.NewWireView(data.Content()), false)
+	require.NoError(t, err)
+	assert.Equal(t, mgmt.FaceEventUp, notif.Val.FaceEventKind)
+	assert.Equal(t, s.m.face.FaceID(), notif.Val.FaceId)
+}
+
+// Tests that consumers that missed notifications catch up from the store,
+// which keeps the last faceEventHistorySize notifications.
+func TestFaceEventHistory(t *testing.T) {
+	s, fwThread := newFaceEventStream(t)
+	s.start()
+
+	kinds := []uint64{mgmt.FaceEventCreated, mgmt.FaceEventDown, mgmt.FaceEventUp}
+	for _, kind := range kinds {
+		s.OnFaceEvent(kind, s.m.face)
+	}
+	for seq, kind := range kinds {
+		notif := s.storedEvent(t, uint64(seq))
+		require.NotNil(t, notif)
+		assert.Equal(t, kind, notif.FaceEventKind)
+		receiveData(t, fwThread)
+	}
+
+	// A consumer that missed the notifications fetches them from the store
+	pitToken := []byte{0, 0, 0, 0, 0, 1}
+	interest := &Interest{Interest: spec.Interest{NameV: s.eventName(0)}, pitToken: pitToken}
+	require.True(t, s.m.sendFromStore(interest))
+	pkt := receiveData(t, fwThread)
+	assert.Equal(t, s.eventName(0), pkt.Name)
+	assert.Equal(t, pitToken, pkt.PitToken)
+
+	total := uint64(faceEventHistorySize + 2)
+	for range total - uint64(len(kinds)) {
+		s.OnFaceEvent(mgmt.FaceEventUp, s.m.face)
+		receiveData(t, fwThread)
+	}
+	assert.Nil(t, s.storedEvent(t, 0))
+	assert.Nil(t, s.storedEvent(t, 1))
+	assert.NotNil(t, s.storedEvent(t, 2))
+	assert.NotNil(t, s.storedEvent(t, total-1))
+	assert.Nil(t, s.storedEvent(t, total))
+
+	// Evicted notifications are no longer served
+	interest.NameV = s.eventName(0)
+	assert.False(t, s.m.sendFromStore(interest))
+}
+
+// Tests that notifications published concurrently are sent in sequence order.
+func TestFaceEventOrder(t *testing.T) {
+	s, fwThread := newFaceEventStream(t)
+	s.start()
+
+	const nPublishers, nEvents = 16, 8
+	var wg sync.WaitGroup
+	for range nPublishers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range nEvents {
+				s.OnFaceEvent(mgmt.FaceEventUp, s.m.face)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for seq := range uint64(nPublishers * nEvents) {
+		assert.Equal(t, s.eventName(seq), receiveData(t, fwThread).Name)
+	}
+}
@@ -0,0 +1,146 @@
+package mgmt
+
+import (
+	"math/rand"
+	"slices"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/ndn"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	"github.com/named-data/ndnd/std/types/optional"
+)
+
+// pendingFetch is an Interest sent by the management thread that is waiting for Data.
+type pendingFetch struct {
+	name        enc.Name
+	canBePrefix bool
+	callback    ndn.ExpressCallbackFunc
+	cancel      func() error
+}
+
+// fetch expresses an Interest on the internal face and calls back with the Data or a timeout.
+// If store is not nil, it is checked for matching Data first. This is used to fetch
+// certificates when validating signed objects, so the callback can run on the timer goroutine.
+func (m *Thread) fetch(name enc.Name, store ndn.Store, config *ndn.InterestConfig, callback ndn.ExpressCallbackFunc) {
+	if store != nil {
+		if wire, err := store.Get(name, config.CanBePrefix); err == nil && wire != nil {
+			data, sigCov, err := spec.Spec{}.ReadData(enc.NewBufferView(wire))
+			if err == nil {
+				callback(ndn.ExpressCallbackArgs{
+					Result:     ndn.InterestResultData,
+					Data:       data,
+					RawData:    enc.Wire{wire},
+					SigCovered: sigCov,
+					IsLocal:    true,
+				})
+				return
+			}
+		}
+	}
+
+	config.Nonce = optional.Some(rand.Uint32())
+	lifetime := config.Lifetime.GetOr(time.Second)
+	config.Lifetime = optional.Some(lifetime)
+
+	interest, err := spec.Spec{}.MakeInterest(name, config, nil, nil)
+	if err != nil {
+		callback(ndn.ExpressCallbackArgs{Result: ndn.InterestResultError, Error: err})
+		return
+	}
+
+	fetch := &pendingFetch{
+		name:        name,
+		canBePrefix: config.CanBePrefix,
+		callback:    callback,
+	}
+
+	m.fetchMutex.Lock()
+	m.fetches = append(m.fetches, fetch)
+	fetch.cancel = m.timer.Schedule(lifetime, func() {
+		if m.removeFetch(fetch) {
+			callback(ndn.ExpressCallbackArgs{Result: ndn.InterestResultTimeout})
+		}
+	})
+	m.fetchMutex.Unlock()
+
+	m.transport.Send(&spec.LpPacket{Fragment: interest.Wire})
+	core.Log.Trace(m, "Sent management fetch Interest", "name", name)
+}
+
+// removeFetch removes a pending fetch, returning false if it was already satisfied.
+func (m *Thread) removeFetch(fetch *pendingFetch) bool {
+	m.fetchMutex.Lock()
+	defer m.fetchMutex.Unlock()
+
+	idx := slices.Index(m.fetches, fetch)
+	if idx < 0 {
+		return false
+	}
+	m.fetches = slices.Delete(m.fetches, idx, idx+1)
+	return true
+}
+
+// onData satisfies the pending fetches matched by a Data packet received on the internal face.
+func (m *Thread) onData(wire enc.Wire) {
+	data, sigCov, err := spec.Spec{}.ReadData(enc.NewWireView(wire))
+	if err != nil {
+		core.Log.Warn(m, "Unable to decode internal Data - DROP", "err", err)
+		return
+	}
+
+	m.fetchMutex.Lock()
+	var matched []*pendingFetch
+	m.fetches = slices.DeleteFunc(m.fetches, func(fetch *pendingFetch) bool {
+		match := fetch.name.Equal(data.Name()) ||
+			(fetch.canBePrefix && fetch.name.IsPrefix(data.Name()))
+		if match {
+			matched = append(matched, fetch)
+		}
+		return match
+	})
+	m.fetchMutex.Unlock()
+
+	if len(matched) == 0 {
+		core.Log.Debug(m, "Dropping unsolicited Data", "name", data.Name())
+		return
+	}
+
+	for _, fetch := range matched {
+		fetch.cancel()
+		fetch.callback(ndn.ExpressCallbackArgs{
+			Result:     ndn.InterestResultData,
+			Data:       data,
+			RawData:    wire,
+			SigCovered: sigCov,
+		})
+	}
+}
+
+// onNack fails the pending fetches for an Interest name that was Nacked on the internal face.
+func (m *Thread) onNack(name enc.Name, reason uint64) {
+	m.fetchMutex.Lock()
+	var matched []*pendingFetch
+	m.fetches = slices.DeleteFunc(m.fetches, func(fetch *pendingFetch) bool {
+		match := fetch.name.Equal(name)
+		if match {
+			matched = append(matched, fetch)
+		}
+		return match
+	})
+	m.fetchMutex.Unlock()
+
+	if len(matched) == 0 {
+		core.Log.Debug(m, "Dropping unsolicited Nack", "name", name)
+		return
+	}
+
+	for _, fetch := range matched {
+		fetch.cancel()
+		fetch.callback(ndn.ExpressCallbackArgs{
+			Result:     ndn.InterestResultNack,
+			NackReason: reason,
+		})
+	}
+}
@@ -81,8 +81,10 @@ func (f *ForwarderStatusModule) general(interest *Interest) {
 		status.NCsEntries += uint64(counters.NCsEntries)
 		status.NInInterests += counters.NInInterests
 		status.NInData += counters.NInData
+		status.NInNacks += counters.NInNacks
 		status.NOutInterests += counters.NOutInterests
 		status.NOutData += counters.NOutData
+		status.NOutNacks += counters.NOutNacks
 		status.NSatisfiedInterests += counters.NSatisfiedInterests
 		status.NUnsatisfiedInterests += counters.NUnsatisfiedInterests
 	}
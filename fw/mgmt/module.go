@@ -8,6 +8,7 @@
 package mgmt
 
 import (
+	enc "github.com/named-data/ndnd/std/encoding"
 	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
 	"github.com/named-data/ndnd/std/types/optional"
 )
@@ -22,6 +23,7 @@ type Module interface {
 
 type Interest struct {
 	spec.Interest
-	pitToken []byte
-	inFace   optional.Optional[uint64]
+	sigCovered enc.Wire
+	pitToken   []byte
+	inFace     optional.Optional[uint64]
 }
@@ -28,9 +28,11 @@ func (r *NlsrReadvertiser) String() string {
 	return "mgmt-nlsr-readvertiser"
 }
 
-// (AI GENERATED DESCRIPTION): Announces a client‑originated route by sending an RIB register interest containing the route’s name, face ID, and cost to the NLSR.
+// (AI GENERATED DESCRIPTION): Announces a client‑originated or prefix-announced route by sending an RIB register interest containing the route’s name, face ID, and cost to the NLSR.
+// Routes created from a signed PrefixAnnouncement carry the announcement as the
+// ApplicationParameters of the command, so the routing daemon can propagate it.
 func (r *NlsrReadvertiser) Announce(name enc.Name, route *table.Route) {
-	if route.Origin != uint64(spec_mgmt.RouteOriginClient) {
+	if !nlsrReadvertiseOrigin(route.Origin) {
 		return
 	}
 	core.Log.Info(r, "NlsrAdvertise", "name", name)
@@ -53,12 +55,12 @@ func (r *NlsrReadvertiser) Announce(name enc.Name, route *table.Route) {
 		enc.NewGenericBytesComponent(params.Encode().Join()),
 	}
 
-	r.m.sendInterest(cmd, enc.Wire{})
+	r.m.sendInterest(cmd, route.Announcement)
 }
 
-// (AI GENERATED DESCRIPTION): Sends an unregister interest to the NLSR to withdraw a client‑originated route from the routing table.
+// (AI GENERATED DESCRIPTION): Sends an unregister interest to the NLSR to withdraw a client‑originated or prefix-announced route from the routing table.
 func (r *NlsrReadvertiser) Withdraw(name enc.Name, route *table.Route) {
-	if route.Origin != uint64(spec_mgmt.RouteOriginClient) {
+	if !nlsrReadvertiseOrigin(route.Origin) {
 		return
 	}
 	core.Log.Info(r, "NlsrWithdraw", "name", name)
@@ -82,3 +84,9 @@ func (r *NlsrReadvertiser) Withdraw(name enc.Name, route *table.Route) {
 
 	r.m.sendInterest(cmd, enc.Wire{})
 }
+
+// Routes registered by clients and signed prefix announcements are readvertised.
+func nlsrReadvertiseOrigin(origin uint64) bool {
+	return origin == uint64(spec_mgmt.RouteOriginClient) ||
+		origin == uint64(spec_mgmt.RouteOriginPrefixAnn)
+}
@@ -17,12 +17,15 @@ import (
 	enc "github.com/named-data/ndnd/std/encoding"
 	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
 	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	sec "github.com/named-data/ndnd/std/security"
 	"github.com/named-data/ndnd/std/types/optional"
 )
 
 // RIBModule is the module that handles RIB Management.
 type RIBModule struct {
 	manager *Thread
+	// prefixAnn validates signed prefix announcements (nil if disabled)
+	prefixAnn *trustValidator
 }
 
 // (AI GENERATED DESCRIPTION): Returns the string identifier for this RIBModule, which is always `"mgmt-rib"`.
@@ -33,6 +36,13 @@ func (r *RIBModule) String() string {
 // (AI GENERATED DESCRIPTION): Registers the supplied Thread as the manager for the RIBModule.
 func (r *RIBModule) registerManager(manager *Thread) {
 	r.manager = manager
+
+	cfg := &core.C.Mgmt.PrefixAnnouncement
+	prefixAnn, err := newTrustValidator(manager, "mgmt-prefix-ann", cfg.Keychain, cfg.TrustSchema, cfg.TrustAnchors)
+	if err != nil {
+		core.Log.Fatal(r, "Unable to set up PrefixAnnouncement validation", "err", err)
+	}
+	r.prefixAnn = prefixAnn
 }
 
 // (AI GENERATED DESCRIPTION): Returns the manager thread associated with this RIBModule.
@@ -158,7 +168,9 @@ func (r *RIBModule) unregister(interest *Interest) {
 	core.Log.Info(r, "Removed route", "name", params.Name, "faceid", faceID, "origin", origin)
 }
 
-// (AI GENERATED DESCRIPTION): Handles a PrefixAnnouncement Interest by validating its name and application parameters and replying with a 501 Not Implemented response, since the announcement logic is not yet implemented.
+// announce handles a signed PrefixAnnouncement. The announcement is validated against
+// the configured trust schema, and a route with origin prefixann to the requesting face
+// is installed until the announcement expires.
 func (r *RIBModule) announce(interest *Interest) {
 	if len(interest.Name()) != len(LOCAL_PREFIX)+3 || interest.Name()[len(LOCAL_PREFIX)+2].Typ != enc.TypeParametersSha256DigestComponent {
 		r.manager.sendCtrlResp(interest, 400, "Name is incorrect", nil)
@@ -172,15 +184,64 @@ func (r *RIBModule) announce(interest *Interest) {
 		return
 	}
 
-	data, _, err := spec.Spec{}.ReadData(enc.NewWireView(appParam))
+	annWire := enc.Wire{appParam.Join()}
+	data, sigCov, err := spec.Spec{}.ReadData(enc.NewWireView(annWire))
+	if err != nil {
+		r.manager.sendCtrlResp(interest, 400, "PrefixAnnouncement is invalid", nil)
+		return
+	}
+	ann, err := sec.ParsePrefixAnn(data)
 	if err != nil {
 		r.manager.sendCtrlResp(interest, 400, "PrefixAnnouncement is invalid", nil)
 		return
 	}
-	if data != nil {
+
+	if r.prefixAnn == nil {
+		r.manager.sendCtrlResp(interest, 403, "PrefixAnnouncement is not accepted", nil)
+		return
+	}
+	if ann.Lifetime(time.Now()) <= 0 {
+		r.manager.sendCtrlResp(interest, 403, "PrefixAnnouncement is expired or not yet valid", nil)
+		return
 	}
 
-	r.manager.sendCtrlResp(interest, 501, "PrefixAnnouncement not implemented yet", nil)
+	faceID := interest.inFace.Unwrap()
+	r.prefixAnn.validate(data, sigCov, func(valid bool, err error) {
+		if !valid || err != nil {
+			core.Log.Warn(r, "Rejected PrefixAnnouncement", "name", data.Name(), "valid", valid, "err", err)
+			r.manager.sendCtrlResp(interest, 403, "PrefixAnnouncement signature is not valid", nil)
+			return
+		}
+
+		// Validation may have fetched certificates, so check the lifetime again
+		lifetime := ann.Lifetime(time.Now())
+		if lifetime <= 0 {
+			r.manager.sendCtrlResp(interest, 403, "PrefixAnnouncement is expired or not yet valid", nil)
+			return
+		}
+
+		origin := uint64(mgmt.RouteOriginPrefixAnn)
+		flags := uint64(mgmt.RouteFlagChildInherit)
+		table.Rib.AddEncRoute(ann.Prefix, &table.Route{
+			FaceID:           faceID,
+			Origin:           origin,
+			Cost:             0,
+			Flags:            flags,
+			ExpirationPeriod: &lifetime,
+			Announcement:     annWire,
+		})
+		core.Log.Info(r, "Created route from PrefixAnnouncement", "name", ann.Prefix, "faceid", faceID,
+			"signer", data.Signature().KeyName(), "expires", lifetime)
+
+		r.manager.sendCtrlResp(interest, 200, "OK", &mgmt.ControlArgs{
+			Name:             ann.Prefix,
+			FaceId:           optional.Some(faceID),
+			Origin:           optional.Some(origin),
+			Cost:             optional.Some(uint64(0)),
+			Flags:            optional.Some(flags),
+			ExpirationPeriod: optional.Some(uint64(lifetime.Milliseconds())),
+		})
+	})
 }
 
 // (AI GENERATED DESCRIPTION): Responds to a “/local/rib/list” Interest by collecting all current RIB entries, encoding them into a mgmt.RibStatus dataset, and sending the dataset back as a Data packet with a name derived from the Interest’s prefix and the components “rib”/“list”.
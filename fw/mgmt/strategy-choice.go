@@ -131,7 +131,18 @@ func (s *StrategyChoiceModule) set(interest *Interest) {
 		params.Strategy.Name = params.Strategy.Name.
 			Append(enc.NewVersionComponent(strategyVersion))
 	}
+
+	// Verify strategy parameters following the version
+	canonicalName, err := fw.ValidateStrategy(params.Strategy.Name)
+	if err != nil {
+		core.Log.Warn(s, "Invalid strategy parameters", "strategy", params.Strategy.Name, "err", err)
+		s.manager.sendCtrlResp(interest, 400, "Invalid strategy parameters", nil)
+		return
+	}
+	params.Strategy.Name = canonicalName
+
 	table.FibStrategyTable.SetStrategyEnc(params.Name, params.Strategy.Name)
+	evictStrategies()
 
 	s.manager.sendCtrlResp(interest, 200, "OK", &mgmt.ControlArgs{
 		Name:     params.Name,
@@ -165,6 +176,7 @@ func (s *StrategyChoiceModule) unset(interest *Interest) {
 	}
 
 	table.FibStrategyTable.UnSetStrategyEnc(params.Name)
+	evictStrategies()
 	core.Log.Info(s, "Unset Strategy", "name", params.Name)
 
 	s.manager.sendCtrlResp(interest, 200, "OK", &mgmt.ControlArgs{Name: params.Name})
@@ -195,3 +207,11 @@ func (s *StrategyChoiceModule) list(interest *Interest) {
 	)
 	s.manager.sendStatusDataset(interest, name, dataset.Encode())
 }
+
+// evictStrategies lets the forwarding threads release the strategy instances
+// of choices that were unset or replaced.
+func evictStrategies() {
+	for _, thread := range fw.Threads {
+		thread.QueueStrategyEviction()
+	}
+}
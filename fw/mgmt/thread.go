@@ -9,6 +9,7 @@ package mgmt
 
 import (
 	"math/rand"
+	"sync"
 	"time"
 
 	"github.com/named-data/ndnd/fw/core"
@@ -39,6 +40,13 @@ type Thread struct {
 	store  ndn.Store
 	objDir *storage.MemoryFifoDir
 	signer ndn.Signer
+
+	// Face event notification stream
+	faceEvents *FaceEventStream
+
+	// Interests sent by the management thread waiting for Data
+	fetches    []*pendingFetch
+	fetchMutex sync.Mutex
 }
 
 // (AI GENERATED DESCRIPTION): Returns the constant string “mgmt”, identifying the Thread as a management thread.
@@ -56,6 +64,8 @@ func MakeMgmtThread() *Thread {
 		signer:  signer.NewSha256Signer(),
 	}
 
+	m.registerModule("capture", new(CaptureModule))
+	m.registerModule("config", new(ConfigModule))
 	m.registerModule("cs", new(ContentStoreModule))
 	m.registerModule("cs-audit", new(CsAuditModule))
 	m.registerModule("faces", new(FaceModule))
@@ -64,6 +74,10 @@ func MakeMgmtThread() *Thread {
 	m.registerModule("status", new(ForwarderStatusModule))
 	m.registerModule("strategy-choice", new(StrategyChoiceModule))
 
+	// face events are published by the management thread
+	m.faceEvents = NewFaceEventStream(m)
+	face.AddFaceEventHandler(m.faceEvents)
+
 	// readvertisers run in the management thread for ease of
 	// implementation, since they use the internal transport
 	if core.C.Tables.Rib.ReadvertiseNlsr {
@@ -85,11 +99,20 @@ func (m *Thread) Run() {
 
 	// Create and register Internal transport
 	m.face, m.transport = face.RegisterInternalTransport()
+	m.faceEvents.start()
 	table.FibStrategyTable.InsertNextHopEnc(LOCAL_PREFIX, m.face.FaceID(), 0)
 	if core.C.Mgmt.AllowLocalhop {
 		table.FibStrategyTable.InsertNextHopEnc(NON_LOCAL_PREFIX, m.face.FaceID(), 0)
 	}
 
+	// Remote CS audit requests are served under the node prefix
+	csAudit := m.modules["cs-audit"].(*CsAuditModule)
+	csAuditPrefix := csAudit.remotePrefix()
+	if csAuditPrefix != nil {
+		table.FibStrategyTable.InsertNextHopEnc(csAuditPrefix, m.face.FaceID(), 0)
+	}
+	go csAudit.runRepairs()
+
 	for {
 		lpPkt := m.transport.Receive()
 		if lpPkt == nil {
@@ -103,13 +126,23 @@ func (m *Thread) Run() {
 			continue
 		}
 
-		pkt, _, err := spec.ReadPacket(enc.NewWireView(lpPkt.Fragment))
+		pkt, ctx, err := spec.ReadPacket(enc.NewWireView(lpPkt.Fragment))
 		if err != nil {
 			core.Log.Warn(m, "Unable to decode internal packet - DROP", "err", err)
 			continue
 		}
 
-		// We only expect Interests, so drop Data packets
+		// Nacks and Data packets can only be replies to our own fetches
+		if lpPkt.Nack != nil {
+			if pkt.Interest != nil {
+				m.onNack(pkt.Interest.NameV, lpPkt.Nack.Reason)
+			}
+			continue
+		}
+		if pkt.Data != nil {
+			m.onData(lpPkt.Fragment)
+			continue
+		}
 		if pkt.Interest == nil {
 			core.Log.Debug(m, "Dropping received non-Interest packet")
 			continue
@@ -117,9 +150,17 @@ func (m *Thread) Run() {
 
 		// Create internal Interest object for easier handling
 		interest := &Interest{
-			Interest: *pkt.Interest,
-			pitToken: lpPkt.PitToken,
-			inFace:   lpPkt.IncomingFaceId,
+			Interest:   *pkt.Interest,
+			sigCovered: ctx.Interest_context.SigCovered(),
+			pitToken:   lpPkt.PitToken,
+			inFace:     lpPkt.IncomingFaceId,
+		}
+
+		if csAuditPrefix != nil && csAuditPrefix.IsPrefix(interest.Name()) {
+			if !m.sendFromStore(interest) {
+				csAudit.handleRemoteInterest(interest)
+			}
+			continue
 		}
 
 		// Ensure Interest name matches expectations
@@ -135,14 +176,7 @@ func (m *Thread) Run() {
 		core.Log.Trace(m, "Received management Interest", "name", interest.Name())
 
 		// Look for any matching data in object store.
-		// We only use exact match here since RDR is unnecessary.
-		segment, err := m.store.Get(interest.Name(), false)
-		if err == nil && segment != nil {
-			m.transport.Send(&spec.LpPacket{
-				Fragment:      enc.Wire{segment},
-				PitToken:      interest.pitToken,
-				NextHopFaceId: interest.inFace,
-			})
+		if m.sendFromStore(interest) {
 			continue
 		}
 
@@ -157,6 +191,21 @@ func (m *Thread) Run() {
 	}
 }
 
+// Send a matching segment from the object store, if any.
+// We only use exact match here since RDR is unnecessary.
+func (m *Thread) sendFromStore(interest *Interest) bool {
+	segment, err := m.store.Get(interest.Name(), false)
+	if err != nil || segment == nil {
+		return false
+	}
+	m.transport.Send(&spec.LpPacket{
+		Fragment:      enc.Wire{segment},
+		PitToken:      interest.pitToken,
+		NextHopFaceId: interest.inFace,
+	})
+	return true
+}
+
 // Send an Interest to the internal transport
 func (m *Thread) sendInterest(name enc.Name, params enc.Wire) {
 	config := ndn.InterestConfig{
@@ -175,13 +224,18 @@ func (m *Thread) sendInterest(name enc.Name, params enc.Wire) {
 
 // Send a Data packet to the internal transport
 func (m *Thread) sendData(interest *Interest, name enc.Name, content enc.Wire) {
+	m.sendSignedData(interest, name, content, m.signer)
+}
+
+// Send a Data packet signed with the given signer to the internal transport
+func (m *Thread) sendSignedData(interest *Interest, name enc.Name, content enc.Wire, signer ndn.Signer) {
 	data, err := spec.Spec{}.MakeData(name,
 		&ndn.DataConfig{
 			ContentType: optional.Some(ndn.ContentTypeBlob),
 			Freshness:   optional.Some(time.Duration(0)),
 		},
 		content,
-		m.signer,
+		signer,
 	)
 	if err != nil {
 		core.Log.Warn(m, "Unable to encode Data", "name", interest.Name(), "err", err)
@@ -0,0 +1,153 @@
+package mgmt
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/ndn"
+	"github.com/named-data/ndnd/std/object/storage"
+	sec "github.com/named-data/ndnd/std/security"
+	"github.com/named-data/ndnd/std/security/keychain"
+	"github.com/named-data/ndnd/std/security/trust_schema"
+	"github.com/named-data/ndnd/std/types/optional"
+)
+
+// trustValidator validates the signature of packets received by management,
+// e.g. prefix announcements (rib/announce) or remote CS audit requests.
+type trustValidator struct {
+	m    *Thread
+	name string
+	// insecure accepts all packets without validation
+	insecure bool
+	trust    *sec.TrustConfig
+	store    ndn.Store
+}
+
+func (v *trustValidator) String() string {
+	return v.name
+}
+
+// newTrustValidator creates a validator from a keychain URI, the path of a compiled
+// LVS trust schema and the names of the trust anchors.
+// Returns nil if the keychain is empty, i.e. all packets are rejected.
+func newTrustValidator(m *Thread, name string, kcUri string, schemaPath string, trustAnchors []string) (*trustValidator, error) {
+	v := &trustValidator{m: m, name: name}
+
+	switch kcUri {
+	case "":
+		return nil, nil
+	case "insecure":
+		core.Log.Warn(v, "Signatures are not validated - insecure mode")
+		v.insecure = true
+		return v, nil
+	}
+
+	v.store = storage.NewMemoryStore()
+	kc, err := keychain.NewKeyChain(kcUri, v.store)
+	if err != nil {
+		return nil, err
+	}
+
+	var schema ndn.TrustSchema = trust_schema.NewNullSchema()
+	if schemaPath != "" {
+		schemaBytes, err := os.ReadFile(core.C.ResolveRelPath(schemaPath))
+		if err != nil {
+			return nil, err
+		}
+		if schema, err = trust_schema.NewLvsSchema(schemaBytes); err != nil {
+			return nil, err
+		}
+	}
+
+	anchors := make([]enc.Name, 0, len(trustAnchors))
+	for _, anchor := range trustAnchors {
+		name, err := enc.NameFromStr(anchor)
+		if err != nil {
+			return nil, err
+		}
+		anchors = append(anchors, name)
+	}
+
+	if v.trust, err = sec.NewTrustConfig(kc, schema, anchors); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// validate checks the signature of a Data packet against the trust schema.
+// Missing certificates are fetched over the internal face, so the callback may run
+// on another goroutine.
+func (v *trustValidator) validate(data ndn.Data, sigCov enc.Wire, callback func(bool, error)) {
+	if v.insecure {
+		callback(true, nil)
+		return
+	}
+
+	v.trust.Validate(sec.TrustConfigValidateArgs{
+		Data:       data,
+		DataSigCov: sigCov,
+		Callback:   callback,
+		Fetch: func(name enc.Name, config *ndn.InterestConfig, callback ndn.ExpressCallbackFunc) {
+			v.m.fetch(name, v.store, config, callback)
+		},
+	})
+}
+
+// validateInterest checks the signature of a signed Interest against the trust schema.
+// The schema is checked with the Interest name without the ParametersSha256DigestComponent.
+// As with validate, the callback may run on another goroutine.
+func (v *trustValidator) validateInterest(interest *Interest, callback func(bool, error)) {
+	if interest.Signature().SigType() == ndn.SignatureNone || len(interest.sigCovered) == 0 {
+		callback(false, fmt.Errorf("interest is not signed"))
+		return
+	}
+	if v.insecure {
+		callback(true, nil)
+		return
+	}
+
+	name := interest.Name()
+	if len(name) > 0 && name.At(-1).Typ == enc.TypeParametersSha256DigestComponent {
+		name = name.Prefix(-1)
+	}
+
+	v.trust.Validate(sec.TrustConfigValidateArgs{
+		Data:         interestAsData{&interest.Interest},
+		DataSigCov:   interest.sigCovered,
+		OverrideName: name,
+		Callback:     callback,
+		Fetch: func(name enc.Name, config *ndn.InterestConfig, callback ndn.ExpressCallbackFunc) {
+			v.m.fetch(name, v.store, config, callback)
+		},
+	})
+}
+
+// interestAsData presents a signed Interest as a Data packet to the TrustConfig,
+// which only uses the name and signature of the packet being validated.
+type interestAsData struct {
+	ndn.Interest
+}
+
+func (interestAsData) ContentType() optional.Optional[ndn.ContentType] {
+	return optional.None[ndn.ContentType]()
+}
+
+func (interestAsData) Freshness() optional.Optional[time.Duration] {
+	return optional.None[time.Duration]()
+}
+
+func (interestAsData) FinalBlockID() optional.Optional[enc.Component] {
+	return optional.None[enc.Component]()
+}
+
+func (interestAsData) Content() enc.Wire {
+	return nil
+}
+
+func (interestAsData) CrossSchema() enc.Wire {
+	return nil
+}
@@ -12,14 +12,14 @@ import (
 	"time"
 
 	"github.com/named-data/ndnd/fw/core"
-	enc "github.com/named-data/ndnd/std/encoding"
 )
 
 // Mutable table configuration
 var mutCfg = struct {
-	csCapacity atomic.Int32
-	csAdmit    atomic.Bool
-	csServe    atomic.Bool
+	csCapacity  atomic.Int32
+	csAdmit     atomic.Bool
+	csServe     atomic.Bool
+	dnlLifetime atomic.Int64
 }{}
 
 // Initialize creates tables and configuration.
@@ -29,6 +29,12 @@ func Initialize() {
 	mutCfg.csAdmit.Store(core.C.Tables.ContentStore.Admit)
 	mutCfg.csServe.Store(core.C.Tables.ContentStore.Serve)
 
+	// Dead Nonce List
+	CfgSetDeadNonceListLifetime(time.Duration(core.C.Tables.DeadNonceList.Lifetime) * time.Millisecond)
+
+	// Content Store audit and SEU injector
+	initCsAudit()
+
 	// Create FIB strategy table
 	switch core.C.Tables.Fib.Algorithm {
 	case "hashtable":
@@ -40,14 +46,11 @@ func Initialize() {
 	}
 
 	// Create Network Region Table
-	for _, region := range core.C.Tables.NetworkRegion.Regions {
-		name, err := enc.NameFromStr(region)
-		if err != nil {
-			core.Log.Fatal(nil, "Could not add producer region", "name", region, "err", err)
-		}
-		NetworkRegion.Add(name)
-		core.Log.Debug(nil, "Added producer region", "name", region)
+	regions, err := ParseNetworkRegions(core.C.Tables.NetworkRegion.Regions)
+	if err != nil {
+		core.Log.Fatal(nil, "Could not add producer region", "err", err)
 	}
+	NetworkRegion.Set(regions)
 }
 
 // CfgCsAdmit returns whether contents will be admitted to the Content Store.
@@ -87,5 +90,10 @@ func CfgCsReplacementPolicy() string {
 
 // CfgDeadNonceListLifetime returns the lifetime of entries in the dead nonce list.
 func CfgDeadNonceListLifetime() time.Duration {
-	return time.Duration(core.C.Tables.DeadNonceList.Lifetime) * time.Millisecond
+	return time.Duration(mutCfg.dnlLifetime.Load())
+}
+
+// CfgSetDeadNonceListLifetime sets the lifetime of new entries in the dead nonce list.
+func CfgSetDeadNonceListLifetime(lifetime time.Duration) {
+	mutCfg.dnlLifetime.Store(int64(lifetime))
 }
@@ -38,5 +38,6 @@ func publishCsAuditEvent(ev CsAuditEvent) {
 	select {
 	case CsAuditEvents <- ev:
 	default:
+		csAuditCounters.eventsDropped.Add(1)
 	}
 }
@@ -0,0 +1,167 @@
+package table
+
+import (
+	"sync"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/security/audit"
+)
+
+// 中文说明：远程审计挑战（/<node>/cs-audit/challenge/<prefix>/<nonce>）的 CS 侧实现。
+// 管理线程不能直接访问 CS，因此与 flip 一样通过通道把请求交给转发线程，
+// 由转发线程基于“实时缓存 wire”重算证明并聚合后返回。
+// 与 flip 不同的是：每个转发线程都有自己的 CS，挑战需要广播到所有线程再合并结果。
+//
+// 聚合为 Σ c_i·σ_i，其中系数 c_i = ChallengeCoefficient(nonce, digest_i) 同时依赖挑战 nonce
+// 与实时 wire 的摘要，因此不能用预先保存的标签之和重放应答。
+
+// CsAuditChallengeResult 是一次挑战在所有转发线程 CS 上得到的聚合证明。
+type CsAuditChallengeResult struct {
+	// LeafCount 为前缀下参与挑战的缓存条目数。
+	LeafCount uint64
+	// InvalidCount 为校验失败（已被删除或正在修复）的条目数。
+	InvalidCount uint64
+	// Aggregate 为所有条目实时证明标签按 nonce 派生系数加权后的同态聚合（方案见 CsAuditTagScheme）。
+	Aggregate []byte
+	// Time 为挑战时间。
+	Time time.Time
+}
+
+type csAuditChallengeReq struct {
+	Prefix enc.Name
+	Nonce  []byte
+	Time   time.Time
+	Reply  chan CsAuditChallengeResult
+}
+
+var csAuditChallengeMutex sync.Mutex
+var csAuditChallengeChs []chan csAuditChallengeReq
+
+// registerCsAuditChallengeCh 为一个转发线程的 PIT-CS 注册挑战请求通道。
+func registerCsAuditChallengeCh() chan csAuditChallengeReq {
+	ch := make(chan csAuditChallengeReq, 4)
+	csAuditChallengeMutex.Lock()
+	defer csAuditChallengeMutex.Unlock()
+	csAuditChallengeChs = append(csAuditChallengeChs, ch)
+	return ch
+}
+
+// RequestCsAuditChallenge 以 nonce 对 prefix 下的所有缓存条目发起一次挑战，并等待所有转发线程的结果。
+//
+// 中文说明：
+// - 若某个线程的请求队列已满，或 timeout 内未收齐结果，返回 ok=false。
+// - 挑战过程中发现损坏的条目会像定时挑战一样被删除（或修复），并计入 InvalidCount。
+func RequestCsAuditChallenge(prefix enc.Name, nonce []byte, timeout time.Duration) (res CsAuditChallengeResult, ok bool) {
+	csAuditChallengeMutex.Lock()
+	chs := csAuditChallengeChs
+	csAuditChallengeMutex.Unlock()
+
+	req := csAuditChallengeReq{
+		Prefix: prefix.Clone(),
+		Nonce:  append([]byte(nil), nonce...),
+		Time:   time.Now(),
+		Reply:  make(chan CsAuditChallengeResult, len(chs)),
+	}
+	for _, ch := range chs {
+		select {
+		case ch <- req:
+		default:
+			return res, false
+		}
+	}
+
+	scheme := CsAuditScheme()
+	res = CsAuditChallengeResult{Aggregate: scheme.Identity(), Time: req.Time}
+	deadline := time.After(timeout)
+	for range chs {
+		select {
+		case part := <-req.Reply:
+			agg, err := scheme.Add(res.Aggregate, part.Aggregate)
+			if err != nil {
+				return res, false
+			}
+			res.Aggregate = agg
+			res.LeafCount += part.LeafCount
+			res.InvalidCount += part.InvalidCount
+		case <-deadline:
+			return res, false
+		}
+	}
+
+	csAuditCounters.remoteChallenges.Add(1)
+	recordCsAuditHistory(CsAuditHistoryEntry{
+		Time:       req.Time,
+		Event:      CsAuditHistoryChallenge,
+		Name:       req.Prefix,
+		Checked:    res.LeafCount,
+		Mismatched: res.InvalidCount,
+	})
+	return res, true
+}
+
+func (p *PitCsTree) popCsAuditChallengeReq() (csAuditChallengeReq, bool) {
+	select {
+	case req := <-p.csAuditChallengeCh:
+		return req, true
+	default:
+		return csAuditChallengeReq{}, false
+	}
+}
+
+func (p *PitCsTree) handleCsAuditChallengeReq(req csAuditChallengeReq) {
+	scheme := CsAuditScheme()
+	res := CsAuditChallengeResult{Aggregate: scheme.Identity(), Time: req.Time}
+
+	var toErase []uint64
+	if node := p.root.findExactMatchEntryEnc(req.Prefix); node != nil {
+		node.forEachCsEntry(func(entry *nameTreeCsEntry) {
+			res.LeafCount++
+			computed, ok := p.csAuditProveEntry(entry, req.Time)
+			if ok {
+				coeff := audit.ChallengeCoefficient(req.Nonce,
+					csAuditLeafDigest(scheme, entry.node.name, entry.wire))
+				agg, err := scheme.Scale(computed, coeff)
+				if err == nil {
+					agg, err = scheme.Add(res.Aggregate, agg)
+				}
+				ok = err == nil
+				if ok {
+					res.Aggregate = agg
+				}
+			}
+			if !ok {
+				res.InvalidCount++
+				toErase = append(toErase, entry.index)
+			}
+		})
+	}
+
+	csAuditCounters.remoteChecked.Add(res.LeafCount)
+	for _, index := range toErase {
+		p.handleCsAuditMismatch(index, req.Time)
+	}
+
+	if CfgCsAuditLogEnabled() {
+		core.Log.Info(nil, "【审计】远程挑战完成",
+			"prefix", req.Prefix,
+			"nEntries", res.LeafCount,
+			"nMismatched", res.InvalidCount,
+			"time", req.Time.Format(time.RFC3339Nano),
+		)
+	}
+
+	// Reply 有足够缓冲，不会阻塞转发线程
+	req.Reply <- res
+}
+
+// forEachCsEntry 遍历以该节点为根的子树中的所有 CS 条目。
+func (p *pitCsTreeNode) forEachCsEntry(fn func(*nameTreeCsEntry)) {
+	if p.csEntry != nil {
+		fn(p.csEntry)
+	}
+	for _, child := range p.children {
+		child.forEachCsEntry(fn)
+	}
+}
@@ -0,0 +1,215 @@
+package table
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	enc "github.com/named-data/ndnd/std/encoding"
+)
+
+// 中文说明：缓存审计与 SEU 注入器的配置（tables.content_store.audit / tables.content_store.seu）。
+//
+// - 启动时由 Initialize 校验全部配置，非法值直接 Fatal（与 FIB 算法等表配置一致）。
+// - 挑战周期、审计日志开关以及 SEU 的 enabled/rate/prefix 可以通过 /localhost/nfd/cs-audit/config 在运行时修改，
+//   因此保存在原子变量中；其余配置只在启动时读取 core.C。
+
+var csAuditCfg = struct {
+	interval   atomic.Int64
+	log        atomic.Bool
+	seuEnabled atomic.Bool
+	seuRate    atomic.Uint64
+	seuPrefix  atomic.Pointer[enc.Name]
+	// seuGen 在 SEU 配置变化时递增，转发线程据此重新采样下一次注入时间。
+	seuGen atomic.Uint64
+	// seuSeed 为注入器的种子（配置为 0 时在启动时随机生成）。
+	seuSeed uint64
+	// intervalCh 在挑战周期变化时通知定时挑战器。
+	intervalCh chan struct{}
+	nodePrefix enc.Name
+	// repairSource 为修复 Interest 的 ForwardingHint（可为空）。
+	repairSource enc.Name
+}{
+	intervalCh: make(chan struct{}, 1),
+}
+
+// initCsAudit 校验并加载审计配置（由 Initialize 调用）。
+func initCsAudit() {
+	cfg := &core.C.Tables.ContentStore.Audit
+	seu := &core.C.Tables.ContentStore.Seu
+
+	switch cfg.TagScheme {
+	case CsAuditSchemeHmac, CsAuditSchemeBls:
+	default:
+		core.Log.Fatal(nil, "Unknown CS audit tag scheme", "scheme", cfg.TagScheme)
+	}
+	switch cfg.SampleMode {
+	case CsAuditSampleLeaf, CsAuditSamplePrefix:
+	default:
+		core.Log.Fatal(nil, "Unknown CS audit sample mode", "mode", cfg.SampleMode)
+	}
+	if cfg.SampleK < 0 || cfg.SampleDepth <= 0 || cfg.Batch <= 0 {
+		core.Log.Fatal(nil, "Invalid CS audit sampling configuration",
+			"sample_k", cfg.SampleK, "sample_depth", cfg.SampleDepth, "batch", cfg.Batch)
+	}
+	if (cfg.Keychain == "") != (cfg.KeyName == "") {
+		core.Log.Fatal(nil, "CS audit keychain and key_name must be set together")
+	}
+	if cfg.NodePrefix != "" {
+		name, err := enc.NameFromStr(cfg.NodePrefix)
+		if err != nil || len(name) == 0 {
+			core.Log.Fatal(nil, "Invalid CS audit node prefix", "prefix", cfg.NodePrefix, "err", err)
+		}
+		csAuditCfg.nodePrefix = name
+	}
+	if repair := cfg.Repair; repair.Enabled {
+		if repair.Timeout == 0 {
+			core.Log.Fatal(nil, "CS audit repair timeout must be positive")
+		}
+		if repair.Source != "" {
+			name, err := enc.NameFromStr(repair.Source)
+			if err != nil || len(name) == 0 {
+				core.Log.Fatal(nil, "Invalid CS audit repair source", "source", repair.Source, "err", err)
+			}
+			csAuditCfg.repairSource = name
+		}
+	}
+
+	switch seu.Model {
+	case CsSeuModelSingle, CsSeuModelAdjacent, CsSeuModelBurst, CsSeuModelStuckAt, CsSeuModelMetadata:
+	default:
+		core.Log.Fatal(nil, "Unknown SEU fault model", "model", seu.Model)
+	}
+	switch seu.Region {
+	case CsSeuRegionAny, CsSeuRegionName, CsSeuRegionContent, CsSeuRegionSignature:
+	default:
+		core.Log.Fatal(nil, "Unknown SEU region", "region", seu.Region)
+	}
+	if seu.Model == CsSeuModelMetadata && seu.Region != CsSeuRegionAny {
+		core.Log.Fatal(nil, "SEU region is not applicable to the metadata model", "region", seu.Region)
+	}
+	if seu.Bits < 1 || seu.BurstSpan < 1 {
+		core.Log.Fatal(nil, "Invalid SEU multi-bit configuration", "bits", seu.Bits, "burst_span", seu.BurstSpan)
+	}
+	csAuditCfg.seuSeed = seu.Seed
+	if csAuditCfg.seuSeed == 0 {
+		csAuditCfg.seuSeed = rand.Uint64()
+	}
+	if seu.FaultLog != "" {
+		if err := openCsSeuFaultLog(core.C.ResolveRelPath(seu.FaultLog)); err != nil {
+			core.Log.Fatal(nil, "Unable to open SEU fault log", "path", seu.FaultLog, "err", err)
+		}
+	}
+
+	CfgSetCsSha256ChallengeInterval(time.Duration(cfg.Interval) * time.Millisecond)
+	CfgSetCsAuditLog(cfg.Log)
+	CfgSetCsSeuEnabled(seu.Enabled)
+	if err := CfgSetCsSeuRatePerBitPerDay(seu.RatePerBitPerDay); err != nil {
+		core.Log.Fatal(nil, "Invalid SEU rate", "err", err)
+	}
+	if err := CfgSetCsSeuPrefix(seu.Prefix); err != nil {
+		core.Log.Fatal(nil, "Invalid SEU prefix", "err", err)
+	}
+
+	// 在读取配置之后创建审计方案与 CSNAT：从 keychain 派生密钥失败时在启动阶段就报错
+	if err := loadCsAuditScheme(); err != nil {
+		core.Log.Fatal(nil, "Unable to initialize CS audit", "err", err)
+	}
+	if cfg.Keychain == "" && cfg.Interval > 0 {
+		core.Log.Warn(nil, "CS audit uses the built-in key, configure a keychain for real deployments")
+	}
+}
+
+// CfgCsSha256ChallengeInterval 返回定时挑战周期；0 表示不启用。
+func CfgCsSha256ChallengeInterval() time.Duration {
+	return time.Duration(csAuditCfg.interval.Load())
+}
+
+// CfgSetCsSha256ChallengeInterval 设置定时挑战周期（0 表示停止定时挑战），立即通知定时挑战器。
+func CfgSetCsSha256ChallengeInterval(interval time.Duration) {
+	csAuditCfg.interval.Store(int64(max(interval, 0)))
+	select {
+	case csAuditCfg.intervalCh <- struct{}{}:
+	default:
+	}
+}
+
+// CfgCsAuditLogEnabled 返回是否输出审计流程日志（避免默认刷屏）。
+func CfgCsAuditLogEnabled() bool {
+	return csAuditCfg.log.Load()
+}
+
+// CfgSetCsAuditLog 设置是否输出审计流程日志。
+func CfgSetCsAuditLog(enabled bool) {
+	csAuditCfg.log.Store(enabled)
+}
+
+// CfgCsAuditNodePrefix 返回本节点的远程审计前缀；未配置则返回 nil（不启用远程挑战）。
+//
+// 中文说明：
+// - 例：node_prefix: /minindn/n1
+// - 远程审计者可发送 /minindn/n1/cs-audit/challenge/<prefix>/<nonce> 挑战本节点缓存。
+func CfgCsAuditNodePrefix() enc.Name {
+	return csAuditCfg.nodePrefix
+}
+
+// CfgCsSeuEnabled 返回是否启用 SEU 注入器。
+func CfgCsSeuEnabled() bool {
+	return csAuditCfg.seuEnabled.Load()
+}
+
+// CfgSetCsSeuEnabled 启用或停用 SEU 注入器。
+func CfgSetCsSeuEnabled(enabled bool) {
+	csAuditCfg.seuEnabled.Store(enabled)
+	csAuditCfg.seuGen.Add(1)
+}
+
+// CfgCsSeuRatePerBitPerDay 返回 SEU 率（bit^-1·day^-1）。
+func CfgCsSeuRatePerBitPerDay() float64 {
+	return math.Float64frombits(csAuditCfg.seuRate.Load())
+}
+
+// CfgSetCsSeuRatePerBitPerDay 设置 SEU 率（bit^-1·day^-1），必须为正的有限值。
+func CfgSetCsSeuRatePerBitPerDay(rate float64) error {
+	if rate <= 0 || math.IsNaN(rate) || math.IsInf(rate, 0) {
+		return fmt.Errorf("invalid SEU rate: %g", rate)
+	}
+	csAuditCfg.seuRate.Store(math.Float64bits(rate))
+	csAuditCfg.seuGen.Add(1)
+	return nil
+}
+
+// CfgCsSeuPrefix 返回 SEU 注入的前缀；空名字表示对全表注入。
+func CfgCsSeuPrefix() enc.Name {
+	if p := csAuditCfg.seuPrefix.Load(); p != nil {
+		return *p
+	}
+	return enc.Name{}
+}
+
+// CfgSetCsSeuPrefix 设置 SEU 注入的前缀；空字符串表示对全表注入。
+func CfgSetCsSeuPrefix(prefix string) error {
+	name := enc.Name{}
+	if prefix != "" {
+		var err error
+		if name, err = enc.NameFromStr(prefix); err != nil {
+			return err
+		}
+	}
+	csAuditCfg.seuPrefix.Store(&name)
+	csAuditCfg.seuGen.Add(1)
+	return nil
+}
+
+// CfgCsSeuSeed 返回 SEU 注入器的种子。
+func CfgCsSeuSeed() uint64 {
+	return csAuditCfg.seuSeed
+}
+
+// cfgCsSeuLogEnabled 返回是否输出 SEU 注入日志；开启审计日志时也会输出。
+func cfgCsSeuLogEnabled() bool {
+	return core.C.Tables.ContentStore.Seu.Log || CfgCsAuditLogEnabled()
+}
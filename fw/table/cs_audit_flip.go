@@ -116,6 +116,7 @@ func (p *PitCsTree) handleCsAuditFlipReq(req csAuditFlipReq) {
 	oldB := node.csEntry.wire[idx]
 	newB := oldB ^ mask
 	node.csEntry.wire[idx] = newB
+	csAuditCounters.flips.Add(1)
 
 	res.Flipped = true
 	res.ByteIndex = idx
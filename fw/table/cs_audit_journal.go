@@ -0,0 +1,474 @@
+package table
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/named-data/ndnd/fw/core"
+	enc "github.com/named-data/ndnd/std/encoding"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+)
+
+// 中文说明：CSNAT 叶子标签与审计结果的持久化日志（可选，使用 badger，与 std/object/storage 一致）。
+//
+// - 配置 tables.content_store.audit.journal 后启用；审计者 goroutine 在更新 CSNAT 的同时把叶子标签写入日志，
+//   挑战轮/远程挑战/损坏条目等审计结果写入历史记录。
+// - 所有磁盘写入都由单独的写日志 goroutine 批量完成，转发线程只做非阻塞投递（队列满则只保留内存中的记录）。
+// - 重启后先用日志中的叶子重建 CSNAT（期望标签），CS 本身是空的：
+//   同名 Data 重新入缓存时与重启前的期望标签对比（不一致记为 restore-mismatch），
+//   超过 reconcile_delay 仍未重新入缓存的叶子从 CSNAT 与日志中删除，使 CSNAT 与真实 CS 重新一致。
+// - 审计方案（hmac/bls）或密钥变化时，旧叶子标签无法比较，直接丢弃；历史记录保留。
+
+// 审计历史记录的事件类型。
+const (
+	// CsAuditHistoryRound 为一轮定时/抽样挑战（每个转发线程各一条）。
+	CsAuditHistoryRound = "round"
+	// CsAuditHistoryChallenge 为一次远程挑战（所有转发线程合并）。
+	CsAuditHistoryChallenge = "challenge"
+	// CsAuditHistoryMismatch 为挑战中发现的损坏条目（随后被删除或修复）。
+	CsAuditHistoryMismatch = "mismatch"
+	// CsAuditHistoryRestore 为重启后从日志重建 CSNAT。
+	CsAuditHistoryRestore = "restore"
+	// CsAuditHistoryRestoreMismatch 为重新入缓存的 Data 与重启前的期望标签不一致。
+	CsAuditHistoryRestoreMismatch = "restore-mismatch"
+	// CsAuditHistoryReconcile 为重启后 CSNAT 与 CS 内容完成对账。
+	CsAuditHistoryReconcile = "reconcile"
+	// CsAuditHistoryRepair 为损坏条目已用重新取回的 Data 替换。
+	CsAuditHistoryRepair = "repair"
+	// CsAuditHistoryRepairFailed 为损坏条目在修复超时后被删除。
+	CsAuditHistoryRepairFailed = "repair-failed"
+)
+
+// CsAuditHistoryEntry 是一条审计结果记录。
+type CsAuditHistoryEntry struct {
+	// Time 为审计时间。
+	Time time.Time
+	// Event 为事件类型（见 CsAuditHistory* 常量）。
+	Event string
+	// Name 为相关的名字或前缀（可为空）。
+	Name enc.Name
+	// Checked 为校验的条目数。
+	Checked uint64
+	// Mismatched 为发现的损坏条目数。
+	Mismatched uint64
+}
+
+// csAuditHistoryMax 是内存中保留（也是 history dataset 返回）的最近记录数。
+const csAuditHistoryMax = 1024
+
+// csAuditJournalHistoryMax 是日志中保留的历史记录数，打开日志时清理更早的记录。
+const csAuditJournalHistoryMax = 65536
+
+var (
+	csAuditJournalLeafPrefix = []byte("l/")
+	csAuditJournalHistPrefix = []byte("h/")
+	csAuditJournalSchemeKey  = []byte("m/scheme")
+)
+
+var csAuditHistoryMutex sync.Mutex
+var csAuditHistory []CsAuditHistoryEntry
+
+var csAuditJournalMutex sync.RWMutex
+var csAuditJournalCur *csAuditJournal
+
+type csAuditJournal struct {
+	db   *badger.DB
+	ops  chan csAuditJournalOp
+	done chan struct{}
+	seq  uint64
+}
+
+// csAuditJournalOp 是一次日志写入：hist=true 时追加历史记录，否则写入（val=nil 时删除）叶子标签。
+type csAuditJournalOp struct {
+	hist bool
+	key  []byte
+	val  []byte
+}
+
+// csNatRestore 记录从日志重建、但尚未在 CS 中重新出现的叶子（只在审计者 goroutine 中使用）。
+type csNatRestore struct {
+	tags        map[string][]byte
+	nRestored   int
+	nMatched    int
+	nMismatched int
+}
+
+// CfgCsAuditJournalPath 返回审计日志目录（相对路径基于配置文件目录）；为空则不启用持久化。
+func CfgCsAuditJournalPath() string {
+	if path := core.C.Tables.ContentStore.Audit.Journal; path != "" {
+		return core.C.ResolveRelPath(path)
+	}
+	return ""
+}
+
+// CfgCsAuditReconcileDelay 返回重启后等待 CS 重新填充的时间。
+func CfgCsAuditReconcileDelay() time.Duration {
+	return time.Duration(core.C.Tables.ContentStore.Audit.ReconcileDelay) * time.Millisecond
+}
+
+// GetCsAuditHistory 返回最近的审计结果记录（按时间先后排列）。
+func GetCsAuditHistory() []CsAuditHistoryEntry {
+	csAuditHistoryMutex.Lock()
+	defer csAuditHistoryMutex.Unlock()
+	return append([]CsAuditHistoryEntry(nil), csAuditHistory...)
+}
+
+// StopCsAuditJournal 写完队列中剩余的记录并关闭审计日志。
+func StopCsAuditJournal() {
+	csAuditJournalMutex.Lock()
+	j := csAuditJournalCur
+	csAuditJournalCur = nil
+	if j != nil {
+		close(j.ops)
+	}
+	csAuditJournalMutex.Unlock()
+
+	if j == nil {
+		return
+	}
+	<-j.done
+	if err := j.db.Close(); err != nil {
+		core.Log.Warn(nil, "Unable to close CS audit journal", "err", err)
+	}
+}
+
+// recordCsAuditHistory 记录一条审计结果（可在任意 goroutine 中调用，不会阻塞）。
+func recordCsAuditHistory(e CsAuditHistoryEntry) {
+	csAuditHistoryMutex.Lock()
+	csAuditHistory = append(csAuditHistory, e)
+	if len(csAuditHistory) > csAuditHistoryMax {
+		csAuditHistory = csAuditHistory[len(csAuditHistory)-csAuditHistoryMax:]
+	}
+	csAuditHistoryMutex.Unlock()
+
+	if csAuditJournalActive() {
+		sendCsAuditJournalOp(csAuditJournalOp{hist: true, val: encodeCsAuditHistoryEntry(e)}, false)
+	}
+}
+
+// journalCsNatLeaf 把叶子标签写入日志（审计者 goroutine 调用，队列满时阻塞，保证叶子不丢失）。
+func journalCsNatLeaf(name enc.Name, tag []byte, staleTime time.Time) {
+	if !csAuditJournalActive() {
+		return
+	}
+	val := make([]byte, 8+len(tag))
+	binary.BigEndian.PutUint64(val, uint64(staleTime.UnixNano()))
+	copy(val[8:], tag)
+	sendCsAuditJournalOp(csAuditJournalOp{key: csAuditJournalLeafKey(name), val: val}, true)
+}
+
+// unjournalCsNatLeaf 从日志中删除叶子标签。
+func unjournalCsNatLeaf(name enc.Name) {
+	if !csAuditJournalActive() {
+		return
+	}
+	sendCsAuditJournalOp(csAuditJournalOp{key: csAuditJournalLeafKey(name)}, true)
+}
+
+func csAuditJournalActive() bool {
+	csAuditJournalMutex.RLock()
+	defer csAuditJournalMutex.RUnlock()
+	return csAuditJournalCur != nil
+}
+
+func sendCsAuditJournalOp(op csAuditJournalOp, block bool) {
+	csAuditJournalMutex.RLock()
+	defer csAuditJournalMutex.RUnlock()
+	j := csAuditJournalCur
+	if j == nil {
+		return
+	}
+	if block {
+		j.ops <- op
+		return
+	}
+	select {
+	case j.ops <- op:
+	default:
+	}
+}
+
+func csAuditJournalLeafKey(name enc.Name) []byte {
+	return append(bytes.Clone(csAuditJournalLeafPrefix), name.Bytes()...)
+}
+
+// csAuditJournalSchemeId 标识审计方案与密钥：方案名加上固定输入的标签前缀（不泄露密钥）。
+func csAuditJournalSchemeId(scheme CsAuditTagScheme) string {
+	probe := scheme.Tag(enc.Name{}, []byte("ndnd-cs-audit-journal"))
+	return scheme.String() + "/" + hex.EncodeToString(probe[:8])
+}
+
+func encodeCsAuditHistoryEntry(e CsAuditHistoryEntry) []byte {
+	return (&mgmt.CsAuditHistoryEntry{
+		Timestamp:  uint64(e.Time.UnixMilli()),
+		Event:      e.Event,
+		Name:       e.Name,
+		Checked:    e.Checked,
+		Mismatched: e.Mismatched,
+	}).Encode().Join()
+}
+
+func decodeCsAuditHistoryEntry(buf []byte) (CsAuditHistoryEntry, error) {
+	m, err := mgmt.ParseCsAuditHistoryEntry(enc.NewBufferView(buf), false)
+	if err != nil {
+		return CsAuditHistoryEntry{}, err
+	}
+	return CsAuditHistoryEntry{
+		Time:       time.UnixMilli(int64(m.Timestamp)),
+		Event:      m.Event,
+		Name:       m.Name,
+		Checked:    m.Checked,
+		Mismatched: m.Mismatched,
+	}, nil
+}
+
+// openCsAuditJournal 打开审计日志，用其中的叶子标签重建 CSNAT，并加载最近的历史记录。
+//
+// 中文说明：
+// - 必须在转发线程启动前（审计者 goroutine 开始消费事件前）调用。
+// - 返回的 csNatRestore 用于之后与重新入缓存的 Data 对账。
+func openCsAuditJournal(path string, scheme CsAuditTagScheme, tree *CsNatSha256Tree) (*csNatRestore, error) {
+	db, err := badger.Open(badger.DefaultOptions(path))
+	if err != nil {
+		return nil, err
+	}
+	j := &csAuditJournal{
+		db:   db,
+		ops:  make(chan csAuditJournalOp, 4096),
+		done: make(chan struct{}),
+	}
+
+	restore := &csNatRestore{tags: make(map[string][]byte)}
+	if err = j.load(scheme, tree, restore); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	csAuditJournalMutex.Lock()
+	csAuditJournalCur = j
+	csAuditJournalMutex.Unlock()
+	go j.run()
+
+	if restore.nRestored > 0 {
+		recordCsAuditHistory(CsAuditHistoryEntry{
+			Time:    time.Now(),
+			Event:   CsAuditHistoryRestore,
+			Checked: uint64(restore.nRestored),
+		})
+	}
+	return restore, nil
+}
+
+func (j *csAuditJournal) load(scheme CsAuditTagScheme, tree *CsNatSha256Tree, restore *csNatRestore) error {
+	// 方案变化时旧叶子标签失效
+	var oldScheme []byte
+	err := j.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(csAuditJournalSchemeKey)
+		if errors.Is(err, badger.ErrKeyNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		oldScheme, err = item.ValueCopy(nil)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	schemeId := csAuditJournalSchemeId(scheme)
+	if oldScheme != nil && string(oldScheme) != schemeId {
+		core.Log.Warn(nil, "CS audit scheme or key changed, dropping journaled leaves",
+			"old", string(oldScheme), "new", schemeId)
+		if err = j.db.DropPrefix(csAuditJournalLeafPrefix); err != nil {
+			return err
+		}
+	}
+	if err = j.db.Update(func(txn *badger.Txn) error {
+		return txn.Set(csAuditJournalSchemeKey, []byte(schemeId))
+	}); err != nil {
+		return err
+	}
+
+	// 重建 CSNAT
+	err = j.db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.IteratorOptions{Prefix: csAuditJournalLeafPrefix, PrefetchValues: true})
+		defer it.Close()
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+			name, err := enc.NameFromBytes(item.Key()[len(csAuditJournalLeafPrefix):])
+			if err != nil {
+				return err
+			}
+			val, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			if len(val) < 8 {
+				continue
+			}
+			staleTime := time.Unix(0, int64(binary.BigEndian.Uint64(val)))
+			tree.OnInsert(name, val[8:], staleTime)
+			restore.tags[string(name.Bytes())] = val[8:]
+			restore.nRestored++
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// 加载最近的历史记录，并清理超出保留数量的旧记录
+	var hist []CsAuditHistoryEntry
+	var stale [][]byte
+	err = j.db.View(func(txn *badger.Txn) error {
+		opts := badger.IteratorOptions{Prefix: csAuditJournalHistPrefix, PrefetchValues: true, Reverse: true}
+		it := txn.NewIterator(opts)
+		defer it.Close()
+		n := 0
+		for it.Seek(append(bytes.Clone(csAuditJournalHistPrefix), 0xFF)); it.Valid(); it.Next() {
+			item := it.Item()
+			if n == 0 {
+				j.seq = binary.BigEndian.Uint64(item.Key()[len(csAuditJournalHistPrefix):]) + 1
+			}
+			n++
+			if n > csAuditJournalHistoryMax {
+				stale = append(stale, item.KeyCopy(nil))
+				continue
+			}
+			if n > csAuditHistoryMax {
+				continue
+			}
+			val, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			if e, err := decodeCsAuditHistoryEntry(val); err == nil {
+				hist = append(hist, e)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(stale) > 0 {
+		wb := j.db.NewWriteBatch()
+		for _, key := range stale {
+			if err = wb.Delete(key); err != nil {
+				wb.Cancel()
+				return err
+			}
+		}
+		if err = wb.Flush(); err != nil {
+			return err
+		}
+	}
+
+	csAuditHistoryMutex.Lock()
+	defer csAuditHistoryMutex.Unlock()
+	for i := len(hist) - 1; i >= 0; i-- {
+		csAuditHistory = append(csAuditHistory, hist[i])
+	}
+	return nil
+}
+
+// run 是写日志 goroutine：每次把队列中已有的记录合并为一个批次写入。
+func (j *csAuditJournal) run() {
+	defer close(j.done)
+	for op := range j.ops {
+		wb := j.db.NewWriteBatch()
+		err := j.apply(wb, op)
+		for n := len(j.ops); err == nil && n > 0; n-- {
+			op, ok := <-j.ops
+			if !ok {
+				break
+			}
+			err = j.apply(wb, op)
+		}
+		if err == nil {
+			err = wb.Flush()
+		} else {
+			wb.Cancel()
+		}
+		if err != nil {
+			core.Log.Warn(nil, "Unable to write CS audit journal", "err", err)
+		}
+	}
+}
+
+func (j *csAuditJournal) apply(wb *badger.WriteBatch, op csAuditJournalOp) error {
+	if op.hist {
+		key := binary.BigEndian.AppendUint64(bytes.Clone(csAuditJournalHistPrefix), j.seq)
+		j.seq++
+		return wb.Set(key, op.val)
+	}
+	if op.val == nil {
+		return wb.Delete(op.key)
+	}
+	return wb.Set(op.key, op.val)
+}
+
+// take 在 Data 重新入缓存时与重启前的期望标签对账；返回 true 表示该叶子来自日志重建。
+func (r *csNatRestore) take(name enc.Name, tag []byte) bool {
+	if r == nil || len(r.tags) == 0 {
+		return false
+	}
+	key := string(name.Bytes())
+	expected, ok := r.tags[key]
+	if !ok {
+		return false
+	}
+	delete(r.tags, key)
+
+	if bytes.Equal(expected, tag) {
+		r.nMatched++
+		return true
+	}
+	r.nMismatched++
+	core.Log.Warn(nil, "【审计】重启前后标签不一致", "name", name)
+	recordCsAuditHistory(CsAuditHistoryEntry{
+		Time:       time.Now(),
+		Event:      CsAuditHistoryRestoreMismatch,
+		Name:       name.Clone(),
+		Checked:    1,
+		Mismatched: 1,
+	})
+	return true
+}
+
+// reconcile 删除重启后仍未在 CS 中重新出现的叶子，使 CSNAT 与真实 CS 一致。
+func (r *csNatRestore) reconcile(tree *CsNatSha256Tree) {
+	if r == nil {
+		return
+	}
+	nDropped := len(r.tags)
+	for key := range r.tags {
+		name, err := enc.NameFromBytes([]byte(key))
+		if err != nil {
+			continue
+		}
+		tree.OnErase(name)
+		unjournalCsNatLeaf(name)
+	}
+	r.tags = nil
+
+	recordCsAuditHistory(CsAuditHistoryEntry{
+		Time:       time.Now(),
+		Event:      CsAuditHistoryReconcile,
+		Checked:    uint64(r.nMatched + r.nMismatched),
+		Mismatched: uint64(r.nMismatched),
+	})
+	if CfgCsAuditLogEnabled() {
+		core.Log.Info(nil, "【审计】重启对账完成",
+			"nRestored", r.nRestored,
+			"nMatched", r.nMatched,
+			"nMismatched", r.nMismatched,
+			"nDropped", nDropped,
+		)
+	}
+}
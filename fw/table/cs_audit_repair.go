@@ -0,0 +1,144 @@
+package table
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+	enc "github.com/named-data/ndnd/std/encoding"
+)
+
+// 中文说明：损坏条目的自愈修复（tables.content_store.audit.repair）。
+//
+// - 未开启修复时，挑战发现的损坏条目直接从 CS 删除。
+// - 开启后，损坏条目先保留在 CS 中并标记为“修复中”（不再用于响应 Interest），由管理线程发出修复 Interest：
+//   LpPacket 携带 CachePolicy=NoCache，转发线程不会用本地 CS 响应；配置了 repair.source 时作为 ForwardingHint 携带，
+//   否则按 FIB 转发。
+// - 同名 Data 到达时，只有其标签与检测时 CSNAT 中的期望标签一致才替换缓存条目；不一致的 Data 不会进入 CS。
+// - repair.timeout 内未修复的条目被删除（与未开启修复时相同）。
+// - 修复 Interest 与被修复条目属于同一个名字，因此总是由检测到损坏的同一个转发线程处理。
+
+// CsAuditRepairReq 是转发线程提交给管理线程的修复请求。
+type CsAuditRepairReq struct {
+	Name enc.Name
+	Time time.Time
+}
+
+// CsAuditRepairs 是修复请求通道（由管理线程消费）。
+// 转发线程不阻塞：通道满时放弃修复，直接删除损坏条目。
+var CsAuditRepairs = make(chan CsAuditRepairReq, 256)
+
+// csAuditRepair 是一个正在修复的缓存条目的状态。
+type csAuditRepair struct {
+	// expected 为检测时 CSNAT 中的期望标签。
+	expected []byte
+	deadline time.Time
+}
+
+// CfgCsAuditRepairEnabled 返回是否开启损坏条目修复。
+func CfgCsAuditRepairEnabled() bool {
+	return core.C.Tables.ContentStore.Audit.Repair.Enabled
+}
+
+// CfgCsAuditRepairSource 返回修复 Interest 的 ForwardingHint；未配置则返回 nil（按 FIB 转发）。
+func CfgCsAuditRepairSource() enc.Name {
+	return csAuditCfg.repairSource
+}
+
+// CfgCsAuditRepairTimeout 返回修复 Interest 的生存期（也是修复超时）。
+func CfgCsAuditRepairTimeout() time.Duration {
+	return time.Duration(core.C.Tables.ContentStore.Audit.Repair.Timeout) * time.Millisecond
+}
+
+// handleCsAuditMismatch 处理挑战中发现的损坏条目：记录后发起修复，或直接删除。
+func (p *PitCsTree) handleCsAuditMismatch(index uint64, now time.Time) {
+	entry, ok := p.csMap[index]
+	if !ok || entry.repair != nil {
+		// 已在修复中的条目不重复记录
+		return
+	}
+
+	p.recordCsAuditMismatch(entry, now)
+	if CfgCsAuditRepairEnabled() && p.startCsAuditRepair(entry, now) {
+		return
+	}
+	csAuditCounters.corruptEvicted.Add(1)
+	p.eraseCsDataFromReplacementStrategy(index)
+}
+
+// startCsAuditRepair 为损坏条目发起修复；CSNAT 中没有期望标签或请求通道已满时返回 false。
+func (p *PitCsTree) startCsAuditRepair(entry *nameTreeCsEntry, now time.Time) bool {
+	expected, ok := GetCsNatSha256Leaf(entry.node.name)
+	if !ok {
+		return false
+	}
+
+	select {
+	case CsAuditRepairs <- CsAuditRepairReq{Name: entry.node.name.Clone(), Time: now}:
+	default:
+		return false
+	}
+
+	entry.repair = &csAuditRepair{
+		expected: expected,
+		deadline: now.Add(CfgCsAuditRepairTimeout()),
+	}
+	p.csAuditRepairing[entry.index] = entry
+	csAuditCounters.repairs.Add(1)
+
+	if CfgCsAuditLogEnabled() {
+		core.Log.Info(nil, "【审计】发起修复", "name", entry.node.name, "source", CfgCsAuditRepairSource())
+	}
+	return true
+}
+
+// acceptCsAuditRepair 检查到达的 Data 能否替换正在修复的条目。
+// 标签与期望标签一致时结束修复并返回 true；否则返回 false，调用方不应写入 CS。
+func (p *PitCsTree) acceptCsAuditRepair(entry *nameTreeCsEntry, wire []byte) bool {
+	name := entry.node.name
+	computed, valid := csAuditProve(CsAuditScheme(), name, wire)
+	if !valid || !bytes.Equal(computed, entry.repair.expected) {
+		csAuditCounters.repairsRejected.Add(1)
+		if CfgCsAuditLogEnabled() {
+			core.Log.Warn(nil, "【审计】修复 Data 与期望标签不一致，未替换", "name", name)
+		}
+		return false
+	}
+
+	entry.repair = nil
+	delete(p.csAuditRepairing, entry.index)
+	csAuditCounters.repairsSucceeded.Add(1)
+	recordCsAuditHistory(CsAuditHistoryEntry{
+		Time:    time.Now(),
+		Event:   CsAuditHistoryRepair,
+		Name:    name.Clone(),
+		Checked: 1,
+	})
+	if CfgCsAuditLogEnabled() {
+		core.Log.Info(nil, "【审计】修复完成", "name", name)
+	}
+	return true
+}
+
+// expireCsAuditRepairs 删除超时仍未修复的条目。
+func (p *PitCsTree) expireCsAuditRepairs(now time.Time) {
+	for index, entry := range p.csAuditRepairing {
+		if now.Before(entry.repair.deadline) {
+			continue
+		}
+
+		csAuditCounters.repairsTimedOut.Add(1)
+		csAuditCounters.corruptEvicted.Add(1)
+		recordCsAuditHistory(CsAuditHistoryEntry{
+			Time:       now,
+			Event:      CsAuditHistoryRepairFailed,
+			Name:       entry.node.name.Clone(),
+			Checked:    1,
+			Mismatched: 1,
+		})
+		if CfgCsAuditLogEnabled() {
+			core.Log.Warn(nil, "【审计】修复超时，删除损坏条目", "name", entry.node.name)
+		}
+		p.eraseCsDataFromReplacementStrategy(index)
+	}
+}
@@ -0,0 +1,429 @@
+package table
+
+import (
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+)
+
+// 中文说明：抽样挑战（概率审计）。
+//
+// - 全表挑战（k=0）会在每个周期重算所有 CS 条目的标签，转发线程上的开销随缓存大小线性增长。
+// - 抽样挑战每轮只从 CS 中选出 k 个叶子（或 k 个前缀子树），选择由审计者提供的 32 字节种子确定：
+//   用 ChaCha8 在 csIndexes 上按位置抽取（Floyd 算法），因此不知道种子就无法预测哪些条目会被挑战；
+//   抽样开销为 O(k)，不会在单个 tick 中排序或遍历整个 CS。
+// - 无论哪种模式，每次 Update() 最多处理 batch 个条目，把一轮挑战分摊到多个 tick 上，限制单个 tick 的延迟。
+// - 若 CS 中有 m 个损坏条目，每轮抽 k/n 时至少发现一个的概率为 1 - C(n-m, k)/C(n, k)（见 CsAuditDetectProb）。
+
+const (
+	CsAuditSampleLeaf   = "leaf"
+	CsAuditSamplePrefix = "prefix"
+)
+
+// csAuditPrefixDraws 限制前缀模式下每个前缀的最大抽取次数，避免 k 大于前缀总数时反复抽到已选前缀。
+const csAuditPrefixDraws = 4
+
+// csAuditRoundReq 是一轮（可能是抽样的）挑战请求。
+// Reply 非空时，转发线程在本轮结束（或因 overrun 跳过本轮）后把结果发送到 Reply。
+type csAuditRoundReq struct {
+	Seed  [32]byte
+	K     int
+	Mode  string
+	Depth int
+	Time  time.Time
+	Reply chan<- CsAuditRoundResult
+}
+
+// CsAuditRoundResult 是一轮挑战的结果（所有转发线程合计）。
+type CsAuditRoundResult struct {
+	// NThreads 为收到请求的转发线程数；NFinished 为已完成本轮的线程数；
+	// NOverruns 为因上一轮尚未完成而跳过本轮的线程数。
+	NThreads  uint64
+	NFinished uint64
+	NOverruns uint64
+
+	// Total 为本轮开始时 CS 的条目数；Sampled 为抽中的条目数；
+	// Checked 为实际校验的条目数；Mismatched 为发现的损坏条目数。
+	Total      uint64
+	Sampled    uint64
+	Checked    uint64
+	Mismatched uint64
+}
+
+// add 把一个转发线程的结果累加到 r。
+func (r *CsAuditRoundResult) add(o CsAuditRoundResult) {
+	r.NFinished += o.NFinished
+	r.NOverruns += o.NOverruns
+	r.Total += o.Total
+	r.Sampled += o.Sampled
+	r.Checked += o.Checked
+	r.Mismatched += o.Mismatched
+}
+
+// csAuditRound 是转发线程上正在进行的一轮挑战。
+type csAuditRound struct {
+	req     csAuditRoundReq
+	batch   int
+	pending []uint64
+	pos     int
+
+	nTotal      int
+	nSampled    int
+	nChecked    int
+	nMismatched int
+}
+
+// CsAuditSampleStats 是抽样挑战的统计信息（所有转发线程合计）。
+type CsAuditSampleStats struct {
+	// Rounds 为已完成的挑战轮数（每个转发线程各计一次）。
+	Rounds uint64
+	// Overruns 为因上一轮尚未完成而被丢弃的挑战请求数。
+	Overruns uint64
+	// Checked 为累计校验的条目数。
+	Checked uint64
+	// Mismatched 为累计发现的损坏条目数。
+	Mismatched uint64
+
+	// LastTotal 为最近一轮开始时 CS 的条目数。
+	LastTotal uint64
+	// LastSampled 为最近一轮抽中的条目数。
+	LastSampled uint64
+	// LastMismatched 为最近一轮发现的损坏条目数。
+	LastMismatched uint64
+	// LastTime 为最近一轮的挑战时间。
+	LastTime time.Time
+}
+
+var csAuditSampleStatsMutex sync.Mutex
+var csAuditSampleStats CsAuditSampleStats
+
+var csAuditRoundMutex sync.Mutex
+var csAuditRoundChs []chan csAuditRoundReq
+
+// CfgCsAuditSampleK 返回每轮每个转发线程抽样的条目（或前缀）数；0 表示全表挑战。
+func CfgCsAuditSampleK() int {
+	return core.C.Tables.ContentStore.Audit.SampleK
+}
+
+// CfgCsAuditSampleMode 返回抽样单位：leaf 或 prefix。
+func CfgCsAuditSampleMode() string {
+	return core.C.Tables.ContentStore.Audit.SampleMode
+}
+
+// CfgCsAuditSampleDepth 返回 prefix 抽样模式下的前缀长度（组件数）。
+func CfgCsAuditSampleDepth() int {
+	return core.C.Tables.ContentStore.Audit.SampleDepth
+}
+
+// CfgCsAuditBatch 返回每次 Update() 最多校验的条目数。
+func CfgCsAuditBatch() int {
+	return core.C.Tables.ContentStore.Audit.Batch
+}
+
+// registerCsAuditRoundCh 为一个转发线程的 PIT-CS 注册挑战轮请求通道。
+func registerCsAuditRoundCh() chan csAuditRoundReq {
+	ch := make(chan csAuditRoundReq, 1)
+	csAuditRoundMutex.Lock()
+	defer csAuditRoundMutex.Unlock()
+	csAuditRoundChs = append(csAuditRoundChs, ch)
+	return ch
+}
+
+// RequestCsAuditRound 向所有转发线程广播一轮挑战，返回成功入队的线程数。
+//
+// 中文说明：
+// - seed 由审计者提供（例如定时挑战器每轮用 crypto/rand 生成），决定抽中哪些条目。
+// - k=0 表示全表挑战；mode/depth 见 CfgCsAuditSampleMode/CfgCsAuditSampleDepth。
+// - 若某个线程的上一轮请求尚未被取走，本次请求对该线程计为 overrun。
+func RequestCsAuditRound(seed [32]byte, k int, mode string, depth int) int {
+	req := csAuditRoundReq{Seed: seed, K: k, Mode: mode, Depth: depth, Time: time.Now()}
+	return sendCsAuditRound(csAuditRoundThreads(), req)
+}
+
+// RunCsAuditRound 向所有转发线程广播一轮挑战，并等待各线程的结果（最多 timeout）。
+//
+// 中文说明：审计者据此得到“用自己的种子”发起的那一轮的结果；
+// 超时前未完成的线程不计入 NFinished，其结果仍会记入抽样统计（GetCsAuditSampleStats）。
+func RunCsAuditRound(seed [32]byte, k int, mode string, depth int, timeout time.Duration) CsAuditRoundResult {
+	chs := csAuditRoundThreads()
+	reply := make(chan CsAuditRoundResult, len(chs))
+	req := csAuditRoundReq{Seed: seed, K: k, Mode: mode, Depth: depth, Time: time.Now(), Reply: reply}
+	queued := sendCsAuditRound(chs, req)
+	res := CsAuditRoundResult{NThreads: uint64(len(chs)), NOverruns: uint64(len(chs) - queued)}
+
+	deadline := time.After(timeout)
+	for i := 0; i < queued; i++ {
+		select {
+		case r := <-reply:
+			res.add(r)
+		case <-deadline:
+			return res
+		}
+	}
+	return res
+}
+
+// csAuditRoundThreads 返回所有转发线程的挑战轮请求通道。
+func csAuditRoundThreads() []chan csAuditRoundReq {
+	csAuditRoundMutex.Lock()
+	defer csAuditRoundMutex.Unlock()
+	return csAuditRoundChs
+}
+
+// sendCsAuditRound 把挑战请求发给各转发线程，返回成功入队的线程数。
+func sendCsAuditRound(chs []chan csAuditRoundReq, req csAuditRoundReq) (queued int) {
+	for _, ch := range chs {
+		select {
+		case ch <- req:
+			queued++
+		default:
+			addCsAuditOverrun()
+		}
+	}
+	return queued
+}
+
+// replyCsAuditRound 把一个转发线程的结果发送给发起者（若有）。
+func replyCsAuditRound(req csAuditRoundReq, res CsAuditRoundResult) {
+	if req.Reply == nil {
+		return
+	}
+	select {
+	case req.Reply <- res:
+	default: // 通道按线程数分配，不会阻塞
+	}
+}
+
+// GetCsAuditSampleStats 返回抽样挑战的统计信息快照。
+func GetCsAuditSampleStats() CsAuditSampleStats {
+	csAuditSampleStatsMutex.Lock()
+	defer csAuditSampleStatsMutex.Unlock()
+	return csAuditSampleStats
+}
+
+// CsAuditDetectProb 返回从 n 个条目中无放回抽取 k 个时，至少抽中 m 个损坏条目之一的概率。
+func CsAuditDetectProb(n, k, m uint64) float64 {
+	if m == 0 || k == 0 || n == 0 {
+		return 0
+	}
+	if k+m > n {
+		return 1
+	}
+	// P(miss) = C(n-m, k) / C(n, k) = prod_{i<k} (n-m-i)/(n-i)
+	miss := 1.0
+	for i := uint64(0); i < k; i++ {
+		miss *= float64(n-m-i) / float64(n-i)
+	}
+	return 1 - miss
+}
+
+func addCsAuditOverrun() {
+	csAuditSampleStatsMutex.Lock()
+	defer csAuditSampleStatsMutex.Unlock()
+	csAuditSampleStats.Overruns++
+}
+
+func (p *PitCsTree) popCsAuditRoundReq() (csAuditRoundReq, bool) {
+	select {
+	case req := <-p.csAuditRoundCh:
+		return req, true
+	default:
+		return csAuditRoundReq{}, false
+	}
+}
+
+// removeCsIndex 以 O(1) 从 csIndexes 中移除指定位置的 index（用最后一个元素填补空位）。
+func (p *PitCsTree) removeCsIndex(pos int) {
+	last := len(p.csIndexes) - 1
+	if pos != last {
+		moved := p.csIndexes[last]
+		p.csIndexes[pos] = moved
+		p.csMap[moved].auditPos = pos
+	}
+	p.csIndexes = p.csIndexes[:last]
+}
+
+// csAuditSample 根据种子从当前 CS 中选出本轮待挑战的条目 index（要求 req.K > 0）。
+//
+// 中文说明：开销只与 k（及抽中前缀下的条目数）有关，与 CS 大小无关。
+func (p *PitCsTree) csAuditSample(req csAuditRoundReq) []uint64 {
+	n := len(p.csIndexes)
+	rng := rand.New(rand.NewChaCha8(req.Seed))
+
+	if req.Mode != CsAuditSamplePrefix {
+		// Floyd 算法：无放回地抽取 k 个位置
+		k := min(req.K, n)
+		picked := make(map[int]struct{}, k)
+		sample := make([]uint64, 0, k)
+		for j := n - k; j < n; j++ {
+			pos := rng.IntN(j + 1)
+			if _, ok := picked[pos]; ok {
+				pos = j
+			}
+			picked[pos] = struct{}{}
+			sample = append(sample, p.csIndexes[pos])
+		}
+		return sample
+	}
+
+	// 前缀模式：随机抽取条目，取其前 depth 个组件对应的子树，抽中的前缀下所有条目都要挑战。
+	// 前缀被抽中的概率与其下的条目数成正比；最多抽取 csAuditPrefixDraws*k 次。
+	seen := make(map[*pitCsTreeNode]struct{}, req.K)
+	var sample []uint64
+	for draws := 0; n > 0 && len(seen) < req.K && draws < csAuditPrefixDraws*req.K; draws++ {
+		node := p.csMap[p.csIndexes[rng.IntN(n)]].node
+		if node.depth < req.Depth {
+			// 名字短于 depth 的条目单独成组
+			if _, ok := seen[node]; !ok {
+				seen[node] = struct{}{}
+				sample = append(sample, node.csEntry.index)
+			}
+			continue
+		}
+		for node.depth > req.Depth {
+			node = node.parent
+		}
+		if _, ok := seen[node]; !ok {
+			seen[node] = struct{}{}
+			sample = node.appendCsIndexes(sample)
+		}
+	}
+	return sample
+}
+
+// appendCsIndexes 把子树中所有 CS 条目的 index 追加到 indexes。
+func (n *pitCsTreeNode) appendCsIndexes(indexes []uint64) []uint64 {
+	if n.csEntry != nil {
+		indexes = append(indexes, n.csEntry.index)
+	}
+	for _, child := range n.children {
+		indexes = child.appendCsIndexes(indexes)
+	}
+	return indexes
+}
+
+// startCsAuditRound 在转发线程上开始一轮挑战（只做抽样，不做标签计算）。
+func (p *PitCsTree) startCsAuditRound(req csAuditRoundReq) {
+	r := &csAuditRound{
+		req:    req,
+		batch:  CfgCsAuditBatch(),
+		nTotal: len(p.csIndexes),
+	}
+	if req.K > 0 {
+		r.pending = p.csAuditSample(req)
+		r.nSampled = len(r.pending)
+	}
+	p.csAuditRound = r
+}
+
+// stepCsAuditRound 处理当前挑战轮中最多 batch 个条目；全部处理完后结束本轮。
+//
+// 中文说明：全表挑战不做快照，而是按位置分批遍历 csIndexes，只遍历本轮开始时的条目数；
+// 本轮中被淘汰的条目会由最后一个条目填补位置，被移到已遍历位置的条目留到下一轮校验。
+func (p *PitCsTree) stepCsAuditRound() {
+	r := p.csAuditRound
+	var batch []uint64
+	var done bool
+	if r.req.K > 0 {
+		end := min(r.pos+r.batch, len(r.pending))
+		batch = r.pending[r.pos:end]
+		r.pos = end
+		done = r.pos >= len(r.pending)
+	} else {
+		total := min(r.nTotal, len(p.csIndexes))
+		end := min(r.pos+r.batch, total)
+		if r.pos < end {
+			batch = slices.Clone(p.csIndexes[r.pos:end])
+		}
+		r.pos = end
+		r.nSampled += len(batch)
+		done = r.pos >= total
+	}
+
+	// 注意：不要在遍历时删除；先收集需要删除的 index。
+	var toErase []uint64
+	for _, index := range batch {
+		entry, ok := p.csMap[index]
+		if !ok {
+			// 本轮开始后已被淘汰
+			continue
+		}
+		r.nChecked++
+		// 若标签校验失败或与 CSNAT 中记录的叶子标签不一致，认为该缓存条目已损坏，标记删除。
+		if _, ok := p.csAuditProveEntry(entry, r.req.Time); !ok {
+			toErase = append(toErase, index)
+		}
+	}
+
+	for _, index := range toErase {
+		p.handleCsAuditMismatch(index, r.req.Time)
+	}
+	r.nMismatched += len(toErase)
+
+	if done {
+		p.finishCsAuditRound()
+	}
+}
+
+// finishCsAuditRound 结束当前挑战轮并更新统计信息。
+func (p *PitCsTree) finishCsAuditRound() {
+	r := p.csAuditRound
+	p.csAuditRound = nil
+
+	csAuditSampleStatsMutex.Lock()
+	csAuditSampleStats.Rounds++
+	csAuditSampleStats.Checked += uint64(r.nChecked)
+	csAuditSampleStats.Mismatched += uint64(r.nMismatched)
+	csAuditSampleStats.LastTotal = uint64(r.nTotal)
+	csAuditSampleStats.LastSampled = uint64(r.nSampled)
+	csAuditSampleStats.LastMismatched = uint64(r.nMismatched)
+	csAuditSampleStats.LastTime = r.req.Time
+	csAuditSampleStatsMutex.Unlock()
+
+	replyCsAuditRound(r.req, CsAuditRoundResult{
+		NFinished:  1,
+		Total:      uint64(r.nTotal),
+		Sampled:    uint64(r.nSampled),
+		Checked:    uint64(r.nChecked),
+		Mismatched: uint64(r.nMismatched),
+	})
+
+	// 空轮（CS 为空）不记入历史，避免淹没有意义的记录
+	if r.nChecked > 0 {
+		recordCsAuditHistory(CsAuditHistoryEntry{
+			Time:       r.req.Time,
+			Event:      CsAuditHistoryRound,
+			Checked:    uint64(r.nChecked),
+			Mismatched: uint64(r.nMismatched),
+		})
+	}
+
+	if CfgCsAuditLogEnabled() {
+		nodeCount, activeLeafCount, rootAgg := GetCsNatSha256Stats()
+		core.Log.Info(nil, "【审计】挑战完成",
+			"nEntries", r.nTotal,
+			"nSampled", r.nSampled,
+			"nChecked", r.nChecked,
+			"nMismatched", r.nMismatched,
+			"detectProb1", CsAuditDetectProb(uint64(r.nTotal), uint64(r.nSampled), 1),
+			"csnatNodes", nodeCount,
+			"csnatLeaves", activeLeafCount,
+			"csnatRootAgg", rootAgg,
+			"time", r.req.Time.Format(time.RFC3339Nano),
+		)
+	}
+}
+
+// recordCsAuditMismatch 记录一个挑战中发现的损坏条目（在删除或修复前调用）。
+func (p *PitCsTree) recordCsAuditMismatch(entry *nameTreeCsEntry, now time.Time) {
+	recordCsAuditHistory(CsAuditHistoryEntry{
+		Time:       now,
+		Event:      CsAuditHistoryMismatch,
+		Name:       entry.node.name.Clone(),
+		Checked:    1,
+		Mismatched: 1,
+	})
+}
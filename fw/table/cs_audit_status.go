@@ -0,0 +1,100 @@
+package table
+
+import "sync/atomic"
+
+// 中文说明：审计运行状态计数器（/localhost/nfd/cs-audit/status 数据集）。
+// 计数器分布在转发线程、审计者与验证器 goroutine 中更新，全部使用原子操作，不需要加锁。
+
+// CsAuditStatus 是审计子系统的运行状态快照。
+type CsAuditStatus struct {
+	// Scheme 为当前审计标签方案。
+	Scheme string
+	// NCsNatNodes / NCsNatLeaves 为 CSNAT 的节点数与有效叶子数。
+	NCsNatNodes  uint64
+	NCsNatLeaves uint64
+	// NRounds / NRoundOverruns 为已完成与被丢弃的定时/抽样挑战轮数（每个转发线程各计一次）。
+	NRounds        uint64
+	NRoundOverruns uint64
+	// NRemoteChallenges 为已完成的远程挑战次数。
+	NRemoteChallenges uint64
+	// NChecked 为挑战中校验的缓存条目总数（定时/抽样挑战与远程挑战合计）。
+	NChecked uint64
+	// NProofsVerified / NMismatches / NUnknownProofs 为验证器对证明的判定结果：
+	// 与 CSNAT 一致、不一致、CSNAT 中没有对应叶子。
+	NProofsVerified uint64
+	NMismatches     uint64
+	NUnknownProofs  uint64
+	// NCorruptEvicted 为因校验失败（或修复超时）而从 CS 删除的条目数。
+	NCorruptEvicted uint64
+	// NSeuInjections 为 SEU 注入器注入的故障数（stuck-at 重新生效也计一次）；NFlips 为 flip 调试接口翻转的比特数。
+	NSeuInjections uint64
+	NFlips         uint64
+	// NEventsDropped / NProofsDropped 为因通道已满被丢弃的 CsAuditEvents / CsSha256Proofs 数。
+	NEventsDropped uint64
+	NProofsDropped uint64
+	// NProducerTagsRejected 为插入 CS 时未通过校验（无可信公钥或签名无效）而未被采用的生产者标签数。
+	NProducerTagsRejected uint64
+	// NRepairs 为发起的修复次数；NRepairsSucceeded 为用重新取回的 Data 替换成功的次数；
+	// NRepairsRejected 为标签与 CSNAT 不一致而被拒绝的 Data 数；NRepairsTimedOut 为超时后删除的条目数。
+	NRepairs          uint64
+	NRepairsSucceeded uint64
+	NRepairsRejected  uint64
+	NRepairsTimedOut  uint64
+	// LastTotal / LastSampled / LastMismatched 为最近一轮挑战开始时的 CS 条目数、抽中的条目数与发现的损坏条目数；
+	// DetectProb 为最近一轮抽中单个损坏条目的概率（见 CsAuditDetectProb）。
+	LastTotal      uint64
+	LastSampled    uint64
+	LastMismatched uint64
+	DetectProb     float64
+}
+
+var csAuditCounters struct {
+	remoteChallenges atomic.Uint64
+	remoteChecked    atomic.Uint64
+	proofsVerified   atomic.Uint64
+	mismatches       atomic.Uint64
+	unknownProofs    atomic.Uint64
+	corruptEvicted   atomic.Uint64
+	seuInjections    atomic.Uint64
+	flips            atomic.Uint64
+	eventsDropped    atomic.Uint64
+	proofsDropped    atomic.Uint64
+	producerRejected atomic.Uint64
+	repairs          atomic.Uint64
+	repairsSucceeded atomic.Uint64
+	repairsRejected  atomic.Uint64
+	repairsTimedOut  atomic.Uint64
+}
+
+// GetCsAuditStatus 返回审计子系统的运行状态快照。
+func GetCsAuditStatus() CsAuditStatus {
+	nodeCount, leafCount, _ := GetCsNatSha256Stats()
+	sample := GetCsAuditSampleStats()
+	c := &csAuditCounters
+	return CsAuditStatus{
+		Scheme:                CsAuditScheme().String(),
+		NCsNatNodes:           nodeCount,
+		NCsNatLeaves:          leafCount,
+		NRounds:               sample.Rounds,
+		NRoundOverruns:        sample.Overruns,
+		NRemoteChallenges:     c.remoteChallenges.Load(),
+		NChecked:              sample.Checked + c.remoteChecked.Load(),
+		NProofsVerified:       c.proofsVerified.Load(),
+		NMismatches:           c.mismatches.Load(),
+		NUnknownProofs:        c.unknownProofs.Load(),
+		NCorruptEvicted:       c.corruptEvicted.Load(),
+		NSeuInjections:        c.seuInjections.Load(),
+		NFlips:                c.flips.Load(),
+		NEventsDropped:        c.eventsDropped.Load(),
+		NProofsDropped:        c.proofsDropped.Load(),
+		NProducerTagsRejected: c.producerRejected.Load(),
+		NRepairs:              c.repairs.Load(),
+		NRepairsSucceeded:     c.repairsSucceeded.Load(),
+		NRepairsRejected:      c.repairsRejected.Load(),
+		NRepairsTimedOut:      c.repairsTimedOut.Load(),
+		LastTotal:             sample.LastTotal,
+		LastSampled:           sample.LastSampled,
+		LastMismatched:        sample.LastMismatched,
+		DetectProb:            CsAuditDetectProb(sample.LastTotal, sample.LastSampled, 1),
+	}
+}
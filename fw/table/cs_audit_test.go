@@ -2,14 +2,37 @@ package table
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/named-data/ndnd/fw/core"
 	"github.com/named-data/ndnd/fw/defn"
 	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/ndn"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	"github.com/named-data/ndnd/std/object/storage"
+	sec "github.com/named-data/ndnd/std/security"
+	"github.com/named-data/ndnd/std/security/audit"
+	"github.com/named-data/ndnd/std/security/keychain"
+	sig "github.com/named-data/ndnd/std/security/signer"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// TestMain 按默认配置创建审计方案与 CSNAT（转发器中由 Initialize 完成）。
+func TestMain(m *testing.M) {
+	if err := loadCsAuditScheme(); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
 func drainCsAuditEvents() {
 	for {
 		select {
@@ -55,3 +78,830 @@ func TestCsAuditEventsOnInsertAndRefresh(t *testing.T) {
 	assert.Equal(t, data1.NameV.Hash(), ev2.Index)
 	assert.Equal(t, data1.NameV.String(), ev2.Name.String())
 }
+
+func TestCsNatTagAggHmac(t *testing.T) {
+	scheme, err := NewCsAuditTagScheme(CsAuditSchemeHmac, csAuditBlsKeyDefault)
+	assert.NoError(t, err)
+	tree := newCsNatSha256Tree(scheme)
+
+	nameA, _ := enc.NameFromStr("/a/x/1")
+	nameB, _ := enc.NameFromStr("/a/y/2")
+	tagA := scheme.Tag(nameA, []byte("A"))
+	tagB := scheme.Tag(nameB, []byte("B"))
+
+	tree.OnInsert(nameA, tagA, time.Time{})
+	tree.OnInsert(nameB, tagB, time.Time{})
+
+	prefix, _ := enc.NameFromStr("/a")
+	agg, ok := tree.GetTagAggByPrefix(prefix)
+	assert.True(t, ok)
+	expected, _ := scheme.Add(tagA, tagB)
+	assert.Equal(t, expected, agg)
+
+	// Erasing a leaf removes its contribution
+	assert.True(t, tree.OnErase(nameA))
+	agg, _ = tree.GetTagAggByPrefix(prefix)
+	assert.Equal(t, tagB, agg)
+
+	// Root aggregate returns to identity once empty
+	assert.True(t, tree.OnErase(nameB))
+	agg, ok = tree.GetTagAggByPrefix(enc.Name{})
+	assert.True(t, ok)
+	assert.Equal(t, scheme.Identity(), agg)
+}
+
+// 中文说明：祖先节点的聚合值无法更新时，路径上的聚合值与叶子标签都保持不变。
+func TestCsNatTagAggAtomic(t *testing.T) {
+	scheme, err := NewCsAuditTagScheme(CsAuditSchemeHmac, csAuditBlsKeyDefault)
+	require.NoError(t, err)
+	tree := newCsNatSha256Tree(scheme)
+
+	nameA, _ := enc.NameFromStr("/a/x/1")
+	nameB, _ := enc.NameFromStr("/a/y/2")
+	tagA := scheme.Tag(nameA, []byte("A"))
+	tree.OnInsert(nameA, tagA, time.Time{})
+
+	// 根节点的聚合值损坏，更新在 /a/y/2 与 /a 成功后于根节点失败
+	tree.root.tagAgg = []byte{0}
+	tree.OnInsert(nameB, scheme.Tag(nameB, []byte("B")), time.Time{})
+	prefix, _ := enc.NameFromStr("/a")
+	agg, _ := tree.GetTagAggByPrefix(prefix)
+	assert.Equal(t, tagA, agg)
+	agg, _ = tree.GetTagAggByPrefix(nameB)
+	assert.Equal(t, scheme.Identity(), agg)
+	assert.Nil(t, tree.findNodeLocked(nameB).leafTag)
+
+	tree.OnRefresh(nameA, scheme.Tag(nameA, []byte("A2")), time.Time{})
+	agg, _ = tree.GetTagAggByPrefix(prefix)
+	assert.Equal(t, tagA, agg)
+	assert.Equal(t, tagA, tree.findNodeLocked(nameA).leafTag)
+}
+
+func TestCsNatPrefixAggregates(t *testing.T) {
+	scheme, err := NewCsAuditTagScheme(CsAuditSchemeHmac, csAuditBlsKeyDefault)
+	require.NoError(t, err)
+
+	// Two nodes caching the same content in a different order give the same aggregates
+	names := []string{"/a/x/1", "/a/x/2", "/a/y/1", "/b/1"}
+	trees := []*CsNatSha256Tree{newCsNatSha256Tree(scheme), newCsNatSha256Tree(scheme)}
+	for i, tree := range trees {
+		for j := range names {
+			n, _ := enc.NameFromStr(names[(i+j)%len(names)])
+			tree.OnInsert(n, scheme.Tag(n, []byte(n.String())), time.Time{})
+		}
+	}
+
+	prefix, _ := enc.NameFromStr("/a")
+	aggs, ok := trees[0].GetPrefixAggregates(prefix, 1)
+	require.True(t, ok)
+	other, _ := trees[1].GetPrefixAggregates(prefix, 1)
+	assert.Equal(t, aggs, other)
+
+	require.Len(t, aggs, 3)
+	assert.Equal(t, "/a", aggs[0].Name.String())
+	assert.Equal(t, uint64(3), aggs[0].LeafCount)
+	assert.Equal(t, "/a/x", aggs[1].Name.String())
+	assert.Equal(t, uint64(2), aggs[1].LeafCount)
+	assert.Equal(t, "/a/y", aggs[2].Name.String())
+	assert.Equal(t, uint64(1), aggs[2].LeafCount)
+
+	agg, _ := trees[0].GetAggregatedTagByPrefix(prefix)
+	assert.Equal(t, agg, aggs[0].Agg)
+	tagAgg, _ := trees[0].GetTagAggByPrefix(prefix)
+	assert.Equal(t, tagAgg, aggs[0].TagAgg)
+
+	// Depth 0 returns only the prefix, deeper levels include the leaves
+	aggs, _ = trees[0].GetPrefixAggregates(prefix, 0)
+	assert.Len(t, aggs, 1)
+	aggs, _ = trees[0].GetPrefixAggregates(enc.Name{}, 8)
+	assert.Len(t, aggs, 9)
+	assert.Equal(t, uint64(4), aggs[0].LeafCount)
+	cached := []string{}
+	for _, a := range aggs {
+		if a.Cached > 0 {
+			cached = append(cached, a.Name.String())
+		}
+	}
+	assert.Equal(t, names, cached)
+
+	// A diverging cache shows up in the aggregates of its prefixes only
+	n, _ := enc.NameFromStr("/a/y/1")
+	trees[1].OnRefresh(n, scheme.Tag(n, []byte("corrupted")), time.Time{})
+	aggs, _ = trees[0].GetPrefixAggregates(prefix, 1)
+	other, _ = trees[1].GetPrefixAggregates(prefix, 1)
+	assert.NotEqual(t, aggs[0].Agg, other[0].Agg)
+	assert.Equal(t, aggs[1], other[1])
+	assert.NotEqual(t, aggs[2].Agg, other[2].Agg)
+
+	_, ok = trees[0].GetPrefixAggregates(enc.Name{enc.NewGenericComponent("c")}, 1)
+	assert.False(t, ok)
+}
+
+func TestCsNatTagAggBls(t *testing.T) {
+	scheme, err := NewCsAuditTagScheme(CsAuditSchemeBls, csAuditBlsKeyDefault)
+	assert.NoError(t, err)
+	tree := newCsNatSha256Tree(scheme)
+
+	pk, err := audit.ParseBlsPublicKey(scheme.PublicKey())
+	assert.NoError(t, err)
+
+	nameA, _ := enc.NameFromStr("/a/x/1")
+	nameB, _ := enc.NameFromStr("/a/y/2")
+	tree.OnInsert(nameA, scheme.Tag(nameA, []byte("A")), time.Time{})
+	tree.OnInsert(nameB, scheme.Tag(nameB, []byte("B")), time.Time{})
+
+	// Refresh with new content replaces the old contribution
+	tree.OnRefresh(nameB, scheme.Tag(nameB, []byte("B2")), time.Time{})
+
+	// A third-party auditor only needs the public key and the digests
+	prefix, _ := enc.NameFromStr("/a")
+	agg, ok := tree.GetTagAggByPrefix(prefix)
+	assert.True(t, ok)
+	digests := [][32]byte{
+		audit.TagDigest(nameA, []byte("A")),
+		audit.TagDigest(nameB, []byte("B2")),
+	}
+	assert.True(t, audit.BlsVerifyAggregate([]*audit.BlsPublicKey{pk, pk}, digests, agg))
+
+	// Stale content must not verify
+	digests[1] = audit.TagDigest(nameB, []byte("B"))
+	assert.False(t, audit.BlsVerifyAggregate([]*audit.BlsPublicKey{pk, pk}, digests, agg))
+}
+
+func TestCsAuditProducerTag(t *testing.T) {
+	scheme, err := NewCsAuditTagScheme(CsAuditSchemeBls, csAuditBlsKeyDefault)
+	assert.NoError(t, err)
+
+	// Producer uses its own key, trusted for its KeyLocator prefix
+	sk, err := audit.BlsKeygen()
+	assert.NoError(t, err)
+	prefix, _ := enc.NameFromStr("/producer")
+	oldKeys := csAuditProducerKeys
+	defer func() { csAuditProducerKeys = oldKeys }()
+	csAuditProducerKeys = []csAuditProducerKey{{prefix: prefix, pub: sk.Public().Bytes()}}
+
+	makeData := func(keyName string) []byte {
+		name, _ := enc.NameFromStr("/producer/obj/seg=0")
+		key, _ := enc.NameFromStr(keyName)
+		signer, err := sig.KeygenEd25519(key)
+		assert.NoError(t, err)
+		data, err := spec.Spec{}.MakeData(name, &ndn.DataConfig{},
+			enc.Wire{[]byte("payload")}, signer)
+		assert.NoError(t, err)
+		return data.Wire.Join()
+	}
+	name, _ := enc.NameFromStr("/producer/obj/seg=0")
+	plain := makeData("/producer/KEY/1")
+	wire, err := audit.NewTagger(sk).Attach(name, plain)
+	assert.NoError(t, err)
+
+	// The producer tag is recorded instead of a locally computed one
+	rejected := csAuditCounters.producerRejected.Load()
+	_, ptag, err := audit.SplitTag(wire)
+	assert.NoError(t, err)
+	leaf := csAuditLeafTag(scheme, name, wire)
+	assert.Equal(t, ptag.TagValue, leaf)
+	assert.NotEqual(t, scheme.Tag(name, wire), leaf)
+	assert.Equal(t, rejected, csAuditCounters.producerRejected.Load())
+
+	proof, valid := csAuditProve(scheme, name, wire)
+	assert.True(t, valid)
+	assert.Equal(t, leaf, proof)
+
+	// Corrupted content no longer verifies against the producer key
+	bad := bytes.Clone(wire)
+	bad[bytes.Index(bad, []byte("payload"))] ^= 0x01
+	proof, valid = csAuditProve(scheme, name, bad)
+	assert.False(t, valid)
+	assert.Equal(t, leaf, proof)
+
+	// A cache re-tagging corrupted content with its own key (and embedding
+	// that key in the tag) is rejected on insert and detected when challenged
+	badPlain, _, err := audit.SplitTag(bad)
+	assert.NoError(t, err)
+	evil, err := audit.BlsKeygen()
+	assert.NoError(t, err)
+	forged, err := audit.AttachTag(badPlain, &spec.AuditTag{
+		TagType:   audit.TagTypeBls12381,
+		TagValue:  evil.Sign(audit.TagDigest(name, badPlain)),
+		PublicKey: evil.Public().Bytes(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, scheme.Tag(name, forged), csAuditLeafTag(scheme, name, forged))
+	assert.Equal(t, rejected+1, csAuditCounters.producerRejected.Load())
+	_, valid = csAuditProve(scheme, name, forged)
+	assert.False(t, valid)
+
+	// Tags of producers without a trusted key are replaced by the node tag
+	other, err := audit.NewTagger(evil).Attach(name, makeData("/other/KEY/1"))
+	assert.NoError(t, err)
+	assert.Equal(t, scheme.Tag(name, other), csAuditLeafTag(scheme, name, other))
+	assert.Equal(t, rejected+2, csAuditCounters.producerRejected.Load())
+	proof, valid = csAuditProve(scheme, name, other)
+	assert.True(t, valid)
+	assert.Equal(t, scheme.Tag(name, other), proof)
+
+	// Untagged Data falls back to the node tag
+	assert.Equal(t, scheme.Tag(name, plain), csAuditLeafTag(scheme, name, plain))
+}
+
+func TestCsAuditProducerKeysFromConfig(t *testing.T) {
+	cfg := &core.C.Tables.ContentStore.Audit
+	old := cfg.ProducerKeys
+	defer func() { cfg.ProducerKeys = old }()
+
+	sk, err := audit.BlsKeygen()
+	require.NoError(t, err)
+	cfg.ProducerKeys = append(cfg.ProducerKeys[:0:0], old...)
+	cfg.ProducerKeys = append(cfg.ProducerKeys, struct {
+		Prefix    string `json:"prefix"`
+		PublicKey string `json:"public_key"`
+	}{Prefix: "/producer", PublicKey: fmt.Sprintf("%x", sk.Public().Bytes())})
+
+	keys, err := cfgCsAuditProducerKeys()
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, "/producer", keys[0].prefix.String())
+	assert.Equal(t, sk.Public().Bytes(), keys[0].pub)
+
+	// Keys that are not valid BLS public keys are rejected
+	cfg.ProducerKeys[0].PublicKey = "00112233"
+	_, err = cfgCsAuditProducerKeys()
+	assert.Error(t, err)
+}
+
+func TestCsAuditChallengeReq(t *testing.T) {
+	setReplacementPolicy("lru")
+	CfgSetCsCapacity(1024)
+
+	pitCS := NewPitCS(func(PitEntry) {})
+	for _, wire := range [][]byte{VALID_DATA_1, VALID_DATA_2} {
+		pkt, _ := defn.ParseFwPacket(enc.NewBufferView(wire), false)
+		pitCS.InsertData(pkt.Data, wire)
+	}
+	drainCsAuditEvents()
+
+	scheme := CsAuditScheme()
+	nonce := []byte("challenge-nonce-1")
+	expected := scheme.Identity()
+	plain := scheme.Identity()
+	for _, entry := range pitCS.csMap {
+		tag := scheme.Tag(entry.node.name, entry.wire)
+		coeff := audit.ChallengeCoefficient(nonce, audit.TagDigest(entry.node.name, entry.wire))
+		scaled, err := scheme.Scale(tag, coeff)
+		require.NoError(t, err)
+		expected, err = scheme.Add(expected, scaled)
+		require.NoError(t, err)
+		plain, err = scheme.Add(plain, tag)
+		require.NoError(t, err)
+	}
+
+	// Challenge on the root covers every cached entry
+	reply := make(chan CsAuditChallengeResult, 1)
+	pitCS.handleCsAuditChallengeReq(csAuditChallengeReq{Prefix: enc.Name{}, Nonce: nonce, Time: time.Now(), Reply: reply})
+	res := <-reply
+	assert.Equal(t, uint64(2), res.LeafCount)
+	assert.Equal(t, uint64(0), res.InvalidCount)
+	assert.Equal(t, expected, res.Aggregate)
+
+	// The aggregate depends on the nonce, so a plain sum of stored tags cannot answer it
+	assert.NotEqual(t, plain, res.Aggregate)
+	pitCS.handleCsAuditChallengeReq(csAuditChallengeReq{Prefix: enc.Name{}, Nonce: []byte("challenge-nonce-2"), Time: time.Now(), Reply: reply})
+	assert.NotEqual(t, expected, (<-reply).Aggregate)
+
+	// Unknown prefix yields an empty proof
+	unknown, _ := enc.NameFromStr("/does/not/exist")
+	pitCS.handleCsAuditChallengeReq(csAuditChallengeReq{Prefix: unknown, Nonce: nonce, Time: time.Now(), Reply: reply})
+	res = <-reply
+	assert.Equal(t, uint64(0), res.LeafCount)
+	assert.Equal(t, scheme.Identity(), res.Aggregate)
+}
+
+func makeCsAuditTestData(t *testing.T, name string) []byte {
+	n, err := enc.NameFromStr(name)
+	assert.NoError(t, err)
+	data, err := spec.Spec{}.MakeData(n, &ndn.DataConfig{},
+		enc.Wire{[]byte(name)}, sig.NewSha256Signer())
+	assert.NoError(t, err)
+	return data.Wire.Join()
+}
+
+func TestCsAuditSampledRound(t *testing.T) {
+	setReplacementPolicy("lru")
+	CfgSetCsCapacity(1024)
+
+	pitCS := NewPitCS(func(PitEntry) {})
+	for i := 0; i < 40; i++ {
+		wire := makeCsAuditTestData(t, fmt.Sprintf("/sample/p%d/obj%d", i%4, i))
+		pkt, _ := defn.ParseFwPacket(enc.NewBufferView(wire), false)
+		pitCS.InsertData(pkt.Data, wire)
+	}
+	drainCsAuditEvents()
+
+	// Same seed gives the same subset, different seed a different one
+	req := csAuditRoundReq{Seed: [32]byte{1}, K: 5, Mode: CsAuditSampleLeaf}
+	s1 := pitCS.csAuditSample(req)
+	assert.Len(t, s1, 5)
+	assert.Equal(t, s1, pitCS.csAuditSample(req))
+	req2 := req
+	req2.Seed = [32]byte{2}
+	assert.NotEqual(t, s1, pitCS.csAuditSample(req2))
+
+	// k>n is capped
+	assert.ElementsMatch(t, pitCS.csIndexes, pitCS.csAuditSample(csAuditRoundReq{K: 100}))
+
+	// Prefix mode picks whole subtrees
+	prefixes := pitCS.csAuditSample(csAuditRoundReq{Seed: [32]byte{3}, K: 2, Mode: CsAuditSamplePrefix, Depth: 2})
+	assert.Len(t, prefixes, 20)
+
+	// Work is spread over ticks in batches
+	before := GetCsAuditSampleStats()
+	reply := make(chan CsAuditRoundResult, 1)
+	pitCS.startCsAuditRound(csAuditRoundReq{Seed: [32]byte{4}, K: 10, Time: time.Now(), Reply: reply})
+	pitCS.csAuditRound.batch = 4
+	pitCS.stepCsAuditRound()
+	assert.NotNil(t, pitCS.csAuditRound)
+	assert.Equal(t, 4, pitCS.csAuditRound.nChecked)
+	assert.Len(t, reply, 0)
+	pitCS.stepCsAuditRound()
+	pitCS.stepCsAuditRound()
+	assert.Nil(t, pitCS.csAuditRound)
+
+	// The result is sent back once the round finishes
+	assert.Equal(t, CsAuditRoundResult{
+		NFinished: 1,
+		Total:     40,
+		Sampled:   10,
+		Checked:   10,
+	}, <-reply)
+
+	after := GetCsAuditSampleStats()
+	assert.Equal(t, before.Rounds+1, after.Rounds)
+	assert.Equal(t, before.Checked+10, after.Checked)
+	assert.Equal(t, uint64(40), after.LastTotal)
+	assert.Equal(t, uint64(10), after.LastSampled)
+
+	// The full table is walked in batches without a snapshot
+	pitCS.startCsAuditRound(csAuditRoundReq{K: 0, Time: time.Now()})
+	pitCS.csAuditRound.batch = 16
+	assert.Nil(t, pitCS.csAuditRound.pending)
+	pitCS.stepCsAuditRound()
+	pitCS.stepCsAuditRound()
+	assert.NotNil(t, pitCS.csAuditRound)
+	pitCS.stepCsAuditRound()
+	assert.Nil(t, pitCS.csAuditRound)
+	after = GetCsAuditSampleStats()
+	assert.Equal(t, uint64(40), after.LastSampled)
+}
+
+func TestCsAuditIndexes(t *testing.T) {
+	setReplacementPolicy("lru")
+	CfgSetCsCapacity(1024)
+
+	pitCS := NewPitCS(func(PitEntry) {})
+	for i := 0; i < 10; i++ {
+		wire := makeCsAuditTestData(t, fmt.Sprintf("/indexes/obj%d", i))
+		pkt, _ := defn.ParseFwPacket(enc.NewBufferView(wire), false)
+		pitCS.InsertData(pkt.Data, wire)
+	}
+	drainCsAuditEvents()
+
+	// Erased entries are removed and the positions of moved entries stay consistent
+	for _, index := range []uint64{pitCS.csIndexes[0], pitCS.csIndexes[4], pitCS.csIndexes[9]} {
+		pitCS.eraseCsDataFromReplacementStrategy(index)
+	}
+	drainCsAuditEvents()
+	require.Len(t, pitCS.csIndexes, 7)
+	require.Len(t, pitCS.csMap, 7)
+	for pos, index := range pitCS.csIndexes {
+		assert.Equal(t, pos, pitCS.csMap[index].auditPos)
+	}
+}
+
+func TestCsAuditDetectProb(t *testing.T) {
+	assert.Equal(t, 0.0, CsAuditDetectProb(100, 10, 0))
+	assert.InDelta(t, 0.1, CsAuditDetectProb(100, 10, 1), 1e-9)
+	assert.Equal(t, 1.0, CsAuditDetectProb(100, 100, 1))
+	assert.Equal(t, 1.0, CsAuditDetectProb(10, 5, 6))
+	// 1 - C(98,10)/C(100,10) = 1 - (90*89)/(100*99)
+	assert.InDelta(t, 1-(90.0*89.0)/(100.0*99.0), CsAuditDetectProb(100, 10, 2), 1e-9)
+}
+
+func TestCsAuditJournalRestore(t *testing.T) {
+	dir := t.TempDir()
+	scheme, err := NewCsAuditTagScheme(CsAuditSchemeHmac, csAuditBlsKeyDefault)
+	assert.NoError(t, err)
+
+	nameA, _ := enc.NameFromStr("/journal/a")
+	nameB, _ := enc.NameFromStr("/journal/b")
+	nameC, _ := enc.NameFromStr("/journal/c")
+	nameD, _ := enc.NameFromStr("/journal/d")
+	tags := map[string][]byte{}
+
+	tree := newCsNatSha256Tree(scheme)
+	_, err = openCsAuditJournal(dir, scheme, tree)
+	assert.NoError(t, err)
+	for _, name := range []enc.Name{nameA, nameB, nameC, nameD} {
+		tags[name.String()] = scheme.Tag(name, []byte(name.String()))
+		tree.OnInsert(name, tags[name.String()], time.Time{})
+		journalCsNatLeaf(name, tags[name.String()], time.Time{})
+	}
+	tree.OnErase(nameC)
+	unjournalCsNatLeaf(nameC)
+	recordCsAuditHistory(CsAuditHistoryEntry{Time: time.Now(), Event: CsAuditHistoryRound, Checked: 3})
+	StopCsAuditJournal()
+
+	// Restart: the tree is rebuilt from the journal along with the history
+	csAuditHistory = nil
+	tree2 := newCsNatSha256Tree(scheme)
+	restore, err := openCsAuditJournal(dir, scheme, tree2)
+	assert.NoError(t, err)
+	defer StopCsAuditJournal()
+	assert.Equal(t, 3, restore.nRestored)
+
+	_, ok := tree2.GetLeafTag(nameC)
+	assert.False(t, ok)
+	agg1, _ := tree.GetTagAggByPrefix(enc.Name{})
+	agg2, _ := tree2.GetTagAggByPrefix(enc.Name{})
+	assert.Equal(t, agg1, agg2)
+
+	hist := GetCsAuditHistory()
+	assert.Len(t, hist, 2)
+	assert.Equal(t, CsAuditHistoryRound, hist[0].Event)
+	assert.Equal(t, uint64(3), hist[0].Checked)
+	assert.Equal(t, CsAuditHistoryRestore, hist[1].Event)
+
+	// A comes back unchanged, D comes back with different content, B never comes back
+	assert.True(t, restore.take(nameA, tags[nameA.String()]))
+	assert.True(t, restore.take(nameD, scheme.Tag(nameD, []byte("changed"))))
+	assert.False(t, restore.take(nameC, tags[nameC.String()]))
+	restore.reconcile(tree2)
+
+	_, ok = tree2.GetLeafTag(nameA)
+	assert.True(t, ok)
+	_, ok = tree2.GetLeafTag(nameB)
+	assert.False(t, ok)
+
+	hist = GetCsAuditHistory()
+	assert.Len(t, hist, 4)
+	assert.Equal(t, CsAuditHistoryRestoreMismatch, hist[2].Event)
+	assert.Equal(t, nameD, hist[2].Name)
+	assert.Equal(t, CsAuditHistoryReconcile, hist[3].Event)
+	assert.Equal(t, uint64(2), hist[3].Checked)
+	assert.Equal(t, uint64(1), hist[3].Mismatched)
+}
+
+func TestCsAuditStatusCounters(t *testing.T) {
+	setReplacementPolicy("lru")
+	CfgSetCsCapacity(1024)
+
+	pitCS := NewPitCS(func(PitEntry) {})
+	wire := makeCsAuditTestData(t, "/status/obj")
+	pkt, _ := defn.ParseFwPacket(enc.NewBufferView(wire), false)
+	pitCS.InsertData(pkt.Data, wire)
+	drainCsAuditEvents()
+
+	// Record the expected leaf as the auditor would
+	name := pkt.Data.NameV
+	csNatSha256.OnInsert(name, csAuditLeafTag(CsAuditScheme(), name, wire), time.Time{})
+	defer csNatSha256.OnErase(name)
+
+	before := GetCsAuditStatus()
+
+	reply := make(chan csAuditFlipResult, 1)
+	pitCS.handleCsAuditFlipReq(csAuditFlipReq{Name: name, Reply: reply})
+	assert.True(t, (<-reply).Flipped)
+
+	challenge := make(chan CsAuditChallengeResult, 1)
+	pitCS.handleCsAuditChallengeReq(csAuditChallengeReq{Prefix: enc.Name{}, Nonce: []byte("nonce"), Time: time.Now(), Reply: challenge})
+	res := <-challenge
+	assert.Equal(t, uint64(1), res.InvalidCount)
+	assert.Empty(t, pitCS.csMap)
+
+	after := GetCsAuditStatus()
+	assert.Equal(t, before.NFlips+1, after.NFlips)
+	assert.Equal(t, before.NChecked+1, after.NChecked)
+	assert.Equal(t, before.NCorruptEvicted+1, after.NCorruptEvicted)
+	assert.Equal(t, CsAuditScheme().String(), after.Scheme)
+}
+
+// loadCfgCsAuditKey 按当前配置加载本节点密钥并派生审计密钥。
+func loadCfgCsAuditKey() ([32]byte, error) {
+	signer, err := cfgCsAuditSigner()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return cfgCsAuditKey(signer)
+}
+
+func TestCsAuditKeychainKey(t *testing.T) {
+	dir := t.TempDir()
+	kc, err := keychain.NewKeyChainDir(dir, storage.NewMemoryStore())
+	require.NoError(t, err)
+
+	idName, _ := enc.NameFromStr("/test/audit")
+	signer, err := sig.KeygenEd25519(sec.MakeKeyName(idName))
+	require.NoError(t, err)
+	require.NoError(t, kc.InsertKey(signer))
+
+	cfg := &core.C.Tables.ContentStore.Audit
+	defer func(keychain, keyName string) {
+		cfg.Keychain, cfg.KeyName = keychain, keyName
+	}(cfg.Keychain, cfg.KeyName)
+
+	// Identity name and full key name resolve to the same key
+	cfg.Keychain, cfg.KeyName = "dir://"+dir, idName.String()
+	byId, err := loadCfgCsAuditKey()
+	require.NoError(t, err)
+	cfg.KeyName = signer.KeyName().String()
+	byKey, err := loadCfgCsAuditKey()
+	require.NoError(t, err)
+	assert.Equal(t, byId, byKey)
+	assert.NotEqual(t, csAuditBlsKeyDefault, byId)
+
+	// Reloading the keychain derives the same key
+	again, err := loadCfgCsAuditKey()
+	require.NoError(t, err)
+	assert.Equal(t, byKey, again)
+
+	cfg.KeyName = "/test/missing"
+	_, err = cfgCsAuditSigner()
+	assert.Error(t, err)
+}
+
+func TestCsAuditSchemeFromConfig(t *testing.T) {
+	dir := t.TempDir()
+	kc, err := keychain.NewKeyChainDir(dir, storage.NewMemoryStore())
+	require.NoError(t, err)
+
+	idName, _ := enc.NameFromStr("/test/audit-scheme")
+	signer, err := sig.KeygenEd25519(sec.MakeKeyName(idName))
+	require.NoError(t, err)
+	require.NoError(t, kc.InsertKey(signer))
+
+	cfg := &core.C.Tables.ContentStore.Audit
+	defer func(scheme, keychain, keyName string) {
+		cfg.TagScheme, cfg.Keychain, cfg.KeyName = scheme, keychain, keyName
+		require.NoError(t, loadCsAuditScheme())
+	}(cfg.TagScheme, cfg.Keychain, cfg.KeyName)
+
+	// The configured scheme and key are in effect after the config is loaded
+	cfg.TagScheme = CsAuditSchemeBls
+	cfg.Keychain, cfg.KeyName = "dir://"+dir, idName.String()
+	initCsAudit()
+
+	scheme := CsAuditScheme()
+	assert.Equal(t, CsAuditSchemeBls, scheme.String())
+	assert.Equal(t, signer.KeyName(), CsAuditSigner().KeyName())
+	key, err := loadCfgCsAuditKey()
+	require.NoError(t, err)
+	expected, err := NewCsAuditTagScheme(CsAuditSchemeBls, key)
+	require.NoError(t, err)
+	assert.Equal(t, expected.PublicKey(), scheme.PublicKey())
+
+	builtin, err := NewCsAuditTagScheme(CsAuditSchemeBls, csAuditBlsKeyDefault)
+	require.NoError(t, err)
+	assert.NotEqual(t, builtin.PublicKey(), scheme.PublicKey())
+
+	// The CSNAT aggregates with the same scheme
+	assert.Same(t, scheme, csNatSha256.scheme)
+	name, _ := enc.NameFromStr("/test/audit-scheme/data")
+	csNatSha256.OnInsert(name, scheme.Tag(name, []byte("A")), time.Time{})
+	defer csNatSha256.OnErase(name)
+	agg, ok := csNatSha256.GetTagAggByPrefix(name)
+	require.True(t, ok)
+	assert.Equal(t, scheme.Tag(name, []byte("A")), agg)
+}
+
+// 中文说明：内置密钥是公开的，bls12-381 与远程挑战在未配置 keychain 时必须拒绝启动。
+func TestCsAuditBuiltinKeyRejected(t *testing.T) {
+	cfg := &core.C.Tables.ContentStore.Audit
+	defer func(scheme, keychain, keyName, nodePrefix string) {
+		cfg.TagScheme, cfg.Keychain, cfg.KeyName, cfg.NodePrefix = scheme, keychain, keyName, nodePrefix
+		require.NoError(t, loadCsAuditScheme())
+	}(cfg.TagScheme, cfg.Keychain, cfg.KeyName, cfg.NodePrefix)
+	cfg.Keychain, cfg.KeyName = "", ""
+
+	cfg.TagScheme, cfg.NodePrefix = CsAuditSchemeBls, ""
+	assert.Error(t, loadCsAuditScheme())
+
+	cfg.TagScheme, cfg.NodePrefix = CsAuditSchemeHmac, "/test/node"
+	assert.Error(t, loadCsAuditScheme())
+
+	// hmac-sha256 本地审计仍可使用内置密钥
+	cfg.TagScheme, cfg.NodePrefix = CsAuditSchemeHmac, ""
+	require.NoError(t, loadCsAuditScheme())
+	assert.Equal(t, CsAuditSchemeHmac, CsAuditScheme().String())
+}
+
+func TestCsAuditRuntimeConfig(t *testing.T) {
+	defer CfgSetCsSha256ChallengeInterval(CfgCsSha256ChallengeInterval())
+	defer CfgSetCsSeuRatePerBitPerDay(CfgCsSeuRatePerBitPerDay())
+	defer CfgSetCsSeuPrefix(CfgCsSeuPrefix().String())
+
+	// Changing the interval wakes up the challenger
+	select {
+	case <-csAuditCfg.intervalCh:
+	default:
+	}
+	CfgSetCsSha256ChallengeInterval(500 * time.Millisecond)
+	assert.Equal(t, 500*time.Millisecond, CfgCsSha256ChallengeInterval())
+	select {
+	case <-csAuditCfg.intervalCh:
+	default:
+		t.Fatal("challenger not notified")
+	}
+
+	// Changing SEU settings bumps the generation seen by forwarding threads
+	gen := csAuditCfg.seuGen.Load()
+	require.NoError(t, CfgSetCsSeuRatePerBitPerDay(1e-3))
+	assert.Equal(t, 1e-3, CfgCsSeuRatePerBitPerDay())
+	assert.Greater(t, csAuditCfg.seuGen.Load(), gen)
+	assert.Error(t, CfgSetCsSeuRatePerBitPerDay(0))
+	assert.Error(t, CfgSetCsSeuRatePerBitPerDay(math.Inf(1)))
+	assert.Equal(t, 1e-3, CfgCsSeuRatePerBitPerDay())
+
+	require.NoError(t, CfgSetCsSeuPrefix("/minindn/a"))
+	assert.Equal(t, "/minindn/a", CfgCsSeuPrefix().String())
+	require.NoError(t, CfgSetCsSeuPrefix(""))
+	assert.Empty(t, CfgCsSeuPrefix())
+}
+
+func TestCsAuditRepair(t *testing.T) {
+	setReplacementPolicy("lru")
+	CfgSetCsCapacity(1024)
+
+	repair := &core.C.Tables.ContentStore.Audit.Repair
+	defer func(enabled bool) { repair.Enabled = enabled }(repair.Enabled)
+	repair.Enabled = true
+
+	pitCS := NewPitCS(func(PitEntry) {})
+	insert := func(name string) (*defn.FwData, []byte) {
+		wire := makeCsAuditTestData(t, name)
+		pkt, _ := defn.ParseFwPacket(enc.NewBufferView(wire), false)
+		pitCS.InsertData(pkt.Data, wire)
+		csNatSha256.OnInsert(pkt.Data.NameV, csAuditLeafTag(CsAuditScheme(), pkt.Data.NameV, wire), time.Time{})
+		return pkt.Data, wire
+	}
+	dataA, wireA := insert("/repair/a")
+	dataB, _ := insert("/repair/b")
+	defer csNatSha256.OnErase(dataA.NameV)
+	defer csNatSha256.OnErase(dataB.NameV)
+	drainCsAuditEvents()
+	for len(CsAuditRepairs) > 0 {
+		<-CsAuditRepairs
+	}
+
+	before := GetCsAuditStatus()
+	for _, data := range []*defn.FwData{dataA, dataB} {
+		reply := make(chan csAuditFlipResult, 1)
+		pitCS.handleCsAuditFlipReq(csAuditFlipReq{Name: data.NameV, Reply: reply})
+		require.True(t, (<-reply).Flipped)
+	}
+
+	// Corrupted entries are kept but not served while repairs are pending
+	challenge := make(chan CsAuditChallengeResult, 1)
+	now := time.Now()
+	pitCS.handleCsAuditChallengeReq(csAuditChallengeReq{Prefix: enc.Name{}, Nonce: []byte("nonce"), Time: now, Reply: challenge})
+	assert.Equal(t, uint64(2), (<-challenge).InvalidCount)
+	assert.Len(t, pitCS.csMap, 2)
+	assert.Len(t, pitCS.csAuditRepairing, 2)
+	assert.Len(t, CsAuditRepairs, 2)
+	assert.Nil(t, pitCS.FindMatchingDataFromCS(&defn.FwInterest{NameV: dataA.NameV}))
+
+	// Data that does not match CSNAT is rejected
+	badWire := bytes.Clone(wireA)
+	badWire[len(badWire)-1] ^= 1
+	bad, _ := defn.ParseFwPacket(enc.NewBufferView(badWire), false)
+	pitCS.InsertData(bad.Data, badWire)
+	assert.NotNil(t, pitCS.csMap[dataA.NameV.Hash()].repair)
+
+	// Data matching CSNAT replaces the corrupted entry
+	pitCS.InsertData(dataA, wireA)
+	entry := pitCS.FindMatchingDataFromCS(&defn.FwInterest{NameV: dataA.NameV})
+	require.NotNil(t, entry)
+	_, wire, err := entry.Copy()
+	require.NoError(t, err)
+	assert.Equal(t, wireA, wire)
+
+	// Unrepaired entries are erased after the timeout
+	pitCS.expireCsAuditRepairs(now.Add(CfgCsAuditRepairTimeout()))
+	assert.Len(t, pitCS.csMap, 1)
+	assert.Empty(t, pitCS.csAuditRepairing)
+
+	after := GetCsAuditStatus()
+	assert.Equal(t, before.NRepairs+2, after.NRepairs)
+	assert.Equal(t, before.NRepairsSucceeded+1, after.NRepairsSucceeded)
+	assert.Equal(t, before.NRepairsRejected+1, after.NRepairsRejected)
+	assert.Equal(t, before.NRepairsTimedOut+1, after.NRepairsTimedOut)
+	assert.Equal(t, before.NCorruptEvicted+1, after.NCorruptEvicted)
+
+	hist := GetCsAuditHistory()
+	require.GreaterOrEqual(t, len(hist), 2)
+	assert.Equal(t, CsAuditHistoryRepair, hist[len(hist)-2].Event)
+	assert.Equal(t, CsAuditHistoryRepairFailed, hist[len(hist)-1].Event)
+	assert.Equal(t, dataB.NameV, hist[len(hist)-1].Name)
+}
+
+func TestCsSeuFaultModels(t *testing.T) {
+	setReplacementPolicy("lru")
+	CfgSetCsCapacity(1024)
+
+	seu := &core.C.Tables.ContentStore.Seu
+	saved := *seu
+	defer func() { *seu = saved }()
+	defer CfgSetCsSeuEnabled(CfgCsSeuEnabled())
+	defer CfgSetCsSeuRatePerBitPerDay(CfgCsSeuRatePerBitPerDay())
+	defer CfgSetCsSeuPrefix(CfgCsSeuPrefix().String())
+	CfgSetCsSeuEnabled(true)
+	require.NoError(t, CfgSetCsSeuRatePerBitPerDay(86400))
+	require.NoError(t, CfgSetCsSeuPrefix("/seu"))
+
+	// Every call after the first injects exactly one fault (λ is far above one per second)
+	run := func(seed uint64, model, region string, n int) (*PitCsTree, map[uint64][]byte) {
+		seu.Model, seu.Region, seu.Bits, seu.BurstSpan = model, region, 3, 4
+		pitCS := NewPitCS(func(PitEntry) {})
+		pitCS.csSeuID = 0
+		pitCS.csSeuRng = rand.New(rand.NewPCG(seed, 0))
+
+		orig := make(map[uint64][]byte)
+		for i := 0; i < 8; i++ {
+			wire := makeCsAuditTestData(t, fmt.Sprintf("/seu/obj%d", i))
+			pkt, _ := defn.ParseFwPacket(enc.NewBufferView(wire), false)
+			pitCS.InsertData(pkt.Data, wire)
+			orig[pkt.Data.NameV.Hash()] = wire
+		}
+		drainCsAuditEvents()
+
+		now := time.Unix(1700000000, 0)
+		for i := 0; i <= n; i++ {
+			pitCS.seuMaybeInject(now.Add(time.Duration(i) * time.Second))
+		}
+		return pitCS, orig
+	}
+	wires := func(p *PitCsTree) map[uint64][]byte {
+		m := make(map[uint64][]byte)
+		for index, entry := range p.csMap {
+			m[index] = entry.wire
+		}
+		return m
+	}
+
+	// The same seed injects the same faults
+	before := GetCsAuditStatus()
+	p1, orig := run(42, CsSeuModelBurst, CsSeuRegionAny, 5)
+	p2, _ := run(42, CsSeuModelBurst, CsSeuRegionAny, 5)
+	p3, _ := run(43, CsSeuModelBurst, CsSeuRegionAny, 5)
+	assert.Equal(t, wires(p1), wires(p2))
+	assert.NotEqual(t, wires(p1), wires(p3))
+	assert.NotEqual(t, orig, wires(p1))
+	assert.Equal(t, uint64(5), p1.csSeuSeq)
+	assert.Equal(t, before.NSeuInjections+15, GetCsAuditStatus().NSeuInjections)
+
+	// Faults are restricted to the configured region
+	path := t.TempDir() + "/faults.jsonl"
+	require.NoError(t, openCsSeuFaultLog(path))
+	p4, orig := run(7, CsSeuModelAdjacent, CsSeuRegionContent, 10)
+	csSeuFaultLog.file.Close()
+	csSeuFaultLog.file = nil
+
+	for index, wire := range wires(p4) {
+		allowed := csSeuRegions(orig[index], CsSeuRegionContent)
+		require.Len(t, allowed, 1)
+		for i := range wire {
+			if i < allowed[0][0] || i >= allowed[0][1] {
+				assert.Equal(t, orig[index][i], wire[i])
+			}
+		}
+	}
+
+	log, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(log)), "\n")
+	require.Len(t, lines, 11)
+	var fault CsSeuFault
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &fault))
+	assert.Equal(t, uint64(1), fault.Seq)
+	assert.Equal(t, CsSeuModelAdjacent, fault.Model)
+	assert.Equal(t, CsSeuRegionContent, fault.Region)
+	assert.NotEmpty(t, fault.Bits)
+
+	// Stuck-at faults survive a refresh of the entry
+	p5, orig := run(9, CsSeuModelStuckAt, CsSeuRegionName, 1)
+	require.Len(t, p5.csSeuStuck, 1)
+	for index := range p5.csSeuStuck {
+		stuck := bytes.Clone(p5.csMap[index].wire)
+		pkt, _ := defn.ParseFwPacket(enc.NewBufferView(orig[index]), false)
+		p5.InsertData(pkt.Data, orig[index])
+		p5.seuMaybeInject(time.Unix(1700000000, 0))
+		assert.Equal(t, stuck, p5.csMap[index].wire)
+
+		p5.eraseCsDataFromReplacementStrategy(index)
+		assert.Empty(t, p5.csSeuStuck)
+	}
+
+	// Metadata faults change the stale time but not the wire
+	p6, orig := run(11, CsSeuModelMetadata, CsSeuRegionAny, 1)
+	assert.Equal(t, orig, wires(p6))
+	assert.Equal(t, uint64(1), p6.csSeuSeq)
+}
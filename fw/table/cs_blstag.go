@@ -5,26 +5,63 @@ import (
 	"crypto/sha256"
 	"encoding/binary"
 	"encoding/hex"
-	"os"
+	"fmt"
 	"sync"
 
+	"github.com/named-data/ndnd/fw/core"
 	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/ndn"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	"github.com/named-data/ndnd/std/object/storage"
+	sec "github.com/named-data/ndnd/std/security"
+	"github.com/named-data/ndnd/std/security/audit"
+	"github.com/named-data/ndnd/std/security/keychain"
+	sig "github.com/named-data/ndnd/std/security/signer"
 )
 
-// 中文说明：当前阶段我们把“BLSTag”先实现为一个“带密钥的标签”，用于检测 CS 缓存内容是否被静默篡改。
+// 中文说明：缓存审计标签（BLSTag）采用“可插拔方案”，用于检测 CS 缓存内容是否被静默篡改。
 //
-// - 目标：当缓存 wire 被修改时，重新计算标签会不一致，从而在挑战阶段被发现并清除。
-// - 约束：尽可能最小改动，且不引入复杂/重依赖。
-// - 现状：暂用 HMAC-SHA256(key, Name||Wire) 作为 BLSTag 的占位实现；
-//   后续若要切换为真正的 BLS 签名/聚合，只需替换 ComputeCsAuditBlstag 的实现即可。
+// - hmac-sha256：HMAC-SHA256(key, Name||Wire)，聚合方式为按位异或（对称方案，只有持有密钥者能验证）。
+//   保留该方案用于对比实验。
+// - bls12-381：真正的 BLS 签名 σ = sk·H(digest(Name, Wire))（G1），聚合方式为 G1 点加法；
+//   第三方审计者只需生产者公钥（G2）即可通过双线性对验证任意前缀的聚合标签。
 //
-// 注意：这里的 key 等价于“本节点私钥”（用户希望用常量定义即可）。
+// 两种方案的聚合都满足交换律/结合律，并支持“减去”某个标签，
+// 因此 CSNAT 可以按论文公式 Parent.Value ← Parent.Value + σi 做增量更新。
 
-var csAuditBlsKeyOnce sync.Once
-var csAuditBlsKey [32]byte
+// CsAuditTagScheme 是缓存审计标签方案的抽象接口。
+type CsAuditTagScheme interface {
+	// String 返回方案名称（用于日志与管理接口）。
+	String() string
+	// Tag 计算 (Name, Wire) 的叶子审计标签。
+	Tag(name enc.Name, wire []byte) []byte
+	// Identity 返回聚合运算的单位元（空集合的聚合值）。
+	Identity() []byte
+	// Add 返回 agg + tag。
+	Add(agg []byte, tag []byte) ([]byte, error)
+	// Sub 返回 agg - tag。
+	Sub(agg []byte, tag []byte) ([]byte, error)
+	// PublicKey 返回第三方审计者验证所需的公钥；对称方案返回 nil。
+	PublicKey() []byte
+	// TagType 返回该方案对应的 Data 内嵌 AuditTag 类型（见 audit.TagType*）。
+	TagType() uint64
+	// Verify 校验生产者标签是否与 (Name, Wire) 匹配；pub 为可信的生产者公钥（对称方案忽略）。
+	Verify(name enc.Name, wire []byte, tag []byte, pub []byte) bool
+	// Sign 用本节点密钥对挑战证明摘要（audit.ProofDigest）签名。
+	Sign(digest [32]byte) []byte
+	// Scale 返回远程挑战中按系数（audit.ChallengeCoefficient）加权后的标签。
+	Scale(tag []byte, coeff [32]byte) ([]byte, error)
+}
+
+const (
+	CsAuditSchemeHmac = "hmac-sha256"
+	CsAuditSchemeBls  = "bls12-381"
+)
 
 // 默认“私钥”（32 bytes）。
-// 中文说明：仅用于实验/调试；生产环境应通过安全方式加载，并避免硬编码。
+// 中文说明：仅用于 hmac-sha256 的实验/调试与测试（未配置 keychain 时使用）；
+// bls12-381 方案与远程挑战（node_prefix）必须通过 keychain 加载密钥，否则公钥与标签可被任何人伪造。
+// 对 hmac-sha256 方案它是 HMAC key；对 bls12-381 方案它被解释为大端标量（模群阶）。
 var csAuditBlsKeyDefault = [32]byte{
 	0x3a, 0x1f, 0x8b, 0x23, 0x71, 0x4c, 0x9d, 0x5e,
 	0x0f, 0x44, 0x12, 0x9a, 0x6d, 0x2c, 0x80, 0x11,
@@ -32,31 +69,269 @@ var csAuditBlsKeyDefault = [32]byte{
 	0x91, 0x0a, 0x7c, 0x3d, 0x18, 0xe6, 0x2b, 0xc0,
 }
 
-func cfgCsAuditBlsKey() [32]byte {
-	csAuditBlsKeyOnce.Do(func() {
-		// 中文说明：允许通过环境变量覆盖（便于实验对比），但默认仍使用常量 key。
-		// - NDND_CS_AUDIT_BLS_SK_HEX: 64 hex chars (32 bytes)
-		if v := os.Getenv("NDND_CS_AUDIT_BLS_SK_HEX"); v != "" {
-			if b, err := hex.DecodeString(v); err == nil && len(b) == 32 {
-				copy(csAuditBlsKey[:], b)
-				return
+// csAuditKeyDomain 是从 keychain 密钥派生审计密钥时使用的域分隔串。
+var csAuditKeyDomain = []byte("ndnd-cs-audit-key-v1")
+
+// cfgCsAuditSigner 返回 keychain 中的本节点密钥（tables.content_store.audit.keychain / key_name）。
+// 未配置 keychain 时返回 nil。
+func cfgCsAuditSigner() (ndn.Signer, error) {
+	cfg := &core.C.Tables.ContentStore.Audit
+	if cfg.Keychain == "" {
+		return nil, nil
+	}
+
+	name, err := enc.NameFromStr(cfg.KeyName)
+	if err != nil {
+		return nil, err
+	}
+	kc, err := keychain.NewKeyChain(cfg.Keychain, storage.NewMemoryStore())
+	if err != nil {
+		return nil, err
+	}
+
+	// key_name 可以是身份名（使用第一把密钥）或完整的密钥名
+	var signer ndn.Signer
+	if id := kc.IdentityByName(name); id != nil && len(id.Keys()) > 0 {
+		signer = id.Keys()[0].Signer()
+	} else if idName, err := sec.GetIdentityFromKeyName(name); err == nil {
+		if id := kc.IdentityByName(idName); id != nil {
+			for _, key := range id.Keys() {
+				if key.KeyName().Equal(name) {
+					signer = key.Signer()
+				}
 			}
 		}
-		csAuditBlsKey = csAuditBlsKeyDefault
-	})
-	return csAuditBlsKey
+	}
+	if signer == nil {
+		return nil, fmt.Errorf("audit key %s not found in keychain %s", name, cfg.Keychain)
+	}
+	return signer, nil
 }
 
-var csAuditBlsTagDomain = []byte("ndnd-cs-blstag-v1")
+// cfgCsAuditKey 由本节点密钥派生审计密钥。
+//
+// 中文说明：
+// - 审计密钥由 keychain 中指定身份（或密钥）的私钥派生：SHA-256(domain || secret)。
+// - 同一把 NDN 密钥在不同重启之间得到相同的审计密钥，keychain 中不需要保存额外的 BLS 密钥。
+// - 未配置 keychain（signer 为 nil）时使用内置默认密钥（仅 hmac-sha256 允许，见 loadCsAuditScheme）。
+func cfgCsAuditKey(signer ndn.Signer) ([32]byte, error) {
+	if signer == nil {
+		return csAuditBlsKeyDefault, nil
+	}
+
+	secret, err := sig.GetSecret(signer)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	h := sha256.New()
+	h.Write(csAuditKeyDomain)
+	h.Write(secret)
+	var key [32]byte
+	copy(key[:], h.Sum(nil))
+	return key, nil
+}
+
+// csAuditProducerKey 是一个可信的生产者公钥（tables.content_store.audit.producer_keys）。
+type csAuditProducerKey struct {
+	prefix enc.Name
+	pub    []byte
+}
 
-// ComputeCsAuditBlstag 计算缓存条目的 BLSTag。
+// cfgCsAuditProducerKeys 解析配置中的可信生产者公钥。
+func cfgCsAuditProducerKeys() ([]csAuditProducerKey, error) {
+	cfg := &core.C.Tables.ContentStore.Audit
+	keys := make([]csAuditProducerKey, 0, len(cfg.ProducerKeys))
+	for _, k := range cfg.ProducerKeys {
+		prefix, err := enc.NameFromStr(k.Prefix)
+		if err != nil {
+			return nil, fmt.Errorf("invalid producer key prefix %s: %w", k.Prefix, err)
+		}
+		pub, err := hex.DecodeString(k.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid producer key for %s: %w", k.Prefix, err)
+		}
+		if _, err := audit.ParseBlsPublicKey(pub); err != nil {
+			return nil, fmt.Errorf("invalid producer key for %s: %w", k.Prefix, err)
+		}
+		keys = append(keys, csAuditProducerKey{prefix: prefix, pub: pub})
+	}
+	return keys, nil
+}
+
+var csAuditScheme CsAuditTagScheme
+
+// csAuditSigner 为本节点密钥，用于签名远程审计响应（未配置 keychain 时为 nil）。
+var csAuditSigner ndn.Signer
+
+// csAuditProducerKeys 为当前生效的可信生产者公钥，与审计方案一起在读取配置后设置。
+var csAuditProducerKeys []csAuditProducerKey
+
+// CsAuditScheme 返回当前进程使用的审计标签方案。
+//
+// 中文说明：方案由 Initialize（initCsAudit）在读取配置后创建，之前调用返回 nil。
+func CsAuditScheme() CsAuditTagScheme {
+	return csAuditScheme
+}
+
+// CsAuditSigner 返回本节点密钥（keychain / key_name）；未配置 keychain 时返回 nil。
+func CsAuditSigner() ndn.Signer {
+	return csAuditSigner
+}
+
+// loadCsAuditScheme 按当前配置（tag_scheme / keychain / key_name / producer_keys）创建审计方案，
+// 并为其新建 CSNAT；必须在审计者与转发线程启动之前调用。
+func loadCsAuditScheme() error {
+	cfg := &core.C.Tables.ContentStore.Audit
+	signer, err := cfgCsAuditSigner()
+	if err != nil {
+		return fmt.Errorf("unable to load CS audit key: %w", err)
+	}
+	// 内置密钥是公开的：公开公钥或响应远程挑战时必须使用 keychain 中的密钥
+	if signer == nil && (cfg.TagScheme == CsAuditSchemeBls || cfg.NodePrefix != "") {
+		return fmt.Errorf("%s tag scheme and node_prefix require an audit keychain", CsAuditSchemeBls)
+	}
+	key, err := cfgCsAuditKey(signer)
+	if err != nil {
+		return fmt.Errorf("unable to load CS audit key: %w", err)
+	}
+	producerKeys, err := cfgCsAuditProducerKeys()
+	if err != nil {
+		return err
+	}
+	scheme, err := NewCsAuditTagScheme(cfg.TagScheme, key)
+	if err != nil {
+		return fmt.Errorf("unable to create CS audit tag scheme: %w", err)
+	}
+	csAuditScheme = scheme
+	csAuditSigner = signer
+	csAuditProducerKeys = producerKeys
+	csNatSha256 = newCsNatSha256Tree(scheme)
+	return nil
+}
+
+// NewCsAuditTagScheme 按名称和 32 字节密钥创建审计标签方案。
+func NewCsAuditTagScheme(name string, key [32]byte) (CsAuditTagScheme, error) {
+	switch name {
+	case CsAuditSchemeHmac:
+		return &csAuditHmacScheme{key: key}, nil
+	case CsAuditSchemeBls:
+		sk, err := audit.ParseBlsSecretKey(key[:])
+		if err != nil {
+			return nil, err
+		}
+		return &csAuditBlsScheme{sk: sk, pk: sk.Public().Bytes()}, nil
+	default:
+		return nil, fmt.Errorf("unknown cs audit tag scheme: %s", name)
+	}
+}
+
+// ComputeCsAuditBlstag 使用当前方案计算缓存条目的 BLSTag。
 //
 // 中文说明：
 // - 输入：Name（绑定命名）+ Wire（绑定内容）
-// - 输出：32 字节标签（便于和现有 CSNAT/管理接口最小兼容）
-func ComputeCsAuditBlstag(name enc.Name, wire []byte) [32]byte {
-	key := cfgCsAuditBlsKey()
-	mac := hmac.New(sha256.New, key[:])
+// - 输出：叶子标签（hmac-sha256 为 32 字节；bls12-381 为 48 字节压缩 G1 点）
+func ComputeCsAuditBlstag(name enc.Name, wire []byte) []byte {
+	return CsAuditScheme().Tag(name, wire)
+}
+
+// csAuditTrustedKey 返回校验生产者标签所用的可信公钥。
+//
+// 中文说明：
+// - 对称方案（PublicKey 为 nil）：生产者与本节点共享审计密钥，不需要公钥。
+// - bls12-381：按 Data 的 KeyLocator 名称在 producer_keys 中做最长前缀匹配。
+// - AuditTag 位于签名覆盖范围之外，其中携带的 PublicKey 不可信，一律忽略。
+func csAuditTrustedKey(scheme CsAuditTagScheme, covered []byte) ([]byte, bool) {
+	if scheme.PublicKey() == nil {
+		return nil, true
+	}
+	if len(csAuditProducerKeys) == 0 {
+		return nil, false
+	}
+
+	data, _, err := spec.Spec{}.ReadData(enc.NewBufferView(covered))
+	if err != nil || data.Signature() == nil {
+		return nil, false
+	}
+	keyName := data.Signature().KeyName()
+	if keyName == nil {
+		return nil, false
+	}
+
+	var pub []byte
+	matchLen := -1
+	for _, k := range csAuditProducerKeys {
+		if len(k.prefix) > matchLen && k.prefix.IsPrefix(keyName) {
+			pub, matchLen = k.pub, len(k.prefix)
+		}
+	}
+	return pub, pub != nil
+}
+
+// csAuditLeafTag 返回缓存条目写入 CSNAT 的叶子标签。
+//
+// 中文说明：
+// - 若 Data 携带生产者嵌入的 AuditTag，且能用可信公钥（见 csAuditTrustedKey）校验通过，直接记录生产者标签（不重算）。
+// - 这样即使缓存节点被攻破，也无法对被篡改的内容“重新打标签”。
+// - 没有可信公钥或校验失败的生产者标签不被采用（计入 NProducerTagsRejected），退化为由本节点计算标签。
+// - 校验失败的条目在下一次挑战时会被判定为损坏（见 csAuditProve）。
+func csAuditLeafTag(scheme CsAuditTagScheme, name enc.Name, wire []byte) []byte {
+	covered, ptag, err := audit.SplitTag(wire)
+	if err == nil && ptag != nil && ptag.TagType == scheme.TagType() {
+		if pub, ok := csAuditTrustedKey(scheme, covered); ok && scheme.Verify(name, covered, ptag.TagValue, pub) {
+			return ptag.TagValue
+		}
+		csAuditCounters.producerRejected.Add(1)
+		core.Log.Debug(nil, "Producer audit tag not accepted", "name", name)
+	}
+	return scheme.Tag(name, wire)
+}
+
+// csAuditProve 在挑战时为缓存条目生成证明。
+//
+// 中文说明：
+// - 本节点计算的标签：重算标签，valid 恒为 true（由调用方与 CSNAT 叶子对比）。
+// - 有可信公钥的生产者标签：返回当前 wire 中携带的标签，并用该公钥校验其是否覆盖当前内容。
+// - 没有可信公钥的生产者标签在插入时已被替换为本节点标签，这里同样重算。
+func csAuditProve(scheme CsAuditTagScheme, name enc.Name, wire []byte) (tag []byte, valid bool) {
+	covered, ptag, err := audit.SplitTag(wire)
+	if err != nil || ptag == nil || ptag.TagType != scheme.TagType() {
+		return scheme.Tag(name, wire), true
+	}
+	pub, ok := csAuditTrustedKey(scheme, covered)
+	if !ok {
+		return scheme.Tag(name, wire), true
+	}
+	return ptag.TagValue, scheme.Verify(name, covered, ptag.TagValue, pub)
+}
+
+// csAuditLeafDigest 返回叶子标签所覆盖内容的 TagDigest，远程挑战的系数由它与 nonce 派生。
+//
+// 中文说明：与 csAuditProve 一致，采用生产者标签时为去掉 AuditTag 的 wire，否则为完整 wire。
+func csAuditLeafDigest(scheme CsAuditTagScheme, name enc.Name, wire []byte) [32]byte {
+	covered, ptag, err := audit.SplitTag(wire)
+	if err == nil && ptag != nil && ptag.TagType == scheme.TagType() {
+		if _, ok := csAuditTrustedKey(scheme, covered); ok {
+			return audit.TagDigest(name, covered)
+		}
+	}
+	return audit.TagDigest(name, wire)
+}
+
+// csAuditHmacScheme 是对称的占位方案：HMAC-SHA256 标签 + 异或聚合。
+type csAuditHmacScheme struct {
+	key [32]byte
+}
+
+var csAuditBlsTagDomain = []byte("ndnd-cs-blstag-v1")
+var csAuditProofDomain = []byte("ndnd-cs-proof-v1")
+var csAuditCoeffDomain = []byte("ndnd-cs-coeff-v1")
+
+func (s *csAuditHmacScheme) String() string {
+	return CsAuditSchemeHmac
+}
+
+func (s *csAuditHmacScheme) Tag(name enc.Name, wire []byte) []byte {
+	mac := hmac.New(sha256.New, s.key[:])
 
 	// 域分离，避免与其它用途的 HMAC 混用
 	mac.Write(csAuditBlsTagDomain)
@@ -72,9 +347,120 @@ func ComputeCsAuditBlstag(name enc.Name, wire []byte) [32]byte {
 	mac.Write(u32[:])
 	mac.Write(wire)
 
-	sum := mac.Sum(nil)
-	var out [32]byte
-	copy(out[:], sum)
-	return out
+	return mac.Sum(nil)
 }
 
+func (s *csAuditHmacScheme) Identity() []byte {
+	return make([]byte, sha256.Size)
+}
+
+// 中文说明：异或聚合（Katz-Lindell 聚合 MAC），加法与减法相同。
+func (s *csAuditHmacScheme) Add(agg []byte, tag []byte) ([]byte, error) {
+	if len(agg) != sha256.Size || len(tag) != sha256.Size {
+		return nil, fmt.Errorf("hmac-sha256 audit tag must be %d bytes", sha256.Size)
+	}
+	out := make([]byte, sha256.Size)
+	for i := range out {
+		out[i] = agg[i] ^ tag[i]
+	}
+	return out, nil
+}
+
+func (s *csAuditHmacScheme) Sub(agg []byte, tag []byte) ([]byte, error) {
+	return s.Add(agg, tag)
+}
+
+func (s *csAuditHmacScheme) PublicKey() []byte {
+	return nil
+}
+
+func (s *csAuditHmacScheme) TagType() uint64 {
+	return audit.TagTypeHmacSha256
+}
+
+// 中文说明：对称方案下生产者与本节点共享密钥，直接重算对比即可（忽略 pub）。
+func (s *csAuditHmacScheme) Verify(name enc.Name, wire []byte, tag []byte, _ []byte) bool {
+	return hmac.Equal(s.Tag(name, wire), tag)
+}
+
+func (s *csAuditHmacScheme) Sign(digest [32]byte) []byte {
+	mac := hmac.New(sha256.New, s.key[:])
+	mac.Write(csAuditProofDomain)
+	mac.Write(digest[:])
+	return mac.Sum(nil)
+}
+
+// 中文说明：异或聚合没有标量乘法，加权标签为 HMAC(key, domain || coeff || tag)。
+func (s *csAuditHmacScheme) Scale(tag []byte, coeff [32]byte) ([]byte, error) {
+	if len(tag) != sha256.Size {
+		return nil, fmt.Errorf("hmac-sha256 audit tag must be %d bytes", sha256.Size)
+	}
+	mac := hmac.New(sha256.New, s.key[:])
+	mac.Write(csAuditCoeffDomain)
+	mac.Write(coeff[:])
+	mac.Write(tag)
+	return mac.Sum(nil), nil
+}
+
+// csAuditBlsScheme 是 BLS12-381 方案：σ = sk·H(digest)，聚合为 G1 点加法。
+type csAuditBlsScheme struct {
+	sk *audit.BlsSecretKey
+	pk []byte
+
+	// 中文说明：已解析的生产者公钥缓存（G2 解压开销较大）。
+	pkCache sync.Map
+}
+
+func (s *csAuditBlsScheme) String() string {
+	return CsAuditSchemeBls
+}
+
+func (s *csAuditBlsScheme) Tag(name enc.Name, wire []byte) []byte {
+	return s.sk.Sign(audit.TagDigest(name, wire))
+}
+
+func (s *csAuditBlsScheme) Identity() []byte {
+	id, _ := audit.BlsAggregate()
+	return id
+}
+
+func (s *csAuditBlsScheme) Add(agg []byte, tag []byte) ([]byte, error) {
+	return audit.BlsAggregate(agg, tag)
+}
+
+func (s *csAuditBlsScheme) Sub(agg []byte, tag []byte) ([]byte, error) {
+	return audit.BlsSubtract(agg, tag)
+}
+
+func (s *csAuditBlsScheme) PublicKey() []byte {
+	return s.pk
+}
+
+func (s *csAuditBlsScheme) TagType() uint64 {
+	return audit.TagTypeBls12381
+}
+
+func (s *csAuditBlsScheme) Verify(name enc.Name, wire []byte, tag []byte, pub []byte) bool {
+	if len(pub) == 0 {
+		return false
+	}
+	var pk *audit.BlsPublicKey
+	if v, ok := s.pkCache.Load(string(pub)); ok {
+		pk = v.(*audit.BlsPublicKey)
+	} else {
+		var err error
+		if pk, err = audit.ParseBlsPublicKey(pub); err != nil {
+			return false
+		}
+		s.pkCache.Store(string(pub), pk)
+	}
+	return audit.BlsVerify(pk, audit.TagDigest(name, wire), tag)
+}
+
+func (s *csAuditBlsScheme) Sign(digest [32]byte) []byte {
+	return s.sk.Sign(digest)
+}
+
+func (s *csAuditBlsScheme) Scale(tag []byte, coeff [32]byte) ([]byte, error) {
+	return audit.BlsScale(tag, coeff)
+}
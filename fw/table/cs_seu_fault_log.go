@@ -0,0 +1,107 @@
+package table
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/named-data/ndnd/fw/core"
+)
+
+// 中文说明：SEU 故障日志（tables.content_store.seu.fault_log）。
+//
+// - 文件在启动时截断；第一行为注入器参数（含实际使用的种子），之后每个故障一行 JSON。
+// - 每行直接写入文件（不缓冲），转发器异常退出时也不会丢失已注入的故障。
+// - 用同一个种子重放时，故障序列（按线程与 seq）应逐行一致；可与审计历史（cs-audit-status）按名字对照检测覆盖率。
+
+// CsSeuBit 是一个被改变的 bit。
+type CsSeuBit struct {
+	// Byte 为 wire 中的字节偏移（metadata 模型为 staleTime 中的字节）。
+	Byte int  `json:"byte"`
+	Bit  int  `json:"bit"`
+	Old  byte `json:"old"`
+	New  byte `json:"new"`
+}
+
+// CsSeuFault 是一次注入的故障。
+type CsSeuFault struct {
+	Seq    uint64     `json:"seq"`
+	Time   time.Time  `json:"time"`
+	Thread int        `json:"thread"`
+	Model  string     `json:"model"`
+	Region string     `json:"region,omitempty"`
+	Name   string     `json:"name"`
+	Bits   []CsSeuBit `json:"bits"`
+
+	OldStaleTime *time.Time `json:"old_stale_time,omitempty"`
+	NewStaleTime *time.Time `json:"new_stale_time,omitempty"`
+	// Reapplied 表示 stuck-at 故障在条目被刷新后再次生效。
+	Reapplied bool `json:"reapplied,omitempty"`
+}
+
+// csSeuFaultLogHeader 是故障日志的第一行。
+type csSeuFaultLogHeader struct {
+	Seed      uint64    `json:"seed"`
+	Model     string    `json:"model"`
+	Region    string    `json:"region"`
+	Bits      int       `json:"bits"`
+	BurstSpan int       `json:"burst_span"`
+	Rate      float64   `json:"rate_per_bit_per_day"`
+	Prefix    string    `json:"prefix"`
+	Start     time.Time `json:"start"`
+}
+
+var csSeuFaultLog struct {
+	mutex sync.Mutex
+	file  *os.File
+}
+
+// openCsSeuFaultLog 创建（截断）故障日志并写入参数行。
+func openCsSeuFaultLog(path string) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	seu := &core.C.Tables.ContentStore.Seu
+	header, _ := json.Marshal(csSeuFaultLogHeader{
+		Seed:      CfgCsSeuSeed(),
+		Model:     seu.Model,
+		Region:    seu.Region,
+		Bits:      seu.Bits,
+		BurstSpan: seu.BurstSpan,
+		Rate:      seu.RatePerBitPerDay,
+		Prefix:    seu.Prefix,
+		Start:     time.Now(),
+	})
+	if _, err := file.Write(append(header, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+
+	csSeuFaultLog.mutex.Lock()
+	defer csSeuFaultLog.mutex.Unlock()
+	if csSeuFaultLog.file != nil {
+		csSeuFaultLog.file.Close()
+	}
+	csSeuFaultLog.file = file
+	return nil
+}
+
+// writeCsSeuFault 把一个故障追加到故障日志；未配置日志时不做任何事。
+func writeCsSeuFault(fault CsSeuFault) {
+	csSeuFaultLog.mutex.Lock()
+	defer csSeuFaultLog.mutex.Unlock()
+	if csSeuFaultLog.file == nil {
+		return
+	}
+
+	line, err := json.Marshal(fault)
+	if err != nil {
+		return
+	}
+	if _, err := csSeuFaultLog.file.Write(append(line, '\n')); err != nil {
+		core.Log.Warn(nil, "Unable to write SEU fault log", "err", err)
+	}
+}
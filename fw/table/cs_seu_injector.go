@@ -1,188 +1,364 @@
 package table
 
 import (
-	"crypto/rand"
-	"encoding/binary"
 	"math"
-	"os"
-	"strconv"
+	"math/rand/v2"
+	"slices"
+	"sync/atomic"
 	"time"
 
 	"github.com/named-data/ndnd/fw/core"
 	enc "github.com/named-data/ndnd/std/encoding"
 )
 
-// 中文说明：SEU（Single Event Upset）比特翻转注入器（泊松过程）。
+// 中文说明：SEU（Single Event Upset）故障注入器（泊松过程）。
 //
-// 目标：用给定的 SEU 率 r（bit^-1·day^-1）模拟缓存内容的静默比特翻转，
-//      以验证审计机制（BLSTag 重算对比）能够检测并清除损坏条目。
+// 目标：用给定的 SEU 率 r（bit^-1·day^-1）模拟缓存内容的静默损坏，
+//      以验证审计机制（BLSTag 重算对比）能够检测并清除损坏条目，并比较不同故障模型下的检测覆盖率。
 //
-// 模型：
+// 到达过程：
 // - 每个 bit 的翻转是独立泊松过程，率为 r（按天计）。
-// - 若当前 CS 中总比特数为 B，则“系统发生一次翻转事件”的到达率为 λ = r * B（按天计）。
+// - 若当前可注入区域的总比特数为 B，则“系统发生一次故障事件”的到达率为 λ = r * B（按天计）。
 // - 等价地，事件间隔服从指数分布：Δt ~ Exp(λ_sec)，其中 λ_sec = λ / 86400。
 //
+// 故障模型（tables.content_store.seu.model）：
+// - single：翻转 1 bit；
+// - adjacent：从目标 bit 起连续翻转 bits 个 bit（相邻多位翻转）；
+// - burst：在目标字节起 burst_span 字节的窗口内随机翻转 bits 个不同的 bit（突发多位翻转）；
+// - stuck-at：目标 bit 被固定为 0 或 1，条目被刷新/修复后仍会再次生效，直到条目被删除；
+// - metadata：翻转条目 staleTime 的 1 bit，而不是 wire（审计标签无法检测，用于对照）。
+// region 可把 wire 上的故障限制在 Name、Content 或签名（SignatureInfo + SignatureValue）的 TLV 值内。
+//
+// 可复现：
+// - 每个转发线程使用由 seed 与线程序号确定的 PCG 随机数；候选条目按 index 排序，与 map 遍历顺序无关；
+//   事件时间按上一次计划时间累加。因此 seed、配置与缓存内容相同时，注入的故障序列完全相同。
+// - 配置 fault_log 后，每个故障以一行 JSON 追加到日志（见 cs_seu_fault_log.go），可与审计历史逐条对照。
+//
 // 注意：
-// - 注入翻转不会发布 CsAuditEvent（因为它模拟“静默损坏”），因此 CSNAT 中仍保存旧标签；
-//   下一次挑战会重算标签并触发不一致，从而删除条目。
+// - 注入故障不会发布 CsAuditEvent（因为它模拟“静默损坏”），因此 CSNAT 中仍保存旧标签；
+//   下一次挑战会重算标签并触发不一致，从而删除（或修复）条目。
 
-const csSeuDefaultRatePerBitPerDay = 1.51e-7
+const (
+	CsSeuModelSingle   = "single"
+	CsSeuModelAdjacent = "adjacent"
+	CsSeuModelBurst    = "burst"
+	CsSeuModelStuckAt  = "stuck-at"
+	CsSeuModelMetadata = "metadata"
+)
 
-func cfgCsSeuEnabled() bool {
-	switch os.Getenv("NDND_CS_SEU_ENABLE") {
-	case "1", "true", "TRUE", "yes", "YES", "on", "ON":
-		return true
-	default:
-		return false
-	}
-}
+const (
+	CsSeuRegionAny       = "any"
+	CsSeuRegionName      = "name"
+	CsSeuRegionContent   = "content"
+	CsSeuRegionSignature = "signature"
+)
 
-func cfgCsSeuLogEnabled() bool {
-	switch os.Getenv("NDND_CS_SEU_LOG") {
-	case "1", "true", "TRUE", "yes", "YES", "on", "ON":
-		return true
-	default:
-		// 中文说明：若未单独开启 SEU 日志，则跟随审计日志开关，方便调试。
-		return CfgCsAuditLogEnabled()
-	}
-}
+// csSeuIdleCheck 为没有可注入条目时的重新检查间隔。
+const csSeuIdleCheck = 30 * time.Second
 
-func cfgCsSeuRatePerBitPerDay() float64 {
-	v := os.Getenv("NDND_CS_SEU_RATE_PER_BIT_PER_DAY")
-	if v == "" {
-		return csSeuDefaultRatePerBitPerDay
-	}
-	r, err := strconv.ParseFloat(v, 64)
-	if err != nil || r <= 0 || math.IsNaN(r) || math.IsInf(r, 0) {
-		return csSeuDefaultRatePerBitPerDay
-	}
-	return r
-}
+// csSeuThreadSeq 为每个 PIT-CS 分配注入器序号（与转发线程创建顺序一致）。
+var csSeuThreadSeq atomic.Int64
 
-func cfgCsSeuPrefix() enc.Name {
-	// 默认只对业务前缀注入，避免影响 /localhost 管理面与路由控制面。
-	v := os.Getenv("NDND_CS_SEU_PREFIX")
-	if v == "" {
-		v = "/minindn"
-	}
-	p, err := enc.NameFromStr(v)
-	if err != nil {
-		return enc.Name{}
-	}
-	return p
+// csSeuStuckBit 是一个 stuck-at 故障：wire 中第 pos 个 bit 被固定为 value。
+type csSeuStuckBit struct {
+	pos   int
+	value byte
 }
 
-// randUint64 returns a random uint64 (best-effort; on failure returns 0).
-func randUint64() uint64 {
-	var b [8]byte
-	_, _ = rand.Read(b[:])
-	return binary.BigEndian.Uint64(b[:])
+// csSeuTarget 是一个可注入的缓存条目及其可注入区域。
+type csSeuTarget struct {
+	entry *nameTreeCsEntry
+	// ranges 为 wire 中可注入的字节区间 [start, end)
+	ranges [][2]int
+	bits   uint64
 }
 
-// randFloat64Open01 returns a float64 in (0,1), best-effort.
-func randFloat64Open01() float64 {
-	u := randUint64()
-	// 将 [0,2^64-1] 映射到 (0,1)；避免 0 导致 ln(0)。
-	if u == 0 {
-		u = 1
-	}
-	return float64(u) / (float64(^uint64(0)) + 1.0)
+// initCsSeu 初始化 PIT-CS 的注入器状态（由 NewPitCS 调用）。
+func (p *PitCsTree) initCsSeu() {
+	p.csSeuID = int(csSeuThreadSeq.Add(1) - 1)
+	p.csSeuRng = rand.New(rand.NewPCG(CfgCsSeuSeed(), uint64(p.csSeuID)))
+	p.csSeuStuck = make(map[uint64][]csSeuStuckBit)
 }
 
-func sampleExpDuration(lambdaPerSec float64) time.Duration {
+// sampleExpDuration 从 Exp(lambdaPerSec) 采样一个时间间隔。
+func sampleExpDuration(rng *rand.Rand, lambdaPerSec float64) time.Duration {
 	if lambdaPerSec <= 0 || math.IsNaN(lambdaPerSec) || math.IsInf(lambdaPerSec, 0) {
 		return 0
 	}
-	u := randFloat64Open01()
-	sec := -math.Log(u) / lambdaPerSec
-	if sec < 0 {
-		sec = 0
-	}
+	sec := rng.ExpFloat64() / lambdaPerSec
 	// 防止极小 λ 导致 Duration 溢出（Duration 最大约 290 年）
 	const max = 200 * 365 * 24 * time.Hour
 	d := time.Duration(sec * float64(time.Second))
-	if d > max {
+	if d > max || d < 0 {
 		return max
 	}
 	return d
 }
 
-// seuMaybeInject flips one random bit in CS according to SEU Poisson process.
-// It must be called from the forwarding thread (PitCsTree.Update()).
-func (p *PitCsTree) seuMaybeInject(now time.Time) {
-	if !cfgCsSeuEnabled() {
-		return
+// csSeuRegions 返回 wire 中属于 region 的字节区间（TLV 值部分）。
+func csSeuRegions(wire []byte, region string) [][2]int {
+	if region == CsSeuRegionAny || region == "" {
+		return [][2]int{{0, len(wire)}}
 	}
-	if !p.csSeuNext.IsZero() && now.Before(p.csSeuNext) {
-		return
+
+	r := enc.NewBufferView(wire)
+	if _, err := r.ReadTLNum(); err != nil {
+		return nil
+	}
+	if _, err := r.ReadTLNum(); err != nil {
+		return nil
 	}
 
-	prefix := cfgCsSeuPrefix()
+	var ranges [][2]int
+	for !r.IsEOF() {
+		typ, err := r.ReadTLNum()
+		if err != nil {
+			break
+		}
+		l, err := r.ReadTLNum()
+		if err != nil {
+			break
+		}
+		start := r.Pos()
+		if r.Skip(int(l)) != nil {
+			break
+		}
+		if start == r.Pos() {
+			continue
+		}
+
+		switch {
+		case region == CsSeuRegionName && typ == enc.TypeName,
+			region == CsSeuRegionContent && typ == 0x15,
+			region == CsSeuRegionSignature && (typ == 0x16 || typ == 0x17):
+			ranges = append(ranges, [2]int{start, r.Pos()})
+		}
+	}
+	return ranges
+}
 
-	// 计算当前可注入集合的总 bit 数 B
-	var totalBits uint64 = 0
-	for _, entry := range p.csMap {
+// seuTargets 返回按 index 排序的可注入条目与总比特数 B。
+func (p *PitCsTree) seuTargets(prefix enc.Name, model, region string) ([]csSeuTarget, uint64) {
+	indexes := make([]uint64, 0, len(p.csMap))
+	for index, entry := range p.csMap {
 		if entry == nil || len(entry.wire) == 0 {
 			continue
 		}
 		if len(prefix) > 0 && !prefix.IsPrefix(entry.node.name) {
 			continue
 		}
-		totalBits += uint64(len(entry.wire)) * 8
+		indexes = append(indexes, index)
+	}
+	slices.Sort(indexes)
+
+	var total uint64
+	targets := make([]csSeuTarget, 0, len(indexes))
+	for _, index := range indexes {
+		entry := p.csMap[index]
+		target := csSeuTarget{entry: entry}
+		if model == CsSeuModelMetadata {
+			target.bits = 64
+		} else {
+			target.ranges = csSeuRegions(entry.wire, region)
+			for _, rg := range target.ranges {
+				target.bits += uint64(rg[1]-rg[0]) * 8
+			}
+		}
+		if target.bits > 0 {
+			targets = append(targets, target)
+			total += target.bits
+		}
 	}
+	return targets, total
+}
+
+// seuMaybeInject injects one fault into the CS according to the SEU Poisson process.
+// It must be called from the forwarding thread (PitCsTree.Update()).
+func (p *PitCsTree) seuMaybeInject(now time.Time) {
+	if !CfgCsSeuEnabled() {
+		return
+	}
+	// stuck-at 故障在条目被刷新/修复后再次生效
+	if len(p.csSeuStuck) > 0 {
+		p.seuApplyStuck(now)
+	}
+
+	// 配置在运行时改变后，按新的速率/前缀重新采样下一次注入时间
+	if gen := csAuditCfg.seuGen.Load(); gen != p.csSeuGen {
+		p.csSeuGen = gen
+		p.csSeuNext = time.Time{}
+	}
+	if !p.csSeuNext.IsZero() && now.Before(p.csSeuNext) {
+		return
+	}
+
+	seu := &core.C.Tables.ContentStore.Seu
+	prefix := CfgCsSeuPrefix()
+	targets, totalBits := p.seuTargets(prefix, seu.Model, seu.Region)
 
 	// 若当前没有可注入条目，则稍后再检查（避免空转）
 	if totalBits == 0 {
-		p.csSeuNext = now.Add(30 * time.Second)
+		p.csSeuNext = now.Add(csSeuIdleCheck)
 		return
 	}
 
-	// 发生一次翻转事件：按 bit 均匀选择目标
-	targetBit := randUint64() % totalBits
-	var curBits uint64 = 0
-	for _, entry := range p.csMap {
-		if entry == nil || len(entry.wire) == 0 {
-			continue
+	// 第一次（或配置变化后）只采样事件时间；之后按计划时间累加，使事件序列与 tick 抖动无关
+	if p.csSeuNext.IsZero() {
+		p.csSeuNext = now
+	} else {
+		// 发生一次故障事件：按 bit 均匀选择目标
+		targetBit := p.csSeuRng.Uint64N(totalBits)
+		for _, target := range targets {
+			if targetBit >= target.bits {
+				targetBit -= target.bits
+				continue
+			}
+			p.seuInject(target, targetBit, now)
+			break
 		}
-		if len(prefix) > 0 && !prefix.IsPrefix(entry.node.name) {
-			continue
+	}
+
+	// 采样下一次事件时间：λ_sec = (r/86400)*B
+	rPerDay := CfgCsSeuRatePerBitPerDay()
+	lambdaPerSec := (rPerDay / 86400.0) * float64(totalBits)
+	delta := sampleExpDuration(p.csSeuRng, lambdaPerSec)
+	if delta <= 0 {
+		delta = csSeuIdleCheck
+	}
+	p.csSeuNext = p.csSeuNext.Add(delta)
+}
+
+// seuInject 按配置的故障模型对 target 注入一次故障；off 为目标 bit 在可注入区域内的偏移。
+func (p *PitCsTree) seuInject(target csSeuTarget, off uint64, now time.Time) {
+	seu := &core.C.Tables.ContentStore.Seu
+	entry := target.entry
+	p.csSeuSeq++
+	fault := CsSeuFault{
+		Seq:    p.csSeuSeq,
+		Time:   now,
+		Thread: p.csSeuID,
+		Model:  seu.Model,
+		Name:   entry.node.name.String(),
+	}
+
+	if seu.Model == CsSeuModelMetadata {
+		bit := off % 64
+		old := entry.staleTime
+		entry.staleTime = time.Unix(0, old.UnixNano()^int64(uint64(1)<<bit))
+		fault.Bits = []CsSeuBit{{Byte: int(bit / 8), Bit: int(bit % 8)}}
+		fault.OldStaleTime = &old
+		fault.NewStaleTime = &entry.staleTime
+	} else {
+		fault.Region = seu.Region
+
+		// 把区域内偏移换算成 wire 中的 bit 位置，并确定该区间的边界
+		var pos, end int
+		for _, rg := range target.ranges {
+			n := uint64(rg[1]-rg[0]) * 8
+			if off < n {
+				pos, end = rg[0]*8+int(off), rg[1]*8
+				break
+			}
+			off -= n
+		}
+
+		switch seu.Model {
+		case CsSeuModelAdjacent:
+			for i := pos; i < min(pos+seu.Bits, end); i++ {
+				fault.Bits = append(fault.Bits, flipCsWireBit(entry.wire, i))
+			}
+		case CsSeuModelBurst:
+			// 窗口从目标字节开始；目标 bit 总是被翻转
+			winStart := pos / 8 * 8
+			winEnd := min(winStart+seu.BurstSpan*8, end)
+			positions := []int{pos}
+			for len(positions) < min(seu.Bits, winEnd-winStart) {
+				i := winStart + p.csSeuRng.IntN(winEnd-winStart)
+				if !slices.Contains(positions, i) {
+					positions = append(positions, i)
+				}
+			}
+			slices.Sort(positions)
+			for _, i := range positions {
+				fault.Bits = append(fault.Bits, flipCsWireBit(entry.wire, i))
+			}
+		case CsSeuModelStuckAt:
+			stuck := csSeuStuckBit{pos: pos, value: byte(p.csSeuRng.IntN(2))}
+			p.csSeuStuck[entry.index] = append(p.csSeuStuck[entry.index], stuck)
+			fault.Bits = []CsSeuBit{setCsWireBit(entry.wire, stuck)}
+		default:
+			fault.Bits = []CsSeuBit{flipCsWireBit(entry.wire, pos)}
 		}
+	}
+
+	p.recordCsSeuFault(fault)
+}
 
-		entryBits := uint64(len(entry.wire)) * 8
-		if targetBit >= curBits+entryBits {
-			curBits += entryBits
+// seuApplyStuck 重新施加 stuck-at 故障；只有确实改变了 wire 时才记录。
+func (p *PitCsTree) seuApplyStuck(now time.Time) {
+	for index, stuck := range p.csSeuStuck {
+		entry, ok := p.csMap[index]
+		if !ok {
+			delete(p.csSeuStuck, index)
 			continue
 		}
 
-		off := targetBit - curBits
-		byteIndex := int(off / 8)
-		bitIndex := int(off % 8)
-		mask := byte(1 << uint(bitIndex))
-
-		oldB := entry.wire[byteIndex]
-		newB := oldB ^ mask
-		entry.wire[byteIndex] = newB
-
-		if cfgCsSeuLogEnabled() {
-			core.Log.Info(nil, "【审计】SEU 注入：随机比特翻转（泊松过程）",
-				"name", entry.node.name,
-				"byteIndex", byteIndex,
-				"bitIndex", bitIndex,
-				"old", oldB,
-				"new", newB,
-				"totalBits", totalBits,
-				"prefix", prefix.String(),
-			)
+		var bits []CsSeuBit
+		for _, s := range stuck {
+			if s.pos/8 < len(entry.wire) && (entry.wire[s.pos/8]>>(s.pos%8))&1 != s.value {
+				bits = append(bits, setCsWireBit(entry.wire, s))
+			}
+		}
+		if len(bits) > 0 {
+			p.csSeuSeq++
+			p.recordCsSeuFault(CsSeuFault{
+				Seq:       p.csSeuSeq,
+				Time:      now,
+				Thread:    p.csSeuID,
+				Model:     CsSeuModelStuckAt,
+				Name:      entry.node.name.String(),
+				Bits:      bits,
+				Reapplied: true,
+			})
 		}
-		break
 	}
+}
 
-	// 采样下一次事件时间：λ_sec = (r/86400)*B
-	rPerDay := cfgCsSeuRatePerBitPerDay()
-	lambdaPerSec := (rPerDay / 86400.0) * float64(totalBits)
-	delta := sampleExpDuration(lambdaPerSec)
-	if delta <= 0 {
-		delta = 30 * time.Second
+// recordCsSeuFault 更新计数器并输出日志。
+func (p *PitCsTree) recordCsSeuFault(fault CsSeuFault) {
+	csAuditCounters.seuInjections.Add(1)
+	writeCsSeuFault(fault)
+
+	if cfgCsSeuLogEnabled() {
+		core.Log.Info(nil, "【审计】SEU 注入（泊松过程）",
+			"name", fault.Name,
+			"model", fault.Model,
+			"region", fault.Region,
+			"bits", fault.Bits,
+			"reapplied", fault.Reapplied,
+			"seq", fault.Seq,
+			"thread", fault.Thread,
+		)
+	}
+}
+
+// flipCsWireBit 翻转 wire 中第 pos 个 bit。
+func flipCsWireBit(wire []byte, pos int) CsSeuBit {
+	b := CsSeuBit{Byte: pos / 8, Bit: pos % 8, Old: wire[pos/8]}
+	wire[pos/8] ^= 1 << uint(pos%8)
+	b.New = wire[pos/8]
+	return b
+}
+
+// setCsWireBit 把 wire 中的一个 bit 固定为 stuck-at 值。
+func setCsWireBit(wire []byte, s csSeuStuckBit) CsSeuBit {
+	b := CsSeuBit{Byte: s.pos / 8, Bit: s.pos % 8, Old: wire[s.pos/8]}
+	mask := byte(1 << uint(s.pos%8))
+	if s.value == 0 {
+		wire[s.pos/8] &^= mask
+	} else {
+		wire[s.pos/8] |= mask
 	}
-	p.csSeuNext = now.Add(delta)
+	b.New = wire[s.pos/8]
+	return b
 }
@@ -2,52 +2,101 @@ package table
 
 import (
 	"sync"
+	"time"
 
+	"github.com/named-data/ndnd/fw/core"
 	enc "github.com/named-data/ndnd/std/encoding"
 )
 
 var csSha256StartOnce sync.Once
-var csNatSha256 = newCsNatSha256Tree()
+
+// csNatSha256 为本节点的 CSNAT，由 loadCsAuditScheme 按配置的审计方案创建。
+var csNatSha256 *CsNatSha256Tree
 
 // StartCsSha256Auditor 启动一个同机进程内的“SHA-256 审计者”。
 //
 // 中文说明：
 // - 审计者会持续从 CsAuditEvents 读取事件，对 (Name, Wire) 计算 BLSTag，并写入 CSNAT（前缀聚合树）。
+// - 若启用了审计日志（见 CfgCsAuditJournalPath），先用日志重建 CSNAT，并在 CfgCsAuditReconcileDelay 后与 CS 对账。
 // - 该函数是幂等的：多次调用只会启动一次 goroutine。
 func StartCsSha256Auditor() {
 	csSha256StartOnce.Do(func() {
+		var restore *csNatRestore
+		var reconcile <-chan time.Time
+		if path := CfgCsAuditJournalPath(); path != "" {
+			var err error
+			restore, err = openCsAuditJournal(path, CsAuditScheme(), csNatSha256)
+			if err != nil {
+				core.Log.Error(nil, "Unable to open CS audit journal", "path", path, "err", err)
+			} else if restore.nRestored > 0 {
+				core.Log.Info(nil, "Restored CSNAT from journal", "path", path, "leaves", restore.nRestored)
+				reconcile = time.After(CfgCsAuditReconcileDelay())
+			}
+		}
+
 		go func() {
-			for ev := range CsAuditEvents {
-				switch ev.Type {
-				case CsAuditEventInsert:
-					tag := ComputeCsAuditBlstag(ev.Name, ev.Wire)
-					// 中文说明：把 BLSTag(Name, Wire) 当作当前阶段的“审计标签”（tag），写入 CSNAT 的对应叶子节点，
-					// 并触发沿路径向上的聚合值重算。
-					csNatSha256.OnInsert(ev.Name, tag, ev.StaleTime)
-				case CsAuditEventRefresh:
-					tag := ComputeCsAuditBlstag(ev.Name, ev.Wire)
-					csNatSha256.OnRefresh(ev.Name, tag, ev.StaleTime)
-				case CsAuditEventErase:
-					// 中文说明：CS 淘汰/删除时，清除叶子标签并尽可能剪枝，保证 CSNAT 与真实 CS 一致。
-					_ = csNatSha256.OnErase(ev.Name)
-				default:
-					// 忽略未知事件
+			for {
+				select {
+				case ev := <-CsAuditEvents:
+					handleCsAuditEvent(ev, restore)
+				case <-reconcile:
+					restore.reconcile(csNatSha256)
+					reconcile = nil
 				}
 			}
 		}()
 	})
 }
 
+// handleCsAuditEvent 在审计者 goroutine 中处理一个 CS 事件。
+func handleCsAuditEvent(ev CsAuditEvent, restore *csNatRestore) {
+	switch ev.Type {
+	case CsAuditEventInsert:
+		// 中文说明：把审计标签（生产者嵌入的标签，或本节点计算的 BLSTag(Name, Wire)）写入 CSNAT 的对应叶子节点，
+		// 并触发沿路径向上的聚合值重算。
+		tag := csAuditLeafTag(CsAuditScheme(), ev.Name, ev.Wire)
+		if restore.take(ev.Name, tag) {
+			// 叶子已由日志重建，替换为实时标签（不重复计数）
+			csNatSha256.OnRefresh(ev.Name, tag, ev.StaleTime)
+		} else {
+			csNatSha256.OnInsert(ev.Name, tag, ev.StaleTime)
+		}
+		journalCsNatLeaf(ev.Name, tag, ev.StaleTime)
+	case CsAuditEventRefresh:
+		tag := csAuditLeafTag(CsAuditScheme(), ev.Name, ev.Wire)
+		csNatSha256.OnRefresh(ev.Name, tag, ev.StaleTime)
+		journalCsNatLeaf(ev.Name, tag, ev.StaleTime)
+	case CsAuditEventErase:
+		// 中文说明：CS 淘汰/删除时，清除叶子标签并尽可能剪枝，保证 CSNAT 与真实 CS 一致。
+		_ = csNatSha256.OnErase(ev.Name)
+		if _, ok := csNatSha256.GetLeafTag(ev.Name); !ok {
+			unjournalCsNatLeaf(ev.Name)
+		}
+	default:
+		// 忽略未知事件
+	}
+}
+
 // GetCsNatSha256Agg 查询某个前缀子树的聚合标签（SHA-256）。
 func GetCsNatSha256Agg(prefix enc.Name) ([32]byte, bool) {
 	return csNatSha256.GetAggregatedTagByPrefix(prefix)
 }
 
-// GetCsNatSha256Leaf 查询某个精确 Name 对应的叶子标签（SHA-256）。
-func GetCsNatSha256Leaf(name enc.Name) ([32]byte, bool) {
+// GetCsNatSha256Leaf 查询某个精确 Name 对应的叶子标签。
+func GetCsNatSha256Leaf(name enc.Name) ([]byte, bool) {
 	return csNatSha256.GetLeafTag(name)
 }
 
+// GetCsNatTagAgg 查询某个前缀子树的同态聚合标签（方案由 CsAuditScheme 决定）。
+func GetCsNatTagAgg(prefix enc.Name) ([]byte, bool) {
+	return csNatSha256.GetTagAggByPrefix(prefix)
+}
+
+// GetCsNatPrefixAggregates 查询 prefix 子树及其下 depth 层以内各前缀的聚合值与叶子数。
+func GetCsNatPrefixAggregates(prefix enc.Name, depth int) ([]CsNatPrefixAggregate, bool) {
+	return csNatSha256.GetPrefixAggregates(prefix, depth)
+}
+
 // GetCsNatSha256Stats 返回 CSNAT 的统计信息（用于审计日志/调试）。
 func GetCsNatSha256Stats() (nodeCount uint64, activeLeafCount uint64, rootAgg [32]byte) {
 	return csNatSha256.Stats()
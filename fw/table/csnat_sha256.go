@@ -3,10 +3,12 @@ package table
 import (
 	"crypto/sha256"
 	"encoding/binary"
+	"slices"
 	"sort"
 	"sync"
 	"time"
 
+	"github.com/named-data/ndnd/fw/core"
 	enc "github.com/named-data/ndnd/std/encoding"
 )
 
@@ -15,12 +17,16 @@ import (
 // 中文说明：
 //   - 结构：按 NDN Name 组件分层的多叉树/Trie（每一层一个 component）。
 //   - 叶子：每个被缓存的 Data name（精确名）对应一个叶子条目（节点可同时有 children）。
-//   - 标签：这里用 BLSTag(Name, Wire) 作为叶子审计标签（当前占位实现为 HMAC-SHA256；后续可替换为真正 BLS 签名标签）。
-//   - 聚合：聚合仍采用 SHA-256 的“Merkle 化”方式：父节点的 Agg 由自己的叶子标签 + 所有子节点的 Agg 计算得到，
-//     支持按 Prefix 查询子树的聚合值。
+//   - 标签：这里用 BLSTag(Name, Wire) 作为叶子审计标签（具体方案见 CsAuditTagScheme）。
+//   - 聚合：维护两种聚合值：
+//     1) agg：SHA-256 的“Merkle 化”聚合，父节点的 Agg 由自己的叶子标签 + 所有子节点的 Agg 计算得到，
+//     绑定树结构，用于本机一致性对比；
+//     2) tagAgg：方案相关的同态聚合（BLS 为 G1 点加法），即子树内所有叶子标签之和，
+//     第三方审计者可用生产者公钥直接验证。
 type CsNatSha256Tree struct {
-	mu   sync.RWMutex
-	root *csNatSha256Node
+	mu     sync.RWMutex
+	root   *csNatSha256Node
+	scheme CsAuditTagScheme
 
 	// 统计信息（用于日志/调试）
 	nodeCount       uint64 // 树节点数量（含 root）
@@ -38,20 +44,23 @@ type csNatSha256Node struct {
 	children map[string]*csNatSha256Node // key: string(component TLV bytes)
 
 	leafCount uint32
-	leafTag   [32]byte
+	leafTag   []byte
 	staleTime time.Time
 
-	agg [32]byte
+	agg    [32]byte
+	tagAgg []byte // 子树内所有叶子标签的同态聚合
 }
 
 // 中文说明：这是“聚合哈希”的域分离标识；即使叶子标签从纯 sha256 切换到 BLSTag，这个值也可以保持不变。
 var csNatSha256Domain = []byte("ndnd-csnat-sha256-v1")
 
-func newCsNatSha256Tree() *CsNatSha256Tree {
+func newCsNatSha256Tree(scheme CsAuditTagScheme) *CsNatSha256Tree {
 	t := &CsNatSha256Tree{
 		root: &csNatSha256Node{
 			children: make(map[string]*csNatSha256Node),
+			tagAgg:   scheme.Identity(),
 		},
+		scheme: scheme,
 	}
 	t.nodeCount = 1
 	// 空树的根节点也有确定的聚合值
@@ -81,7 +90,7 @@ func (n *csNatSha256Node) recomputeAgg() {
 	binary.BigEndian.PutUint32(u32[:], n.leafCount)
 	h.Write(u32[:])
 	if n.leafCount > 0 {
-		h.Write(n.leafTag[:])
+		h.Write(n.leafTag)
 	}
 
 	// 为避免拼接歧义，加入 child 数量与每个 component 的长度前缀
@@ -116,6 +125,7 @@ func (t *CsNatSha256Tree) findOrCreateNodeLocked(name enc.Name) *csNatSha256Node
 				parent:   cur,
 				compWire: compWire,
 				children: make(map[string]*csNatSha256Node),
+				tagAgg:   t.scheme.Identity(),
 			}
 			cur.children[key] = child
 			t.nodeCount++
@@ -138,17 +148,56 @@ func (t *CsNatSha256Tree) findNodeLocked(prefix enc.Name) *csNatSha256Node {
 	return cur
 }
 
+// updateTagAggLocked 沿 leaf 到 root 的路径增量更新同态聚合值：agg ← agg - oldTag + newTag。
+//
+// 中文说明：
+//   - oldTag/newTag 为 nil 表示“无贡献”（例如首次插入或最后一次删除）。
+//   - 先计算路径上所有新的聚合值，全部成功后再一次性提交；
+//     任一节点失败时返回错误且不修改任何聚合值，调用方据此保持叶子标签不变，使叶子与聚合值保持一致。
+func (t *CsNatSha256Tree) updateTagAggLocked(leaf *csNatSha256Node, oldTag []byte, newTag []byte) error {
+	aggs := make([][]byte, 0, 8)
+	for n := leaf; n != nil; n = n.parent {
+		agg := n.tagAgg
+		var err error
+		if oldTag != nil {
+			if agg, err = t.scheme.Sub(agg, oldTag); err != nil {
+				return err
+			}
+		}
+		if newTag != nil {
+			if agg, err = t.scheme.Add(agg, newTag); err != nil {
+				return err
+			}
+		}
+		aggs = append(aggs, agg)
+	}
+
+	i := 0
+	for n := leaf; n != nil; n = n.parent {
+		n.tagAgg = aggs[i]
+		i++
+	}
+	return nil
+}
+
 // OnInsert 用于处理“首次入缓存”事件：增加 leafCount，并更新叶子标签，然后自底向上重算聚合值。
-func (t *CsNatSha256Tree) OnInsert(name enc.Name, tag [32]byte, staleTime time.Time) {
+func (t *CsNatSha256Tree) OnInsert(name enc.Name, tag []byte, staleTime time.Time) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
 	leaf := t.findOrCreateNodeLocked(name)
+	var oldTag []byte
 	if leaf.leafCount == 0 {
 		t.activeLeafCount++
+	} else {
+		oldTag = leaf.leafTag
+	}
+	if err := t.updateTagAggLocked(leaf, oldTag, tag); err != nil {
+		core.Log.Warn(nil, "Unable to update CSNAT tag aggregate", "name", name, "err", err)
+	} else {
+		leaf.leafTag = tag
 	}
 	leaf.leafCount++
-	leaf.leafTag = tag
 	leaf.staleTime = staleTime
 
 	// 自底向上更新聚合值
@@ -158,17 +207,24 @@ func (t *CsNatSha256Tree) OnInsert(name enc.Name, tag [32]byte, staleTime time.T
 }
 
 // OnRefresh 用于处理“同名覆盖/刷新缓存”事件：不改变 leafCount，只更新标签并重算。
-func (t *CsNatSha256Tree) OnRefresh(name enc.Name, tag [32]byte, staleTime time.Time) {
+func (t *CsNatSha256Tree) OnRefresh(name enc.Name, tag []byte, staleTime time.Time) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
 	leaf := t.findOrCreateNodeLocked(name)
+	var oldTag []byte
 	if leaf.leafCount == 0 {
 		// 容错：若审计端漏了 Insert 事件，把 Refresh 当作 Insert。
 		leaf.leafCount = 1
 		t.activeLeafCount++
+	} else {
+		oldTag = leaf.leafTag
+	}
+	if err := t.updateTagAggLocked(leaf, oldTag, tag); err != nil {
+		core.Log.Warn(nil, "Unable to update CSNAT tag aggregate", "name", name, "err", err)
+	} else {
+		leaf.leafTag = tag
 	}
-	leaf.leafTag = tag
 	leaf.staleTime = staleTime
 
 	for n := leaf; n != nil; n = n.parent {
@@ -192,6 +248,10 @@ func (t *CsNatSha256Tree) OnErase(name enc.Name) bool {
 
 	leaf.leafCount--
 	if leaf.leafCount == 0 {
+		if err := t.updateTagAggLocked(leaf, leaf.leafTag, nil); err != nil {
+			core.Log.Warn(nil, "Unable to update CSNAT tag aggregate", "name", name, "err", err)
+		}
+		leaf.leafTag = nil
 		leaf.staleTime = time.Time{}
 		if t.activeLeafCount > 0 {
 			t.activeLeafCount--
@@ -229,16 +289,28 @@ func (t *CsNatSha256Tree) GetAggregatedTagByPrefix(prefix enc.Name) ([32]byte, b
 	return node.agg, true
 }
 
+// GetTagAggByPrefix 查询某个前缀子树内所有叶子标签的同态聚合值（BLS 方案下可由第三方用公钥验证）。
+func (t *CsNatSha256Tree) GetTagAggByPrefix(prefix enc.Name) ([]byte, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	node := t.findNodeLocked(prefix)
+	if node == nil {
+		return nil, false
+	}
+	return slices.Clone(node.tagAgg), true
+}
+
 // GetLeafTag 查询某个精确 Name 对应的叶子标签（如果该 Name 处有缓存条目）。
-func (t *CsNatSha256Tree) GetLeafTag(name enc.Name) ([32]byte, bool) {
+func (t *CsNatSha256Tree) GetLeafTag(name enc.Name) ([]byte, bool) {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 
 	node := t.findNodeLocked(name)
 	if node == nil || node.leafCount == 0 {
-		return [32]byte{}, false
+		return nil, false
 	}
-	return node.leafTag, true
+	return slices.Clone(node.leafTag), true
 }
 
 // Stats 返回 CSNAT 的调试统计信息（节点数、有效叶子数、根聚合值）。
@@ -247,3 +319,61 @@ func (t *CsNatSha256Tree) Stats() (nodeCount uint64, activeLeafCount uint64, roo
 	defer t.mu.RUnlock()
 	return t.nodeCount, t.activeLeafCount, t.root.agg
 }
+
+// CsNatPrefixAggregate 是某个前缀子树的聚合值。
+type CsNatPrefixAggregate struct {
+	Name enc.Name
+	// LeafCount 为子树内的缓存条目数（多线程时同一 Name 可能计多次）。
+	LeafCount uint64
+	// Cached 为名字恰好等于 Name 的缓存条目数（0 表示只是前缀）。
+	Cached uint64
+	Agg    [32]byte
+	TagAgg []byte
+}
+
+// GetPrefixAggregates 返回 prefix 子树及其下 depth 层以内所有节点的聚合值。
+//
+// 中文说明：
+// - 结果按先序遍历排列，子节点按 component 的 TLV 字节序排序，因此不同节点上相同的子树给出相同的列表，可直接逐项比较。
+// - depth=0 只返回 prefix 本身；prefix 不存在时返回 false。
+func (t *CsNatSha256Tree) GetPrefixAggregates(prefix enc.Name, depth int) ([]CsNatPrefixAggregate, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	node := t.findNodeLocked(prefix)
+	if node == nil {
+		return nil, false
+	}
+
+	var aggs []CsNatPrefixAggregate
+	var walk func(n *csNatSha256Node, name enc.Name, level int) uint64
+	walk = func(n *csNatSha256Node, name enc.Name, level int) uint64 {
+		// 先占位，子树的叶子数在遍历完子节点后回填
+		pos := len(aggs)
+		if level <= depth {
+			aggs = append(aggs, CsNatPrefixAggregate{
+				Name:   name.Clone(),
+				Cached: uint64(n.leafCount),
+				Agg:    n.agg,
+				TagAgg: slices.Clone(n.tagAgg),
+			})
+		}
+
+		count := uint64(n.leafCount)
+		for _, k := range n.childKeysSorted() {
+			child := n.children[k]
+			comp, err := enc.ComponentFromBytes(child.compWire)
+			if err != nil {
+				continue
+			}
+			count += walk(child, name.Append(comp), level+1)
+		}
+
+		if level <= depth {
+			aggs[pos].LeafCount = count
+		}
+		return count
+	}
+	walk(node, prefix, 0)
+	return aggs, true
+}
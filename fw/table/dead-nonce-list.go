@@ -8,6 +8,7 @@
 package table
 
 import (
+	"sync/atomic"
 	"time"
 
 	enc "github.com/named-data/ndnd/std/encoding"
@@ -19,6 +20,8 @@ type DeadNonceList struct {
 	list            map[uint64]bool
 	expirationQueue priority_queue.Queue[uint64, int64]
 	Ticker          *time.Ticker
+	// Number of entries, readable from other goroutines
+	size atomic.Int64
 }
 
 // NewDeadNonceList creates a new Dead Nonce List for a forwarding thread.
@@ -45,6 +48,7 @@ func (d *DeadNonceList) Insert(name enc.Name, nonce uint32) bool {
 	if !exists {
 		d.list[hash] = true
 		d.expirationQueue.Push(hash, time.Now().Add(CfgDeadNonceListLifetime()).UnixNano())
+		d.size.Add(1)
 	}
 	return exists
 }
@@ -55,6 +59,7 @@ func (d *DeadNonceList) RemoveExpiredEntries() {
 	for d.expirationQueue.Len() > 0 && d.expirationQueue.PeekPriority() < time.Now().UnixNano() {
 		hash := d.expirationQueue.Pop()
 		delete(d.list, hash)
+		d.size.Add(-1)
 		evicted += 1
 
 		if evicted >= 100 {
@@ -62,3 +67,9 @@ func (d *DeadNonceList) RemoveExpiredEntries() {
 		}
 	}
 }
+
+// Size returns the number of entries in the Dead Nonce List.
+// This is safe to call from any goroutine.
+func (d *DeadNonceList) Size() int {
+	return int(d.size.Load())
+}
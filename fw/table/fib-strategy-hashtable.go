@@ -249,6 +249,27 @@ func (f *FibStrategyHashTable) FindNextHopsEnc(name enc.Name) []*FibNextHopEntry
 	return nil
 }
 
+// FindNextHopsPrefixEnc returns the prefix of the entry FindNextHopsEnc takes the nexthops from.
+// Returns nil if there are no matching nexthops.
+func (f *FibStrategyHashTable) FindNextHopsPrefixEnc(name enc.Name) enc.Name {
+	f.fibStrategyRWMutex.RLock()
+	defer f.fibStrategyRWMutex.RUnlock()
+
+	entry := f.findLongestPrefixMatchEnc(name)
+	if entry == nil {
+		return nil
+	}
+
+	prefixHash := name.PrefixHash()
+	for pfx := len(entry.name); pfx >= 0; pfx-- {
+		val, ok := f.realTable[prefixHash[pfx]]
+		if ok && len(val.nexthops) > 0 {
+			return val.name
+		}
+	}
+	return nil
+}
+
 // FindStrategy returns the longest-prefix matching strategy choice entry for the specified name.
 
 // (AI GENERATED DESCRIPTION): Finds and returns the strategy name for the longest‑matching prefix of a given name, searching ancestor prefixes in the FIB until a strategy is found or nil is returned if none exists.
@@ -53,6 +53,10 @@ func TestFindNextHopsEncEnc_HT(t *testing.T) {
 	nexthops1a := FibStrategyTable.FindNextHopsEnc(name1)
 	assert.Equal(t, 0, len(nexthops1a))
 
+	// Prefix of the matching entry
+	assert.True(t, name2.Equal(FibStrategyTable.FindNextHopsPrefixEnc(name3)))
+	assert.Nil(t, FibStrategyTable.FindNextHopsPrefixEnc(name1))
+
 	// Next hops should be updated when they're removed
 	FibStrategyTable.RemoveNextHopEnc(name2, 25)
 	nexthops2b := FibStrategyTable.FindNextHopsEnc(name2)
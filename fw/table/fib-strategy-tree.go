@@ -124,6 +124,20 @@ func (f *FibStrategyTree) FindNextHopsEnc(name enc.Name) []*FibNextHopEntry {
 	return []*FibNextHopEntry{}
 }
 
+// FindNextHopsPrefixEnc returns the prefix of the entry FindNextHopsEnc takes the nexthops from.
+// Returns nil if there are no matching nexthops.
+func (f *FibStrategyTree) FindNextHopsPrefixEnc(name enc.Name) enc.Name {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	for entry := f.root.findLongestPrefixEntryEnc(name); entry != nil; entry = entry.parent {
+		if len(entry.nexthops) > 0 {
+			return entry.name
+		}
+	}
+	return nil
+}
+
 // FindStrategy returns the longest-prefix matching strategy choice entry for the specified name.
 func (f *FibStrategyTree) FindStrategyEnc(name enc.Name) enc.Name {
 	f.mutex.RLock()
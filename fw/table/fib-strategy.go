@@ -36,6 +36,7 @@ type FibNextHopEntry struct {
 // FibStrategy represents the functionality that a FIB-strategy table should implement.
 type FibStrategy interface {
 	FindNextHopsEnc(name enc.Name) []*FibNextHopEntry
+	FindNextHopsPrefixEnc(name enc.Name) enc.Name
 	FindStrategyEnc(name enc.Name) enc.Name
 	InsertNextHopEnc(name enc.Name, nextHop uint64, cost uint64)
 	ClearNextHopsEnc(name enc.Name)
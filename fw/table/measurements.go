@@ -0,0 +1,135 @@
+package table
+
+import (
+	"time"
+
+	enc "github.com/named-data/ndnd/std/encoding"
+)
+
+// Bounds of the retransmission timeout computed from RTT samples (RFC 6298).
+const (
+	measurementsInitialRto = time.Second
+	measurementsMinRto     = 200 * time.Millisecond
+	measurementsMaxRto     = time.Minute
+)
+
+// Measurements stores per-prefix measurements for the strategies of a forwarding thread.
+// Warning: All functions must be called in the same forwarding goroutine as the creation of the table.
+type Measurements struct {
+	entries map[uint64]*MeasurementsEntry
+	Ticker  *time.Ticker
+}
+
+// MeasurementsEntry contains the measurements for a name prefix.
+type MeasurementsEntry struct {
+	name           enc.Name
+	expirationTime time.Time
+
+	// Faces contains the statistics of each upstream face, keyed by face ID.
+	Faces map[uint64]*FaceMeasurement
+	// StrategyInfo is strategy-specific state attached to the entry.
+	StrategyInfo any
+}
+
+// FaceMeasurement contains the RTT and timeout statistics of a face for a prefix.
+type FaceMeasurement struct {
+	// SRTT is the smoothed round-trip time, zero if there is no sample yet.
+	SRTT time.Duration
+	// RTTVar is the round-trip time variation.
+	RTTVar time.Duration
+	// RTO is the retransmission timeout.
+	RTO time.Duration
+	// LastRTT is the latest round-trip time sample.
+	LastRTT time.Duration
+	// NTimeouts is the number of consecutive timeouts or Nacks.
+	NTimeouts int
+	// LastTimeout is the time of the latest timeout or Nack.
+	LastTimeout time.Time
+}
+
+// NewMeasurements creates a new measurements table for a forwarding thread.
+func NewMeasurements() *Measurements {
+	return &Measurements{
+		entries: make(map[uint64]*MeasurementsEntry),
+		Ticker:  time.NewTicker(time.Second),
+	}
+}
+
+// Get returns the entry for a name prefix, creating it if needed.
+// The entry is kept for at least the given lifetime from now.
+func (m *Measurements) Get(name enc.Name, lifetime time.Duration) *MeasurementsEntry {
+	hash := name.Hash()
+	entry, ok := m.entries[hash]
+	if !ok || !entry.name.Equal(name) {
+		entry = &MeasurementsEntry{
+			name:  name.Clone(),
+			Faces: make(map[uint64]*FaceMeasurement),
+		}
+		m.entries[hash] = entry
+	}
+
+	if expiry := time.Now().Add(lifetime); expiry.After(entry.expirationTime) {
+		entry.expirationTime = expiry
+	}
+	return entry
+}
+
+// Find returns the entry for a name prefix, or nil if there is none.
+func (m *Measurements) Find(name enc.Name) *MeasurementsEntry {
+	if entry, ok := m.entries[name.Hash()]; ok && entry.name.Equal(name) {
+		return entry
+	}
+	return nil
+}
+
+// Size returns the number of entries in the table.
+func (m *Measurements) Size() int {
+	return len(m.entries)
+}
+
+// RemoveExpiredEntries removes all entries that have not been used within their lifetime.
+func (m *Measurements) RemoveExpiredEntries() {
+	now := time.Now()
+	for hash, entry := range m.entries {
+		if entry.expirationTime.Before(now) {
+			delete(m.entries, hash)
+		}
+	}
+}
+
+// Name returns the name prefix of the entry.
+func (e *MeasurementsEntry) Name() enc.Name {
+	return e.name
+}
+
+// Face returns the statistics of a face, creating them if needed.
+func (e *MeasurementsEntry) Face(faceID uint64) *FaceMeasurement {
+	fm, ok := e.Faces[faceID]
+	if !ok {
+		fm = &FaceMeasurement{RTO: measurementsInitialRto}
+		e.Faces[faceID] = fm
+	}
+	return fm
+}
+
+// AddRttSample updates the statistics with a round-trip time sample
+// and resets the consecutive timeout counter.
+func (f *FaceMeasurement) AddRttSample(rtt time.Duration) {
+	if f.SRTT == 0 {
+		f.SRTT = rtt
+		f.RTTVar = rtt / 2
+	} else {
+		f.RTTVar = (3*f.RTTVar + (f.SRTT - rtt).Abs()) / 4
+		f.SRTT = (7*f.SRTT + rtt) / 8
+	}
+	f.RTO = min(max(f.SRTT+4*f.RTTVar, measurementsMinRto), measurementsMaxRto)
+	f.LastRTT = rtt
+	f.NTimeouts = 0
+}
+
+// AddTimeout records a timeout or Nack and backs off the retransmission timeout.
+func (f *FaceMeasurement) AddTimeout() {
+	f.NTimeouts++
+	f.LastTimeout = time.Now()
+	f.RTO = min(2*f.RTO, measurementsMaxRto)
+}
@@ -0,0 +1,62 @@
+package table
+
+import (
+	"testing"
+	"time"
+
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/stretchr/testify/assert"
+)
+
+// Tests creation, lookup and expiration of measurements entries.
+func TestMeasurementsEntries(t *testing.T) {
+	m := NewMeasurements()
+	defer m.Ticker.Stop()
+
+	name1, _ := enc.NameFromStr("/test/one")
+	name2, _ := enc.NameFromStr("/test/two")
+
+	assert.Nil(t, m.Find(name1))
+	entry1 := m.Get(name1, time.Hour)
+	assert.True(t, name1.Equal(entry1.Name()))
+	assert.Equal(t, entry1, m.Find(name1))
+	assert.Equal(t, entry1, m.Get(name1, time.Hour))
+
+	entry2 := m.Get(name2, -time.Second)
+	assert.NotEqual(t, entry1, entry2)
+	assert.Equal(t, 2, m.Size())
+
+	// Only the expired entry is removed
+	m.RemoveExpiredEntries()
+	assert.Equal(t, 1, m.Size())
+	assert.Equal(t, entry1, m.Find(name1))
+	assert.Nil(t, m.Find(name2))
+}
+
+// Tests the RTT estimator and timeout backoff of face measurements.
+func TestFaceMeasurement(t *testing.T) {
+	entry := NewMeasurements().Get(enc.Name{}, time.Hour)
+	fm := entry.Face(10)
+	assert.Equal(t, fm, entry.Face(10))
+	assert.Equal(t, measurementsInitialRto, fm.RTO)
+
+	fm.AddRttSample(100 * time.Millisecond)
+	assert.Equal(t, 100*time.Millisecond, fm.SRTT)
+	assert.Equal(t, 50*time.Millisecond, fm.RTTVar)
+	assert.Equal(t, 300*time.Millisecond, fm.RTO)
+
+	fm.AddRttSample(20 * time.Millisecond)
+	assert.Equal(t, 90*time.Millisecond, fm.SRTT)
+	assert.Equal(t, 57500*time.Microsecond, fm.RTTVar)
+	assert.Equal(t, 20*time.Millisecond, fm.LastRTT)
+
+	fm.AddTimeout()
+	fm.AddTimeout()
+	assert.Equal(t, 2, fm.NTimeouts)
+	assert.Equal(t, 4*(90+4*57500/1000)*time.Millisecond, fm.RTO)
+
+	// A new sample resets the timeout counter
+	fm.AddRttSample(10 * time.Millisecond)
+	assert.Equal(t, 0, fm.NTimeouts)
+	assert.Equal(t, fm.SRTT+4*fm.RTTVar, fm.RTO)
+}
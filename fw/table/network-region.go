@@ -8,6 +8,10 @@
 package table
 
 import (
+	"fmt"
+	"slices"
+	"sync/atomic"
+
 	enc "github.com/named-data/ndnd/std/encoding"
 )
 
@@ -15,25 +19,59 @@ import (
 var NetworkRegion = &networkRegionTable{}
 
 type networkRegionTable struct {
-	table []enc.Name
+	// Replaced as a whole, since it is read by all forwarding threads
+	table atomic.Pointer[[]enc.Name]
+}
+
+// get returns the current producer region names.
+func (n *networkRegionTable) get() []enc.Name {
+	if table := n.table.Load(); table != nil {
+		return *table
+	}
+	return nil
 }
 
 // Add adds a name to the network region table.
 func (n *networkRegionTable) Add(name enc.Name) {
-	for _, region := range n.table {
+	for _, region := range n.get() {
 		if region.Equal(name) {
 			return
 		}
 	}
-	n.table = append(n.table, name)
+	table := append(slices.Clone(n.get()), name)
+	n.table.Store(&table)
+}
+
+// Set replaces all names in the network region table.
+func (n *networkRegionTable) Set(names []enc.Name) {
+	table := make([]enc.Name, 0, len(names))
+	for _, name := range names {
+		if !slices.ContainsFunc(table, name.Equal) {
+			table = append(table, name)
+		}
+	}
+	n.table.Store(&table)
 }
 
 // IsProducer returns whether an entry in the network region table is a prefix of the specified name.
 func (n *networkRegionTable) IsProducer(name enc.Name) bool {
-	for _, region := range n.table {
+	for _, region := range n.get() {
 		if region.IsPrefix(name) {
 			return true
 		}
 	}
 	return false
 }
+
+// ParseNetworkRegions parses the producer region names of the configuration.
+func ParseNetworkRegions(regions []string) ([]enc.Name, error) {
+	names := make([]enc.Name, 0, len(regions))
+	for _, region := range regions {
+		name, err := enc.NameFromStr(region)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network region %s: %w", region, err)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
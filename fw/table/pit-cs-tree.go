@@ -1,6 +1,8 @@
 package table
 
 import (
+	"bytes"
+	"math/rand/v2"
 	"sync/atomic"
 	"time"
 
@@ -27,6 +29,8 @@ type PitCsTree struct {
 	nCsEntries    atomic.Int64
 	csReplacement CsReplacementPolicy
 	csMap         map[uint64]*nameTreeCsEntry
+	// 中文说明：CS 条目 index 的数组（无序），供挑战轮按位置抽样或分批遍历（见 cs_audit_sample.go）。
+	csIndexes []uint64
 
 	pitExpiryQueue priority_queue.Queue[*nameTreePitEntry, int64]
 	updateTicker   *time.Ticker
@@ -34,6 +38,19 @@ type PitCsTree struct {
 
 	// 中文说明：SEU（Single Event Upset）注入器的下一次触发时间（泊松过程采样得到）。
 	csSeuNext time.Time
+	csSeuGen  uint64
+	// 中文说明：注入器序号、确定性随机数、已注入故障的序号，以及 stuck-at 故障（按条目 index）。
+	csSeuID    int
+	csSeuRng   *rand.Rand
+	csSeuSeq   uint64
+	csSeuStuck map[uint64][]csSeuStuckBit
+	// 中文说明：远程审计挑战请求通道（每个转发线程一个）。
+	csAuditChallengeCh chan csAuditChallengeReq
+	// 中文说明：定时（抽样）挑战请求通道，以及正在进行的挑战轮。
+	csAuditRoundCh chan csAuditRoundReq
+	csAuditRound   *csAuditRound
+	// 中文说明：正在修复的损坏条目（见 cs_audit_repair.go）。
+	csAuditRepairing map[uint64]*nameTreeCsEntry
 }
 
 type nameTreePitEntry struct {
@@ -46,6 +63,8 @@ type nameTreePitEntry struct {
 type nameTreeCsEntry struct {
 	baseCsEntry                // compose with BasePitEntry
 	node        *pitCsTreeNode // the tree node associated with this entry
+	repair      *csAuditRepair // non-nil while a corrupted entry is being repaired
+	auditPos    int            // position of the index in PitCsTree.csIndexes
 }
 
 // pitCsTreeNode represents an entry in a PIT-CS tree.
@@ -81,6 +100,10 @@ func NewPitCS(onExpiration OnPitExpiration) *PitCsTree {
 		core.Log.Fatal(nil, "Unknown CS replacement policy", "policy", CfgCsReplacementPolicy())
 	}
 	pitCs.csMap = make(map[uint64]*nameTreeCsEntry)
+	pitCs.csAuditChallengeCh = registerCsAuditChallengeCh()
+	pitCs.csAuditRoundCh = registerCsAuditRoundCh()
+	pitCs.csAuditRepairing = make(map[uint64]*nameTreeCsEntry)
+	pitCs.initCsSeu()
 
 	return pitCs
 }
@@ -109,56 +132,58 @@ func (p *PitCsTree) Update() {
 		p.handleCsAuditFlipReq(req)
 	}
 
+	// 中文说明：处理远程审计挑战（来自管理线程），基于实时缓存 wire 计算聚合证明。
+	for {
+		req, ok := p.popCsAuditChallengeReq()
+		if !ok {
+			break
+		}
+		p.handleCsAuditChallengeReq(req)
+	}
+
 	// 中文说明：SEU 注入器（泊松过程）——按给定 bit^-1·day^-1 概率对 CS 条目随机翻转 1 bit（静默损坏模拟）。
 	p.seuMaybeInject(time.Now())
 
 	// 中文说明：定时挑战（由 table.StartCsSha256Challenger 触发），在转发线程内重算 CS 条目的 BLSTag，
-	// 把 proof 发给 auditor 进行验证。
-	if popCsSha256ChallengeReq() {
-		p.auditSha256ChallengeOnce()
-	}
-}
-
-func (p *PitCsTree) auditSha256ChallengeOnce() {
-	now := time.Now()
-
-	// 注意：不要在 range csMap 时删除 map 元素；先收集需要删除的 index。
-	var toErase []uint64
-	nTotal := 0
-
-	for index, entry := range p.csMap {
-		nTotal++
-		// 中文说明：挑战时在转发线程内重算 BLSTag（当前占位实现为 HMAC-SHA256），用于检测缓存 wire 是否被篡改。
-		computed := ComputeCsAuditBlstag(entry.node.name, entry.wire)
-		publishCsSha256Proof(CsSha256Proof{
-			Name:     entry.node.name.Clone(),
-			Index:    index,
-			Computed: computed,
-			Time:     now,
-		})
-
-		// 若与 CSNAT 中记录的叶子标签不一致，认为该缓存条目已损坏，标记删除。
-		if expected, ok := GetCsNatSha256Leaf(entry.node.name); ok && expected != computed {
-			toErase = append(toErase, index)
+	// 把 proof 发给 auditor 进行验证。每轮可以只抽样部分条目，并分摊到多个 tick 上完成（见 cs_audit_sample.go）。
+	if req, ok := p.popCsAuditRoundReq(); ok {
+		if p.csAuditRound != nil {
+			addCsAuditOverrun()
+			replyCsAuditRound(req, CsAuditRoundResult{NOverruns: 1})
+		} else {
+			p.startCsAuditRound(req)
 		}
 	}
+	if p.csAuditRound != nil {
+		p.stepCsAuditRound()
+	}
 
-	// 先打印汇总，再执行删除，方便定位“哪些条目触发了删除”。
-	for _, index := range toErase {
-		p.eraseCsDataFromReplacementStrategy(index)
+	// 中文说明：超时仍未修复的损坏条目直接删除。
+	if len(p.csAuditRepairing) > 0 {
+		p.expireCsAuditRepairs(time.Now())
 	}
+}
 
-	if CfgCsAuditLogEnabled() {
-		nodeCount, activeLeafCount, rootAgg := GetCsNatSha256Stats()
-		core.Log.Info(nil, "【审计】挑战完成",
-			"nEntries", nTotal,
-			"nMismatched", len(toErase),
-			"csnatNodes", nodeCount,
-			"csnatLeaves", activeLeafCount,
-			"csnatRootAgg", rootAgg,
-			"time", now.Format(time.RFC3339Nano),
-		)
+// csAuditProveEntry 为单个缓存条目生成挑战证明，并发布给 verifier。
+//
+// 中文说明：
+// - 在转发线程内重算 BLSTag（方案见 CsAuditTagScheme），用于检测缓存 wire 是否被篡改；
+// - 对携带生产者标签的条目，改为用可信的生产者公钥（见 csAuditTrustedKey）校验标签。
+// - 返回证明标签，以及条目是否完好（ok=false 表示应当删除）。
+func (p *PitCsTree) csAuditProveEntry(entry *nameTreeCsEntry, now time.Time) ([]byte, bool) {
+	computed, valid := csAuditProve(CsAuditScheme(), entry.node.name, entry.wire)
+	publishCsSha256Proof(CsSha256Proof{
+		Name:     entry.node.name.Clone(),
+		Index:    entry.index,
+		Computed: computed,
+		Valid:    valid,
+		Time:     now,
+	})
+
+	if expected, ok := GetCsNatSha256Leaf(entry.node.name); ok && (!valid || !bytes.Equal(expected, computed)) {
+		return computed, false
 	}
+	return computed, true
 }
 
 // (AI GENERATED DESCRIPTION): Updates or inserts the given PIT entry into the expiration priority queue, setting its priority to the entry’s expiration time.
@@ -409,7 +434,7 @@ func (p *PitCsTree) FindMatchingDataFromCS(interest *defn.FwInterest) CsEntry {
 	node := p.root.findExactMatchEntryEnc(interest.NameV)
 	if node != nil {
 		if !interest.CanBePrefixV {
-			if node.csEntry != nil &&
+			if node.csEntry != nil && node.csEntry.repair == nil &&
 				(!interest.MustBeFreshV || time.Now().Before(node.csEntry.staleTime)) {
 				p.csReplacement.BeforeUse(node.csEntry.index, node.csEntry.wire)
 				return node.csEntry
@@ -436,6 +461,11 @@ func (p *PitCsTree) InsertData(data *defn.FwData, wire []byte) {
 	copy(store, wire)
 
 	if entry, ok := p.csMap[index]; ok {
+		// 中文说明：正在修复的损坏条目只能被标签与 CSNAT 一致的 Data 替换。
+		if entry.repair != nil && !p.acceptCsAuditRepair(entry, store) {
+			return
+		}
+
 		// Replace existing entry
 		entry.wire = store
 		entry.staleTime = staleTime
@@ -471,9 +501,11 @@ func (p *PitCsTree) InsertData(data *defn.FwData, wire []byte) {
 				wire:      store,
 				staleTime: staleTime,
 			},
+			auditPos: len(p.csIndexes),
 		}
 
 		p.csMap[index] = node.csEntry
+		p.csIndexes = append(p.csIndexes, index)
 		p.csReplacement.AfterInsert(index, wire, data)
 		// 中文说明：同上，给审计事件一份独立的 wire 拷贝。
 		auditWire := make([]byte, len(store))
@@ -515,6 +547,9 @@ func (p *PitCsTree) eraseCsDataFromReplacementStrategy(index uint64) {
 
 		entry.node.csEntry = nil
 		delete(p.csMap, index)
+		p.removeCsIndex(entry.auditPos)
+		delete(p.csAuditRepairing, index)
+		delete(p.csSeuStuck, index)
 		p.nCsEntries.Add(-1)
 	}
 }
@@ -525,7 +560,8 @@ func (p *PitCsTree) eraseCsDataFromReplacementStrategy(index uint64) {
 // For example, if we have data for /a/b/v=10 and the interest is /a/b,
 // p should be the `b` node, not the root node.
 func (p *pitCsTreeNode) findMatchingDataCSPrefix(interest *defn.FwInterest) CsEntry {
-	if p.csEntry != nil && (!interest.MustBeFreshV || time.Now().Before(p.csEntry.staleTime)) {
+	if p.csEntry != nil && p.csEntry.repair == nil &&
+		(!interest.MustBeFreshV || time.Now().Before(p.csEntry.staleTime)) {
 		// A csEntry exists at this node and is acceptable to satisfy the interest
 		return p.csEntry
 	}
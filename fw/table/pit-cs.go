@@ -12,6 +12,7 @@ import (
 
 	"github.com/named-data/ndnd/fw/defn"
 	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/types/optional"
 )
 
 // PitCsTable dictates what functionality a Pit-Cs table should implement
@@ -104,6 +105,8 @@ type PitInRecord struct {
 	LatestNonce     uint32
 	ExpirationTime  time.Time
 	PitToken        []byte
+	// NonDiscovery is the NDNLPv2 NonDiscovery flag of the latest Interest.
+	NonDiscovery bool
 }
 
 // PitOutRecord records an outgoing Interest on a given face.
@@ -112,6 +115,8 @@ type PitOutRecord struct {
 	LatestTimestamp time.Time
 	LatestNonce     uint32
 	ExpirationTime  time.Time
+	// NackReason is the reason of the Nack received for the latest Interest, if any.
+	NackReason optional.Optional[uint64]
 }
 
 // CsEntry is an entry in a thread's CS.
@@ -170,6 +175,7 @@ func (bpe *basePitEntry) InsertOutRecord(interest *defn.FwInterest, face uint64)
 		record.LatestNonce = interest.NonceV.Unwrap()
 		record.LatestTimestamp = time.Now()
 		record.ExpirationTime = time.Now().Add(lifetime)
+		record.NackReason = optional.None[uint64]()
 		bpe.outRecords[face] = record
 		return record
 	}
@@ -178,6 +184,7 @@ func (bpe *basePitEntry) InsertOutRecord(interest *defn.FwInterest, face uint64)
 	record.LatestNonce = interest.NonceV.Unwrap()
 	record.LatestTimestamp = time.Now()
 	record.ExpirationTime = time.Now().Add(lifetime)
+	record.NackReason = optional.None[uint64]()
 	return record
 }
 
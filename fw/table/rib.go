@@ -43,6 +43,14 @@ type Route struct {
 	Cost             uint64
 	Flags            uint64
 	ExpirationPeriod *time.Duration
+	// Announcement is the signed PrefixAnnouncement that created the route,
+	// if any, so readvertisers can propagate the signed object.
+	Announcement enc.Wire
+
+	// expirationTime is when the route expires, if it has an expiration period.
+	expirationTime time.Time
+	// expirationTimer removes the route from the RIB when it expires.
+	expirationTimer *time.Timer
 }
 
 // Rib is the Routing Information Base.
@@ -162,11 +170,72 @@ func (r *RibTable) AddEncRoute(name enc.Name, route *Route) {
 			existingRoute.Cost = route.Cost
 			existingRoute.Flags = route.Flags
 			existingRoute.ExpirationPeriod = route.ExpirationPeriod
+			existingRoute.Announcement = route.Announcement
+			r.scheduleExpirationEnc(node.Name, existingRoute) // refresh
 			return
 		}
 	}
 
 	node.routes = append(node.routes, route)
+	r.scheduleExpirationEnc(node.Name, route)
+}
+
+// scheduleExpirationEnc (re)starts the expiration timer of a route in the RIB.
+// Must be called with the RIB lock held.
+func (r *RibTable) scheduleExpirationEnc(name enc.Name, route *Route) {
+	route.stopExpiration()
+	if route.ExpirationPeriod == nil {
+		return
+	}
+
+	route.expirationTime = time.Now().Add(*route.ExpirationPeriod)
+	route.expirationTimer = time.AfterFunc(*route.ExpirationPeriod, func() {
+		r.expireRouteEnc(name, route)
+	})
+}
+
+// expireRouteEnc removes a route from the RIB when its expiration period has elapsed.
+func (r *RibTable) expireRouteEnc(name enc.Name, route *Route) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// The route may have been refreshed while the timer fired
+	if route.expirationTimer == nil || time.Now().Before(route.expirationTime) {
+		return
+	}
+
+	entry := r.root.findExactMatchEntryEnc(name)
+	if entry == nil {
+		return
+	}
+
+	i := slices.Index(entry.routes, route)
+	if i < 0 {
+		return
+	}
+	entry.routes = slices.Delete(entry.routes, i, i+1)
+	route.expirationTimer = nil
+	readvertiseWithdraw(name, route)
+
+	entry.pruneIfEmpty()
+	entry.updateNexthopsEnc() // recursive
+}
+
+// FindRouteToFaceEnc returns the longest prefix of the name with a route to the face,
+// and a copy of the route. Returns nil if there is no such route.
+func (r *RibTable) FindRouteToFaceEnc(name enc.Name, faceID uint64) (enc.Name, *Route) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for entry := r.root.findLongestPrefixEntryEnc(name); entry != nil; entry = entry.parent {
+		for _, route := range entry.routes {
+			if route.FaceID == faceID {
+				found := *route
+				return entry.Name, &found
+			}
+		}
+	}
+	return nil, nil
 }
 
 // GetAllEntries returns all routes in the RIB.
@@ -210,6 +279,7 @@ func (r *RibTable) RemoveRouteEnc(name enc.Name, faceID uint64, origin uint64) {
 				copy(entry.routes[i:], entry.routes[i+1:])
 			}
 			entry.routes = entry.routes[:len(entry.routes)-1]
+			route.stopExpiration()
 			readvertiseWithdraw(name, route)
 			break
 		}
@@ -246,6 +316,7 @@ func (r *RibEntry) cleanUpFace(faceId uint64) {
 				copy(r.routes[i:], r.routes[i+1:])
 			}
 			r.routes = r.routes[:len(r.routes)-1]
+			route.stopExpiration()
 			readvertiseWithdraw(r.Name, route)
 
 			// entry changed, check and update FIB
@@ -275,3 +346,11 @@ func (r *Route) HasCaptureFlag() bool {
 func (r *Route) HasChildInheritFlag() bool {
 	return r.Flags&uint64(spec_mgmt.RouteFlagChildInherit) != 0
 }
+
+// stopExpiration cancels the expiration timer of the route, if any.
+func (r *Route) stopExpiration() {
+	if r.expirationTimer != nil {
+		r.expirationTimer.Stop()
+		r.expirationTimer = nil
+	}
+}
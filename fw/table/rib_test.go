@@ -0,0 +1,55 @@
+package table
+
+import (
+	"testing"
+	"time"
+
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/stretchr/testify/assert"
+)
+
+// Tests that routes are removed from the RIB and FIB when their expiration period elapses.
+func TestRibRouteExpiration(t *testing.T) {
+	newFibStrategyTableTree()
+	rib := RibTable{root: RibEntry{children: make(map[uint64]*RibEntry)}}
+
+	name, _ := enc.NameFromStr("/expire")
+	short := 50 * time.Millisecond
+	rib.AddEncRoute(name, &Route{FaceID: 10, ExpirationPeriod: &short})
+	rib.AddEncRoute(name, &Route{FaceID: 11})
+	assert.Equal(t, 2, len(FibStrategyTable.FindNextHopsEnc(name)))
+
+	assert.Eventually(t, func() bool {
+		return len(FibStrategyTable.FindNextHopsEnc(name)) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	entries := rib.GetAllEntries()
+	assert.Equal(t, 1, len(entries))
+	assert.Equal(t, uint64(11), entries[0].GetRoutes()[0].FaceID)
+}
+
+// Tests that refreshing a route restarts its expiration timer.
+func TestRibRouteRefresh(t *testing.T) {
+	newFibStrategyTableTree()
+	rib := RibTable{root: RibEntry{children: make(map[uint64]*RibEntry)}}
+
+	name, _ := enc.NameFromStr("/refresh")
+	period := 100 * time.Millisecond
+	rib.AddEncRoute(name, &Route{FaceID: 10, ExpirationPeriod: &period})
+
+	// Refresh before expiry
+	time.Sleep(60 * time.Millisecond)
+	rib.AddEncRoute(name, &Route{FaceID: 10, ExpirationPeriod: &period})
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, 1, len(FibStrategyTable.FindNextHopsEnc(name)))
+
+	// Refresh without expiration period makes the route permanent
+	rib.AddEncRoute(name, &Route{FaceID: 10})
+	time.Sleep(150 * time.Millisecond)
+	assert.Equal(t, 1, len(FibStrategyTable.FindNextHopsEnc(name)))
+
+	// Removed routes do not expire again
+	rib.AddEncRoute(name, &Route{FaceID: 10, ExpirationPeriod: &period})
+	rib.RemoveRouteEnc(name, 10, 0)
+	assert.Equal(t, 0, len(rib.GetAllEntries()))
+}
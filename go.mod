@@ -10,6 +10,7 @@ require (
 	github.com/goccy/go-yaml v1.18.0
 	github.com/gorilla/schema v1.4.1
 	github.com/gorilla/websocket v1.5.3
+	github.com/kilic/bls12-381 v0.1.0
 	github.com/mattn/go-sqlite3 v1.14.32
 	github.com/quic-go/quic-go v0.57.0
 	github.com/quic-go/webtransport-go v0.9.0
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 
 	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/utils/metrics"
 )
 
 type Config struct {
@@ -19,6 +20,8 @@ type Config struct {
 	TrustAnchors []string `json:"trust_anchors"`
 	// IgnoreValidity skips validity period checks when fetching remote data (e.g. SVS snapshots).
 	IgnoreValidity bool `json:"ignore_validity"`
+	// Metrics is the Prometheus metrics HTTP endpoint.
+	Metrics metrics.Config `json:"metrics"`
 
 	// NameN is the parsed name of the repo service.
 	NameN enc.Name
@@ -43,6 +46,10 @@ func (c *Config) Parse() (err error) {
 		}
 		c.StorageDir = path
 	}
+
+	if c.Metrics.Enabled && c.Metrics.Address == "" {
+		return fmt.Errorf("metrics address must be set if metrics are enabled")
+	}
 	return nil
 }
 
@@ -64,6 +71,10 @@ func DefaultConfig() *Config {
 	return &Config{
 		Name:       "", // invalid
 		StorageDir: "", // invalid
+		Metrics: metrics.Config{
+			Enabled: false,
+			Address: "127.0.0.1:9103",
+		},
 
 		NameN: nil,
 	}
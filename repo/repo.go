@@ -2,6 +2,7 @@ package repo
 
 import (
 	"sync"
+	"sync/atomic"
 
 	enc "github.com/named-data/ndnd/std/encoding"
 	"github.com/named-data/ndnd/std/engine"
@@ -13,6 +14,7 @@ import (
 	sec "github.com/named-data/ndnd/std/security"
 	"github.com/named-data/ndnd/std/security/keychain"
 	"github.com/named-data/ndnd/std/security/trust_schema"
+	"github.com/named-data/ndnd/std/utils/metrics"
 )
 
 type Repo struct {
@@ -27,6 +29,12 @@ type Repo struct {
 
 	groupsSvs map[string]*RepoSvs
 	mutex     sync.Mutex
+
+	metrics *metrics.Server
+	// Number of Data packets stored from the network
+	nStoredData atomic.Uint64
+	// Number of Data packets that failed to be stored
+	nStoreErrors atomic.Uint64
 }
 
 // (AI GENERATED DESCRIPTION): Creates a new Repo instance, initializing it with the supplied configuration and an empty map for its groupsSvs.
@@ -97,6 +105,9 @@ func (r *Repo) Start() (err error) {
 		Expose: true,
 	})
 
+	// Start metrics endpoint
+	r.startMetrics()
+
 	return nil
 }
 
@@ -104,6 +115,8 @@ func (r *Repo) Start() (err error) {
 func (r *Repo) Stop() error {
 	log.Info(r, "Stopping NDN Data Repository")
 
+	r.stopMetrics()
+
 	for _, svs := range r.groupsSvs {
 		svs.Stop()
 	}
@@ -132,7 +145,11 @@ func (r *Repo) setupEngineHook() {
 		// We might not want to store non-versioned data anyway (?)
 		if ver := data.Name().At(-2); ver.IsVersion() {
 			log.Trace(r, "Storing data", "name", data.Name())
-			return r.store.Put(data.Name(), raw.Join())
+			if err := r.store.Put(data.Name(), raw.Join()); err != nil {
+				r.nStoreErrors.Add(1)
+				return err
+			}
+			r.nStoredData.Add(1)
 		} else {
 			log.Trace(r, "Ignoring non-versioned data", "name", data.Name())
 		}
@@ -0,0 +1,47 @@
+package repo
+
+import (
+	"github.com/named-data/ndnd/std/log"
+	"github.com/named-data/ndnd/std/utils"
+	"github.com/named-data/ndnd/std/utils/metrics"
+)
+
+// startMetrics starts the metrics endpoint if enabled in the configuration.
+func (r *Repo) startMetrics() {
+	if !r.config.Metrics.Enabled {
+		return
+	}
+
+	r.metrics = metrics.NewServer(r.config.Metrics.Address, r.collectMetrics)
+	if err := r.metrics.Start(); err != nil {
+		log.Error(r, "Unable to start metrics server", "address", r.config.Metrics.Address, "err", err)
+		r.metrics = nil
+		return
+	}
+	log.Info(r, "Serving metrics", "url", r.metrics.URL())
+}
+
+// stopMetrics stops the metrics endpoint.
+func (r *Repo) stopMetrics() {
+	if r.metrics != nil {
+		r.metrics.Stop()
+	}
+}
+
+// collectMetrics returns the current repo metrics.
+func (r *Repo) collectMetrics() []*metrics.Family {
+	r.mutex.Lock()
+	nGroups := len(r.groupsSvs)
+	r.mutex.Unlock()
+
+	return []*metrics.Family{
+		metrics.NewGauge("ndnd_repo_info", "Repo version and name.").
+			Add(1, "version", utils.NDNdVersion, "name", r.config.NameN.String()),
+		metrics.NewGauge("ndnd_repo_svs_groups", "Number of joined SVS groups.").
+			Add(float64(nGroups)),
+		metrics.NewCounter("ndnd_repo_stored_data_total", "Number of Data packets stored.").
+			Add(float64(r.nStoredData.Load())),
+		metrics.NewCounter("ndnd_repo_store_errors_total", "Number of Data packets that could not be stored.").
+			Add(float64(r.nStoreErrors.Load())),
+	}
+}
@@ -148,7 +148,9 @@ func (r *Repo) startSvs(cmd *tlv.SyncJoin) error {
 		if err := svs.Start(); err != nil {
 			return err
 		}
+		r.mutex.Lock()
 		r.groupsSvs[hash] = svs
+		r.mutex.Unlock()
 		return nil
 	}
 }
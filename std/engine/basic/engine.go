@@ -596,11 +596,6 @@ func (e *Engine) Express(interest *ndn.EncodedInterest, callback ndn.ExpressCall
 
 // (AI GENERATED DESCRIPTION): Executes a named‑data management command by crafting a signed Interest for the specified module and command with the given arguments, sending it, validating the response signature, parsing the control response, and returning the result or an error.
 func (e *Engine) ExecMgmtCmd(module string, cmd string, args any) (any, error) {
-	cmdArgs, ok := args.(*mgmt.ControlArgs)
-	if !ok {
-		return nil, ndn.ErrInvalidValue{Item: "args", Value: args}
-	}
-
 	intCfg := &ndn.InterestConfig{
 		Lifetime:    optional.Some(1 * time.Second),
 		Nonce:       utils.ConvertNonce(e.timer.Nonce()),
@@ -610,7 +605,17 @@ func (e *Engine) ExecMgmtCmd(module string, cmd string, args any) (any, error) {
 		SigNonce: e.timer.Nonce(),
 		SigTime:  optional.Some(time.Duration(e.timer.Now().UnixMilli()) * time.Millisecond),
 	}
-	interest, err := e.mgmtConf.MakeCmd(module, cmd, cmdArgs, intCfg)
+
+	var interest *ndn.EncodedInterest
+	var err error
+	switch cmdArgs := args.(type) {
+	case *mgmt.ControlArgs:
+		interest, err = e.mgmtConf.MakeCmd(module, cmd, cmdArgs, intCfg)
+	case enc.Wire:
+		interest, err = e.mgmtConf.MakeCmdAppParam(module, cmd, cmdArgs, intCfg)
+	default:
+		return nil, ndn.ErrInvalidValue{Item: "args", Value: args}
+	}
 	if err != nil {
 		return nil, err
 	}
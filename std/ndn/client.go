@@ -88,6 +88,14 @@ type ProduceArgs struct {
 	FreshnessPeriod time.Duration
 	// NoMetadata disables RDR metadata (advanced usage).
 	NoMetadata bool
+	// AuditTagger attaches a producer audit tag to each Data (optional).
+	AuditTagger AuditTagger
+}
+
+// AuditTagger attaches a cache audit tag to an encoded Data packet.
+type AuditTagger interface {
+	// Attach returns the Data encoding with the audit tag appended.
+	Attach(name enc.Name, wire []byte) ([]byte, error)
 }
 
 // ConsumeState is the state of the consume operation
@@ -179,6 +187,13 @@ type Announcement struct {
 	Cost uint64
 	// Expose the prefix to the global network.
 	Expose bool
+	// Signer signs a PrefixAnnouncement for the prefix. If set, the prefix
+	// is announced with rib/announce instead of rib/register, and the signed
+	// announcement is propagated by the forwarder's readvertisers.
+	Signer Signer
+	// Expiration is the lifetime of the signed announcement (default 1h).
+	// The announcement is refreshed before it expires.
+	Expiration time.Duration
 	// OnError is called when an error occurs.
 	// It may be called multiple times, e.g. if the face is reopened.
 	OnError func(error)
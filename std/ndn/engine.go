@@ -41,7 +41,8 @@ type Engine interface {
 	Express(interest *EncodedInterest, callback ExpressCallbackFunc) error
 
 	// ExecMgmtCmd executes a management command.
-	//   args are the control arguments (*mgmt.ControlArgs)
+	//   args are the control arguments (*mgmt.ControlArgs), or the
+	//   ApplicationParameters of the command (enc.Wire), e.g. for rib/announce
 	//   returns response and error if any (*mgmt.ControlResponse, error)
 	ExecMgmtCmd(module string, cmd string, args any) (any, error)
 	// SetCmdSec sets the interest signing parameters for management commands.
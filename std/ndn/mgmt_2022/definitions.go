@@ -45,6 +45,17 @@ const (
 	CsEnableServe = uint64(2)
 )
 
+const (
+	CsAuditEnableLog = uint64(1)
+	CsAuditEnableSeu = uint64(2)
+)
+
+const (
+	CapturePktInterest = uint64(1)
+	CapturePktData     = uint64(2)
+	CapturePktNack     = uint64(4)
+)
+
 // +tlv-model:dict
 type Strategy struct {
 	//+field:name
@@ -87,6 +98,14 @@ type ControlArgs struct {
 	DefaultCongestionThreshold optional.Optional[uint64] `tlv:"0x88"`
 	//+field:natural:optional
 	Mtu optional.Optional[uint64] `tlv:"0x89"`
+	//+field:natural:optional
+	EgressRate optional.Optional[uint64] `tlv:"0x8a"`
+	//+field:natural:optional
+	EgressBurst optional.Optional[uint64] `tlv:"0x8b"`
+	//+field:binary
+	CertHash []byte `tlv:"0x8c"`
+	//+field:natural:optional
+	PacketTypes optional.Optional[uint64] `tlv:"0x8d"`
 }
 
 // +tlv-model:dict
@@ -228,6 +247,21 @@ type FaceStatus struct {
 
 	//+field:natural
 	Flags uint64 `tlv:"0x6c"`
+
+	//+field:natural:optional
+	NInLpInvalid optional.Optional[uint64] `tlv:"0xca"`
+	//+field:natural:optional
+	NReassemblyTimeouts optional.Optional[uint64] `tlv:"0xcb"`
+	//+field:natural:optional
+	EgressRate optional.Optional[uint64] `tlv:"0x8a"`
+	//+field:natural:optional
+	EgressBurst optional.Optional[uint64] `tlv:"0x8b"`
+	//+field:natural:optional
+	NOutShaped optional.Optional[uint64] `tlv:"0xd1"`
+	//+field:natural:optional
+	NOutDropped optional.Optional[uint64] `tlv:"0xd2"`
+	//+field:natural:optional
+	NReassemblyEvictions optional.Optional[uint64] `tlv:"0xd3"`
 }
 
 type FaceStatusMsg struct {
@@ -337,3 +371,174 @@ type CsQuery struct {
 	PacketSize      uint64
 	FreshnessPeriod uint64
 }
+
+// CsAuditProof is the response to a remote CS audit challenge.
+// ProofSignature covers the encoding of all other fields.
+type CsAuditProof struct {
+	//+field:name
+	Prefix enc.Name `tlv:"0x07"`
+	//+field:binary
+	Nonce []byte `tlv:"0x0301"`
+	//+field:natural
+	Timestamp uint64 `tlv:"0x0302"`
+	//+field:string
+	Scheme string `tlv:"0x0303"`
+	//+field:natural
+	LeafCount uint64 `tlv:"0x0304"`
+	//+field:natural
+	InvalidCount uint64 `tlv:"0x0305"`
+	//+field:binary
+	Aggregate []byte `tlv:"0x0306"`
+	//+field:binary
+	ProofSignature []byte `tlv:"0x0307"`
+}
+
+// CsAuditHistoryEntry is a single recorded CS audit outcome.
+type CsAuditHistoryEntry struct {
+	//+field:natural
+	Timestamp uint64 `tlv:"0x0311"`
+	//+field:string
+	Event string `tlv:"0x0312"`
+	//+field:name
+	Name enc.Name `tlv:"0x07"`
+	//+field:natural
+	Checked uint64 `tlv:"0x0313"`
+	//+field:natural
+	Mismatched uint64 `tlv:"0x0314"`
+}
+
+// CsAuditHistoryMsg is the CS audit history dataset.
+type CsAuditHistoryMsg struct {
+	//+field:sequence:*CsAuditHistoryEntry:struct:CsAuditHistoryEntry
+	Entries []*CsAuditHistoryEntry `tlv:"0x0310"`
+}
+
+// CsAuditStatus is the CS audit status dataset.
+type CsAuditStatus struct {
+	//+field:string
+	Scheme string `tlv:"0x0303"`
+	//+field:natural
+	NCsNatNodes uint64 `tlv:"0x0321"`
+	//+field:natural
+	NCsNatLeaves uint64 `tlv:"0x0322"`
+	//+field:natural
+	NRounds uint64 `tlv:"0x0323"`
+	//+field:natural
+	NRoundOverruns uint64 `tlv:"0x0324"`
+	//+field:natural
+	NRemoteChallenges uint64 `tlv:"0x0325"`
+	//+field:natural
+	NChecked uint64 `tlv:"0x0326"`
+	//+field:natural
+	NProofsVerified uint64 `tlv:"0x0327"`
+	//+field:natural
+	NMismatches uint64 `tlv:"0x0328"`
+	//+field:natural
+	NUnknownProofs uint64 `tlv:"0x0329"`
+	//+field:natural
+	NCorruptEvicted uint64 `tlv:"0x032a"`
+	//+field:natural
+	NSeuInjections uint64 `tlv:"0x032b"`
+	//+field:natural
+	NFlips uint64 `tlv:"0x032c"`
+	//+field:natural
+	NEventsDropped uint64 `tlv:"0x032d"`
+	//+field:natural
+	NProofsDropped uint64 `tlv:"0x032e"`
+	//+field:natural
+	NProducerTagsRejected uint64 `tlv:"0x032f"`
+	//+field:natural
+	NRepairs uint64 `tlv:"0x0341"`
+	//+field:natural
+	NRepairsSucceeded uint64 `tlv:"0x0342"`
+	//+field:natural
+	NRepairsRejected uint64 `tlv:"0x0343"`
+	//+field:natural
+	NRepairsTimedOut uint64 `tlv:"0x0344"`
+	//+field:natural
+	LastTotal uint64 `tlv:"0x0345"`
+	//+field:natural
+	LastSampled uint64 `tlv:"0x0346"`
+	//+field:natural
+	LastMismatched uint64 `tlv:"0x0347"`
+	//+field:string
+	DetectProb string `tlv:"0x0348"`
+}
+
+// CsAuditRoundResult is the result of a CS audit round requested with an auditor seed.
+// NThreads forwarding threads received the request; NFinished of them completed the
+// round before the reply, and NOverruns skipped it because a previous round was running.
+// DetectProb is the probability that the round picked a single corrupted entry.
+type CsAuditRoundResult struct {
+	//+field:binary
+	Seed []byte `tlv:"0x0361"`
+	//+field:natural
+	SampleK uint64 `tlv:"0x0362"`
+	//+field:string
+	SampleMode string `tlv:"0x0363"`
+	//+field:natural
+	NThreads uint64 `tlv:"0x0364"`
+	//+field:natural
+	NFinished uint64 `tlv:"0x0365"`
+	//+field:natural
+	NOverruns uint64 `tlv:"0x0366"`
+	//+field:natural
+	Total uint64 `tlv:"0x0345"`
+	//+field:natural
+	Sampled uint64 `tlv:"0x0346"`
+	//+field:natural
+	Mismatched uint64 `tlv:"0x0347"`
+	//+field:string
+	DetectProb string `tlv:"0x0348"`
+	//+field:natural
+	Checked uint64 `tlv:"0x0326"`
+}
+
+// CsNatAggregate is the CSNAT aggregate of a name prefix.
+// LeafCount counts the cache entries under the prefix, Cached those with exactly this name.
+type CsNatAggregate struct {
+	//+field:name
+	Name enc.Name `tlv:"0x07"`
+	//+field:natural
+	LeafCount uint64 `tlv:"0x0351"`
+	//+field:binary
+	Agg []byte `tlv:"0x0352"`
+	//+field:binary
+	TagAgg []byte `tlv:"0x0353"`
+	//+field:natural
+	Cached uint64 `tlv:"0x0355"`
+}
+
+// CsNatAggregateMsg is the CSNAT aggregate dataset of a node.
+// Aggregates lists the requested prefix followed by its descendants, in canonical order.
+// Replies to remote requests echo the request Nonce, and set Truncated if the
+// list was cut at the size limit.
+type CsNatAggregateMsg struct {
+	//+field:string
+	Scheme string `tlv:"0x0303"`
+	//+field:binary
+	PublicKey []byte `tlv:"0x0354"`
+	//+field:natural
+	Timestamp uint64 `tlv:"0x0302"`
+	//+field:binary
+	Nonce []byte `tlv:"0x0301"`
+	//+field:bool
+	Truncated bool `tlv:"0x0356"`
+	//+field:sequence:*CsNatAggregate:struct:CsNatAggregate
+	Aggregates []*CsNatAggregate `tlv:"0x0350"`
+}
+
+// CsAuditConfig carries the runtime-adjustable CS audit and SEU settings.
+// In a request, absent fields are left unchanged; Flags and Mask work as in ControlArgs.
+type CsAuditConfig struct {
+	//+field:natural:optional
+	Interval optional.Optional[uint64] `tlv:"0x0331"`
+	//+field:string:optional
+	SeuRate optional.Optional[string] `tlv:"0x0332"`
+	//+field:string:optional
+	SeuPrefix optional.Optional[string] `tlv:"0x0333"`
+	//+field:natural:optional
+	Flags optional.Optional[uint64] `tlv:"0x6c"`
+	//+field:natural:optional
+	Mask optional.Optional[uint64] `tlv:"0x70"`
+}
@@ -21,6 +21,22 @@ func (mgmt *MgmtConfig) MakeCmd(module string, cmd string,
 
 	params := ControlParameters{Val: args}
 
+	name := append(mgmt.cmdPrefix(module, cmd),
+		enc.NewGenericBytesComponent(params.Bytes()))
+
+	// Make and sign Interest
+	return mgmt.spec.MakeInterest(name, config, enc.Wire{}, mgmt.signer)
+}
+
+// MakeCmdAppParam makes a command Interest that carries its argument in
+// ApplicationParameters, e.g. a signed PrefixAnnouncement for rib/announce.
+func (mgmt *MgmtConfig) MakeCmdAppParam(module string, cmd string,
+	appParam enc.Wire, config *ndn.InterestConfig) (*ndn.EncodedInterest, error) {
+	return mgmt.spec.MakeInterest(mgmt.cmdPrefix(module, cmd), config, appParam, mgmt.signer)
+}
+
+// cmdPrefix returns the name prefix of a command.
+func (mgmt *MgmtConfig) cmdPrefix(module string, cmd string) enc.Name {
 	var name enc.Name
 	if mgmt.local {
 		name = enc.Name{enc.LOCALHOST}
@@ -28,15 +44,11 @@ func (mgmt *MgmtConfig) MakeCmd(module string, cmd string,
 		name = enc.Name{enc.LOCALHOP}
 	}
 
-	name = append(name,
+	return append(name,
 		enc.NewGenericComponent("nfd"),
 		enc.NewGenericComponent(module),
 		enc.NewGenericComponent(cmd),
-		enc.NewGenericBytesComponent(params.Bytes()),
 	)
-
-	// Make and sign Interest
-	return mgmt.spec.MakeInterest(name, config, enc.Wire{}, mgmt.signer)
 }
 
 // MakeCmdDict is the same as MakeCmd but receives a map[string]any as arguments.
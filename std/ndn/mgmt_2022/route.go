@@ -33,6 +33,7 @@ const (
 	RouteOriginStatic    RouteOrigin = 255
 	RouteOriginNLSR      RouteOrigin = 128
 	RouteOriginPrefixAnn RouteOrigin = 129
+	RouteOriginSelfLearn RouteOrigin = 130
 	RouteOriginClient    RouteOrigin = 65
 	RouteOriginAutoreg   RouteOrigin = 64
 	RouteOriginAutoconf  RouteOrigin = 66
@@ -43,6 +44,7 @@ var RouteOriginList = map[RouteOrigin]string{
 	RouteOriginStatic:    "static",
 	RouteOriginNLSR:      "nlsr",
 	RouteOriginPrefixAnn: "prefixann",
+	RouteOriginSelfLearn: "selflearn",
 	RouteOriginClient:    "client",
 	RouteOriginAutoreg:   "autoreg",
 	RouteOriginAutoconf:  "autoconf",
@@ -2,6 +2,7 @@
 package mgmt_2022
 
 import (
+	"encoding/binary"
 	"io"
 	"strings"
 
@@ -265,6 +266,23 @@ func (encoder *ControlArgsEncoder) Init(value *ControlArgs) {
 		l += 1
 		l += uint(1 + enc.Nat(optval).EncodingLength())
 	}
+	if optval, ok := value.EgressRate.Get(); ok {
+		l += 1
+		l += uint(1 + enc.Nat(optval).EncodingLength())
+	}
+	if optval, ok := value.EgressBurst.Get(); ok {
+		l += 1
+		l += uint(1 + enc.Nat(optval).EncodingLength())
+	}
+	if value.CertHash != nil {
+		l += 1
+		l += uint(enc.TLNum(len(value.CertHash)).EncodingLength())
+		l += uint(len(value.CertHash))
+	}
+	if optval, ok := value.PacketTypes.Get(); ok {
+		l += 1
+		l += uint(1 + enc.Nat(optval).EncodingLength())
+	}
 	encoder.Length = l
 
 }
@@ -406,6 +424,37 @@ func (encoder *ControlArgsEncoder) EncodeInto(value *ControlArgs, buf []byte) {
 		pos += uint(1 + buf[pos])
 
 	}
+	if optval, ok := value.EgressRate.Get(); ok {
+		buf[pos] = byte(138)
+		pos += 1
+
+		buf[pos] = byte(enc.Nat(optval).EncodeInto(buf[pos+1:]))
+		pos += uint(1 + buf[pos])
+
+	}
+	if optval, ok := value.EgressBurst.Get(); ok {
+		buf[pos] = byte(139)
+		pos += 1
+
+		buf[pos] = byte(enc.Nat(optval).EncodeInto(buf[pos+1:]))
+		pos += uint(1 + buf[pos])
+
+	}
+	if value.CertHash != nil {
+		buf[pos] = byte(140)
+		pos += 1
+		pos += uint(enc.TLNum(len(value.CertHash)).EncodeInto(buf[pos:]))
+		copy(buf[pos:], value.CertHash)
+		pos += uint(len(value.CertHash))
+	}
+	if optval, ok := value.PacketTypes.Get(); ok {
+		buf[pos] = byte(141)
+		pos += 1
+
+		buf[pos] = byte(enc.Nat(optval).EncodeInto(buf[pos+1:]))
+		pos += uint(1 + buf[pos])
+
+	}
 }
 
 func (encoder *ControlArgsEncoder) Encode(value *ControlArgs) enc.Wire {
@@ -436,6 +485,10 @@ func (context *ControlArgsParsingContext) Parse(reader enc.WireView, ignoreCriti
 	var handled_BaseCongestionMarkInterval bool = false
 	var handled_DefaultCongestionThreshold bool = false
 	var handled_Mtu bool = false
+	var handled_EgressRate bool = false
+	var handled_EgressBurst bool = false
+	var handled_CertHash bool = false
+	var handled_PacketTypes bool = false
 
 	progress := -1
 	_ = progress
@@ -775,6 +828,82 @@ func (context *ControlArgsParsingContext) Parse(reader enc.WireView, ignoreCriti
 						value.Mtu.Set(optval)
 					}
 				}
+			case 138:
+				if true {
+					handled = true
+					handled_EgressRate = true
+					{
+						optval := uint64(0)
+						optval = uint64(0)
+						{
+							for i := 0; i < int(l); i++ {
+								x := byte(0)
+								x, err = reader.ReadByte()
+								if err != nil {
+									if err == io.EOF {
+										err = io.ErrUnexpectedEOF
+									}
+									break
+								}
+								optval = uint64(optval<<8) | uint64(x)
+							}
+						}
+						value.EgressRate.Set(optval)
+					}
+				}
+			case 139:
+				if true {
+					handled = true
+					handled_EgressBurst = true
+					{
+						optval := uint64(0)
+						optval = uint64(0)
+						{
+							for i := 0; i < int(l); i++ {
+								x := byte(0)
+								x, err = reader.ReadByte()
+								if err != nil {
+									if err == io.EOF {
+										err = io.ErrUnexpectedEOF
+									}
+									break
+								}
+								optval = uint64(optval<<8) | uint64(x)
+							}
+						}
+						value.EgressBurst.Set(optval)
+					}
+				}
+			case 140:
+				if true {
+					handled = true
+					handled_CertHash = true
+					value.CertHash = make([]byte, l)
+					_, err = reader.ReadFull(value.CertHash)
+				}
+			case 141:
+				if true {
+					handled = true
+					handled_PacketTypes = true
+					{
+						optval := uint64(0)
+						optval = uint64(0)
+						{
+							for i := 0; i < int(l); i++ {
+								x := byte(0)
+								x, err = reader.ReadByte()
+								if err != nil {
+									if err == io.EOF {
+										err = io.ErrUnexpectedEOF
+									}
+									break
+								}
+								optval = uint64(optval<<8) | uint64(x)
+							}
+						}
+						value.PacketTypes.Set(optval)
+					}
+				}
 			default:
 				if !ignoreCritical && ((typ <= 31) || ((typ & 1) == 1)) {
 					return nil, enc.ErrUnrecognizedField{TypeNum: typ}
@@ -841,6 +970,18 @@ func (context *ControlArgsParsingContext) Parse(reader enc.WireView, ignoreCriti
 	if !handled_Mtu && err == nil {
 		value.Mtu.Unset()
 	}
+	if !handled_EgressRate && err == nil {
+		value.EgressRate.Unset()
+	}
+	if !handled_EgressBurst && err == nil {
+		value.EgressBurst.Unset()
+	}
+	if !handled_CertHash && err == nil {
+		value.CertHash = nil
+	}
+	if !handled_PacketTypes && err == nil {
+		value.PacketTypes.Unset()
+	}
 
 	if err != nil {
 		return nil, err
@@ -915,6 +1056,18 @@ func (value *ControlArgs) ToDict() map[string]any {
 	if optval, ok := value.Mtu.Get(); ok {
 		dict["Mtu"] = optval
 	}
+	if optval, ok := value.EgressRate.Get(); ok {
+		dict["EgressRate"] = optval
+	}
+	if optval, ok := value.EgressBurst.Get(); ok {
+		dict["EgressBurst"] = optval
+	}
+	if value.CertHash != nil {
+		dict["CertHash"] = value.CertHash
+	}
+	if optval, ok := value.PacketTypes.Get(); ok {
+		dict["PacketTypes"] = optval
+	}
 	return dict
 }
 
@@ -1113,6 +1266,54 @@ func DictToControlArgs(dict map[string]any) (*ControlArgs, error) {
 	if err != nil {
 		return nil, err
 	}
+	if vv, ok := dict["EgressRate"]; ok {
+		if v, ok := vv.(uint64); ok {
+			value.EgressRate.Set(v)
+		} else {
+			err = enc.ErrIncompatibleType{Name: "EgressRate", TypeNum: 138, ValType: "uint64", Value: vv}
+		}
+	} else {
+		value.EgressRate.Unset()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if vv, ok := dict["EgressBurst"]; ok {
+		if v, ok := vv.(uint64); ok {
+			value.EgressBurst.Set(v)
+		} else {
+			err = enc.ErrIncompatibleType{Name: "EgressBurst", TypeNum: 139, ValType: "uint64", Value: vv}
+		}
+	} else {
+		value.EgressBurst.Unset()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if vv, ok := dict["CertHash"]; ok {
+		if v, ok := vv.([]byte); ok {
+			value.CertHash = v
+		} else {
+			err = enc.ErrIncompatibleType{Name: "CertHash", TypeNum: 140, ValType: "[]byte", Value: vv}
+		}
+	} else {
+		value.CertHash = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if vv, ok := dict["PacketTypes"]; ok {
+		if v, ok := vv.(uint64); ok {
+			value.PacketTypes.Set(v)
+		} else {
+			err = enc.ErrIncompatibleType{Name: "PacketTypes", TypeNum: 141, ValType: "uint64", Value: vv}
+		}
+	} else {
+		value.PacketTypes.Unset()
+	}
+	if err != nil {
+		return nil, err
+	}
 	return value, nil
 }
 
@@ -3087,6 +3288,34 @@ func (encoder *FaceStatusEncoder) Init(value *FaceStatus) {
 	l += uint(1 + enc.Nat(value.NOutBytes).EncodingLength())
 	l += 1
 	l += uint(1 + enc.Nat(value.Flags).EncodingLength())
+	if optval, ok := value.NInLpInvalid.Get(); ok {
+		l += 1
+		l += uint(1 + enc.Nat(optval).EncodingLength())
+	}
+	if optval, ok := value.NReassemblyTimeouts.Get(); ok {
+		l += 1
+		l += uint(1 + enc.Nat(optval).EncodingLength())
+	}
+	if optval, ok := value.EgressRate.Get(); ok {
+		l += 1
+		l += uint(1 + enc.Nat(optval).EncodingLength())
+	}
+	if optval, ok := value.EgressBurst.Get(); ok {
+		l += 1
+		l += uint(1 + enc.Nat(optval).EncodingLength())
+	}
+	if optval, ok := value.NOutShaped.Get(); ok {
+		l += 1
+		l += uint(1 + enc.Nat(optval).EncodingLength())
+	}
+	if optval, ok := value.NOutDropped.Get(); ok {
+		l += 1
+		l += uint(1 + enc.Nat(optval).EncodingLength())
+	}
+	if optval, ok := value.NReassemblyEvictions.Get(); ok {
+		l += 1
+		l += uint(1 + enc.Nat(optval).EncodingLength())
+	}
 	encoder.Length = l
 
 }
@@ -3206,6 +3435,62 @@ func (encoder *FaceStatusEncoder) EncodeInto(value *FaceStatus, buf []byte) {
 
 	buf[pos] = byte(enc.Nat(value.Flags).EncodeInto(buf[pos+1:]))
 	pos += uint(1 + buf[pos])
+	if optval, ok := value.NInLpInvalid.Get(); ok {
+		buf[pos] = byte(202)
+		pos += 1
+
+		buf[pos] = byte(enc.Nat(optval).EncodeInto(buf[pos+1:]))
+		pos += uint(1 + buf[pos])
+
+	}
+	if optval, ok := value.NReassemblyTimeouts.Get(); ok {
+		buf[pos] = byte(203)
+		pos += 1
+
+		buf[pos] = byte(enc.Nat(optval).EncodeInto(buf[pos+1:]))
+		pos += uint(1 + buf[pos])
+
+	}
+	if optval, ok := value.EgressRate.Get(); ok {
+		buf[pos] = byte(138)
+		pos += 1
+
+		buf[pos] = byte(enc.Nat(optval).EncodeInto(buf[pos+1:]))
+		pos += uint(1 + buf[pos])
+
+	}
+	if optval, ok := value.EgressBurst.Get(); ok {
+		buf[pos] = byte(139)
+		pos += 1
+
+		buf[pos] = byte(enc.Nat(optval).EncodeInto(buf[pos+1:]))
+		pos += uint(1 + buf[pos])
+
+	}
+	if optval, ok := value.NOutShaped.Get(); ok {
+		buf[pos] = byte(209)
+		pos += 1
+
+		buf[pos] = byte(enc.Nat(optval).EncodeInto(buf[pos+1:]))
+		pos += uint(1 + buf[pos])
+
+	}
+	if optval, ok := value.NOutDropped.Get(); ok {
+		buf[pos] = byte(210)
+		pos += 1
+
+		buf[pos] = byte(enc.Nat(optval).EncodeInto(buf[pos+1:]))
+		pos += uint(1 + buf[pos])
+
+	}
+	if optval, ok := value.NReassemblyEvictions.Get(); ok {
+		buf[pos] = byte(211)
+		pos += 1
+
+		buf[pos] = byte(enc.Nat(optval).EncodeInto(buf[pos+1:]))
+		pos += uint(1 + buf[pos])
+
+	}
 }
 
 func (encoder *FaceStatusEncoder) Encode(value *FaceStatus) enc.Wire {
@@ -3239,6 +3524,13 @@ func (context *FaceStatusParsingContext) Parse(reader enc.WireView, ignoreCritic
 	var handled_NInBytes bool = false
 	var handled_NOutBytes bool = false
 	var handled_Flags bool = false
+	var handled_NInLpInvalid bool = false
+	var handled_NReassemblyTimeouts bool = false
+	var handled_EgressRate bool = false
+	var handled_EgressBurst bool = false
+	var handled_NOutShaped bool = false
+	var handled_NOutDropped bool = false
+	var handled_NReassemblyEvictions bool = false
 
 	progress := -1
 	_ = progress
@@ -3628,37 +3920,198 @@ func (context *FaceStatusParsingContext) Parse(reader enc.WireView, ignoreCritic
 						}
 					}
 				}
-			default:
-				if !ignoreCritical && ((typ <= 31) || ((typ & 1) == 1)) {
-					return nil, enc.ErrUnrecognizedField{TypeNum: typ}
+			case 202:
+				if true {
+					handled = true
+					handled_NInLpInvalid = true
+					{
+						optval := uint64(0)
+						optval = uint64(0)
+						{
+							for i := 0; i < int(l); i++ {
+								x := byte(0)
+								x, err = reader.ReadByte()
+								if err != nil {
+									if err == io.EOF {
+										err = io.ErrUnexpectedEOF
+									}
+									break
+								}
+								optval = uint64(optval<<8) | uint64(x)
+							}
+						}
+						value.NInLpInvalid.Set(optval)
+					}
 				}
-				handled = true
-				err = reader.Skip(int(l))
-			}
-			if err == nil && !handled {
-			}
-			if err != nil {
-				return nil, enc.ErrFailToParse{TypeNum: typ, Err: err}
-			}
-		}
-	}
-
-	startPos = reader.Pos()
-	err = nil
-
-	if !handled_FaceId && err == nil {
-		err = enc.ErrSkipRequired{Name: "FaceId", TypeNum: 105}
-	}
-	if !handled_Uri && err == nil {
-		err = enc.ErrSkipRequired{Name: "Uri", TypeNum: 114}
-	}
-	if !handled_LocalUri && err == nil {
-		err = enc.ErrSkipRequired{Name: "LocalUri", TypeNum: 129}
-	}
-	if !handled_ExpirationPeriod && err == nil {
-		value.ExpirationPeriod.Unset()
-	}
-	if !handled_FaceScope && err == nil {
+			case 203:
+				if true {
+					handled = true
+					handled_NReassemblyTimeouts = true
+					{
+						optval := uint64(0)
+						optval = uint64(0)
+						{
+							for i := 0; i < int(l); i++ {
+								x := byte(0)
+								x, err = reader.ReadByte()
+								if err != nil {
+									if err == io.EOF {
+										err = io.ErrUnexpectedEOF
+									}
+									break
+								}
+								optval = uint64(optval<<8) | uint64(x)
+							}
+						}
+						value.NReassemblyTimeouts.Set(optval)
+					}
+				}
+			case 138:
+				if true {
+					handled = true
+					handled_EgressRate = true
+					{
+						optval := uint64(0)
+						optval = uint64(0)
+						{
+							for i := 0; i < int(l); i++ {
+								x := byte(0)
+								x, err = reader.ReadByte()
+								if err != nil {
+									if err == io.EOF {
+										err = io.ErrUnexpectedEOF
+									}
+									break
+								}
+								optval = uint64(optval<<8) | uint64(x)
+							}
+						}
+						value.EgressRate.Set(optval)
+					}
+				}
+			case 139:
+				if true {
+					handled = true
+					handled_EgressBurst = true
+					{
+						optval := uint64(0)
+						optval = uint64(0)
+						{
+							for i := 0; i < int(l); i++ {
+								x := byte(0)
+								x, err = reader.ReadByte()
+								if err != nil {
+									if err == io.EOF {
+										err = io.ErrUnexpectedEOF
+									}
+									break
+								}
+								optval = uint64(optval<<8) | uint64(x)
+							}
+						}
+						value.EgressBurst.Set(optval)
+					}
+				}
+			case 209:
+				if true {
+					handled = true
+					handled_NOutShaped = true
+					{
+						optval := uint64(0)
+						optval = uint64(0)
+						{
+							for i := 0; i < int(l); i++ {
+								x := byte(0)
+								x, err = reader.ReadByte()
+								if err != nil {
+									if err == io.EOF {
+										err = io.ErrUnexpectedEOF
+									}
+									break
+								}
+								optval = uint64(optval<<8) | uint64(x)
+							}
+						}
+						value.NOutShaped.Set(optval)
+					}
+				}
+			case 210:
+				if true {
+					handled = true
+					handled_NOutDropped = true
+					{
+						optval := uint64(0)
+						optval = uint64(0)
+						{
+							for i := 0; i < int(l); i++ {
+								x := byte(0)
+								x, err = reader.ReadByte()
+								if err != nil {
+									if err == io.EOF {
+										err = io.ErrUnexpectedEOF
+									}
+									break
+								}
+								optval = uint64(optval<<8) | uint64(x)
+							}
+						}
+						value.NOutDropped.Set(optval)
+					}
+				}
+			case 211:
+				if true {
+					handled = true
+					handled_NReassemblyEvictions = true
+					{
+						optval := uint64(0)
+						optval = uint64(0)
+						{
+							for i := 0; i < int(l); i++ {
+								x := byte(0)
+								x, err = reader.ReadByte()
+								if err != nil {
+									if err == io.EOF {
+										err = io.ErrUnexpectedEOF
+									}
+									break
+								}
+								optval = uint64(optval<<8) | uint64(x)
+							}
+						}
+						value.NReassemblyEvictions.Set(optval)
+					}
+				}
+			default:
+				if !ignoreCritical && ((typ <= 31) || ((typ & 1) == 1)) {
+					return nil, enc.ErrUnrecognizedField{TypeNum: typ}
+				}
+				handled = true
+				err = reader.Skip(int(l))
+			}
+			if err == nil && !handled {
+			}
+			if err != nil {
+				return nil, enc.ErrFailToParse{TypeNum: typ, Err: err}
+			}
+		}
+	}
+
+	startPos = reader.Pos()
+	err = nil
+
+	if !handled_FaceId && err == nil {
+		err = enc.ErrSkipRequired{Name: "FaceId", TypeNum: 105}
+	}
+	if !handled_Uri && err == nil {
+		err = enc.ErrSkipRequired{Name: "Uri", TypeNum: 114}
+	}
+	if !handled_LocalUri && err == nil {
+		err = enc.ErrSkipRequired{Name: "LocalUri", TypeNum: 129}
+	}
+	if !handled_ExpirationPeriod && err == nil {
+		value.ExpirationPeriod.Unset()
+	}
+	if !handled_FaceScope && err == nil {
 		err = enc.ErrSkipRequired{Name: "FaceScope", TypeNum: 132}
 	}
 	if !handled_FacePersistency && err == nil {
@@ -3703,6 +4156,27 @@ func (context *FaceStatusParsingContext) Parse(reader enc.WireView, ignoreCritic
 	if !handled_Flags && err == nil {
 		err = enc.ErrSkipRequired{Name: "Flags", TypeNum: 108}
 	}
+	if !handled_NInLpInvalid && err == nil {
+		value.NInLpInvalid.Unset()
+	}
+	if !handled_NReassemblyTimeouts && err == nil {
+		value.NReassemblyTimeouts.Unset()
+	}
+	if !handled_EgressRate && err == nil {
+		value.EgressRate.Unset()
+	}
+	if !handled_EgressBurst && err == nil {
+		value.EgressBurst.Unset()
+	}
+	if !handled_NOutShaped && err == nil {
+		value.NOutShaped.Unset()
+	}
+	if !handled_NOutDropped && err == nil {
+		value.NOutDropped.Unset()
+	}
+	if !handled_NReassemblyEvictions && err == nil {
+		value.NReassemblyEvictions.Unset()
+	}
 
 	if err != nil {
 		return nil, err
@@ -6461,3 +6935,2906 @@ func ParseCsInfoMsg(reader enc.WireView, ignoreCritical bool) (*CsInfoMsg, error
 	context.Init()
 	return context.Parse(reader, ignoreCritical)
 }
+
+type CsAuditProofEncoder struct {
+	Length uint
+
+	Prefix_length uint
+}
+
+type CsAuditProofParsingContext struct {
+}
+
+func (encoder *CsAuditProofEncoder) Init(value *CsAuditProof) {
+	if value.Prefix != nil {
+		encoder.Prefix_length = 0
+		for _, c := range value.Prefix {
+			encoder.Prefix_length += uint(c.EncodingLength())
+		}
+	}
+
+	l := uint(0)
+	if value.Prefix != nil {
+		l += 1
+		l += uint(enc.TLNum(encoder.Prefix_length).EncodingLength())
+		l += encoder.Prefix_length
+	}
+	if value.Nonce != nil {
+		l += 3
+		l += uint(enc.TLNum(len(value.Nonce)).EncodingLength())
+		l += uint(len(value.Nonce))
+	}
+	l += 3
+	l += uint(1 + enc.Nat(value.Timestamp).EncodingLength())
+	l += 3
+	l += uint(enc.TLNum(len(value.Scheme)).EncodingLength())
+	l += uint(len(value.Scheme))
+	l += 3
+	l += uint(1 + enc.Nat(value.LeafCount).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.InvalidCount).EncodingLength())
+	if value.Aggregate != nil {
+		l += 3
+		l += uint(enc.TLNum(len(value.Aggregate)).EncodingLength())
+		l += uint(len(value.Aggregate))
+	}
+	if value.ProofSignature != nil {
+		l += 3
+		l += uint(enc.TLNum(len(value.ProofSignature)).EncodingLength())
+		l += uint(len(value.ProofSignature))
+	}
+	encoder.Length = l
+
+}
+
+func (context *CsAuditProofParsingContext) Init() {
+
+}
+
+func (encoder *CsAuditProofEncoder) EncodeInto(value *CsAuditProof, buf []byte) {
+
+	pos := uint(0)
+
+	if value.Prefix != nil {
+		buf[pos] = byte(7)
+		pos += 1
+		pos += uint(enc.TLNum(encoder.Prefix_length).EncodeInto(buf[pos:]))
+		for _, c := range value.Prefix {
+			pos += uint(c.EncodeInto(buf[pos:]))
+		}
+	}
+	if value.Nonce != nil {
+		buf[pos] = 253
+		binary.BigEndian.PutUint16(buf[pos+1:], uint16(769))
+		pos += 3
+		pos += uint(enc.TLNum(len(value.Nonce)).EncodeInto(buf[pos:]))
+		copy(buf[pos:], value.Nonce)
+		pos += uint(len(value.Nonce))
+	}
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(770))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.Timestamp).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(771))
+	pos += 3
+	pos += uint(enc.TLNum(len(value.Scheme)).EncodeInto(buf[pos:]))
+	copy(buf[pos:], value.Scheme)
+	pos += uint(len(value.Scheme))
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(772))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.LeafCount).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(773))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.InvalidCount).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	if value.Aggregate != nil {
+		buf[pos] = 253
+		binary.BigEndian.PutUint16(buf[pos+1:], uint16(774))
+		pos += 3
+		pos += uint(enc.TLNum(len(value.Aggregate)).EncodeInto(buf[pos:]))
+		copy(buf[pos:], value.Aggregate)
+		pos += uint(len(value.Aggregate))
+	}
+	if value.ProofSignature != nil {
+		buf[pos] = 253
+		binary.BigEndian.PutUint16(buf[pos+1:], uint16(775))
+		pos += 3
+		pos += uint(enc.TLNum(len(value.ProofSignature)).EncodeInto(buf[pos:]))
+		copy(buf[pos:], value.ProofSignature)
+		pos += uint(len(value.ProofSignature))
+	}
+}
+
+func (encoder *CsAuditProofEncoder) Encode(value *CsAuditProof) enc.Wire {
+
+	wire := make(enc.Wire, 1)
+	wire[0] = make([]byte, encoder.Length)
+	buf := wire[0]
+	encoder.EncodeInto(value, buf)
+
+	return wire
+}
+
+func (context *CsAuditProofParsingContext) Parse(reader enc.WireView, ignoreCritical bool) (*CsAuditProof, error) {
+
+	var handled_Prefix bool = false
+	var handled_Nonce bool = false
+	var handled_Timestamp bool = false
+	var handled_Scheme bool = false
+	var handled_LeafCount bool = false
+	var handled_InvalidCount bool = false
+	var handled_Aggregate bool = false
+	var handled_ProofSignature bool = false
+
+	progress := -1
+	_ = progress
+
+	value := &CsAuditProof{}
+	var err error
+	var startPos int
+	for {
+		startPos = reader.Pos()
+		if startPos >= reader.Length() {
+			break
+		}
+		typ := enc.TLNum(0)
+		l := enc.TLNum(0)
+		typ, err = reader.ReadTLNum()
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+		l, err = reader.ReadTLNum()
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+
+		err = nil
+		if handled := false; true {
+			switch typ {
+			case 7:
+				if true {
+					handled = true
+					handled_Prefix = true
+					delegate := reader.Delegate(int(l))
+					value.Prefix, err = delegate.ReadName()
+				}
+			case 769:
+				if true {
+					handled = true
+					handled_Nonce = true
+					value.Nonce = make([]byte, l)
+					_, err = reader.ReadFull(value.Nonce)
+				}
+			case 770:
+				if true {
+					handled = true
+					handled_Timestamp = true
+					value.Timestamp = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.Timestamp = uint64(value.Timestamp<<8) | uint64(x)
+						}
+					}
+				}
+			case 771:
+				if true {
+					handled = true
+					handled_Scheme = true
+					{
+						var builder strings.Builder
+						_, err = reader.CopyN(&builder, int(l))
+						if err == nil {
+							value.Scheme = builder.String()
+						}
+					}
+				}
+			case 772:
+				if true {
+					handled = true
+					handled_LeafCount = true
+					value.LeafCount = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.LeafCount = uint64(value.LeafCount<<8) | uint64(x)
+						}
+					}
+				}
+			case 773:
+				if true {
+					handled = true
+					handled_InvalidCount = true
+					value.InvalidCount = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.InvalidCount = uint64(value.InvalidCount<<8) | uint64(x)
+						}
+					}
+				}
+			case 774:
+				if true {
+					handled = true
+					handled_Aggregate = true
+					value.Aggregate = make([]byte, l)
+					_, err = reader.ReadFull(value.Aggregate)
+				}
+			case 775:
+				if true {
+					handled = true
+					handled_ProofSignature = true
+					value.ProofSignature = make([]byte, l)
+					_, err = reader.ReadFull(value.ProofSignature)
+				}
+			default:
+				if !ignoreCritical && ((typ <= 31) || ((typ & 1) == 1)) {
+					return nil, enc.ErrUnrecognizedField{TypeNum: typ}
+				}
+				handled = true
+				err = reader.Skip(int(l))
+			}
+			if err == nil && !handled {
+			}
+			if err != nil {
+				return nil, enc.ErrFailToParse{TypeNum: typ, Err: err}
+			}
+		}
+	}
+
+	startPos = reader.Pos()
+	err = nil
+
+	if !handled_Prefix && err == nil {
+		value.Prefix = nil
+	}
+	if !handled_Nonce && err == nil {
+		value.Nonce = nil
+	}
+	if !handled_Timestamp && err == nil {
+		err = enc.ErrSkipRequired{Name: "Timestamp", TypeNum: 770}
+	}
+	if !handled_Scheme && err == nil {
+		err = enc.ErrSkipRequired{Name: "Scheme", TypeNum: 771}
+	}
+	if !handled_LeafCount && err == nil {
+		err = enc.ErrSkipRequired{Name: "LeafCount", TypeNum: 772}
+	}
+	if !handled_InvalidCount && err == nil {
+		err = enc.ErrSkipRequired{Name: "InvalidCount", TypeNum: 773}
+	}
+	if !handled_Aggregate && err == nil {
+		value.Aggregate = nil
+	}
+	if !handled_ProofSignature && err == nil {
+		value.ProofSignature = nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (value *CsAuditProof) Encode() enc.Wire {
+	encoder := CsAuditProofEncoder{}
+	encoder.Init(value)
+	return encoder.Encode(value)
+}
+
+func (value *CsAuditProof) Bytes() []byte {
+	return value.Encode().Join()
+}
+
+func ParseCsAuditProof(reader enc.WireView, ignoreCritical bool) (*CsAuditProof, error) {
+	context := CsAuditProofParsingContext{}
+	context.Init()
+	return context.Parse(reader, ignoreCritical)
+}
+
+type CsAuditHistoryEntryEncoder struct {
+	Length uint
+
+	Name_length uint
+}
+
+type CsAuditHistoryEntryParsingContext struct {
+}
+
+func (encoder *CsAuditHistoryEntryEncoder) Init(value *CsAuditHistoryEntry) {
+
+	if value.Name != nil {
+		encoder.Name_length = 0
+		for _, c := range value.Name {
+			encoder.Name_length += uint(c.EncodingLength())
+		}
+	}
+
+	l := uint(0)
+	l += 3
+	l += uint(1 + enc.Nat(value.Timestamp).EncodingLength())
+	l += 3
+	l += uint(enc.TLNum(len(value.Event)).EncodingLength())
+	l += uint(len(value.Event))
+	if value.Name != nil {
+		l += 1
+		l += uint(enc.TLNum(encoder.Name_length).EncodingLength())
+		l += encoder.Name_length
+	}
+	l += 3
+	l += uint(1 + enc.Nat(value.Checked).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.Mismatched).EncodingLength())
+	encoder.Length = l
+
+}
+
+func (context *CsAuditHistoryEntryParsingContext) Init() {
+
+}
+
+func (encoder *CsAuditHistoryEntryEncoder) EncodeInto(value *CsAuditHistoryEntry, buf []byte) {
+
+	pos := uint(0)
+
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(785))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.Timestamp).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(786))
+	pos += 3
+	pos += uint(enc.TLNum(len(value.Event)).EncodeInto(buf[pos:]))
+	copy(buf[pos:], value.Event)
+	pos += uint(len(value.Event))
+	if value.Name != nil {
+		buf[pos] = byte(7)
+		pos += 1
+		pos += uint(enc.TLNum(encoder.Name_length).EncodeInto(buf[pos:]))
+		for _, c := range value.Name {
+			pos += uint(c.EncodeInto(buf[pos:]))
+		}
+	}
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(787))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.Checked).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(788))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.Mismatched).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+}
+
+func (encoder *CsAuditHistoryEntryEncoder) Encode(value *CsAuditHistoryEntry) enc.Wire {
+
+	wire := make(enc.Wire, 1)
+	wire[0] = make([]byte, encoder.Length)
+	buf := wire[0]
+	encoder.EncodeInto(value, buf)
+
+	return wire
+}
+
+func (context *CsAuditHistoryEntryParsingContext) Parse(reader enc.WireView, ignoreCritical bool) (*CsAuditHistoryEntry, error) {
+
+	var handled_Timestamp bool = false
+	var handled_Event bool = false
+	var handled_Name bool = false
+	var handled_Checked bool = false
+	var handled_Mismatched bool = false
+
+	progress := -1
+	_ = progress
+
+	value := &CsAuditHistoryEntry{}
+	var err error
+	var startPos int
+	for {
+		startPos = reader.Pos()
+		if startPos >= reader.Length() {
+			break
+		}
+		typ := enc.TLNum(0)
+		l := enc.TLNum(0)
+		typ, err = reader.ReadTLNum()
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+		l, err = reader.ReadTLNum()
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+
+		err = nil
+		if handled := false; true {
+			switch typ {
+			case 785:
+				if true {
+					handled = true
+					handled_Timestamp = true
+					value.Timestamp = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.Timestamp = uint64(value.Timestamp<<8) | uint64(x)
+						}
+					}
+				}
+			case 786:
+				if true {
+					handled = true
+					handled_Event = true
+					{
+						var builder strings.Builder
+						_, err = reader.CopyN(&builder, int(l))
+						if err == nil {
+							value.Event = builder.String()
+						}
+					}
+				}
+			case 7:
+				if true {
+					handled = true
+					handled_Name = true
+					delegate := reader.Delegate(int(l))
+					value.Name, err = delegate.ReadName()
+				}
+			case 787:
+				if true {
+					handled = true
+					handled_Checked = true
+					value.Checked = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.Checked = uint64(value.Checked<<8) | uint64(x)
+						}
+					}
+				}
+			case 788:
+				if true {
+					handled = true
+					handled_Mismatched = true
+					value.Mismatched = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.Mismatched = uint64(value.Mismatched<<8) | uint64(x)
+						}
+					}
+				}
+			default:
+				if !ignoreCritical && ((typ <= 31) || ((typ & 1) == 1)) {
+					return nil, enc.ErrUnrecognizedField{TypeNum: typ}
+				}
+				handled = true
+				err = reader.Skip(int(l))
+			}
+			if err == nil && !handled {
+			}
+			if err != nil {
+				return nil, enc.ErrFailToParse{TypeNum: typ, Err: err}
+			}
+		}
+	}
+
+	startPos = reader.Pos()
+	err = nil
+
+	if !handled_Timestamp && err == nil {
+		err = enc.ErrSkipRequired{Name: "Timestamp", TypeNum: 785}
+	}
+	if !handled_Event && err == nil {
+		err = enc.ErrSkipRequired{Name: "Event", TypeNum: 786}
+	}
+	if !handled_Name && err == nil {
+		value.Name = nil
+	}
+	if !handled_Checked && err == nil {
+		err = enc.ErrSkipRequired{Name: "Checked", TypeNum: 787}
+	}
+	if !handled_Mismatched && err == nil {
+		err = enc.ErrSkipRequired{Name: "Mismatched", TypeNum: 788}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (value *CsAuditHistoryEntry) Encode() enc.Wire {
+	encoder := CsAuditHistoryEntryEncoder{}
+	encoder.Init(value)
+	return encoder.Encode(value)
+}
+
+func (value *CsAuditHistoryEntry) Bytes() []byte {
+	return value.Encode().Join()
+}
+
+func ParseCsAuditHistoryEntry(reader enc.WireView, ignoreCritical bool) (*CsAuditHistoryEntry, error) {
+	context := CsAuditHistoryEntryParsingContext{}
+	context.Init()
+	return context.Parse(reader, ignoreCritical)
+}
+
+type CsAuditHistoryMsgEncoder struct {
+	Length uint
+
+	Entries_subencoder []struct {
+		Entries_encoder CsAuditHistoryEntryEncoder
+	}
+}
+
+type CsAuditHistoryMsgParsingContext struct {
+	Entries_context CsAuditHistoryEntryParsingContext
+}
+
+func (encoder *CsAuditHistoryMsgEncoder) Init(value *CsAuditHistoryMsg) {
+	{
+		Entries_l := len(value.Entries)
+		encoder.Entries_subencoder = make([]struct {
+			Entries_encoder CsAuditHistoryEntryEncoder
+		}, Entries_l)
+		for i := 0; i < Entries_l; i++ {
+			pseudoEncoder := &encoder.Entries_subencoder[i]
+			pseudoValue := struct {
+				Entries *CsAuditHistoryEntry
+			}{
+				Entries: value.Entries[i],
+			}
+			{
+				encoder := pseudoEncoder
+				value := &pseudoValue
+				if value.Entries != nil {
+					encoder.Entries_encoder.Init(value.Entries)
+				}
+				_ = encoder
+				_ = value
+			}
+		}
+	}
+
+	l := uint(0)
+	if value.Entries != nil {
+		for seq_i, seq_v := range value.Entries {
+			pseudoEncoder := &encoder.Entries_subencoder[seq_i]
+			pseudoValue := struct {
+				Entries *CsAuditHistoryEntry
+			}{
+				Entries: seq_v,
+			}
+			{
+				encoder := pseudoEncoder
+				value := &pseudoValue
+				if value.Entries != nil {
+					l += 3
+					l += uint(enc.TLNum(encoder.Entries_encoder.Length).EncodingLength())
+					l += encoder.Entries_encoder.Length
+				}
+				_ = encoder
+				_ = value
+			}
+		}
+	}
+	encoder.Length = l
+
+}
+
+func (context *CsAuditHistoryMsgParsingContext) Init() {
+	context.Entries_context.Init()
+}
+
+func (encoder *CsAuditHistoryMsgEncoder) EncodeInto(value *CsAuditHistoryMsg, buf []byte) {
+
+	pos := uint(0)
+
+	if value.Entries != nil {
+		for seq_i, seq_v := range value.Entries {
+			pseudoEncoder := &encoder.Entries_subencoder[seq_i]
+			pseudoValue := struct {
+				Entries *CsAuditHistoryEntry
+			}{
+				Entries: seq_v,
+			}
+			{
+				encoder := pseudoEncoder
+				value := &pseudoValue
+				if value.Entries != nil {
+					buf[pos] = 253
+					binary.BigEndian.PutUint16(buf[pos+1:], uint16(784))
+					pos += 3
+					pos += uint(enc.TLNum(encoder.Entries_encoder.Length).EncodeInto(buf[pos:]))
+					if encoder.Entries_encoder.Length > 0 {
+						encoder.Entries_encoder.EncodeInto(value.Entries, buf[pos:])
+						pos += encoder.Entries_encoder.Length
+					}
+				}
+				_ = encoder
+				_ = value
+			}
+		}
+	}
+}
+
+func (encoder *CsAuditHistoryMsgEncoder) Encode(value *CsAuditHistoryMsg) enc.Wire {
+
+	wire := make(enc.Wire, 1)
+	wire[0] = make([]byte, encoder.Length)
+	buf := wire[0]
+	encoder.EncodeInto(value, buf)
+
+	return wire
+}
+
+func (context *CsAuditHistoryMsgParsingContext) Parse(reader enc.WireView, ignoreCritical bool) (*CsAuditHistoryMsg, error) {
+
+	var handled_Entries bool = false
+
+	progress := -1
+	_ = progress
+
+	value := &CsAuditHistoryMsg{}
+	var err error
+	var startPos int
+	for {
+		startPos = reader.Pos()
+		if startPos >= reader.Length() {
+			break
+		}
+		typ := enc.TLNum(0)
+		l := enc.TLNum(0)
+		typ, err = reader.ReadTLNum()
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+		l, err = reader.ReadTLNum()
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+
+		err = nil
+		if handled := false; true {
+			switch typ {
+			case 784:
+				if true {
+					handled = true
+					handled_Entries = true
+					if value.Entries == nil {
+						value.Entries = make([]*CsAuditHistoryEntry, 0)
+					}
+					{
+						pseudoValue := struct {
+							Entries *CsAuditHistoryEntry
+						}{}
+						{
+							value := &pseudoValue
+							value.Entries, err = context.Entries_context.Parse(reader.Delegate(int(l)), ignoreCritical)
+							_ = value
+						}
+						value.Entries = append(value.Entries, pseudoValue.Entries)
+					}
+					progress--
+				}
+			default:
+				if !ignoreCritical && ((typ <= 31) || ((typ & 1) == 1)) {
+					return nil, enc.ErrUnrecognizedField{TypeNum: typ}
+				}
+				handled = true
+				err = reader.Skip(int(l))
+			}
+			if err == nil && !handled {
+			}
+			if err != nil {
+				return nil, enc.ErrFailToParse{TypeNum: typ, Err: err}
+			}
+		}
+	}
+
+	startPos = reader.Pos()
+	err = nil
+
+	if !handled_Entries && err == nil {
+		// sequence - skip
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (value *CsAuditHistoryMsg) Encode() enc.Wire {
+	encoder := CsAuditHistoryMsgEncoder{}
+	encoder.Init(value)
+	return encoder.Encode(value)
+}
+
+func (value *CsAuditHistoryMsg) Bytes() []byte {
+	return value.Encode().Join()
+}
+
+func ParseCsAuditHistoryMsg(reader enc.WireView, ignoreCritical bool) (*CsAuditHistoryMsg, error) {
+	context := CsAuditHistoryMsgParsingContext{}
+	context.Init()
+	return context.Parse(reader, ignoreCritical)
+}
+
+type CsAuditStatusEncoder struct {
+	Length uint
+}
+
+type CsAuditStatusParsingContext struct {
+}
+
+func (encoder *CsAuditStatusEncoder) Init(value *CsAuditStatus) {
+
+	l := uint(0)
+	l += 3
+	l += uint(enc.TLNum(len(value.Scheme)).EncodingLength())
+	l += uint(len(value.Scheme))
+	l += 3
+	l += uint(1 + enc.Nat(value.NCsNatNodes).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.NCsNatLeaves).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.NRounds).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.NRoundOverruns).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.NRemoteChallenges).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.NChecked).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.NProofsVerified).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.NMismatches).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.NUnknownProofs).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.NCorruptEvicted).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.NSeuInjections).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.NFlips).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.NEventsDropped).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.NProofsDropped).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.NProducerTagsRejected).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.NRepairs).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.NRepairsSucceeded).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.NRepairsRejected).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.NRepairsTimedOut).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.LastTotal).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.LastSampled).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.LastMismatched).EncodingLength())
+	l += 3
+	l += uint(enc.TLNum(len(value.DetectProb)).EncodingLength())
+	l += uint(len(value.DetectProb))
+	encoder.Length = l
+
+}
+
+func (context *CsAuditStatusParsingContext) Init() {
+
+}
+
+func (encoder *CsAuditStatusEncoder) EncodeInto(value *CsAuditStatus, buf []byte) {
+
+	pos := uint(0)
+
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(771))
+	pos += 3
+	pos += uint(enc.TLNum(len(value.Scheme)).EncodeInto(buf[pos:]))
+	copy(buf[pos:], value.Scheme)
+	pos += uint(len(value.Scheme))
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(801))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.NCsNatNodes).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(802))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.NCsNatLeaves).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(803))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.NRounds).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(804))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.NRoundOverruns).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(805))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.NRemoteChallenges).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(806))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.NChecked).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(807))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.NProofsVerified).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(808))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.NMismatches).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(809))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.NUnknownProofs).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(810))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.NCorruptEvicted).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(811))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.NSeuInjections).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(812))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.NFlips).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(813))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.NEventsDropped).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(814))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.NProofsDropped).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(815))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.NProducerTagsRejected).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(833))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.NRepairs).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(834))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.NRepairsSucceeded).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(835))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.NRepairsRejected).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(836))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.NRepairsTimedOut).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(837))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.LastTotal).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(838))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.LastSampled).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(839))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.LastMismatched).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(840))
+	pos += 3
+	pos += uint(enc.TLNum(len(value.DetectProb)).EncodeInto(buf[pos:]))
+	copy(buf[pos:], value.DetectProb)
+	pos += uint(len(value.DetectProb))
+}
+
+func (encoder *CsAuditStatusEncoder) Encode(value *CsAuditStatus) enc.Wire {
+
+	wire := make(enc.Wire, 1)
+	wire[0] = make([]byte, encoder.Length)
+	buf := wire[0]
+	encoder.EncodeInto(value, buf)
+
+	return wire
+}
+
+func (context *CsAuditStatusParsingContext) Parse(reader enc.WireView, ignoreCritical bool) (*CsAuditStatus, error) {
+
+	var handled_Scheme bool = false
+	var handled_NCsNatNodes bool = false
+	var handled_NCsNatLeaves bool = false
+	var handled_NRounds bool = false
+	var handled_NRoundOverruns bool = false
+	var handled_NRemoteChallenges bool = false
+	var handled_NChecked bool = false
+	var handled_NProofsVerified bool = false
+	var handled_NMismatches bool = false
+	var handled_NUnknownProofs bool = false
+	var handled_NCorruptEvicted bool = false
+	var handled_NSeuInjections bool = false
+	var handled_NFlips bool = false
+	var handled_NEventsDropped bool = false
+	var handled_NProofsDropped bool = false
+	var handled_NProducerTagsRejected bool = false
+	var handled_NRepairs bool = false
+	var handled_NRepairsSucceeded bool = false
+	var handled_NRepairsRejected bool = false
+	var handled_NRepairsTimedOut bool = false
+	var handled_LastTotal bool = false
+	var handled_LastSampled bool = false
+	var handled_LastMismatched bool = false
+	var handled_DetectProb bool = false
+
+	progress := -1
+	_ = progress
+
+	value := &CsAuditStatus{}
+	var err error
+	var startPos int
+	for {
+		startPos = reader.Pos()
+		if startPos >= reader.Length() {
+			break
+		}
+		typ := enc.TLNum(0)
+		l := enc.TLNum(0)
+		typ, err = reader.ReadTLNum()
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+		l, err = reader.ReadTLNum()
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+
+		err = nil
+		if handled := false; true {
+			switch typ {
+			case 771:
+				if true {
+					handled = true
+					handled_Scheme = true
+					{
+						var builder strings.Builder
+						_, err = reader.CopyN(&builder, int(l))
+						if err == nil {
+							value.Scheme = builder.String()
+						}
+					}
+				}
+			case 801:
+				if true {
+					handled = true
+					handled_NCsNatNodes = true
+					value.NCsNatNodes = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.NCsNatNodes = uint64(value.NCsNatNodes<<8) | uint64(x)
+						}
+					}
+				}
+			case 802:
+				if true {
+					handled = true
+					handled_NCsNatLeaves = true
+					value.NCsNatLeaves = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.NCsNatLeaves = uint64(value.NCsNatLeaves<<8) | uint64(x)
+						}
+					}
+				}
+			case 803:
+				if true {
+					handled = true
+					handled_NRounds = true
+					value.NRounds = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.NRounds = uint64(value.NRounds<<8) | uint64(x)
+						}
+					}
+				}
+			case 804:
+				if true {
+					handled = true
+					handled_NRoundOverruns = true
+					value.NRoundOverruns = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.NRoundOverruns = uint64(value.NRoundOverruns<<8) | uint64(x)
+						}
+					}
+				}
+			case 805:
+				if true {
+					handled = true
+					handled_NRemoteChallenges = true
+					value.NRemoteChallenges = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.NRemoteChallenges = uint64(value.NRemoteChallenges<<8) | uint64(x)
+						}
+					}
+				}
+			case 806:
+				if true {
+					handled = true
+					handled_NChecked = true
+					value.NChecked = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.NChecked = uint64(value.NChecked<<8) | uint64(x)
+						}
+					}
+				}
+			case 807:
+				if true {
+					handled = true
+					handled_NProofsVerified = true
+					value.NProofsVerified = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.NProofsVerified = uint64(value.NProofsVerified<<8) | uint64(x)
+						}
+					}
+				}
+			case 808:
+				if true {
+					handled = true
+					handled_NMismatches = true
+					value.NMismatches = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.NMismatches = uint64(value.NMismatches<<8) | uint64(x)
+						}
+					}
+				}
+			case 809:
+				if true {
+					handled = true
+					handled_NUnknownProofs = true
+					value.NUnknownProofs = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.NUnknownProofs = uint64(value.NUnknownProofs<<8) | uint64(x)
+						}
+					}
+				}
+			case 810:
+				if true {
+					handled = true
+					handled_NCorruptEvicted = true
+					value.NCorruptEvicted = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.NCorruptEvicted = uint64(value.NCorruptEvicted<<8) | uint64(x)
+						}
+					}
+				}
+			case 811:
+				if true {
+					handled = true
+					handled_NSeuInjections = true
+					value.NSeuInjections = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.NSeuInjections = uint64(value.NSeuInjections<<8) | uint64(x)
+						}
+					}
+				}
+			case 812:
+				if true {
+					handled = true
+					handled_NFlips = true
+					value.NFlips = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.NFlips = uint64(value.NFlips<<8) | uint64(x)
+						}
+					}
+				}
+			case 813:
+				if true {
+					handled = true
+					handled_NEventsDropped = true
+					value.NEventsDropped = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.NEventsDropped = uint64(value.NEventsDropped<<8) | uint64(x)
+						}
+					}
+				}
+			case 814:
+				if true {
+					handled = true
+					handled_NProofsDropped = true
+					value.NProofsDropped = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.NProofsDropped = uint64(value.NProofsDropped<<8) | uint64(x)
+						}
+					}
+				}
+			case 815:
+				if true {
+					handled = true
+					handled_NProducerTagsRejected = true
+					value.NProducerTagsRejected = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.NProducerTagsRejected = uint64(value.NProducerTagsRejected<<8) | uint64(x)
+						}
+					}
+				}
+			case 833:
+				if true {
+					handled = true
+					handled_NRepairs = true
+					value.NRepairs = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.NRepairs = uint64(value.NRepairs<<8) | uint64(x)
+						}
+					}
+				}
+			case 834:
+				if true {
+					handled = true
+					handled_NRepairsSucceeded = true
+					value.NRepairsSucceeded = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.NRepairsSucceeded = uint64(value.NRepairsSucceeded<<8) | uint64(x)
+						}
+					}
+				}
+			case 835:
+				if true {
+					handled = true
+					handled_NRepairsRejected = true
+					value.NRepairsRejected = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.NRepairsRejected = uint64(value.NRepairsRejected<<8) | uint64(x)
+						}
+					}
+				}
+			case 836:
+				if true {
+					handled = true
+					handled_NRepairsTimedOut = true
+					value.NRepairsTimedOut = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.NRepairsTimedOut = uint64(value.NRepairsTimedOut<<8) | uint64(x)
+						}
+					}
+				}
+			case 837:
+				if true {
+					handled = true
+					handled_LastTotal = true
+					value.LastTotal = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.LastTotal = uint64(value.LastTotal<<8) | uint64(x)
+						}
+					}
+				}
+			case 838:
+				if true {
+					handled = true
+					handled_LastSampled = true
+					value.LastSampled = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.LastSampled = uint64(value.LastSampled<<8) | uint64(x)
+						}
+					}
+				}
+			case 839:
+				if true {
+					handled = true
+					handled_LastMismatched = true
+					value.LastMismatched = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.LastMismatched = uint64(value.LastMismatched<<8) | uint64(x)
+						}
+					}
+				}
+			case 840:
+				if true {
+					handled = true
+					handled_DetectProb = true
+					{
+						var builder strings.Builder
+						_, err = reader.CopyN(&builder, int(l))
+						if err == nil {
+							value.DetectProb = builder.String()
+						}
+					}
+				}
+			default:
+				if !ignoreCritical && ((typ <= 31) || ((typ & 1) == 1)) {
+					return nil, enc.ErrUnrecognizedField{TypeNum: typ}
+				}
+				handled = true
+				err = reader.Skip(int(l))
+			}
+			if err == nil && !handled {
+			}
+			if err != nil {
+				return nil, enc.ErrFailToParse{TypeNum: typ, Err: err}
+			}
+		}
+	}
+
+	startPos = reader.Pos()
+	err = nil
+
+	if !handled_Scheme && err == nil {
+		err = enc.ErrSkipRequired{Name: "Scheme", TypeNum: 771}
+	}
+	if !handled_NCsNatNodes && err == nil {
+		err = enc.ErrSkipRequired{Name: "NCsNatNodes", TypeNum: 801}
+	}
+	if !handled_NCsNatLeaves && err == nil {
+		err = enc.ErrSkipRequired{Name: "NCsNatLeaves", TypeNum: 802}
+	}
+	if !handled_NRounds && err == nil {
+		err = enc.ErrSkipRequired{Name: "NRounds", TypeNum: 803}
+	}
+	if !handled_NRoundOverruns && err == nil {
+		err = enc.ErrSkipRequired{Name: "NRoundOverruns", TypeNum: 804}
+	}
+	if !handled_NRemoteChallenges && err == nil {
+		err = enc.ErrSkipRequired{Name: "NRemoteChallenges", TypeNum: 805}
+	}
+	if !handled_NChecked && err == nil {
+		err = enc.ErrSkipRequired{Name: "NChecked", TypeNum: 806}
+	}
+	if !handled_NProofsVerified && err == nil {
+		err = enc.ErrSkipRequired{Name: "NProofsVerified", TypeNum: 807}
+	}
+	if !handled_NMismatches && err == nil {
+		err = enc.ErrSkipRequired{Name: "NMismatches", TypeNum: 808}
+	}
+	if !handled_NUnknownProofs && err == nil {
+		err = enc.ErrSkipRequired{Name: "NUnknownProofs", TypeNum: 809}
+	}
+	if !handled_NCorruptEvicted && err == nil {
+		err = enc.ErrSkipRequired{Name: "NCorruptEvicted", TypeNum: 810}
+	}
+	if !handled_NSeuInjections && err == nil {
+		err = enc.ErrSkipRequired{Name: "NSeuInjections", TypeNum: 811}
+	}
+	if !handled_NFlips && err == nil {
+		err = enc.ErrSkipRequired{Name: "NFlips", TypeNum: 812}
+	}
+	if !handled_NEventsDropped && err == nil {
+		err = enc.ErrSkipRequired{Name: "NEventsDropped", TypeNum: 813}
+	}
+	if !handled_NProofsDropped && err == nil {
+		err = enc.ErrSkipRequired{Name: "NProofsDropped", TypeNum: 814}
+	}
+	if !handled_NProducerTagsRejected && err == nil {
+		err = enc.ErrSkipRequired{Name: "NProducerTagsRejected", TypeNum: 815}
+	}
+	if !handled_NRepairs && err == nil {
+		err = enc.ErrSkipRequired{Name: "NRepairs", TypeNum: 833}
+	}
+	if !handled_NRepairsSucceeded && err == nil {
+		err = enc.ErrSkipRequired{Name: "NRepairsSucceeded", TypeNum: 834}
+	}
+	if !handled_NRepairsRejected && err == nil {
+		err = enc.ErrSkipRequired{Name: "NRepairsRejected", TypeNum: 835}
+	}
+	if !handled_NRepairsTimedOut && err == nil {
+		err = enc.ErrSkipRequired{Name: "NRepairsTimedOut", TypeNum: 836}
+	}
+	if !handled_LastTotal && err == nil {
+		err = enc.ErrSkipRequired{Name: "LastTotal", TypeNum: 837}
+	}
+	if !handled_LastSampled && err == nil {
+		err = enc.ErrSkipRequired{Name: "LastSampled", TypeNum: 838}
+	}
+	if !handled_LastMismatched && err == nil {
+		err = enc.ErrSkipRequired{Name: "LastMismatched", TypeNum: 839}
+	}
+	if !handled_DetectProb && err == nil {
+		err = enc.ErrSkipRequired{Name: "DetectProb", TypeNum: 840}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (value *CsAuditStatus) Encode() enc.Wire {
+	encoder := CsAuditStatusEncoder{}
+	encoder.Init(value)
+	return encoder.Encode(value)
+}
+
+func (value *CsAuditStatus) Bytes() []byte {
+	return value.Encode().Join()
+}
+
+func ParseCsAuditStatus(reader enc.WireView, ignoreCritical bool) (*CsAuditStatus, error) {
+	context := CsAuditStatusParsingContext{}
+	context.Init()
+	return context.Parse(reader, ignoreCritical)
+}
+
+type CsAuditRoundResultEncoder struct {
+	Length uint
+}
+
+type CsAuditRoundResultParsingContext struct {
+}
+
+func (encoder *CsAuditRoundResultEncoder) Init(value *CsAuditRoundResult) {
+
+	l := uint(0)
+	if value.Seed != nil {
+		l += 3
+		l += uint(enc.TLNum(len(value.Seed)).EncodingLength())
+		l += uint(len(value.Seed))
+	}
+	l += 3
+	l += uint(1 + enc.Nat(value.SampleK).EncodingLength())
+	l += 3
+	l += uint(enc.TLNum(len(value.SampleMode)).EncodingLength())
+	l += uint(len(value.SampleMode))
+	l += 3
+	l += uint(1 + enc.Nat(value.NThreads).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.NFinished).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.NOverruns).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.Total).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.Sampled).EncodingLength())
+	l += 3
+	l += uint(1 + enc.Nat(value.Mismatched).EncodingLength())
+	l += 3
+	l += uint(enc.TLNum(len(value.DetectProb)).EncodingLength())
+	l += uint(len(value.DetectProb))
+	l += 3
+	l += uint(1 + enc.Nat(value.Checked).EncodingLength())
+	encoder.Length = l
+
+}
+
+func (context *CsAuditRoundResultParsingContext) Init() {
+
+}
+
+func (encoder *CsAuditRoundResultEncoder) EncodeInto(value *CsAuditRoundResult, buf []byte) {
+
+	pos := uint(0)
+
+	if value.Seed != nil {
+		buf[pos] = 253
+		binary.BigEndian.PutUint16(buf[pos+1:], uint16(865))
+		pos += 3
+		pos += uint(enc.TLNum(len(value.Seed)).EncodeInto(buf[pos:]))
+		copy(buf[pos:], value.Seed)
+		pos += uint(len(value.Seed))
+	}
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(866))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.SampleK).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(867))
+	pos += 3
+	pos += uint(enc.TLNum(len(value.SampleMode)).EncodeInto(buf[pos:]))
+	copy(buf[pos:], value.SampleMode)
+	pos += uint(len(value.SampleMode))
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(868))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.NThreads).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(869))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.NFinished).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(870))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.NOverruns).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(837))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.Total).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(838))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.Sampled).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(839))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.Mismatched).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(840))
+	pos += 3
+	pos += uint(enc.TLNum(len(value.DetectProb)).EncodeInto(buf[pos:]))
+	copy(buf[pos:], value.DetectProb)
+	pos += uint(len(value.DetectProb))
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(806))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.Checked).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+}
+
+func (encoder *CsAuditRoundResultEncoder) Encode(value *CsAuditRoundResult) enc.Wire {
+
+	wire := make(enc.Wire, 1)
+	wire[0] = make([]byte, encoder.Length)
+	buf := wire[0]
+	encoder.EncodeInto(value, buf)
+
+	return wire
+}
+
+func (context *CsAuditRoundResultParsingContext) Parse(reader enc.WireView, ignoreCritical bool) (*CsAuditRoundResult, error) {
+
+	var handled_Seed bool = false
+	var handled_SampleK bool = false
+	var handled_SampleMode bool = false
+	var handled_NThreads bool = false
+	var handled_NFinished bool = false
+	var handled_NOverruns bool = false
+	var handled_Total bool = false
+	var handled_Sampled bool = false
+	var handled_Mismatched bool = false
+	var handled_DetectProb bool = false
+	var handled_Checked bool = false
+
+	progress := -1
+	_ = progress
+
+	value := &CsAuditRoundResult{}
+	var err error
+	var startPos int
+	for {
+		startPos = reader.Pos()
+		if startPos >= reader.Length() {
+			break
+		}
+		typ := enc.TLNum(0)
+		l := enc.TLNum(0)
+		typ, err = reader.ReadTLNum()
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+		l, err = reader.ReadTLNum()
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+
+		err = nil
+		if handled := false; true {
+			switch typ {
+			case 865:
+				if true {
+					handled = true
+					handled_Seed = true
+					value.Seed = make([]byte, l)
+					_, err = reader.ReadFull(value.Seed)
+				}
+			case 866:
+				if true {
+					handled = true
+					handled_SampleK = true
+					value.SampleK = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.SampleK = uint64(value.SampleK<<8) | uint64(x)
+						}
+					}
+				}
+			case 867:
+				if true {
+					handled = true
+					handled_SampleMode = true
+					{
+						var builder strings.Builder
+						_, err = reader.CopyN(&builder, int(l))
+						if err == nil {
+							value.SampleMode = builder.String()
+						}
+					}
+				}
+			case 868:
+				if true {
+					handled = true
+					handled_NThreads = true
+					value.NThreads = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.NThreads = uint64(value.NThreads<<8) | uint64(x)
+						}
+					}
+				}
+			case 869:
+				if true {
+					handled = true
+					handled_NFinished = true
+					value.NFinished = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.NFinished = uint64(value.NFinished<<8) | uint64(x)
+						}
+					}
+				}
+			case 870:
+				if true {
+					handled = true
+					handled_NOverruns = true
+					value.NOverruns = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.NOverruns = uint64(value.NOverruns<<8) | uint64(x)
+						}
+					}
+				}
+			case 837:
+				if true {
+					handled = true
+					handled_Total = true
+					value.Total = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.Total = uint64(value.Total<<8) | uint64(x)
+						}
+					}
+				}
+			case 838:
+				if true {
+					handled = true
+					handled_Sampled = true
+					value.Sampled = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.Sampled = uint64(value.Sampled<<8) | uint64(x)
+						}
+					}
+				}
+			case 839:
+				if true {
+					handled = true
+					handled_Mismatched = true
+					value.Mismatched = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.Mismatched = uint64(value.Mismatched<<8) | uint64(x)
+						}
+					}
+				}
+			case 840:
+				if true {
+					handled = true
+					handled_DetectProb = true
+					{
+						var builder strings.Builder
+						_, err = reader.CopyN(&builder, int(l))
+						if err == nil {
+							value.DetectProb = builder.String()
+						}
+					}
+				}
+			case 806:
+				if true {
+					handled = true
+					handled_Checked = true
+					value.Checked = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.Checked = uint64(value.Checked<<8) | uint64(x)
+						}
+					}
+				}
+			default:
+				if !ignoreCritical && ((typ <= 31) || ((typ & 1) == 1)) {
+					return nil, enc.ErrUnrecognizedField{TypeNum: typ}
+				}
+				handled = true
+				err = reader.Skip(int(l))
+			}
+			if err == nil && !handled {
+			}
+			if err != nil {
+				return nil, enc.ErrFailToParse{TypeNum: typ, Err: err}
+			}
+		}
+	}
+
+	startPos = reader.Pos()
+	err = nil
+
+	if !handled_Seed && err == nil {
+		value.Seed = nil
+	}
+	if !handled_SampleK && err == nil {
+		err = enc.ErrSkipRequired{Name: "SampleK", TypeNum: 866}
+	}
+	if !handled_SampleMode && err == nil {
+		err = enc.ErrSkipRequired{Name: "SampleMode", TypeNum: 867}
+	}
+	if !handled_NThreads && err == nil {
+		err = enc.ErrSkipRequired{Name: "NThreads", TypeNum: 868}
+	}
+	if !handled_NFinished && err == nil {
+		err = enc.ErrSkipRequired{Name: "NFinished", TypeNum: 869}
+	}
+	if !handled_NOverruns && err == nil {
+		err = enc.ErrSkipRequired{Name: "NOverruns", TypeNum: 870}
+	}
+	if !handled_Total && err == nil {
+		err = enc.ErrSkipRequired{Name: "Total", TypeNum: 837}
+	}
+	if !handled_Sampled && err == nil {
+		err = enc.ErrSkipRequired{Name: "Sampled", TypeNum: 838}
+	}
+	if !handled_Mismatched && err == nil {
+		err = enc.ErrSkipRequired{Name: "Mismatched", TypeNum: 839}
+	}
+	if !handled_DetectProb && err == nil {
+		err = enc.ErrSkipRequired{Name: "DetectProb", TypeNum: 840}
+	}
+	if !handled_Checked && err == nil {
+		err = enc.ErrSkipRequired{Name: "Checked", TypeNum: 806}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (value *CsAuditRoundResult) Encode() enc.Wire {
+	encoder := CsAuditRoundResultEncoder{}
+	encoder.Init(value)
+	return encoder.Encode(value)
+}
+
+func (value *CsAuditRoundResult) Bytes() []byte {
+	return value.Encode().Join()
+}
+
+func ParseCsAuditRoundResult(reader enc.WireView, ignoreCritical bool) (*CsAuditRoundResult, error) {
+	context := CsAuditRoundResultParsingContext{}
+	context.Init()
+	return context.Parse(reader, ignoreCritical)
+}
+
+type CsNatAggregateEncoder struct {
+	Length uint
+
+	Name_length uint
+}
+
+type CsNatAggregateParsingContext struct {
+}
+
+func (encoder *CsNatAggregateEncoder) Init(value *CsNatAggregate) {
+	if value.Name != nil {
+		encoder.Name_length = 0
+		for _, c := range value.Name {
+			encoder.Name_length += uint(c.EncodingLength())
+		}
+	}
+
+	l := uint(0)
+	if value.Name != nil {
+		l += 1
+		l += uint(enc.TLNum(encoder.Name_length).EncodingLength())
+		l += encoder.Name_length
+	}
+	l += 3
+	l += uint(1 + enc.Nat(value.LeafCount).EncodingLength())
+	if value.Agg != nil {
+		l += 3
+		l += uint(enc.TLNum(len(value.Agg)).EncodingLength())
+		l += uint(len(value.Agg))
+	}
+	if value.TagAgg != nil {
+		l += 3
+		l += uint(enc.TLNum(len(value.TagAgg)).EncodingLength())
+		l += uint(len(value.TagAgg))
+	}
+	l += 3
+	l += uint(1 + enc.Nat(value.Cached).EncodingLength())
+	encoder.Length = l
+
+}
+
+func (context *CsNatAggregateParsingContext) Init() {
+
+}
+
+func (encoder *CsNatAggregateEncoder) EncodeInto(value *CsNatAggregate, buf []byte) {
+
+	pos := uint(0)
+
+	if value.Name != nil {
+		buf[pos] = byte(7)
+		pos += 1
+		pos += uint(enc.TLNum(encoder.Name_length).EncodeInto(buf[pos:]))
+		for _, c := range value.Name {
+			pos += uint(c.EncodeInto(buf[pos:]))
+		}
+	}
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(849))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.LeafCount).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	if value.Agg != nil {
+		buf[pos] = 253
+		binary.BigEndian.PutUint16(buf[pos+1:], uint16(850))
+		pos += 3
+		pos += uint(enc.TLNum(len(value.Agg)).EncodeInto(buf[pos:]))
+		copy(buf[pos:], value.Agg)
+		pos += uint(len(value.Agg))
+	}
+	if value.TagAgg != nil {
+		buf[pos] = 253
+		binary.BigEndian.PutUint16(buf[pos+1:], uint16(851))
+		pos += 3
+		pos += uint(enc.TLNum(len(value.TagAgg)).EncodeInto(buf[pos:]))
+		copy(buf[pos:], value.TagAgg)
+		pos += uint(len(value.TagAgg))
+	}
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(853))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.Cached).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+}
+
+func (encoder *CsNatAggregateEncoder) Encode(value *CsNatAggregate) enc.Wire {
+
+	wire := make(enc.Wire, 1)
+	wire[0] = make([]byte, encoder.Length)
+	buf := wire[0]
+	encoder.EncodeInto(value, buf)
+
+	return wire
+}
+
+func (context *CsNatAggregateParsingContext) Parse(reader enc.WireView, ignoreCritical bool) (*CsNatAggregate, error) {
+
+	var handled_Name bool = false
+	var handled_LeafCount bool = false
+	var handled_Agg bool = false
+	var handled_TagAgg bool = false
+	var handled_Cached bool = false
+
+	progress := -1
+	_ = progress
+
+	value := &CsNatAggregate{}
+	var err error
+	var startPos int
+	for {
+		startPos = reader.Pos()
+		if startPos >= reader.Length() {
+			break
+		}
+		typ := enc.TLNum(0)
+		l := enc.TLNum(0)
+		typ, err = reader.ReadTLNum()
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+		l, err = reader.ReadTLNum()
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+
+		err = nil
+		if handled := false; true {
+			switch typ {
+			case 7:
+				if true {
+					handled = true
+					handled_Name = true
+					delegate := reader.Delegate(int(l))
+					value.Name, err = delegate.ReadName()
+				}
+			case 849:
+				if true {
+					handled = true
+					handled_LeafCount = true
+					value.LeafCount = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.LeafCount = uint64(value.LeafCount<<8) | uint64(x)
+						}
+					}
+				}
+			case 850:
+				if true {
+					handled = true
+					handled_Agg = true
+					value.Agg = make([]byte, l)
+					_, err = reader.ReadFull(value.Agg)
+				}
+			case 851:
+				if true {
+					handled = true
+					handled_TagAgg = true
+					value.TagAgg = make([]byte, l)
+					_, err = reader.ReadFull(value.TagAgg)
+				}
+			case 853:
+				if true {
+					handled = true
+					handled_Cached = true
+					value.Cached = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.Cached = uint64(value.Cached<<8) | uint64(x)
+						}
+					}
+				}
+			default:
+				if !ignoreCritical && ((typ <= 31) || ((typ & 1) == 1)) {
+					return nil, enc.ErrUnrecognizedField{TypeNum: typ}
+				}
+				handled = true
+				err = reader.Skip(int(l))
+			}
+			if err == nil && !handled {
+			}
+			if err != nil {
+				return nil, enc.ErrFailToParse{TypeNum: typ, Err: err}
+			}
+		}
+	}
+
+	startPos = reader.Pos()
+	err = nil
+
+	if !handled_Name && err == nil {
+		value.Name = nil
+	}
+	if !handled_LeafCount && err == nil {
+		err = enc.ErrSkipRequired{Name: "LeafCount", TypeNum: 849}
+	}
+	if !handled_Agg && err == nil {
+		value.Agg = nil
+	}
+	if !handled_TagAgg && err == nil {
+		value.TagAgg = nil
+	}
+	if !handled_Cached && err == nil {
+		err = enc.ErrSkipRequired{Name: "Cached", TypeNum: 853}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (value *CsNatAggregate) Encode() enc.Wire {
+	encoder := CsNatAggregateEncoder{}
+	encoder.Init(value)
+	return encoder.Encode(value)
+}
+
+func (value *CsNatAggregate) Bytes() []byte {
+	return value.Encode().Join()
+}
+
+func ParseCsNatAggregate(reader enc.WireView, ignoreCritical bool) (*CsNatAggregate, error) {
+	context := CsNatAggregateParsingContext{}
+	context.Init()
+	return context.Parse(reader, ignoreCritical)
+}
+
+type CsNatAggregateMsgEncoder struct {
+	Length uint
+
+	Aggregates_subencoder []struct {
+		Aggregates_encoder CsNatAggregateEncoder
+	}
+}
+
+type CsNatAggregateMsgParsingContext struct {
+	Aggregates_context CsNatAggregateParsingContext
+}
+
+func (encoder *CsNatAggregateMsgEncoder) Init(value *CsNatAggregateMsg) {
+
+	{
+		Aggregates_l := len(value.Aggregates)
+		encoder.Aggregates_subencoder = make([]struct {
+			Aggregates_encoder CsNatAggregateEncoder
+		}, Aggregates_l)
+		for i := 0; i < Aggregates_l; i++ {
+			pseudoEncoder := &encoder.Aggregates_subencoder[i]
+			pseudoValue := struct {
+				Aggregates *CsNatAggregate
+			}{
+				Aggregates: value.Aggregates[i],
+			}
+			{
+				encoder := pseudoEncoder
+				value := &pseudoValue
+				if value.Aggregates != nil {
+					encoder.Aggregates_encoder.Init(value.Aggregates)
+				}
+				_ = encoder
+				_ = value
+			}
+		}
+	}
+
+	l := uint(0)
+	l += 3
+	l += uint(enc.TLNum(len(value.Scheme)).EncodingLength())
+	l += uint(len(value.Scheme))
+	if value.PublicKey != nil {
+		l += 3
+		l += uint(enc.TLNum(len(value.PublicKey)).EncodingLength())
+		l += uint(len(value.PublicKey))
+	}
+	l += 3
+	l += uint(1 + enc.Nat(value.Timestamp).EncodingLength())
+	if value.Nonce != nil {
+		l += 3
+		l += uint(enc.TLNum(len(value.Nonce)).EncodingLength())
+		l += uint(len(value.Nonce))
+	}
+	if value.Truncated {
+		l += 3
+		l += 1
+	}
+	if value.Aggregates != nil {
+		for seq_i, seq_v := range value.Aggregates {
+			pseudoEncoder := &encoder.Aggregates_subencoder[seq_i]
+			pseudoValue := struct {
+				Aggregates *CsNatAggregate
+			}{
+				Aggregates: seq_v,
+			}
+			{
+				encoder := pseudoEncoder
+				value := &pseudoValue
+				if value.Aggregates != nil {
+					l += 3
+					l += uint(enc.TLNum(encoder.Aggregates_encoder.Length).EncodingLength())
+					l += encoder.Aggregates_encoder.Length
+				}
+				_ = encoder
+				_ = value
+			}
+		}
+	}
+	encoder.Length = l
+
+}
+
+func (context *CsNatAggregateMsgParsingContext) Init() {
+
+	context.Aggregates_context.Init()
+}
+
+func (encoder *CsNatAggregateMsgEncoder) EncodeInto(value *CsNatAggregateMsg, buf []byte) {
+
+	pos := uint(0)
+
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(771))
+	pos += 3
+	pos += uint(enc.TLNum(len(value.Scheme)).EncodeInto(buf[pos:]))
+	copy(buf[pos:], value.Scheme)
+	pos += uint(len(value.Scheme))
+	if value.PublicKey != nil {
+		buf[pos] = 253
+		binary.BigEndian.PutUint16(buf[pos+1:], uint16(852))
+		pos += 3
+		pos += uint(enc.TLNum(len(value.PublicKey)).EncodeInto(buf[pos:]))
+		copy(buf[pos:], value.PublicKey)
+		pos += uint(len(value.PublicKey))
+	}
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(770))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.Timestamp).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	if value.Nonce != nil {
+		buf[pos] = 253
+		binary.BigEndian.PutUint16(buf[pos+1:], uint16(769))
+		pos += 3
+		pos += uint(enc.TLNum(len(value.Nonce)).EncodeInto(buf[pos:]))
+		copy(buf[pos:], value.Nonce)
+		pos += uint(len(value.Nonce))
+	}
+	if value.Truncated {
+		buf[pos] = 253
+		binary.BigEndian.PutUint16(buf[pos+1:], uint16(854))
+		pos += 3
+		buf[pos] = byte(0)
+		pos += 1
+	}
+	if value.Aggregates != nil {
+		for seq_i, seq_v := range value.Aggregates {
+			pseudoEncoder := &encoder.Aggregates_subencoder[seq_i]
+			pseudoValue := struct {
+				Aggregates *CsNatAggregate
+			}{
+				Aggregates: seq_v,
+			}
+			{
+				encoder := pseudoEncoder
+				value := &pseudoValue
+				if value.Aggregates != nil {
+					buf[pos] = 253
+					binary.BigEndian.PutUint16(buf[pos+1:], uint16(848))
+					pos += 3
+					pos += uint(enc.TLNum(encoder.Aggregates_encoder.Length).EncodeInto(buf[pos:]))
+					if encoder.Aggregates_encoder.Length > 0 {
+						encoder.Aggregates_encoder.EncodeInto(value.Aggregates, buf[pos:])
+						pos += encoder.Aggregates_encoder.Length
+					}
+				}
+				_ = encoder
+				_ = value
+			}
+		}
+	}
+}
+
+func (encoder *CsNatAggregateMsgEncoder) Encode(value *CsNatAggregateMsg) enc.Wire {
+
+	wire := make(enc.Wire, 1)
+	wire[0] = make([]byte, encoder.Length)
+	buf := wire[0]
+	encoder.EncodeInto(value, buf)
+
+	return wire
+}
+
+func (context *CsNatAggregateMsgParsingContext) Parse(reader enc.WireView, ignoreCritical bool) (*CsNatAggregateMsg, error) {
+
+	var handled_Scheme bool = false
+	var handled_PublicKey bool = false
+	var handled_Timestamp bool = false
+	var handled_Nonce bool = false
+	var handled_Truncated bool = false
+	var handled_Aggregates bool = false
+
+	progress := -1
+	_ = progress
+
+	value := &CsNatAggregateMsg{}
+	var err error
+	var startPos int
+	for {
+		startPos = reader.Pos()
+		if startPos >= reader.Length() {
+			break
+		}
+		typ := enc.TLNum(0)
+		l := enc.TLNum(0)
+		typ, err = reader.ReadTLNum()
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+		l, err = reader.ReadTLNum()
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+
+		err = nil
+		if handled := false; true {
+			switch typ {
+			case 771:
+				if true {
+					handled = true
+					handled_Scheme = true
+					{
+						var builder strings.Builder
+						_, err = reader.CopyN(&builder, int(l))
+						if err == nil {
+							value.Scheme = builder.String()
+						}
+					}
+				}
+			case 852:
+				if true {
+					handled = true
+					handled_PublicKey = true
+					value.PublicKey = make([]byte, l)
+					_, err = reader.ReadFull(value.PublicKey)
+				}
+			case 770:
+				if true {
+					handled = true
+					handled_Timestamp = true
+					value.Timestamp = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.Timestamp = uint64(value.Timestamp<<8) | uint64(x)
+						}
+					}
+				}
+			case 769:
+				if true {
+					handled = true
+					handled_Nonce = true
+					value.Nonce = make([]byte, l)
+					_, err = reader.ReadFull(value.Nonce)
+				}
+			case 854:
+				if true {
+					handled = true
+					handled_Truncated = true
+					value.Truncated = true
+					err = reader.Skip(int(l))
+				}
+			case 848:
+				if true {
+					handled = true
+					handled_Aggregates = true
+					if value.Aggregates == nil {
+						value.Aggregates = make([]*CsNatAggregate, 0)
+					}
+					{
+						pseudoValue := struct {
+							Aggregates *CsNatAggregate
+						}{}
+						{
+							value := &pseudoValue
+							value.Aggregates, err = context.Aggregates_context.Parse(reader.Delegate(int(l)), ignoreCritical)
+							_ = value
+						}
+						value.Aggregates = append(value.Aggregates, pseudoValue.Aggregates)
+					}
+					progress--
+				}
+			default:
+				if !ignoreCritical && ((typ <= 31) || ((typ & 1) == 1)) {
+					return nil, enc.ErrUnrecognizedField{TypeNum: typ}
+				}
+				handled = true
+				err = reader.Skip(int(l))
+			}
+			if err == nil && !handled {
+			}
+			if err != nil {
+				return nil, enc.ErrFailToParse{TypeNum: typ, Err: err}
+			}
+		}
+	}
+
+	startPos = reader.Pos()
+	err = nil
+
+	if !handled_Scheme && err == nil {
+		err = enc.ErrSkipRequired{Name: "Scheme", TypeNum: 771}
+	}
+	if !handled_PublicKey && err == nil {
+		value.PublicKey = nil
+	}
+	if !handled_Timestamp && err == nil {
+		err = enc.ErrSkipRequired{Name: "Timestamp", TypeNum: 770}
+	}
+	if !handled_Nonce && err == nil {
+		value.Nonce = nil
+	}
+	if !handled_Truncated && err == nil {
+		value.Truncated = false
+	}
+	if !handled_Aggregates && err == nil {
+		// sequence - skip
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (value *CsNatAggregateMsg) Encode() enc.Wire {
+	encoder := CsNatAggregateMsgEncoder{}
+	encoder.Init(value)
+	return encoder.Encode(value)
+}
+
+func (value *CsNatAggregateMsg) Bytes() []byte {
+	return value.Encode().Join()
+}
+
+func ParseCsNatAggregateMsg(reader enc.WireView, ignoreCritical bool) (*CsNatAggregateMsg, error) {
+	context := CsNatAggregateMsgParsingContext{}
+	context.Init()
+	return context.Parse(reader, ignoreCritical)
+}
+
+type CsAuditConfigEncoder struct {
+	Length uint
+}
+
+type CsAuditConfigParsingContext struct {
+}
+
+func (encoder *CsAuditConfigEncoder) Init(value *CsAuditConfig) {
+
+	l := uint(0)
+	if optval, ok := value.Interval.Get(); ok {
+		l += 3
+		l += uint(1 + enc.Nat(optval).EncodingLength())
+	}
+	if optval, ok := value.SeuRate.Get(); ok {
+		l += 3
+		l += uint(enc.TLNum(len(optval)).EncodingLength())
+		l += uint(len(optval))
+	}
+	if optval, ok := value.SeuPrefix.Get(); ok {
+		l += 3
+		l += uint(enc.TLNum(len(optval)).EncodingLength())
+		l += uint(len(optval))
+	}
+	if optval, ok := value.Flags.Get(); ok {
+		l += 1
+		l += uint(1 + enc.Nat(optval).EncodingLength())
+	}
+	if optval, ok := value.Mask.Get(); ok {
+		l += 1
+		l += uint(1 + enc.Nat(optval).EncodingLength())
+	}
+	encoder.Length = l
+
+}
+
+func (context *CsAuditConfigParsingContext) Init() {
+
+}
+
+func (encoder *CsAuditConfigEncoder) EncodeInto(value *CsAuditConfig, buf []byte) {
+
+	pos := uint(0)
+
+	if optval, ok := value.Interval.Get(); ok {
+		buf[pos] = 253
+		binary.BigEndian.PutUint16(buf[pos+1:], uint16(817))
+		pos += 3
+
+		buf[pos] = byte(enc.Nat(optval).EncodeInto(buf[pos+1:]))
+		pos += uint(1 + buf[pos])
+
+	}
+	if optval, ok := value.SeuRate.Get(); ok {
+		buf[pos] = 253
+		binary.BigEndian.PutUint16(buf[pos+1:], uint16(818))
+		pos += 3
+		pos += uint(enc.TLNum(len(optval)).EncodeInto(buf[pos:]))
+		copy(buf[pos:], optval)
+		pos += uint(len(optval))
+	}
+	if optval, ok := value.SeuPrefix.Get(); ok {
+		buf[pos] = 253
+		binary.BigEndian.PutUint16(buf[pos+1:], uint16(819))
+		pos += 3
+		pos += uint(enc.TLNum(len(optval)).EncodeInto(buf[pos:]))
+		copy(buf[pos:], optval)
+		pos += uint(len(optval))
+	}
+	if optval, ok := value.Flags.Get(); ok {
+		buf[pos] = byte(108)
+		pos += 1
+
+		buf[pos] = byte(enc.Nat(optval).EncodeInto(buf[pos+1:]))
+		pos += uint(1 + buf[pos])
+
+	}
+	if optval, ok := value.Mask.Get(); ok {
+		buf[pos] = byte(112)
+		pos += 1
+
+		buf[pos] = byte(enc.Nat(optval).EncodeInto(buf[pos+1:]))
+		pos += uint(1 + buf[pos])
+
+	}
+}
+
+func (encoder *CsAuditConfigEncoder) Encode(value *CsAuditConfig) enc.Wire {
+
+	wire := make(enc.Wire, 1)
+	wire[0] = make([]byte, encoder.Length)
+	buf := wire[0]
+	encoder.EncodeInto(value, buf)
+
+	return wire
+}
+
+func (context *CsAuditConfigParsingContext) Parse(reader enc.WireView, ignoreCritical bool) (*CsAuditConfig, error) {
+
+	var handled_Interval bool = false
+	var handled_SeuRate bool = false
+	var handled_SeuPrefix bool = false
+	var handled_Flags bool = false
+	var handled_Mask bool = false
+
+	progress := -1
+	_ = progress
+
+	value := &CsAuditConfig{}
+	var err error
+	var startPos int
+	for {
+		startPos = reader.Pos()
+		if startPos >= reader.Length() {
+			break
+		}
+		typ := enc.TLNum(0)
+		l := enc.TLNum(0)
+		typ, err = reader.ReadTLNum()
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+		l, err = reader.ReadTLNum()
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+
+		err = nil
+		if handled := false; true {
+			switch typ {
+			case 817:
+				if true {
+					handled = true
+					handled_Interval = true
+					{
+						optval := uint64(0)
+						optval = uint64(0)
+						{
+							for i := 0; i < int(l); i++ {
+								x := byte(0)
+								x, err = reader.ReadByte()
+								if err != nil {
+									if err == io.EOF {
+										err = io.ErrUnexpectedEOF
+									}
+									break
+								}
+								optval = uint64(optval<<8) | uint64(x)
+							}
+						}
+						value.Interval.Set(optval)
+					}
+				}
+			case 818:
+				if true {
+					handled = true
+					handled_SeuRate = true
+					{
+						var builder strings.Builder
+						_, err = reader.CopyN(&builder, int(l))
+						if err == nil {
+							value.SeuRate.Set(builder.String())
+						}
+					}
+				}
+			case 819:
+				if true {
+					handled = true
+					handled_SeuPrefix = true
+					{
+						var builder strings.Builder
+						_, err = reader.CopyN(&builder, int(l))
+						if err == nil {
+							value.SeuPrefix.Set(builder.String())
+						}
+					}
+				}
+			case 108:
+				if true {
+					handled = true
+					handled_Flags = true
+					{
+						optval := uint64(0)
+						optval = uint64(0)
+						{
+							for i := 0; i < int(l); i++ {
+								x := byte(0)
+								x, err = reader.ReadByte()
+								if err != nil {
+									if err == io.EOF {
+										err = io.ErrUnexpectedEOF
+									}
+									break
+								}
+								optval = uint64(optval<<8) | uint64(x)
+							}
+						}
+						value.Flags.Set(optval)
+					}
+				}
+			case 112:
+				if true {
+					handled = true
+					handled_Mask = true
+					{
+						optval := uint64(0)
+						optval = uint64(0)
+						{
+							for i := 0; i < int(l); i++ {
+								x := byte(0)
+								x, err = reader.ReadByte()
+								if err != nil {
+									if err == io.EOF {
+										err = io.ErrUnexpectedEOF
+									}
+									break
+								}
+								optval = uint64(optval<<8) | uint64(x)
+							}
+						}
+						value.Mask.Set(optval)
+					}
+				}
+			default:
+				if !ignoreCritical && ((typ <= 31) || ((typ & 1) == 1)) {
+					return nil, enc.ErrUnrecognizedField{TypeNum: typ}
+				}
+				handled = true
+				err = reader.Skip(int(l))
+			}
+			if err == nil && !handled {
+			}
+			if err != nil {
+				return nil, enc.ErrFailToParse{TypeNum: typ, Err: err}
+			}
+		}
+	}
+
+	startPos = reader.Pos()
+	err = nil
+
+	if !handled_Interval && err == nil {
+		value.Interval.Unset()
+	}
+	if !handled_SeuRate && err == nil {
+		value.SeuRate.Unset()
+	}
+	if !handled_SeuPrefix && err == nil {
+		value.SeuPrefix.Unset()
+	}
+	if !handled_Flags && err == nil {
+		value.Flags.Unset()
+	}
+	if !handled_Mask && err == nil {
+		value.Mask.Unset()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (value *CsAuditConfig) Encode() enc.Wire {
+	encoder := CsAuditConfigEncoder{}
+	encoder.Init(value)
+	return encoder.Encode(value)
+}
+
+func (value *CsAuditConfig) Bytes() []byte {
+	return value.Encode().Join()
+}
+
+func ParseCsAuditConfig(reader enc.WireView, ignoreCritical bool) (*CsAuditConfig, error) {
+	context := CsAuditConfigParsingContext{}
+	context.Init()
+	return context.Parse(reader, ignoreCritical)
+}
@@ -27,6 +27,23 @@ type Data struct {
 
 	//+field:wire
 	CrossSchemaV enc.Wire `tlv:"0x258"`
+
+	// Producer audit tag, outside the signed portion
+	//+field:struct:AuditTag
+	AuditTagV *AuditTag `tlv:"0x25a"`
+}
+
+// AuditTag is a producer-generated cache audit tag carried in a Data packet.
+// The tag covers the Data packet encoded without the AuditTag element.
+type AuditTag struct {
+	//+field:natural
+	TagType uint64 `tlv:"0x25c"`
+	//+field:binary
+	TagValue []byte `tlv:"0x25e"`
+	// PublicKey is an optional hint of the producer key. It is not signed,
+	// and must not be used to verify the tag.
+	//+field:binary
+	PublicKey []byte `tlv:"0x260"`
 }
 
 type MetaInfo struct {
@@ -80,3 +97,12 @@ type CertAdditionalDescription struct {
 	//+field:sequence:*CertDescriptionEntry:struct:CertDescriptionEntry
 	DescriptionEntries []*CertDescriptionEntry `tlv:"0x0200"`
 }
+
+// PrefixAnnouncementContent is the content of a prefix announcement object.
+// https://redmine.named-data.net/projects/nfd/wiki/PrefixAnnouncement
+type PrefixAnnouncementContent struct {
+	//+field:time
+	ExpirationPeriod time.Duration `tlv:"0x6d"`
+	//+field:struct:ValidityPeriod
+	ValidityPeriod *ValidityPeriod `tlv:"0xfd"`
+}
@@ -27,6 +27,7 @@ type DataEncoder struct {
 	SignatureValue_wireIdx int
 	SignatureValue_estLen  uint
 	CrossSchemaV_length    uint
+	AuditTagV_encoder      AuditTagEncoder
 }
 
 type DataParsingContext struct {
@@ -36,6 +37,8 @@ type DataParsingContext struct {
 	MetaInfo_context MetaInfoParsingContext
 
 	SignatureInfo_context SignatureInfoParsingContext
+
+	AuditTagV_context AuditTagParsingContext
 }
 
 func (encoder *DataEncoder) Init(value *Data) {
@@ -65,6 +68,9 @@ func (encoder *DataEncoder) Init(value *Data) {
 			encoder.CrossSchemaV_length += uint(len(c))
 		}
 	}
+	if value.AuditTagV != nil {
+		encoder.AuditTagV_encoder.Init(value.AuditTagV)
+	}
 
 	l := uint(0)
 
@@ -99,6 +105,11 @@ func (encoder *DataEncoder) Init(value *Data) {
 		l += uint(enc.TLNum(encoder.CrossSchemaV_length).EncodingLength())
 		l += encoder.CrossSchemaV_length
 	}
+	if value.AuditTagV != nil {
+		l += 3
+		l += uint(enc.TLNum(encoder.AuditTagV_encoder.Length).EncodingLength())
+		l += encoder.AuditTagV_encoder.Length
+	}
 	encoder.Length = l
 
 	wirePlan := make([]uint, 0, 8)
@@ -148,6 +159,11 @@ func (encoder *DataEncoder) Init(value *Data) {
 			l = 0
 		}
 	}
+	if value.AuditTagV != nil {
+		l += 3
+		l += uint(enc.TLNum(encoder.AuditTagV_encoder.Length).EncodingLength())
+		l += encoder.AuditTagV_encoder.Length
+	}
 	if l > 0 {
 		wirePlan = append(wirePlan, l)
 	}
@@ -161,6 +177,7 @@ func (context *DataParsingContext) Init() {
 	context.SignatureInfo_context.Init()
 	context.sigCovered = make(enc.Wire, 0)
 
+	context.AuditTagV_context.Init()
 }
 
 func (encoder *DataEncoder) EncodeInto(value *Data, wire enc.Wire) {
@@ -275,6 +292,16 @@ func (encoder *DataEncoder) EncodeInto(value *Data, wire enc.Wire) {
 			}
 		}
 	}
+	if value.AuditTagV != nil {
+		buf[pos] = 253
+		binary.BigEndian.PutUint16(buf[pos+1:], uint16(602))
+		pos += 3
+		pos += uint(enc.TLNum(encoder.AuditTagV_encoder.Length).EncodeInto(buf[pos:]))
+		if encoder.AuditTagV_encoder.Length > 0 {
+			encoder.AuditTagV_encoder.EncodeInto(value.AuditTagV, buf[pos:])
+			pos += encoder.AuditTagV_encoder.Length
+		}
+	}
 }
 
 func (encoder *DataEncoder) Encode(value *Data) enc.Wire {
@@ -306,6 +333,7 @@ func (context *DataParsingContext) Parse(reader enc.WireView, ignoreCritical boo
 	var handled_SignatureInfo bool = false
 	var handled_SignatureValue bool = false
 	var handled_CrossSchemaV bool = false
+	var handled_AuditTagV bool = false
 
 	progress := -1
 	_ = progress
@@ -330,7 +358,7 @@ func (context *DataParsingContext) Parse(reader enc.WireView, ignoreCritical boo
 		}
 
 		err = nil
-		for handled := false; !handled && progress < 8; progress++ {
+		for handled := false; !handled && progress < 9; progress++ {
 			switch typ {
 			case 7:
 				if progress+1 == 2 {
@@ -373,6 +401,12 @@ func (context *DataParsingContext) Parse(reader enc.WireView, ignoreCritical boo
 					handled_CrossSchemaV = true
 					value.CrossSchemaV, err = reader.ReadWire(int(l))
 				}
+			case 602:
+				if progress+1 == 8 {
+					handled = true
+					handled_AuditTagV = true
+					value.AuditTagV, err = context.AuditTagV_context.Parse(reader.Delegate(int(l)), ignoreCritical)
+				}
 			default:
 				if !ignoreCritical && ((typ <= 31) || ((typ & 1) == 1)) {
 					return nil, enc.ErrUnrecognizedField{TypeNum: typ}
@@ -406,6 +440,9 @@ func (context *DataParsingContext) Parse(reader enc.WireView, ignoreCritical boo
 				case 7 - 1:
 					handled_CrossSchemaV = true
 					value.CrossSchemaV = nil
+				case 8 - 1:
+					handled_AuditTagV = true
+					value.AuditTagV = nil
 				}
 			}
 			if err != nil {
@@ -441,6 +478,176 @@ func (context *DataParsingContext) Parse(reader enc.WireView, ignoreCritical boo
 	if !handled_CrossSchemaV && err == nil {
 		value.CrossSchemaV = nil
 	}
+	if !handled_AuditTagV && err == nil {
+		value.AuditTagV = nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+type AuditTagEncoder struct {
+	Length uint
+}
+
+type AuditTagParsingContext struct {
+}
+
+func (encoder *AuditTagEncoder) Init(value *AuditTag) {
+
+	l := uint(0)
+	l += 3
+	l += uint(1 + enc.Nat(value.TagType).EncodingLength())
+	if value.TagValue != nil {
+		l += 3
+		l += uint(enc.TLNum(len(value.TagValue)).EncodingLength())
+		l += uint(len(value.TagValue))
+	}
+	if value.PublicKey != nil {
+		l += 3
+		l += uint(enc.TLNum(len(value.PublicKey)).EncodingLength())
+		l += uint(len(value.PublicKey))
+	}
+	encoder.Length = l
+
+}
+
+func (context *AuditTagParsingContext) Init() {
+
+}
+
+func (encoder *AuditTagEncoder) EncodeInto(value *AuditTag, buf []byte) {
+
+	pos := uint(0)
+
+	buf[pos] = 253
+	binary.BigEndian.PutUint16(buf[pos+1:], uint16(604))
+	pos += 3
+
+	buf[pos] = byte(enc.Nat(value.TagType).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	if value.TagValue != nil {
+		buf[pos] = 253
+		binary.BigEndian.PutUint16(buf[pos+1:], uint16(606))
+		pos += 3
+		pos += uint(enc.TLNum(len(value.TagValue)).EncodeInto(buf[pos:]))
+		copy(buf[pos:], value.TagValue)
+		pos += uint(len(value.TagValue))
+	}
+	if value.PublicKey != nil {
+		buf[pos] = 253
+		binary.BigEndian.PutUint16(buf[pos+1:], uint16(608))
+		pos += 3
+		pos += uint(enc.TLNum(len(value.PublicKey)).EncodeInto(buf[pos:]))
+		copy(buf[pos:], value.PublicKey)
+		pos += uint(len(value.PublicKey))
+	}
+}
+
+func (encoder *AuditTagEncoder) Encode(value *AuditTag) enc.Wire {
+
+	wire := make(enc.Wire, 1)
+	wire[0] = make([]byte, encoder.Length)
+	buf := wire[0]
+	encoder.EncodeInto(value, buf)
+
+	return wire
+}
+
+func (context *AuditTagParsingContext) Parse(reader enc.WireView, ignoreCritical bool) (*AuditTag, error) {
+
+	var handled_TagType bool = false
+	var handled_TagValue bool = false
+	var handled_PublicKey bool = false
+
+	progress := -1
+	_ = progress
+
+	value := &AuditTag{}
+	var err error
+	var startPos int
+	for {
+		startPos = reader.Pos()
+		if startPos >= reader.Length() {
+			break
+		}
+		typ := enc.TLNum(0)
+		l := enc.TLNum(0)
+		typ, err = reader.ReadTLNum()
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+		l, err = reader.ReadTLNum()
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+
+		err = nil
+		if handled := false; true {
+			switch typ {
+			case 604:
+				if true {
+					handled = true
+					handled_TagType = true
+					value.TagType = uint64(0)
+					{
+						for i := 0; i < int(l); i++ {
+							x := byte(0)
+							x, err = reader.ReadByte()
+							if err != nil {
+								if err == io.EOF {
+									err = io.ErrUnexpectedEOF
+								}
+								break
+							}
+							value.TagType = uint64(value.TagType<<8) | uint64(x)
+						}
+					}
+				}
+			case 606:
+				if true {
+					handled = true
+					handled_TagValue = true
+					value.TagValue = make([]byte, l)
+					_, err = reader.ReadFull(value.TagValue)
+				}
+			case 608:
+				if true {
+					handled = true
+					handled_PublicKey = true
+					value.PublicKey = make([]byte, l)
+					_, err = reader.ReadFull(value.PublicKey)
+				}
+			default:
+				if !ignoreCritical && ((typ <= 31) || ((typ & 1) == 1)) {
+					return nil, enc.ErrUnrecognizedField{TypeNum: typ}
+				}
+				handled = true
+				err = reader.Skip(int(l))
+			}
+			if err == nil && !handled {
+			}
+			if err != nil {
+				return nil, enc.ErrFailToParse{TypeNum: typ, Err: err}
+			}
+		}
+	}
+
+	startPos = reader.Pos()
+	err = nil
+
+	if !handled_TagType && err == nil {
+		err = enc.ErrSkipRequired{Name: "TagType", TypeNum: 604}
+	}
+	if !handled_TagValue && err == nil {
+		value.TagValue = nil
+	}
+	if !handled_PublicKey && err == nil {
+		value.PublicKey = nil
+	}
 
 	if err != nil {
 		return nil, err
@@ -449,6 +656,22 @@ func (context *DataParsingContext) Parse(reader enc.WireView, ignoreCritical boo
 	return value, nil
 }
 
+func (value *AuditTag) Encode() enc.Wire {
+	encoder := AuditTagEncoder{}
+	encoder.Init(value)
+	return encoder.Encode(value)
+}
+
+func (value *AuditTag) Bytes() []byte {
+	return value.Encode().Join()
+}
+
+func ParseAuditTag(reader enc.WireView, ignoreCritical bool) (*AuditTag, error) {
+	context := AuditTagParsingContext{}
+	context.Init()
+	return context.Parse(reader, ignoreCritical)
+}
+
 type MetaInfoEncoder struct {
 	Length uint
 }
@@ -1659,6 +1882,177 @@ func ParseCertAdditionalDescription(reader enc.WireView, ignoreCritical bool) (*
 	return context.Parse(reader, ignoreCritical)
 }
 
+type PrefixAnnouncementContentEncoder struct {
+	Length uint
+
+	ValidityPeriod_encoder ValidityPeriodEncoder
+}
+
+type PrefixAnnouncementContentParsingContext struct {
+	ValidityPeriod_context ValidityPeriodParsingContext
+}
+
+func (encoder *PrefixAnnouncementContentEncoder) Init(value *PrefixAnnouncementContent) {
+
+	if value.ValidityPeriod != nil {
+		encoder.ValidityPeriod_encoder.Init(value.ValidityPeriod)
+	}
+
+	l := uint(0)
+	l += 1
+	l += uint(1 + enc.Nat(uint64(value.ExpirationPeriod/time.Millisecond)).EncodingLength())
+	if value.ValidityPeriod != nil {
+		l += 3
+		l += uint(enc.TLNum(encoder.ValidityPeriod_encoder.Length).EncodingLength())
+		l += encoder.ValidityPeriod_encoder.Length
+	}
+	encoder.Length = l
+
+}
+
+func (context *PrefixAnnouncementContentParsingContext) Init() {
+
+	context.ValidityPeriod_context.Init()
+}
+
+func (encoder *PrefixAnnouncementContentEncoder) EncodeInto(value *PrefixAnnouncementContent, buf []byte) {
+
+	pos := uint(0)
+
+	buf[pos] = byte(109)
+	pos += 1
+
+	buf[pos] = byte(enc.Nat(uint64(value.ExpirationPeriod / time.Millisecond)).EncodeInto(buf[pos+1:]))
+	pos += uint(1 + buf[pos])
+	if value.ValidityPeriod != nil {
+		buf[pos] = 253
+		binary.BigEndian.PutUint16(buf[pos+1:], uint16(253))
+		pos += 3
+		pos += uint(enc.TLNum(encoder.ValidityPeriod_encoder.Length).EncodeInto(buf[pos:]))
+		if encoder.ValidityPeriod_encoder.Length > 0 {
+			encoder.ValidityPeriod_encoder.EncodeInto(value.ValidityPeriod, buf[pos:])
+			pos += encoder.ValidityPeriod_encoder.Length
+		}
+	}
+}
+
+func (encoder *PrefixAnnouncementContentEncoder) Encode(value *PrefixAnnouncementContent) enc.Wire {
+
+	wire := make(enc.Wire, 1)
+	wire[0] = make([]byte, encoder.Length)
+	buf := wire[0]
+	encoder.EncodeInto(value, buf)
+
+	return wire
+}
+
+func (context *PrefixAnnouncementContentParsingContext) Parse(reader enc.WireView, ignoreCritical bool) (*PrefixAnnouncementContent, error) {
+
+	var handled_ExpirationPeriod bool = false
+	var handled_ValidityPeriod bool = false
+
+	progress := -1
+	_ = progress
+
+	value := &PrefixAnnouncementContent{}
+	var err error
+	var startPos int
+	for {
+		startPos = reader.Pos()
+		if startPos >= reader.Length() {
+			break
+		}
+		typ := enc.TLNum(0)
+		l := enc.TLNum(0)
+		typ, err = reader.ReadTLNum()
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+		l, err = reader.ReadTLNum()
+		if err != nil {
+			return nil, enc.ErrFailToParse{TypeNum: 0, Err: err}
+		}
+
+		err = nil
+		if handled := false; true {
+			switch typ {
+			case 109:
+				if true {
+					handled = true
+					handled_ExpirationPeriod = true
+					{
+						timeInt := uint64(0)
+						timeInt = uint64(0)
+						{
+							for i := 0; i < int(l); i++ {
+								x := byte(0)
+								x, err = reader.ReadByte()
+								if err != nil {
+									if err == io.EOF {
+										err = io.ErrUnexpectedEOF
+									}
+									break
+								}
+								timeInt = uint64(timeInt<<8) | uint64(x)
+							}
+						}
+						value.ExpirationPeriod = time.Duration(timeInt) * time.Millisecond
+					}
+				}
+			case 253:
+				if true {
+					handled = true
+					handled_ValidityPeriod = true
+					value.ValidityPeriod, err = context.ValidityPeriod_context.Parse(reader.Delegate(int(l)), ignoreCritical)
+				}
+			default:
+				if !ignoreCritical && ((typ <= 31) || ((typ & 1) == 1)) {
+					return nil, enc.ErrUnrecognizedField{TypeNum: typ}
+				}
+				handled = true
+				err = reader.Skip(int(l))
+			}
+			if err == nil && !handled {
+			}
+			if err != nil {
+				return nil, enc.ErrFailToParse{TypeNum: typ, Err: err}
+			}
+		}
+	}
+
+	startPos = reader.Pos()
+	err = nil
+
+	if !handled_ExpirationPeriod && err == nil {
+		err = enc.ErrSkipRequired{Name: "ExpirationPeriod", TypeNum: 109}
+	}
+	if !handled_ValidityPeriod && err == nil {
+		value.ValidityPeriod = nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (value *PrefixAnnouncementContent) Encode() enc.Wire {
+	encoder := PrefixAnnouncementContentEncoder{}
+	encoder.Init(value)
+	return encoder.Encode(value)
+}
+
+func (value *PrefixAnnouncementContent) Bytes() []byte {
+	return value.Encode().Join()
+}
+
+func ParsePrefixAnnouncementContent(reader enc.WireView, ignoreCritical bool) (*PrefixAnnouncementContent, error) {
+	context := PrefixAnnouncementContentParsingContext{}
+	context.Init()
+	return context.Parse(reader, ignoreCritical)
+}
+
 type InterestEncoder struct {
 	Length uint
 
@@ -21,6 +21,7 @@ type Client struct {
 
 	// announcements
 	announcements sync.Map
+	annRefresh    sync.Map
 	faceCancel    func()
 }
 
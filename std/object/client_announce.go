@@ -8,6 +8,7 @@ import (
 	"github.com/named-data/ndnd/std/log"
 	"github.com/named-data/ndnd/std/ndn"
 	"github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	sec "github.com/named-data/ndnd/std/security"
 	"github.com/named-data/ndnd/std/types/optional"
 )
 
@@ -30,6 +31,9 @@ func (c *Client) WithdrawPrefix(name enc.Name, onError func(error)) {
 	if !ok {
 		return
 	}
+	if cancel, ok := c.annRefresh.LoadAndDelete(hash); ok {
+		cancel.(func() error)()
+	}
 
 	if c.engine.Face().IsRunning() {
 		go c.withdrawPrefix_(ann.(ndn.Announcement), onError)
@@ -42,16 +46,21 @@ func (c *Client) announcePrefix_(args ndn.Announcement) {
 	time.Sleep(1 * time.Millisecond) // thanks NFD
 	announceMutex.Unlock()
 
-	origin := optional.None[uint64]()
-	if args.Expose {
-		origin = optional.Some(uint64(mgmt_2022.RouteOriginClient))
-	}
+	var err error
+	if args.Signer != nil {
+		err = c.sendPrefixAnn_(args)
+	} else {
+		origin := optional.None[uint64]()
+		if args.Expose {
+			origin = optional.Some(uint64(mgmt_2022.RouteOriginClient))
+		}
 
-	_, err := c.engine.ExecMgmtCmd("rib", "register", &mgmt_2022.ControlArgs{
-		Name:   args.Name,
-		Origin: origin,
-		Cost:   optional.Some(uint64(args.Cost)),
-	})
+		_, err = c.engine.ExecMgmtCmd("rib", "register", &mgmt_2022.ControlArgs{
+			Name:   args.Name,
+			Origin: origin,
+			Cost:   optional.Some(uint64(args.Cost)),
+		})
+	}
 	if err != nil {
 		log.Warn(c, "Failed to register route", "err", err)
 		if args.OnError != nil {
@@ -62,6 +71,41 @@ func (c *Client) announcePrefix_(args ndn.Announcement) {
 	}
 }
 
+// sendPrefixAnn_ signs a PrefixAnnouncement for the prefix, sends it with
+// rib/announce and schedules a refresh before the announcement expires.
+func (c *Client) sendPrefixAnn_(args ndn.Announcement) error {
+	expiration := args.Expiration
+	if expiration <= 0 {
+		expiration = time.Hour
+	}
+
+	pa, err := sec.MakePrefixAnn(sec.PrefixAnnArgs{
+		Signer:     args.Signer,
+		Prefix:     args.Name,
+		Expiration: expiration,
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.engine.ExecMgmtCmd("rib", "announce", pa); err != nil {
+		return err
+	}
+
+	hash := args.Name.TlvStr()
+	cancel := c.engine.Timer().Schedule(expiration*3/4, func() {
+		c.annRefresh.Delete(hash)
+		if ann, ok := c.announcements.Load(hash); ok && c.engine.Face().IsRunning() {
+			go c.announcePrefix_(ann.(ndn.Announcement))
+		}
+	})
+	if old, ok := c.annRefresh.Swap(hash, cancel); ok {
+		old.(func() error)()
+	}
+
+	return nil
+}
+
 // (AI GENERATED DESCRIPTION): Withdraws a previously announced prefix from the local NFD’s RIB (optionally marking it as client‑originated) by issuing an “rib unregister” command and logs the result.
 func (c *Client) withdrawPrefix_(args ndn.Announcement, onError func(error)) {
 	announceMutex.Lock()
@@ -69,7 +113,9 @@ func (c *Client) withdrawPrefix_(args ndn.Announcement, onError func(error)) {
 	announceMutex.Unlock()
 
 	origin := optional.None[uint64]()
-	if args.Expose {
+	if args.Signer != nil {
+		origin = optional.Some(uint64(mgmt_2022.RouteOriginPrefixAnn))
+	} else if args.Expose {
 		origin = optional.Some(uint64(mgmt_2022.RouteOriginClient))
 	}
 
@@ -77,7 +77,12 @@ func Produce(args ndn.ProduceArgs, store ndn.Store, signer ndn.Signer) (enc.Name
 			return nil, err
 		}
 
-		err = tx.Put(name, data.Wire.Join())
+		wire, err := attachAuditTag(args.AuditTagger, name, data.Wire.Join())
+		if err != nil {
+			return nil, err
+		}
+
+		err = tx.Put(name, wire)
 		if err != nil {
 			return nil, err
 		}
@@ -104,7 +109,12 @@ func Produce(args ndn.ProduceArgs, store ndn.Store, signer ndn.Signer) (enc.Name
 			return nil, err
 		}
 
-		err = tx.Put(name, data.Wire.Join())
+		wire, err := attachAuditTag(args.AuditTagger, name, data.Wire.Join())
+		if err != nil {
+			return nil, err
+		}
+
+		err = tx.Put(name, wire)
 		if err != nil {
 			return nil, err
 		}
@@ -113,6 +123,14 @@ func Produce(args ndn.ProduceArgs, store ndn.Store, signer ndn.Signer) (enc.Name
 	return args.Name, nil
 }
 
+// attachAuditTag appends a producer audit tag to the Data if a tagger is set
+func attachAuditTag(tagger ndn.AuditTagger, name enc.Name, wire []byte) ([]byte, error) {
+	if tagger == nil {
+		return wire, nil
+	}
+	return tagger.Attach(name, wire)
+}
+
 // Produce and sign data, and insert into the client's store.
 // The input data will be freed as the object is segmented.
 func (c *Client) Produce(args ndn.ProduceArgs) (enc.Name, error) {
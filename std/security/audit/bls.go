@@ -0,0 +1,260 @@
+package audit
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+
+	bls "github.com/kilic/bls12-381"
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/ndn"
+)
+
+// BlsTagSize is the size of a compressed BLS12-381 G1 audit tag.
+const BlsTagSize = 48
+
+// BlsPublicKeySize is the size of a compressed BLS12-381 G2 public key.
+const BlsPublicKeySize = 96
+
+// BlsSecretKeySize is the size of an encoded BLS secret scalar.
+const BlsSecretKeySize = 32
+
+// blsTagDst is the hash-to-curve domain separation tag for audit tags.
+var blsTagDst = []byte("NDND-CS-AUDIT-V1_BLS12381G1_XMD:SHA-256_SSWU_RO_")
+
+// tagDigestDomain separates audit tag digests from other SHA-256 uses.
+var tagDigestDomain = []byte("ndnd-cs-audit-digest-v1")
+
+// proofDigestDomain separates challenge proof digests from tag digests.
+var proofDigestDomain = []byte("ndnd-cs-audit-proof-v1")
+
+// challengeCoeffDomain separates challenge coefficients from other digests.
+var challengeCoeffDomain = []byte("ndnd-cs-audit-coeff-v1")
+
+// BlsSecretKey is a BLS12-381 secret key used to create audit tags.
+type BlsSecretKey struct {
+	s *bls.Fr
+}
+
+// BlsPublicKey is a BLS12-381 public key (G2) used to verify audit tags.
+type BlsPublicKey struct {
+	p *bls.PointG2
+}
+
+// TagDigest computes the message digest that an audit tag is bound to.
+// The digest covers the Data name and the wire encoding with length prefixes,
+// so an auditor only needs the digests (not the full content) to verify.
+func TagDigest(name enc.Name, wire []byte) [32]byte {
+	h := sha256.New()
+	h.Write(tagDigestDomain)
+
+	var u32 [4]byte
+	nameBytes := name.Bytes()
+	binary.BigEndian.PutUint32(u32[:], uint32(len(nameBytes)))
+	h.Write(u32[:])
+	h.Write(nameBytes)
+
+	binary.BigEndian.PutUint32(u32[:], uint32(len(wire)))
+	h.Write(u32[:])
+	h.Write(wire)
+
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
+
+// ProofDigest computes the digest that a node signs when answering an audit
+// challenge. The input is the encoded proof without its signature field.
+// A separate domain ensures a proof signature can never be confused with a tag.
+func ProofDigest(proof []byte) [32]byte {
+	h := sha256.New()
+	h.Write(proofDigestDomain)
+	h.Write(proof)
+
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
+
+// ChallengeCoefficient derives the coefficient of a cached Data packet in the
+// answer to an audit challenge. The coefficient depends on the challenge nonce
+// and the tag digest of the packet, so a node must hash the cached wire again
+// to answer each challenge, and cannot reuse the answer to an earlier one.
+func ChallengeCoefficient(nonce []byte, digest [32]byte) [32]byte {
+	h := sha256.New()
+	h.Write(challengeCoeffDomain)
+
+	var u32 [4]byte
+	binary.BigEndian.PutUint32(u32[:], uint32(len(nonce)))
+	h.Write(u32[:])
+	h.Write(nonce)
+	h.Write(digest[:])
+
+	var out [32]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
+
+// BlsKeygen generates a new random BLS secret key.
+func BlsKeygen() (*BlsSecretKey, error) {
+	s, err := bls.NewFr().Rand(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	if s.IsZero() {
+		return nil, ndn.ErrInvalidValue{Item: "bls secret key", Value: "zero"}
+	}
+	return &BlsSecretKey{s: s}, nil
+}
+
+// ParseBlsSecretKey parses a 32-byte big-endian secret scalar.
+// The value is reduced modulo the group order.
+func ParseBlsSecretKey(b []byte) (*BlsSecretKey, error) {
+	if len(b) != BlsSecretKeySize {
+		return nil, ndn.ErrInvalidValue{Item: "bls secret key length", Value: len(b)}
+	}
+	s := blsScalar(b)
+	if s.IsZero() {
+		return nil, ndn.ErrInvalidValue{Item: "bls secret key", Value: "zero"}
+	}
+	return &BlsSecretKey{s: s}, nil
+}
+
+// blsScalar reduces a big-endian integer modulo the group order.
+func blsScalar(b []byte) *bls.Fr {
+	v := new(big.Int).SetBytes(b)
+	v.Mod(v, bls.NewG1().Q())
+	return bls.NewFr().FromBytes(v.FillBytes(make([]byte, BlsSecretKeySize)))
+}
+
+// Bytes returns the 32-byte big-endian encoding of the secret scalar.
+func (sk *BlsSecretKey) Bytes() []byte {
+	return sk.s.ToBytes()
+}
+
+// Public returns the public key corresponding to the secret key.
+func (sk *BlsSecretKey) Public() *BlsPublicKey {
+	g2 := bls.NewG2()
+	p := g2.New()
+	g2.MulScalar(p, g2.One(), sk.s)
+	return &BlsPublicKey{p: p}
+}
+
+// Sign creates an audit tag over a tag digest.
+func (sk *BlsSecretKey) Sign(digest [32]byte) []byte {
+	g1 := bls.NewG1()
+	h, err := g1.HashToCurve(digest[:], blsTagDst)
+	if err != nil {
+		// Hash-to-curve only fails for oversized domain tags
+		panic(err)
+	}
+	g1.MulScalar(h, h, sk.s)
+	return g1.ToCompressed(h)
+}
+
+// ParseBlsPublicKey parses a 96-byte compressed G2 public key.
+func ParseBlsPublicKey(b []byte) (*BlsPublicKey, error) {
+	p, err := bls.NewG2().FromCompressed(b)
+	if err != nil {
+		return nil, ndn.ErrInvalidValue{Item: "bls public key", Value: err}
+	}
+	return &BlsPublicKey{p: p}, nil
+}
+
+// Bytes returns the 96-byte compressed encoding of the public key.
+func (pk *BlsPublicKey) Bytes() []byte {
+	return bls.NewG2().ToCompressed(pk.p)
+}
+
+// BlsAggregate adds a list of audit tags together.
+// The aggregate of an empty list is the identity element.
+func BlsAggregate(tags ...[]byte) ([]byte, error) {
+	g1 := bls.NewG1()
+	acc := g1.Zero()
+	for _, tag := range tags {
+		p, err := g1.FromCompressed(tag)
+		if err != nil {
+			return nil, ndn.ErrInvalidValue{Item: "bls tag", Value: err}
+		}
+		g1.Add(acc, acc, p)
+	}
+	return g1.ToCompressed(acc), nil
+}
+
+// BlsScale multiplies an audit tag by a coefficient (see ChallengeCoefficient),
+// which is interpreted as a big-endian scalar modulo the group order.
+func BlsScale(tag []byte, coeff [32]byte) ([]byte, error) {
+	g1 := bls.NewG1()
+	p, err := g1.FromCompressed(tag)
+	if err != nil {
+		return nil, ndn.ErrInvalidValue{Item: "bls tag", Value: err}
+	}
+	g1.MulScalar(p, p, blsScalar(coeff[:]))
+	return g1.ToCompressed(p), nil
+}
+
+// BlsVerify checks a single audit tag against a public key and digest.
+func BlsVerify(pk *BlsPublicKey, digest [32]byte, tag []byte) bool {
+	return BlsVerifyAggregate([]*BlsPublicKey{pk}, [][32]byte{digest}, tag)
+}
+
+// BlsVerifyAggregate checks an aggregated audit tag against the digests of the
+// aggregated Data packets and the public keys of the producers that tagged them.
+// pks[i] must be the public key that signed digests[i].
+func BlsVerifyAggregate(pks []*BlsPublicKey, digests [][32]byte, agg []byte) bool {
+	return BlsVerifyWeighted(pks, digests, nil, agg)
+}
+
+// BlsVerifyWeighted checks an aggregate of audit tags that were each multiplied
+// by a coefficient with BlsScale, i.e. agg = sum(coeffs[i] * tag_i).
+// If coeffs is nil, all coefficients are one (see BlsVerifyAggregate).
+func BlsVerifyWeighted(pks []*BlsPublicKey, digests [][32]byte, coeffs [][32]byte, agg []byte) bool {
+	if len(pks) != len(digests) || (coeffs != nil && len(coeffs) != len(digests)) {
+		return false
+	}
+
+	engine := bls.NewEngine()
+	sig, err := engine.G1.FromCompressed(agg)
+	if err != nil {
+		return false
+	}
+
+	// An empty aggregate is only valid for an empty set
+	if len(digests) == 0 {
+		return engine.G1.IsZero(sig)
+	}
+
+	// e(agg, g2) == prod e(c_i * H(m_i), pk_i)
+	engine.AddPairInv(sig, engine.G2.One())
+	for i, digest := range digests {
+		if pks[i] == nil {
+			return false
+		}
+		h, err := engine.G1.HashToCurve(digest[:], blsTagDst)
+		if err != nil {
+			return false
+		}
+		if coeffs != nil {
+			engine.G1.MulScalar(h, h, blsScalar(coeffs[i][:]))
+		}
+		engine.AddPair(h, pks[i].p)
+	}
+	return engine.Check()
+}
+
+// BlsSubtract removes a tag from an aggregate, i.e. returns agg - tag.
+// Together with BlsAggregate this allows incremental aggregate maintenance.
+func BlsSubtract(agg []byte, tag []byte) ([]byte, error) {
+	g1 := bls.NewG1()
+	a, err := g1.FromCompressed(agg)
+	if err != nil {
+		return nil, ndn.ErrInvalidValue{Item: "bls aggregate", Value: err}
+	}
+	p, err := g1.FromCompressed(tag)
+	if err != nil {
+		return nil, ndn.ErrInvalidValue{Item: "bls tag", Value: err}
+	}
+	g1.Sub(a, a, p)
+	return g1.ToCompressed(a), nil
+}
@@ -0,0 +1,131 @@
+package audit_test
+
+import (
+	"testing"
+
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/security/audit"
+	tu "github.com/named-data/ndnd/std/utils/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlsSignVerify(t *testing.T) {
+	tu.SetT(t)
+
+	sk := tu.NoErr(audit.BlsKeygen())
+	pk := sk.Public()
+
+	name := tu.NoErr(enc.NameFromStr("/ndn/test/v=1/seg=0"))
+	digest := audit.TagDigest(name, []byte("wire"))
+	tag := sk.Sign(digest)
+	require.Len(t, tag, audit.BlsTagSize)
+	require.True(t, audit.BlsVerify(pk, digest, tag))
+
+	// Corrupted wire must not verify
+	bad := audit.TagDigest(name, []byte("wirf"))
+	require.False(t, audit.BlsVerify(pk, bad, tag))
+
+	// Different key must not verify
+	sk2 := tu.NoErr(audit.BlsKeygen())
+	require.False(t, audit.BlsVerify(sk2.Public(), digest, tag))
+}
+
+func TestBlsKeyEncoding(t *testing.T) {
+	tu.SetT(t)
+
+	sk := tu.NoErr(audit.BlsKeygen())
+	sk2 := tu.NoErr(audit.ParseBlsSecretKey(sk.Bytes()))
+	require.Equal(t, sk.Bytes(), sk2.Bytes())
+
+	pk := tu.NoErr(audit.ParseBlsPublicKey(sk.Public().Bytes()))
+	require.Len(t, pk.Bytes(), audit.BlsPublicKeySize)
+	require.Equal(t, sk2.Public().Bytes(), pk.Bytes())
+
+	_, err := audit.ParseBlsSecretKey(make([]byte, audit.BlsSecretKeySize))
+	require.Error(t, err)
+	_, err = audit.ParseBlsSecretKey([]byte{1, 2, 3})
+	require.Error(t, err)
+}
+
+func TestBlsAggregate(t *testing.T) {
+	tu.SetT(t)
+
+	skA := tu.NoErr(audit.BlsKeygen())
+	skB := tu.NoErr(audit.BlsKeygen())
+
+	var pks []*audit.BlsPublicKey
+	var digests [][32]byte
+	var tags [][]byte
+	for i, sk := range []*audit.BlsSecretKey{skA, skB, skA} {
+		name := tu.NoErr(enc.NameFromStr("/prefix/obj")).Append(enc.NewSegmentComponent(uint64(i)))
+		d := audit.TagDigest(name, []byte{byte(i), 0xaa})
+		pks = append(pks, sk.Public())
+		digests = append(digests, d)
+		tags = append(tags, sk.Sign(d))
+	}
+
+	agg := tu.NoErr(audit.BlsAggregate(tags...))
+	require.True(t, audit.BlsVerifyAggregate(pks, digests, agg))
+
+	// Aggregation is order independent
+	agg2 := tu.NoErr(audit.BlsAggregate(tags[2], tags[0], tags[1]))
+	require.Equal(t, agg, agg2)
+
+	// Subtracting a member undoes its contribution
+	sub := tu.NoErr(audit.BlsSubtract(agg, tags[2]))
+	require.Equal(t, tu.NoErr(audit.BlsAggregate(tags[0], tags[1])), sub)
+	require.True(t, audit.BlsVerifyAggregate(pks[:2], digests[:2], sub))
+
+	// Missing a member must fail
+	require.False(t, audit.BlsVerifyAggregate(pks[:2], digests[:2], agg))
+
+	// Empty aggregate is the identity
+	empty := tu.NoErr(audit.BlsAggregate())
+	require.True(t, audit.BlsVerifyAggregate(nil, nil, empty))
+	require.False(t, audit.BlsVerifyAggregate(nil, nil, agg))
+
+	// Invalid tag encoding is rejected
+	_, err := audit.BlsAggregate([]byte{1, 2, 3})
+	require.Error(t, err)
+}
+
+func TestBlsWeightedAggregate(t *testing.T) {
+	tu.SetT(t)
+
+	sk := tu.NoErr(audit.BlsKeygen())
+	nonce := []byte("challenge-nonce")
+
+	var pks []*audit.BlsPublicKey
+	var digests, coeffs [][32]byte
+	var scaled [][]byte
+	for i := range 3 {
+		name := tu.NoErr(enc.NameFromStr("/prefix/obj")).Append(enc.NewSegmentComponent(uint64(i)))
+		d := audit.TagDigest(name, []byte{byte(i), 0xaa})
+		c := audit.ChallengeCoefficient(nonce, d)
+		pks = append(pks, sk.Public())
+		digests = append(digests, d)
+		coeffs = append(coeffs, c)
+		scaled = append(scaled, tu.NoErr(audit.BlsScale(sk.Sign(d), c)))
+	}
+
+	agg := tu.NoErr(audit.BlsAggregate(scaled...))
+	require.True(t, audit.BlsVerifyWeighted(pks, digests, coeffs, agg))
+
+	// Coefficients depend on the nonce and the digest
+	require.NotEqual(t, coeffs[0], coeffs[1])
+	require.NotEqual(t, coeffs[0], audit.ChallengeCoefficient([]byte("other-nonce"), digests[0]))
+
+	// The unweighted aggregate does not answer the challenge
+	plain := make([][]byte, 0, len(digests))
+	for _, d := range digests {
+		plain = append(plain, sk.Sign(d))
+	}
+	require.False(t, audit.BlsVerifyWeighted(pks, digests, coeffs, tu.NoErr(audit.BlsAggregate(plain...))))
+
+	// Coefficients of another nonce do not verify
+	other := make([][32]byte, len(digests))
+	for i, d := range digests {
+		other[i] = audit.ChallengeCoefficient([]byte("other-nonce"), d)
+	}
+	require.False(t, audit.BlsVerifyWeighted(pks, digests, other, agg))
+}
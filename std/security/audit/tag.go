@@ -0,0 +1,135 @@
+package audit
+
+import (
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/ndn"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+)
+
+// Audit tag types carried in the AuditTag element of a Data packet.
+const (
+	// TagTypeHmacSha256 is a symmetric HMAC-SHA256 tag (shared key with the auditor).
+	TagTypeHmacSha256 uint64 = 1
+	// TagTypeBls12381 is a BLS12-381 G1 signature over TagDigest.
+	TagTypeBls12381 uint64 = 2
+)
+
+// TypeAuditTag is the TLV type of the AuditTag element in a Data packet.
+const TypeAuditTag enc.TLNum = 0x25a
+
+// typeData is the TLV type of a Data packet.
+const typeData enc.TLNum = 0x06
+
+// Tagger attaches producer audit tags to encoded Data packets.
+type Tagger struct {
+	sk *BlsSecretKey
+}
+
+// NewTagger creates a Tagger that signs with the given BLS secret key.
+// The producer public key is not embedded in the tag: the AuditTag is
+// outside the signed portion of the Data, so caches and auditors must
+// obtain the key from a trusted source, e.g. by the KeyLocator of the Data.
+func NewTagger(sk *BlsSecretKey) *Tagger {
+	return &Tagger{sk: sk}
+}
+
+// Attach computes the audit tag of an encoded Data packet and returns
+// a new encoding of the packet with the AuditTag element appended.
+// The input must not already carry an audit tag.
+func (t *Tagger) Attach(name enc.Name, wire []byte) ([]byte, error) {
+	tag := &spec.AuditTag{
+		TagType:  TagTypeBls12381,
+		TagValue: t.sk.Sign(TagDigest(name, wire)),
+	}
+	return AttachTag(wire, tag)
+}
+
+// AttachTag appends an AuditTag element to an encoded Data packet.
+func AttachTag(wire []byte, tag *spec.AuditTag) ([]byte, error) {
+	typ, inner, err := readOuterTlv(wire)
+	if err != nil {
+		return nil, err
+	}
+	if typ != typeData {
+		return nil, ndn.ErrInvalidValue{Item: "audit tag packet type", Value: typ}
+	}
+
+	tagVal := tag.Bytes()
+	innerLen := len(inner) + TypeAuditTag.EncodingLength() +
+		enc.TLNum(len(tagVal)).EncodingLength() + len(tagVal)
+
+	out := make([]byte, typ.EncodingLength()+enc.TLNum(innerLen).EncodingLength()+innerLen)
+	pos := typ.EncodeInto(out)
+	pos += enc.TLNum(innerLen).EncodeInto(out[pos:])
+	pos += copy(out[pos:], inner)
+	pos += TypeAuditTag.EncodeInto(out[pos:])
+	pos += enc.TLNum(len(tagVal)).EncodeInto(out[pos:])
+	copy(out[pos:], tagVal)
+	return out, nil
+}
+
+// SplitTag separates a producer audit tag from an encoded Data packet.
+// It returns the encoding of the packet without the AuditTag element,
+// i.e. the bytes covered by the tag, and the parsed tag.
+// If the packet has no audit tag, the input wire and a nil tag are returned.
+func SplitTag(wire []byte) ([]byte, *spec.AuditTag, error) {
+	typ, inner, err := readOuterTlv(wire)
+	if err != nil {
+		return nil, nil, err
+	}
+	if typ != typeData {
+		return nil, nil, ndn.ErrInvalidValue{Item: "audit tag packet type", Value: typ}
+	}
+
+	// The AuditTag is always the last element of the Data
+	var lastStart, lastValStart int
+	var lastType enc.TLNum
+	reader := enc.NewBufferView(inner)
+	for !reader.IsEOF() {
+		start := reader.Pos()
+		t, err := reader.ReadTLNum()
+		if err != nil {
+			return nil, nil, err
+		}
+		l, err := reader.ReadTLNum()
+		if err != nil {
+			return nil, nil, err
+		}
+		valStart := reader.Pos()
+		if err = reader.Skip(int(l)); err != nil {
+			return nil, nil, err
+		}
+		lastStart, lastValStart, lastType = start, valStart, t
+	}
+	if lastType != TypeAuditTag {
+		return wire, nil, nil
+	}
+
+	tag, err := spec.ParseAuditTag(enc.NewBufferView(inner[lastValStart:]), true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	covered := inner[:lastStart]
+	out := make([]byte, typ.EncodingLength()+enc.TLNum(len(covered)).EncodingLength()+len(covered))
+	pos := typ.EncodeInto(out)
+	pos += enc.TLNum(len(covered)).EncodeInto(out[pos:])
+	copy(out[pos:], covered)
+	return out, tag, nil
+}
+
+// readOuterTlv parses the outermost TLV header of a packet.
+func readOuterTlv(wire []byte) (typ enc.TLNum, inner []byte, err error) {
+	reader := enc.NewBufferView(wire)
+	if typ, err = reader.ReadTLNum(); err != nil {
+		return 0, nil, err
+	}
+	l, err := reader.ReadTLNum()
+	if err != nil {
+		return 0, nil, err
+	}
+	if reader.Pos()+int(l) != len(wire) {
+		return 0, nil, enc.ErrBufferOverflow
+	}
+	return typ, wire[reader.Pos():], nil
+}
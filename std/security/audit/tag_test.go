@@ -0,0 +1,61 @@
+package audit_test
+
+import (
+	"testing"
+
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/ndn"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	"github.com/named-data/ndnd/std/security/audit"
+	sig "github.com/named-data/ndnd/std/security/signer"
+	tu "github.com/named-data/ndnd/std/utils/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaggerAttachSplit(t *testing.T) {
+	tu.SetT(t)
+
+	sk := tu.NoErr(audit.BlsKeygen())
+	tagger := audit.NewTagger(sk)
+
+	name := tu.NoErr(enc.NameFromStr("/producer/obj/v=1/seg=0"))
+	data := tu.NoErr(spec.Spec{}.MakeData(name, &ndn.DataConfig{},
+		enc.Wire{[]byte("hello world")}, sig.NewSha256Signer()))
+	wire := data.Wire.Join()
+
+	tagged := tu.NoErr(tagger.Attach(name, wire))
+	require.Greater(t, len(tagged), len(wire))
+
+	// The tagged Data is still a valid Data with an intact signature
+	pkt, sigCov, err := spec.Spec{}.ReadData(enc.NewBufferView(tagged))
+	require.NoError(t, err)
+	require.Equal(t, name, pkt.Name())
+	require.Equal(t, data.SigCovered.Join(), sigCov.Join())
+	require.NotNil(t, pkt.(*spec.Data).AuditTagV)
+
+	// Splitting recovers the original encoding and the tag
+	covered, tag, err := audit.SplitTag(tagged)
+	require.NoError(t, err)
+	require.Equal(t, wire, covered)
+	require.Equal(t, audit.TagTypeBls12381, tag.TagType)
+	require.Nil(t, tag.PublicKey)
+
+	pk := sk.Public()
+	require.True(t, audit.BlsVerify(pk, audit.TagDigest(name, covered), tag.TagValue))
+
+	// Corrupted Data must not verify
+	tagged[len(tagged)-len(tag.Bytes())-8] ^= 0x01
+	covered, tag, err = audit.SplitTag(tagged)
+	require.NoError(t, err)
+	require.False(t, audit.BlsVerify(pk, audit.TagDigest(name, covered), tag.TagValue))
+
+	// Untagged Data is returned as-is
+	covered, tag, err = audit.SplitTag(wire)
+	require.NoError(t, err)
+	require.Nil(t, tag)
+	require.Equal(t, wire, covered)
+
+	// Truncated wire is rejected
+	_, _, err = audit.SplitTag(tagged[:len(tagged)-1])
+	require.Error(t, err)
+}
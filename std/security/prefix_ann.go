@@ -0,0 +1,132 @@
+package security
+
+import (
+	"time"
+
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/ndn"
+	spec "github.com/named-data/ndnd/std/ndn/spec_2022"
+	"github.com/named-data/ndnd/std/types/optional"
+)
+
+// PrefixAnnKeyword is the keyword component of a prefix announcement name.
+var PrefixAnnKeyword = enc.NewKeywordComponent("PA")
+
+// PrefixAnnArgs are the arguments to MakePrefixAnn.
+type PrefixAnnArgs struct {
+	// Signer is the key used to sign the announcement.
+	Signer ndn.Signer
+	// Prefix is the announced name prefix.
+	Prefix enc.Name
+	// Expiration is the lifetime of the route created by the announcement.
+	Expiration time.Duration
+	// NotBefore is the optional start of the announcement validity period.
+	NotBefore time.Time
+	// NotAfter is the optional end of the announcement validity period.
+	NotAfter time.Time
+}
+
+// PrefixAnn is a parsed prefix announcement.
+type PrefixAnn struct {
+	// Prefix is the announced name prefix.
+	Prefix enc.Name
+	// Expiration is the lifetime of the route created by the announcement.
+	Expiration time.Duration
+	// NotBefore is the start of the validity period, if any.
+	NotBefore optional.Optional[time.Time]
+	// NotAfter is the end of the validity period, if any.
+	NotAfter optional.Optional[time.Time]
+}
+
+// MakePrefixAnn creates a signed prefix announcement object.
+// The object is named /<prefix>/32=PA/<version>/<segment=0>.
+func MakePrefixAnn(args PrefixAnnArgs) (enc.Wire, error) {
+	if args.Signer == nil || len(args.Prefix) == 0 {
+		return nil, ndn.ErrInvalidValue{Item: "PrefixAnnArgs", Value: args}
+	}
+	if args.Expiration <= 0 {
+		return nil, ndn.ErrInvalidValue{Item: "Expiration", Value: args.Expiration}
+	}
+
+	content := &spec.PrefixAnnouncementContent{
+		ExpirationPeriod: args.Expiration.Truncate(time.Millisecond),
+	}
+	if !args.NotBefore.IsZero() || !args.NotAfter.IsZero() {
+		if args.NotBefore.IsZero() || args.NotAfter.IsZero() || args.NotAfter.Before(args.NotBefore) {
+			return nil, ndn.ErrInvalidValue{Item: "Validity", Value: args}
+		}
+		content.ValidityPeriod = &spec.ValidityPeriod{
+			NotBefore: args.NotBefore.UTC().Format(spec.TimeFmt),
+			NotAfter:  args.NotAfter.UTC().Format(spec.TimeFmt),
+		}
+	}
+
+	name := args.Prefix.
+		Append(PrefixAnnKeyword).
+		WithVersion(enc.VersionUnixMicro).
+		Append(enc.NewSegmentComponent(0))
+
+	cfg := &ndn.DataConfig{
+		ContentType:  optional.Some(ndn.ContentTypePrefixAnnouncement),
+		Freshness:    optional.Some(time.Second),
+		FinalBlockID: optional.Some(enc.NewSegmentComponent(0)),
+	}
+
+	data, err := spec.Spec{}.MakeData(name, cfg, content.Encode(), args.Signer)
+	if err != nil {
+		return nil, err
+	}
+	return data.Wire, nil
+}
+
+// ParsePrefixAnn parses a prefix announcement object.
+// The signature of the object is not checked.
+func ParsePrefixAnn(data ndn.Data) (*PrefixAnn, error) {
+	if contentType, ok := data.ContentType().Get(); !ok || contentType != ndn.ContentTypePrefixAnnouncement {
+		return nil, ndn.ErrInvalidValue{Item: "Data.ContentType", Value: data.ContentType()}
+	}
+
+	// Name must be /<prefix>/32=PA/<version>/<segment>
+	name := data.Name()
+	if len(name) < 3 || !name.At(-3).Equal(PrefixAnnKeyword) ||
+		!name.At(-2).IsVersion() || !name.At(-1).IsSegment() {
+		return nil, ndn.ErrInvalidValue{Item: "Data.Name", Value: name}
+	}
+
+	content, err := spec.ParsePrefixAnnouncementContent(enc.NewWireView(data.Content()), true)
+	if err != nil {
+		return nil, err
+	}
+
+	ann := &PrefixAnn{
+		Prefix:     name[:len(name)-3],
+		Expiration: content.ExpirationPeriod,
+	}
+	if vp := content.ValidityPeriod; vp != nil {
+		notBefore, err := time.Parse(spec.TimeFmt, vp.NotBefore)
+		if err != nil {
+			return nil, err
+		}
+		notAfter, err := time.Parse(spec.TimeFmt, vp.NotAfter)
+		if err != nil {
+			return nil, err
+		}
+		ann.NotBefore = optional.Some(notBefore)
+		ann.NotAfter = optional.Some(notAfter)
+	}
+
+	return ann, nil
+}
+
+// Lifetime returns how long a route created from the announcement at the
+// given time should live, or zero if the announcement is not valid at that time.
+func (a *PrefixAnn) Lifetime(now time.Time) time.Duration {
+	lifetime := a.Expiration
+	if notBefore, ok := a.NotBefore.Get(); ok && now.Before(notBefore) {
+		return 0
+	}
+	if notAfter, ok := a.NotAfter.Get(); ok {
+		lifetime = min(lifetime, notAfter.Sub(now))
+	}
+	return max(lifetime, 0)
+}
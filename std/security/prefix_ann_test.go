@@ -0,0 +1,100 @@
+package security_test
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/ndn"
+	"github.com/named-data/ndnd/std/ndn/spec_2022"
+	sec "github.com/named-data/ndnd/std/security"
+	"github.com/named-data/ndnd/std/security/signer"
+	tu "github.com/named-data/ndnd/std/utils/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+// Tests that a signed prefix announcement round-trips through MakePrefixAnn and ParsePrefixAnn.
+func TestPrefixAnn(t *testing.T) {
+	tu.SetT(t)
+
+	aliceKey, _ := base64.StdEncoding.DecodeString(KEY_ALICE)
+	aliceKeyData, _, _ := spec_2022.Spec{}.ReadData(enc.NewBufferView(aliceKey))
+	aliceSigner := tu.NoErr(signer.UnmarshalSecret(aliceKeyData))
+	prefix := tu.NoErr(enc.NameFromStr("/ndn/alice/app"))
+
+	wire, err := sec.MakePrefixAnn(sec.PrefixAnnArgs{
+		Signer:     aliceSigner,
+		Prefix:     prefix,
+		Expiration: time.Hour,
+		NotBefore:  T1,
+		NotAfter:   T2,
+	})
+	require.NoError(t, err)
+	data, sigCov, err := spec_2022.Spec{}.ReadData(enc.NewWireView(wire))
+	require.NoError(t, err)
+
+	// check name and signature
+	name := data.Name()
+	require.Equal(t, len(prefix)+3, len(name))
+	require.True(t, prefix.IsPrefix(name))
+	require.Equal(t, sec.PrefixAnnKeyword, name.At(-3))
+	require.True(t, name.At(-2).IsVersion())
+	require.Equal(t, uint64(0), name.At(-1).NumberVal())
+	require.Equal(t, ndn.ContentTypePrefixAnnouncement, data.ContentType().Unwrap())
+	require.Equal(t, aliceSigner.KeyName(), data.Signature().KeyName())
+
+	aliceCert := tu.NoErr(sec.SelfSign(sec.SignCertArgs{
+		Signer:    aliceSigner,
+		NotBefore: T1,
+		NotAfter:  T2,
+	}))
+	aliceCertData, _, _ := spec_2022.Spec{}.ReadData(enc.NewWireView(aliceCert))
+	require.True(t, tu.NoErr(signer.ValidateData(data, sigCov, aliceCertData)))
+
+	ann, err := sec.ParsePrefixAnn(data)
+	require.NoError(t, err)
+	require.Equal(t, prefix, ann.Prefix)
+	require.Equal(t, time.Hour, ann.Expiration)
+	require.Equal(t, T1, ann.NotBefore.Unwrap())
+	require.Equal(t, T2, ann.NotAfter.Unwrap())
+
+	// lifetime is bounded by the validity period
+	require.Equal(t, time.Hour, ann.Lifetime(T1.Add(time.Minute)))
+	require.Equal(t, time.Minute, ann.Lifetime(T2.Add(-time.Minute)))
+	require.Equal(t, time.Duration(0), ann.Lifetime(T1.Add(-time.Second)))
+	require.Equal(t, time.Duration(0), ann.Lifetime(T2.Add(time.Second)))
+}
+
+// Tests that invalid prefix announcements are rejected.
+func TestPrefixAnnInvalid(t *testing.T) {
+	tu.SetT(t)
+
+	aliceSigner := tu.NoErr(signer.KeygenEd25519(KEY_ALICE_NAME))
+	prefix := tu.NoErr(enc.NameFromStr("/ndn/alice/app"))
+
+	// no expiration
+	_, err := sec.MakePrefixAnn(sec.PrefixAnnArgs{Signer: aliceSigner, Prefix: prefix})
+	require.Error(t, err)
+
+	// validity period ends before it starts
+	_, err = sec.MakePrefixAnn(sec.PrefixAnnArgs{
+		Signer:     aliceSigner,
+		Prefix:     prefix,
+		Expiration: time.Hour,
+		NotBefore:  T2,
+		NotAfter:   T1,
+	})
+	require.Error(t, err)
+
+	// not a prefix announcement
+	cert := tu.NoErr(sec.SelfSign(sec.SignCertArgs{
+		Signer:    aliceSigner,
+		NotBefore: T1,
+		NotAfter:  T2,
+	}))
+	data, _, err := spec_2022.Spec{}.ReadData(enc.NewWireView(cert))
+	require.NoError(t, err)
+	_, err = sec.ParsePrefixAnn(data)
+	require.Error(t, err)
+}
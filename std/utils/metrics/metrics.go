@@ -0,0 +1,124 @@
+// Package metrics exports daemon counters in the Prometheus text format.
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Type is the type of a metric family.
+type Type string
+
+const (
+	// Counter is a value that only increases, except on restart.
+	Counter Type = "counter"
+	// Gauge is a value that can go up and down.
+	Gauge Type = "gauge"
+)
+
+// Family is a metric with all its samples.
+type Family struct {
+	// Name of the metric, e.g. ndnd_fw_in_interests_total
+	Name string
+	// Help text of the metric
+	Help string
+	// Type of the metric
+	Type Type
+	// Samples of the metric, one for each set of labels
+	Samples []Sample
+}
+
+// Sample is a single value of a metric family.
+type Sample struct {
+	Labels []Label
+	Value  float64
+}
+
+// Label is a name-value pair that identifies a sample.
+type Label struct {
+	Name  string
+	Value string
+}
+
+// NewCounter creates a counter family.
+func NewCounter(name, help string) *Family {
+	return &Family{Name: name, Help: help, Type: Counter}
+}
+
+// NewGauge creates a gauge family.
+func NewGauge(name, help string) *Family {
+	return &Family{Name: name, Help: help, Type: Gauge}
+}
+
+// Add appends a sample to the family.
+// Labels are given as alternating names and values.
+func (f *Family) Add(value float64, labels ...string) *Family {
+	sample := Sample{Value: value}
+	for i := 0; i+1 < len(labels); i += 2 {
+		sample.Labels = append(sample.Labels, Label{Name: labels[i], Value: labels[i+1]})
+	}
+	f.Samples = append(f.Samples, sample)
+	return f
+}
+
+// WriteText writes metric families in the Prometheus text exposition format.
+// Families without samples are omitted.
+func WriteText(w io.Writer, families []*Family) error {
+	bw := bufio.NewWriter(w)
+	for _, f := range families {
+		if len(f.Samples) == 0 {
+			continue
+		}
+
+		bw.WriteString("# HELP ")
+		bw.WriteString(f.Name)
+		bw.WriteByte(' ')
+		bw.WriteString(helpEscaper.Replace(f.Help))
+		bw.WriteString("\n# TYPE ")
+		bw.WriteString(f.Name)
+		bw.WriteByte(' ')
+		bw.WriteString(string(f.Type))
+		bw.WriteByte('\n')
+
+		for _, s := range f.Samples {
+			bw.WriteString(f.Name)
+			if len(s.Labels) > 0 {
+				bw.WriteByte('{')
+				for i, l := range s.Labels {
+					if i > 0 {
+						bw.WriteByte(',')
+					}
+					bw.WriteString(l.Name)
+					bw.WriteString(`="`)
+					bw.WriteString(labelEscaper.Replace(l.Value))
+					bw.WriteByte('"')
+				}
+				bw.WriteByte('}')
+			}
+			bw.WriteByte(' ')
+			bw.WriteString(formatValue(s.Value))
+			bw.WriteByte('\n')
+		}
+	}
+	return bw.Flush()
+}
+
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+var labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+// formatValue formats a sample value, without exponents for integers.
+func formatValue(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+}
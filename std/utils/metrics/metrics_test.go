@@ -0,0 +1,57 @@
+package metrics_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/named-data/ndnd/std/utils/metrics"
+	tu "github.com/named-data/ndnd/std/utils/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteText(t *testing.T) {
+	tu.SetT(t)
+
+	families := []*metrics.Family{
+		metrics.NewCounter("test_packets_total", "Number of packets.\nPer face.").
+			Add(12345678, "face", "1").
+			Add(0, "face", "2", "uri", `udp4://"a"\b`),
+		metrics.NewGauge("test_empty", "Family without samples."),
+		metrics.NewGauge("test_ratio", "A ratio.").
+			Add(0.25),
+	}
+
+	sb := &strings.Builder{}
+	require.NoError(t, metrics.WriteText(sb, families))
+	require.Equal(t, `# HELP test_packets_total Number of packets.\nPer face.
+# TYPE test_packets_total counter
+test_packets_total{face="1"} 12345678
+test_packets_total{face="2",uri="udp4://\"a\"\\b"} 0
+# HELP test_ratio A ratio.
+# TYPE test_ratio gauge
+test_ratio 0.25
+`, sb.String())
+}
+
+func TestServer(t *testing.T) {
+	tu.SetT(t)
+
+	srv := metrics.NewServer("127.0.0.1:0", func() []*metrics.Family {
+		return []*metrics.Family{metrics.NewGauge("test_up", "Up.").Add(1)}
+	})
+	require.Equal(t, "", srv.URL())
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	res, err := http.Get(srv.URL())
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, metrics.ContentType, res.Header.Get("Content-Type"))
+	require.Equal(t, "# HELP test_up Up.\n# TYPE test_up gauge\ntest_up 1\n", string(body))
+}
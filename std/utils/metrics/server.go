@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"net"
+	"net/http"
+)
+
+// ContentType is the content type of the Prometheus text format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Config is the configuration of a metrics endpoint.
+type Config struct {
+	// Enabled starts the HTTP metrics endpoint.
+	Enabled bool `json:"enabled"`
+	// Address is the TCP address to listen on, e.g. 127.0.0.1:9101
+	Address string `json:"address"`
+}
+
+// Collector returns the current value of all metrics.
+// It is called from the HTTP server goroutine for each scrape.
+type Collector func() []*Family
+
+// Server serves metrics over HTTP at /metrics.
+type Server struct {
+	address string
+	collect Collector
+	srv     *http.Server
+	ln      net.Listener
+}
+
+// NewServer creates a metrics server on the given address.
+func NewServer(address string, collect Collector) *Server {
+	s := &Server{
+		address: address,
+		collect: collect,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", s.serveMetrics)
+	s.srv = &http.Server{Handler: mux}
+
+	return s
+}
+
+// Start listens on the address and serves metrics in the background.
+func (s *Server) Start() error {
+	ln, err := net.Listen("tcp", s.address)
+	if err != nil {
+		return err
+	}
+	s.ln = ln
+
+	go s.srv.Serve(ln)
+	return nil
+}
+
+// URL returns the URL of the metrics endpoint once started.
+func (s *Server) URL() string {
+	if s.ln == nil {
+		return ""
+	}
+	return "http://" + s.ln.Addr().String() + "/metrics"
+}
+
+// Stop closes the server.
+func (s *Server) Stop() {
+	s.srv.Close()
+}
+
+// serveMetrics handles a scrape request.
+func (s *Server) serveMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	WriteText(w, s.collect()) // errors only if the client is gone
+}
@@ -0,0 +1,147 @@
+// Package pcapng writes packet captures in the pcapng format.
+// See https://www.ietf.org/archive/id/draft-ietf-opsawg-pcapng-02.html
+package pcapng
+
+import (
+	"encoding/binary"
+	"io"
+	"time"
+)
+
+// Link types of an interface.
+const (
+	LinkTypeEthernet = uint16(1)
+)
+
+// EtherTypeNDN is the EtherType of NDN packets over Ethernet.
+const EtherTypeNDN = uint16(0x8624)
+
+// Direction of a captured packet.
+type Direction uint32
+
+const (
+	DirUnknown  Direction = 0
+	DirInbound  Direction = 1
+	DirOutbound Direction = 2
+)
+
+const (
+	blockSectionHeader  = uint32(0x0a0d0d0a)
+	blockInterface      = uint32(0x00000001)
+	blockEnhancedPacket = uint32(0x00000006)
+	byteOrderMagic      = uint32(0x1a2b3c4d)
+	optEndOfOpt         = uint16(0)
+	optShbUserAppl      = uint16(4)
+	optIfName           = uint16(2)
+	optIfDescription    = uint16(3)
+	optEpbFlags         = uint16(2)
+	blockHeaderLen      = 8 // type and total length
+	blockTrailerLen     = 4 // total length
+)
+
+// Writer writes a single section of a pcapng file.
+// Writer is not safe for concurrent use.
+type Writer struct {
+	w      io.Writer
+	buf    []byte
+	nIface uint32
+}
+
+// NewWriter writes the section header to w and returns a writer for the section.
+func NewWriter(w io.Writer, application string) (*Writer, error) {
+	pw := &Writer{w: w}
+
+	pw.begin(blockSectionHeader)
+	pw.buf = binary.LittleEndian.AppendUint32(pw.buf, byteOrderMagic)
+	pw.buf = binary.LittleEndian.AppendUint16(pw.buf, 1)          // major version
+	pw.buf = binary.LittleEndian.AppendUint16(pw.buf, 0)          // minor version
+	pw.buf = binary.LittleEndian.AppendUint64(pw.buf, ^uint64(0)) // unknown section length
+	pw.appendOption(optShbUserAppl, []byte(application))
+	pw.appendOption(optEndOfOpt, nil)
+
+	if err := pw.end(); err != nil {
+		return nil, err
+	}
+	return pw, nil
+}
+
+// AddInterface describes a new interface and returns its ID.
+// Timestamps of packets on the interface have microsecond resolution.
+func (pw *Writer) AddInterface(linkType uint16, name string, description string) (uint32, error) {
+	pw.begin(blockInterface)
+	pw.buf = binary.LittleEndian.AppendUint16(pw.buf, linkType)
+	pw.buf = binary.LittleEndian.AppendUint16(pw.buf, 0) // reserved
+	pw.buf = binary.LittleEndian.AppendUint32(pw.buf, 0) // no snap length
+	if name != "" {
+		pw.appendOption(optIfName, []byte(name))
+	}
+	if description != "" {
+		pw.appendOption(optIfDescription, []byte(description))
+	}
+	pw.appendOption(optEndOfOpt, nil)
+
+	if err := pw.end(); err != nil {
+		return 0, err
+	}
+	pw.nIface++
+	return pw.nIface - 1, nil
+}
+
+// WritePacket writes a packet captured on an interface.
+// The packet is the concatenation of data, which avoids copying headers.
+func (pw *Writer) WritePacket(iface uint32, ts time.Time, dir Direction, data ...[]byte) error {
+	length := 0
+	for _, d := range data {
+		length += len(d)
+	}
+	micros := uint64(ts.UnixMicro())
+
+	pw.begin(blockEnhancedPacket)
+	pw.buf = binary.LittleEndian.AppendUint32(pw.buf, iface)
+	pw.buf = binary.LittleEndian.AppendUint32(pw.buf, uint32(micros>>32))
+	pw.buf = binary.LittleEndian.AppendUint32(pw.buf, uint32(micros))
+	pw.buf = binary.LittleEndian.AppendUint32(pw.buf, uint32(length)) // captured length
+	pw.buf = binary.LittleEndian.AppendUint32(pw.buf, uint32(length)) // original length
+	for _, d := range data {
+		pw.buf = append(pw.buf, d...)
+	}
+	pw.pad()
+	if dir != DirUnknown {
+		pw.appendOption(optEpbFlags, binary.LittleEndian.AppendUint32(nil, uint32(dir)))
+		pw.appendOption(optEndOfOpt, nil)
+	}
+
+	return pw.end()
+}
+
+// begin starts a block in the buffer.
+func (pw *Writer) begin(blockType uint32) {
+	pw.buf = pw.buf[:0]
+	pw.buf = binary.LittleEndian.AppendUint32(pw.buf, blockType)
+	pw.buf = binary.LittleEndian.AppendUint32(pw.buf, 0) // total length, set in end
+}
+
+// end completes the block in the buffer and writes it.
+func (pw *Writer) end() error {
+	total := uint32(len(pw.buf) + blockTrailerLen)
+	binary.LittleEndian.PutUint32(pw.buf[4:blockHeaderLen], total)
+	pw.buf = binary.LittleEndian.AppendUint32(pw.buf, total)
+
+	_, err := pw.w.Write(pw.buf)
+	return err
+}
+
+// appendOption appends an option to the block in the buffer.
+func (pw *Writer) appendOption(code uint16, value []byte) {
+	pw.buf = binary.LittleEndian.AppendUint16(pw.buf, code)
+	pw.buf = binary.LittleEndian.AppendUint16(pw.buf, uint16(len(value)))
+	pw.buf = append(pw.buf, value...)
+	pw.pad()
+}
+
+// pad aligns the block in the buffer to 32 bits.
+func (pw *Writer) pad() {
+	for len(pw.buf)%4 != 0 {
+		pw.buf = append(pw.buf, 0)
+	}
+}
@@ -0,0 +1,68 @@
+package pcapng_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/named-data/ndnd/std/utils/pcapng"
+	tu "github.com/named-data/ndnd/std/utils/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter(t *testing.T) {
+	tu.SetT(t)
+
+	buf := &bytes.Buffer{}
+	pw := tu.NoErr(pcapng.NewWriter(buf, "test"))
+	require.Equal(t, uint32(0), tu.NoErr(pw.AddInterface(pcapng.LinkTypeEthernet, "face=1", "udp4://10.0.0.1:6363")))
+	require.Equal(t, uint32(1), tu.NoErr(pw.AddInterface(pcapng.LinkTypeEthernet, "face=2", "")))
+
+	ts := time.UnixMicro(0x123456789a)
+	require.NoError(t, pw.WritePacket(1, ts, pcapng.DirOutbound, []byte{0x05, 0x03}, []byte{0x07, 0x01, 0x08}))
+
+	// split into blocks, each with matching leading and trailing lengths
+	data := buf.Bytes()
+	blocks := make([][]byte, 0)
+	for len(data) > 0 {
+		require.GreaterOrEqual(t, len(data), 12)
+		total := binary.LittleEndian.Uint32(data[4:8])
+		require.Zero(t, total%4)
+		require.GreaterOrEqual(t, uint32(len(data)), total)
+		require.Equal(t, total, binary.LittleEndian.Uint32(data[total-4:total]))
+		blocks = append(blocks, data[:total])
+		data = data[total:]
+	}
+	require.Len(t, blocks, 4)
+
+	// section header
+	shb := blocks[0]
+	require.Equal(t, uint32(0x0a0d0d0a), binary.LittleEndian.Uint32(shb[0:4]))
+	require.Equal(t, uint32(0x1a2b3c4d), binary.LittleEndian.Uint32(shb[8:12]))
+	require.Equal(t, uint16(1), binary.LittleEndian.Uint16(shb[12:14]))
+	require.Contains(t, string(shb), "test")
+
+	// interfaces
+	idb := blocks[1]
+	require.Equal(t, uint32(1), binary.LittleEndian.Uint32(idb[0:4]))
+	require.Equal(t, pcapng.LinkTypeEthernet, binary.LittleEndian.Uint16(idb[8:10]))
+	require.Contains(t, string(idb), "face=1")
+	require.Contains(t, string(idb), "udp4://10.0.0.1:6363")
+	require.Equal(t, uint32(1), binary.LittleEndian.Uint32(blocks[2][0:4]))
+
+	// packet
+	epb := blocks[3]
+	require.Equal(t, uint32(6), binary.LittleEndian.Uint32(epb[0:4]))
+	require.Equal(t, uint32(1), binary.LittleEndian.Uint32(epb[8:12]))
+	require.Equal(t, uint32(0x12), binary.LittleEndian.Uint32(epb[12:16]))
+	require.Equal(t, uint32(0x3456789a), binary.LittleEndian.Uint32(epb[16:20]))
+	require.Equal(t, uint32(5), binary.LittleEndian.Uint32(epb[20:24]))
+	require.Equal(t, uint32(5), binary.LittleEndian.Uint32(epb[24:28]))
+	require.Equal(t, []byte{0x05, 0x03, 0x07, 0x01, 0x08, 0, 0, 0}, epb[28:36])
+
+	// epb_flags option with the direction, then end of options
+	require.Equal(t, []byte{0x02, 0x00, 0x04, 0x00, 0x02, 0x00, 0x00, 0x00}, epb[36:44])
+	require.Equal(t, []byte{0x00, 0x00, 0x00, 0x00}, epb[44:48])
+	require.Len(t, epb, 52)
+}
@@ -36,11 +36,21 @@ func Cmds() []*cobra.Command {
 		Run: cmd("faces", "create", []string{
 			"persistency=persistent",
 		}),
+	}, {
+		Use:   "face-update [params]",
+		Short: "Update a face",
+		Args:  cobra.ArbitraryArgs,
+		Run:   cmd("faces", "update", []string{}),
 	}, {
 		Use:   "face-destroy [params]",
 		Short: "Destroy a face",
 		Args:  cobra.ArbitraryArgs,
 		Run:   cmd("faces", "destroy", []string{}),
+	}, {
+		Use:   "face-events",
+		Short: "Print face events as they occur",
+		Args:  cobra.NoArgs,
+		Run:   t.ExecFaceEvents,
 	}, {
 		Use:   "route-list",
 		Short: "Print RIB routes",
@@ -70,11 +80,31 @@ func Cmds() []*cobra.Command {
 		Short: "Print content store info",
 		Args:  cobra.NoArgs,
 		Run:   t.ExecCsInfo,
+	}, {
+		Use:   "cs-audit-status",
+		Short: "Print CS audit status counters",
+		Args:  cobra.NoArgs,
+		Run:   t.ExecCsAuditStatus,
+	}, {
+		Use:   "cs-audit-config [interval=DURATION] [log=on|off] [seu=on|off] [seu-rate=RATE] [seu-prefix=/prefix]",
+		Short: "Print or update runtime CS audit and SEU settings",
+		Args:  cobra.ArbitraryArgs,
+		Run:   t.ExecCsAuditConfig,
 	}, {
 		Use:   "cs-audit-agg [/prefix]",
 		Short: "Query CS audit aggregated tag by prefix (empty = root)",
 		Args:  cobra.RangeArgs(0, 1),
 		Run:   t.ExecCsAuditAgg,
+	}, {
+		Use:   "cs-audit-sig [/prefix]",
+		Short: "Query CS audit homomorphic tag aggregate by prefix (empty = root)",
+		Args:  cobra.RangeArgs(0, 1),
+		Run:   t.ExecCsAuditSig,
+	}, {
+		Use:   "cs-audit-pubkey",
+		Short: "Print the CS audit public key (bls12-381 scheme only)",
+		Args:  cobra.NoArgs,
+		Run:   t.ExecCsAuditPubkey,
 	}, {
 		Use:   "cs-audit-leaf /name",
 		Short: "Query CS audit leaf tag by exact name",
@@ -85,6 +115,46 @@ func Cmds() []*cobra.Command {
 		Short: "Flip 1 random bit in a cached packet (debug only)",
 		Args:  cobra.ExactArgs(1),
 		Run:   t.ExecCsAuditFlip,
+	}, {
+		Use:   "cs-audit-round K [seed-hex]",
+		Short: "Run a sampled CS audit round over K entries per thread (0 = all) and print its result",
+		Args:  cobra.RangeArgs(1, 2),
+		Run:   t.ExecCsAuditRound,
+	}, {
+		Use:   "cs-audit-sample-stats",
+		Short: "Print sampled CS audit statistics and detection probability",
+		Args:  cobra.NoArgs,
+		Run:   t.ExecCsAuditSampleStats,
+	}, {
+		Use:   "cs-audit-history",
+		Short: "Print recorded CS audit outcomes",
+		Args:  cobra.NoArgs,
+		Run:   t.ExecCsAuditHistory,
+	}, {
+		Use:   "cs-audit-challenge /node [/prefix] [keychain=URI key=NAME]",
+		Short: "Send a signed remote CS audit challenge to a node",
+		Args:  cobra.RangeArgs(1, 4),
+		Run:   t.ExecCsAuditChallenge,
+	}, {
+		Use:   "cs-audit-aggregates [/prefix] [depth=N] [node=/node] [keychain=URI key=NAME]",
+		Short: "Print CSNAT prefix aggregates of the local or a remote node",
+		Args:  cobra.ArbitraryArgs,
+		Run:   t.ExecCsAuditAggregates,
+	}, {
+		Use:   "cs-audit-compare TOPOLOGY [/prefix] [depth=N] [nodes=a,b,c] [node-prefix=/minindn] [keychain=URI key=NAME] [producer-key=HEX]",
+		Short: "Compare CSNAT aggregates across the nodes of an e2e topology",
+		Args:  cobra.MinimumNArgs(1),
+		Run:   t.ExecCsAuditCompare,
+	}, {
+		Use:   "capture start|stop|status [params]",
+		Short: "Start, stop or print the packet capture",
+		Args:  cobra.MinimumNArgs(1),
+		Run:   t.ExecCapture,
+	}, {
+		Use:   "config-reload",
+		Short: "Reload the forwarder configuration file",
+		Args:  cobra.NoArgs,
+		Run:   cmd("config", "reload", []string{}),
 	}, {
 		Use:   "strategy-list",
 		Short: "Print strategy choices",
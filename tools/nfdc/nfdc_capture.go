@@ -0,0 +1,67 @@
+package nfdc
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	"github.com/spf13/cobra"
+)
+
+// Names of the packet types of a capture filter
+var capturePktTypes = []struct {
+	name string
+	bit  uint64
+}{
+	{"interest", mgmt.CapturePktInterest},
+	{"data", mgmt.CapturePktData},
+	{"nack", mgmt.CapturePktNack},
+}
+
+// ExecCapture starts, stops or prints the packet capture of the forwarder.
+func (t *Tool) ExecCapture(c *cobra.Command, args []string) {
+	switch verb := args[0]; verb {
+	case "start":
+		t.ExecCmd(c, "capture", verb, args[1:], []string{})
+	case "stop", "status":
+		if len(args) > 1 {
+			fmt.Fprintf(os.Stderr, "capture %s takes no arguments\n", verb)
+			os.Exit(9)
+			return
+		}
+		t.ExecCmd(c, "capture", verb, nil, []string{})
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown capture command: %s (should be start, stop or status)\n", verb)
+		os.Exit(9)
+	}
+}
+
+// parseCaptureTypes converts a comma-separated list of packet types to a bitmask.
+func parseCaptureTypes(val string) (uint64, error) {
+	types := uint64(0)
+	for _, name := range strings.Split(val, ",") {
+		found := false
+		for _, t := range capturePktTypes {
+			if t.name == name {
+				types |= t.bit
+				found = true
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("unknown packet type: %s", name)
+		}
+	}
+	return types, nil
+}
+
+// formatCaptureTypes converts a bitmask of packet types to a comma-separated list.
+func formatCaptureTypes(types uint64) string {
+	names := make([]string, 0, len(capturePktTypes))
+	for _, t := range capturePktTypes {
+		if types&t.bit != 0 {
+			names = append(names, t.name)
+		}
+	}
+	return strings.Join(names, ",")
+}
@@ -1,6 +1,8 @@
 package nfdc
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"sort"
@@ -114,10 +116,26 @@ func (n *Tool) preprocessArg(
 				faceArgs.Mtu = ctrlArgs.Mtu
 				ctrlArgs.Mtu.Unset()
 			}
+			if ctrlArgs.EgressRate.IsSet() {
+				faceArgs.EgressRate = ctrlArgs.EgressRate
+				faceArgs.EgressBurst = ctrlArgs.EgressBurst
+				ctrlArgs.EgressRate.Unset()
+				ctrlArgs.EgressBurst.Unset()
+			}
+			if ctrlArgs.CertHash != nil {
+				faceArgs.CertHash = ctrlArgs.CertHash
+				ctrlArgs.CertHash = nil
+			}
 			if ctrlArgs.FacePersistency.IsSet() {
 				faceArgs.FacePersistency = ctrlArgs.FacePersistency
 				ctrlArgs.FacePersistency.Unset()
 			}
+			if ctrlArgs.Mask.IsSet() {
+				faceArgs.Flags = ctrlArgs.Flags
+				faceArgs.Mask = ctrlArgs.Mask
+				ctrlArgs.Flags.Unset()
+				ctrlArgs.Mask.Unset()
+			}
 
 			// create or use existing face
 			raw, execErr := n.engine.ExecMgmtCmd("faces", "create", &faceArgs)
@@ -177,6 +195,17 @@ func (n *Tool) convCmdArg(ctrlArgs *mgmt.ControlArgs, key string, val string) {
 		ctrlArgs.LocalUri = optional.Some(val)
 	case "mtu":
 		ctrlArgs.Mtu = optional.Some(parseUint(val))
+	case "rate":
+		ctrlArgs.EgressRate = optional.Some(parseUint(val))
+	case "burst":
+		ctrlArgs.EgressBurst = optional.Some(parseUint(val))
+	case "cert-hash":
+		hash, err := hex.DecodeString(val)
+		if err != nil || len(hash) != sha256.Size {
+			fmt.Fprintf(os.Stderr, "Invalid value for %s: %s (should be a hex SHA-256 digest)\n", key, val)
+			os.Exit(9)
+		}
+		ctrlArgs.CertHash = hash
 	case "persistency":
 		persistency, err := mgmt.ParsePersistency(val)
 		if err != nil {
@@ -184,6 +213,23 @@ func (n *Tool) convCmdArg(ctrlArgs *mgmt.ControlArgs, key string, val string) {
 			os.Exit(9)
 		}
 		ctrlArgs.FacePersistency = optional.Some(uint64(persistency))
+	case "reliability":
+		var on bool
+		switch val {
+		case "on", "true", "1":
+			on = true
+		case "off", "false", "0":
+			on = false
+		default:
+			fmt.Fprintf(os.Stderr, "Invalid value for %s: %s (should be on or off)\n", key, val)
+			os.Exit(9)
+		}
+		ctrlArgs.Mask = optional.Some(ctrlArgs.Mask.GetOr(0) | mgmt.FaceFlagLpReliabilityEnabled)
+		if on {
+			ctrlArgs.Flags = optional.Some(ctrlArgs.Flags.GetOr(0) | mgmt.FaceFlagLpReliabilityEnabled)
+		} else {
+			ctrlArgs.Flags = optional.Some(ctrlArgs.Flags.GetOr(0) &^ mgmt.FaceFlagLpReliabilityEnabled)
+		}
 
 	// route arguments
 	case "prefix":
@@ -195,6 +241,17 @@ func (n *Tool) convCmdArg(ctrlArgs *mgmt.ControlArgs, key string, val string) {
 	case "expires":
 		ctrlArgs.ExpirationPeriod = optional.Some(parseUint(val))
 
+	// capture arguments
+	case "file":
+		ctrlArgs.Uri = optional.Some(val)
+	case "type":
+		types, err := parseCaptureTypes(val)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid value for %s: %s (should be interest, data or nack)\n", key, val)
+			os.Exit(9)
+		}
+		ctrlArgs.PacketTypes = optional.Some(types)
+
 	// strategy arguments
 	case "strategy":
 		ctrlArgs.Strategy = &mgmt.Strategy{Name: parseName(val)}
@@ -232,6 +289,8 @@ func (n *Tool) printCtrlResponse(res *mgmt.ControlResponse) {
 			val = mgmt.Persistency(val.(uint64)).String()
 		case "Origin":
 			val = mgmt.RouteOrigin(val.(uint64)).String()
+		case "PacketTypes":
+			val = formatCaptureTypes(val.(uint64))
 		}
 
 		fmt.Printf("  %s=%v\n", key, val)
@@ -1,11 +1,25 @@
 package nfdc
 
 import (
+	"bytes"
+	"crypto/rand"
 	"encoding/hex"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/ndn"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	"github.com/named-data/ndnd/std/object"
+	"github.com/named-data/ndnd/std/object/storage"
+	sec "github.com/named-data/ndnd/std/security"
+	"github.com/named-data/ndnd/std/security/keychain"
+	"github.com/named-data/ndnd/std/security/signer"
+	"github.com/named-data/ndnd/std/types/optional"
+	"github.com/named-data/ndnd/std/utils/toolutils"
 	"github.com/spf13/cobra"
 )
 
@@ -102,3 +116,498 @@ func (t *Tool) ExecCsAuditFlip(_ *cobra.Command, args []string) {
 	// 中文说明：flip 返回的是一段可读字符串，直接打印即可。
 	fmt.Printf("%s\n", string(data.Join()))
 }
+
+// cs-audit-sig [/prefix]
+func (t *Tool) ExecCsAuditSig(_ *cobra.Command, args []string) {
+	t.Start()
+	defer t.Stop()
+
+	var prefix enc.Name
+	if len(args) == 1 {
+		var err error
+		prefix, err = enc.NameFromStr(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid prefix: %+v\n", err)
+			os.Exit(1)
+			return
+		}
+	} else {
+		prefix = enc.Name{}
+	}
+
+	suffix := enc.Name{
+		enc.NewGenericComponent("cs-audit"),
+		enc.NewGenericComponent("sig"),
+	}.Append(prefix...).
+		// 中文说明：同 agg，追加 "_" 避免 prefix 末尾的版本组件被覆盖。
+		Append(enc.NewGenericComponent("_"))
+
+	data, err := t.fetchStatusDataset(suffix)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching cs-audit sig: %+v\n", err)
+		os.Exit(1)
+		return
+	}
+
+	fmt.Printf("%s\n", hex.EncodeToString(data.Join()))
+}
+
+// cs-audit-pubkey
+func (t *Tool) ExecCsAuditPubkey(_ *cobra.Command, _ []string) {
+	t.Start()
+	defer t.Stop()
+
+	suffix := enc.Name{
+		enc.NewGenericComponent("cs-audit"),
+		enc.NewGenericComponent("pubkey"),
+	}
+
+	data, err := t.fetchStatusDataset(suffix)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching cs-audit pubkey: %+v\n", err)
+		os.Exit(1)
+		return
+	}
+
+	fmt.Printf("%s\n", hex.EncodeToString(data.Join()))
+}
+
+// cs-audit-challenge /node [/prefix] [keychain=URI key=NAME]
+func (t *Tool) ExecCsAuditChallenge(_ *cobra.Command, args []string) {
+	node, err := enc.NameFromStr(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid node prefix: %+v\n", err)
+		os.Exit(1)
+		return
+	}
+
+	var prefix enc.Name
+	var kcUri, keyName string
+	for _, arg := range args[1:] {
+		key, val, ok := strings.Cut(arg, "=")
+		switch {
+		case !ok && prefix == nil:
+			prefix, err = enc.NameFromStr(arg)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid prefix: %+v\n", err)
+				os.Exit(1)
+				return
+			}
+		case key == "keychain":
+			kcUri = val
+		case key == "key":
+			keyName = val
+		default:
+			fmt.Fprintf(os.Stderr, "Invalid argument: %s\n", arg)
+			os.Exit(9)
+			return
+		}
+	}
+
+	sgn, err := csAuditRequestSigner(kcUri, keyName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to load signing key: %+v\n", err)
+		os.Exit(1)
+		return
+	}
+
+	t.Start()
+	defer t.Stop()
+
+	nonce := make([]byte, 16)
+	rand.Read(nonce)
+
+	name := node.
+		Append(enc.NewGenericComponent("cs-audit")).
+		Append(enc.NewGenericComponent("challenge")).
+		Append(prefix...).
+		Append(enc.NewGenericBytesComponent(nonce))
+
+	res := t.expressCsAuditRequest(name, nonce, sgn)
+	if res.Result != ndn.InterestResultData {
+		fmt.Fprintf(os.Stderr, "Challenge failed: %s %+v\n", res.Result, res.Error)
+		os.Exit(1)
+		return
+	}
+
+	proof, err := mgmt.ParseCsAuditProof(enc.NewWireView(res.Data.Content()), true)
+	if err != nil || proof.Nonce == nil {
+		if rerr := csAuditRejection(res.Data); rerr != nil {
+			fmt.Fprintf(os.Stderr, "Challenge rejected: %+v\n", rerr)
+		} else {
+			fmt.Fprintf(os.Stderr, "Error parsing challenge proof: %+v\n", err)
+		}
+		os.Exit(1)
+		return
+	}
+	if !bytes.Equal(proof.Nonce, nonce) {
+		fmt.Fprintf(os.Stderr, "Challenge proof nonce mismatch\n")
+		os.Exit(1)
+		return
+	}
+
+	p := toolutils.StatusPrinter{File: os.Stdout, Padding: 14}
+	fmt.Println("CS audit proof:")
+	p.Print("prefix", proof.Prefix)
+	p.Print("nonce", hex.EncodeToString(proof.Nonce))
+	p.Print("time", time.UnixMilli(int64(proof.Timestamp)).Format(time.RFC3339Nano))
+	p.Print("scheme", proof.Scheme)
+	p.Print("leafCount", proof.LeafCount)
+	p.Print("invalidCount", proof.InvalidCount)
+	p.Print("aggregate", hex.EncodeToString(proof.Aggregate))
+	p.Print("signature", hex.EncodeToString(proof.ProofSignature))
+	p.Print("signedBy", csAuditSignedBy(res.Data))
+}
+
+// csAuditRequestSigner 返回远程审计请求的签名密钥。
+//
+// 中文说明：节点按 auditors 信任模式验证请求的签名；未指定密钥时使用 SHA-256 摘要签名，
+// 只有配置为 insecure 的节点会接受。
+func csAuditRequestSigner(kcUri string, keyName string) (ndn.Signer, error) {
+	if kcUri == "" && keyName == "" {
+		return signer.NewSha256Signer(), nil
+	}
+	return loadSigner(kcUri, keyName)
+}
+
+// expressCsAuditRequest 以 signed Interest 发送远程审计请求并等待响应。
+//
+// 中文说明：随机 nonce 同时放在名字末尾与 SignatureNonce 中，节点会拒绝重放的 nonce。
+func (t *Tool) expressCsAuditRequest(name enc.Name, nonce []byte, sgn ndn.Signer) ndn.ExpressCallbackArgs {
+	ch := make(chan ndn.ExpressCallbackArgs, 1)
+	object.ExpressR(t.engine, ndn.ExpressRArgs{
+		Name: name,
+		Config: &ndn.InterestConfig{
+			MustBeFresh: true,
+			Lifetime:    optional.Some(4 * time.Second),
+			SigNonce:    nonce,
+			SigTime:     optional.Some(time.Duration(time.Now().UnixMilli()) * time.Millisecond),
+		},
+		AppParam: enc.Wire{},
+		Signer:   sgn,
+		Callback: func(args ndn.ExpressCallbackArgs) { ch <- args },
+	})
+	return <-ch
+}
+
+// csAuditRejection 在节点拒绝远程请求（响应为 ControlResponse）时返回错误。
+func csAuditRejection(data ndn.Data) error {
+	cr, err := mgmt.ParseControlResponse(enc.NewWireView(data.Content()), true)
+	if err != nil || cr.Val == nil || cr.Val.StatusCode == 200 {
+		return nil
+	}
+	return fmt.Errorf("%d %s", cr.Val.StatusCode, cr.Val.StatusText)
+}
+
+// csAuditSignedBy 返回响应 Data 的签名密钥名（摘要签名时为签名类型）。
+func csAuditSignedBy(data ndn.Data) string {
+	if kl := data.Signature().KeyName(); kl != nil {
+		return kl.String()
+	}
+	return data.Signature().SigType().String()
+}
+
+// cs-audit-round K [seed-hex]
+func (t *Tool) ExecCsAuditRound(_ *cobra.Command, args []string) {
+	t.Start()
+	defer t.Stop()
+
+	k, err := strconv.ParseUint(args[0], 10, 32)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid sample size: %+v\n", err)
+		os.Exit(1)
+		return
+	}
+
+	// 中文说明：未指定种子时随机生成；指定种子可复现被抽中的条目集合。
+	seed := make([]byte, 32)
+	if len(args) == 2 {
+		seed, err = hex.DecodeString(args[1])
+		if err != nil || len(seed) != 32 {
+			fmt.Fprintf(os.Stderr, "Invalid seed: must be 64 hex chars\n")
+			os.Exit(1)
+			return
+		}
+	} else {
+		rand.Read(seed)
+	}
+
+	suffix := enc.Name{
+		enc.NewGenericComponent("cs-audit"),
+		enc.NewGenericComponent("round"),
+		enc.NewGenericComponent(strconv.FormatUint(k, 10)),
+		enc.NewGenericBytesComponent(seed),
+	}
+
+	data, err := t.fetchStatusDataset(suffix)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error requesting cs-audit round: %+v\n", err)
+		os.Exit(1)
+		return
+	}
+
+	res, err := mgmt.ParseCsAuditRoundResult(enc.NewWireView(data), true)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing cs-audit round result: %+v\n", err)
+		os.Exit(1)
+		return
+	}
+
+	p := toolutils.StatusPrinter{File: os.Stdout, Padding: 16}
+	fmt.Println("CS audit round:")
+	p.Print("seed", hex.EncodeToString(res.Seed))
+	p.Print("k", res.SampleK)
+	p.Print("mode", res.SampleMode)
+	p.Print("nThreads", res.NThreads)
+	p.Print("nFinished", res.NFinished)
+	p.Print("nOverruns", res.NOverruns)
+	p.Print("total", res.Total)
+	p.Print("sampled", res.Sampled)
+	p.Print("checked", res.Checked)
+	p.Print("mismatched", res.Mismatched)
+	p.Print("detectProb", res.DetectProb)
+}
+
+// fetchCsAuditStatus 获取 CsAuditStatus 数据集（失败时退出）。
+func (t *Tool) fetchCsAuditStatus() *mgmt.CsAuditStatus {
+	suffix := enc.Name{
+		enc.NewGenericComponent("cs-audit"),
+		enc.NewGenericComponent("status"),
+	}
+
+	data, err := t.fetchStatusDataset(suffix)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching status dataset: %+v\n", err)
+		os.Exit(1)
+		return nil
+	}
+
+	status, err := mgmt.ParseCsAuditStatus(enc.NewWireView(data), true)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing cs-audit status: %+v\n", err)
+		os.Exit(1)
+		return nil
+	}
+	return status
+}
+
+// cs-audit-sample-stats
+func (t *Tool) ExecCsAuditSampleStats(_ *cobra.Command, _ []string) {
+	t.Start()
+	defer t.Stop()
+
+	status := t.fetchCsAuditStatus()
+
+	p := toolutils.StatusPrinter{File: os.Stdout, Padding: 16}
+	fmt.Println("CS audit sampling:")
+	p.Print("nRounds", status.NRounds)
+	p.Print("nRoundOverruns", status.NRoundOverruns)
+	p.Print("lastTotal", status.LastTotal)
+	p.Print("lastSampled", status.LastSampled)
+	p.Print("lastMismatched", status.LastMismatched)
+	p.Print("detectProb", status.DetectProb)
+}
+
+// cs-audit-history
+func (t *Tool) ExecCsAuditHistory(_ *cobra.Command, _ []string) {
+	t.Start()
+	defer t.Stop()
+
+	suffix := enc.Name{
+		enc.NewGenericComponent("cs-audit"),
+		enc.NewGenericComponent("history"),
+	}
+
+	data, err := t.fetchStatusDataset(suffix)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching cs-audit history: %+v\n", err)
+		os.Exit(1)
+		return
+	}
+
+	hist, err := mgmt.ParseCsAuditHistoryMsg(enc.NewWireView(data), true)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing cs-audit history: %+v\n", err)
+		os.Exit(1)
+		return
+	}
+
+	for _, e := range hist.Entries {
+		info := []string{
+			time.UnixMilli(int64(e.Timestamp)).Format(time.RFC3339Nano),
+			fmt.Sprintf("event=%s", e.Event),
+		}
+		if len(e.Name) > 0 {
+			info = append(info, fmt.Sprintf("name=%s", e.Name))
+		}
+		info = append(info, fmt.Sprintf("checked=%d", e.Checked))
+		info = append(info, fmt.Sprintf("mismatched=%d", e.Mismatched))
+		fmt.Printf("%s\n", strings.Join(info, " "))
+	}
+}
+
+// cs-audit-status
+func (t *Tool) ExecCsAuditStatus(_ *cobra.Command, _ []string) {
+	t.Start()
+	defer t.Stop()
+
+	status := t.fetchCsAuditStatus()
+
+	p := toolutils.StatusPrinter{File: os.Stdout, Padding: 24}
+	fmt.Println("CS audit status:")
+	p.Print("scheme", status.Scheme)
+	p.Print("nCsNatNodes", status.NCsNatNodes)
+	p.Print("nCsNatLeaves", status.NCsNatLeaves)
+	p.Print("nRounds", status.NRounds)
+	p.Print("nRoundOverruns", status.NRoundOverruns)
+	p.Print("nRemoteChallenges", status.NRemoteChallenges)
+	p.Print("nChecked", status.NChecked)
+	p.Print("nProofsVerified", status.NProofsVerified)
+	p.Print("nMismatches", status.NMismatches)
+	p.Print("nUnknownProofs", status.NUnknownProofs)
+	p.Print("nCorruptEvicted", status.NCorruptEvicted)
+	p.Print("nSeuInjections", status.NSeuInjections)
+	p.Print("nFlips", status.NFlips)
+	p.Print("nEventsDropped", status.NEventsDropped)
+	p.Print("nProofsDropped", status.NProofsDropped)
+	p.Print("nProducerTagsRejected", status.NProducerTagsRejected)
+	p.Print("nRepairs", status.NRepairs)
+	p.Print("nRepairsSucceeded", status.NRepairsSucceeded)
+	p.Print("nRepairsRejected", status.NRepairsRejected)
+	p.Print("nRepairsTimedOut", status.NRepairsTimedOut)
+	p.Print("lastTotal", status.LastTotal)
+	p.Print("lastSampled", status.LastSampled)
+	p.Print("lastMismatched", status.LastMismatched)
+	p.Print("detectProb", status.DetectProb)
+}
+
+// cs-audit-config [key=value...]
+func (t *Tool) ExecCsAuditConfig(_ *cobra.Command, args []string) {
+	t.Start()
+	defer t.Stop()
+
+	// 中文说明：不带参数时只查询当前配置；interval=0 暂停定时挑战。
+	params := mgmt.CsAuditConfig{}
+	setFlag := func(bit uint64, val string) {
+		var on bool
+		switch val {
+		case "on", "true", "1":
+			on = true
+		case "off", "false", "0":
+			on = false
+		default:
+			fmt.Fprintf(os.Stderr, "Invalid flag value: %s (should be on or off)\n", val)
+			os.Exit(9)
+		}
+		params.Mask = optional.Some(params.Mask.GetOr(0) | bit)
+		if on {
+			params.Flags = optional.Some(params.Flags.GetOr(0) | bit)
+		} else {
+			params.Flags = optional.Some(params.Flags.GetOr(0))
+		}
+	}
+
+	for _, arg := range args {
+		kv := strings.SplitN(arg, "=", 2)
+		if len(kv) != 2 {
+			fmt.Fprintf(os.Stderr, "Invalid argument: %s (should be key=value)\n", arg)
+			os.Exit(9)
+			return
+		}
+
+		switch key, val := kv[0], kv[1]; key {
+		case "interval":
+			d, err := time.ParseDuration(val)
+			if err != nil || d < 0 {
+				fmt.Fprintf(os.Stderr, "Invalid interval: %s\n", val)
+				os.Exit(9)
+				return
+			}
+			params.Interval = optional.Some(uint64(d.Milliseconds()))
+		case "log":
+			setFlag(mgmt.CsAuditEnableLog, val)
+		case "seu":
+			setFlag(mgmt.CsAuditEnableSeu, val)
+		case "seu-rate":
+			if rate, err := strconv.ParseFloat(val, 64); err != nil || !(rate > 0) {
+				fmt.Fprintf(os.Stderr, "Invalid SEU rate: %s\n", val)
+				os.Exit(9)
+				return
+			}
+			params.SeuRate = optional.Some(val)
+		case "seu-prefix":
+			if _, err := enc.NameFromStr(val); err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid SEU prefix: %s\n", val)
+				os.Exit(9)
+				return
+			}
+			params.SeuPrefix = optional.Some(val)
+		default:
+			fmt.Fprintf(os.Stderr, "Unknown argument: %s\n", key)
+			os.Exit(9)
+			return
+		}
+	}
+
+	suffix := enc.Name{
+		enc.NewGenericComponent("cs-audit"),
+		enc.NewGenericComponent("config"),
+	}
+	if len(args) > 0 {
+		suffix = append(suffix, enc.NewGenericBytesComponent(params.Encode().Join()))
+	}
+
+	data, err := t.fetchStatusDataset(suffix)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error updating cs-audit config: %+v\n", err)
+		os.Exit(1)
+		return
+	}
+
+	cfg, err := mgmt.ParseCsAuditConfig(enc.NewWireView(data), true)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing cs-audit config: %+v\n", err)
+		os.Exit(1)
+		return
+	}
+
+	flags := cfg.Flags.GetOr(0)
+	p := toolutils.StatusPrinter{File: os.Stdout, Padding: 12}
+	fmt.Println("CS audit config:")
+	p.Print("interval", time.Duration(cfg.Interval.GetOr(0))*time.Millisecond)
+	p.Print("log", flags&mgmt.CsAuditEnableLog != 0)
+	p.Print("seu", flags&mgmt.CsAuditEnableSeu != 0)
+	p.Print("seu-rate", cfg.SeuRate.GetOr(""))
+	p.Print("seu-prefix", cfg.SeuPrefix.GetOr(""))
+}
+
+// loadSigner returns the signer of a key in a keychain.
+// If the name is an identity, the first key of the identity is used.
+func loadSigner(kcUri string, keyName string) (ndn.Signer, error) {
+	if kcUri == "" || keyName == "" {
+		return nil, fmt.Errorf("both keychain and key must be specified")
+	}
+
+	name, err := enc.NameFromStr(keyName)
+	if err != nil {
+		return nil, err
+	}
+	kc, err := keychain.NewKeyChain(kcUri, storage.NewMemoryStore())
+	if err != nil {
+		return nil, err
+	}
+
+	if id := kc.IdentityByName(name); id != nil && len(id.Keys()) > 0 {
+		return id.Keys()[0].Signer(), nil
+	}
+	if idName, err := sec.GetIdentityFromKeyName(name); err == nil {
+		if id := kc.IdentityByName(idName); id != nil {
+			for _, key := range id.Keys() {
+				if key.KeyName().Equal(name) {
+					return key.Signer(), nil
+				}
+			}
+		}
+	}
+	return nil, fmt.Errorf("key %s not found in keychain %s", name, kcUri)
+}
@@ -0,0 +1,482 @@
+package nfdc
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/ndn"
+	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	"github.com/named-data/ndnd/std/object"
+	"github.com/named-data/ndnd/std/security/audit"
+	"github.com/named-data/ndnd/std/types/optional"
+	"github.com/named-data/ndnd/std/utils/toolutils"
+	"github.com/spf13/cobra"
+)
+
+// 中文说明：多节点 CSNAT 聚合值比较（路径审计）。
+//
+// - cs-audit-aggregates 获取一个节点的前缀聚合数据集：不指定 node 时查询本机，否则以 signed Interest 查询 /<node>/cs-audit/aggregates。
+// - cs-audit-compare 从 e2e 拓扑文件（Mini-NDN 格式）的 [nodes] 节读取节点名，逐个获取 /<node-prefix>/<节点名> 的数据集，
+//   对每个前缀比较缓存了它的各节点的 agg，并把各节点的同态聚合标签相加得到整条路径的组合证明：
+//   hmac-sha256 为异或，bls12-381 为 G1 点加法。
+// - 各节点可以合法地缓存前缀下不同的内容，因此只有同一个 Data 名字（叶子）在多个节点上的 agg 不同才算不一致；
+//   上层前缀只报告各节点是否相同。
+// - 指定 producer-key（bls12-381 生产者公钥）时，审计者获取各叶子的 Data，校验其生产者标签，
+//   并用 audit.BlsVerifyAggregate 验证组合证明；列表未覆盖前缀下所有叶子时无法验证。
+
+// csAuditAggregatesArgs 是两个命令共用的参数。
+type csAuditAggregatesArgs struct {
+	prefix      enc.Name
+	depth       uint64
+	node        enc.Name
+	nodes       []string
+	nodePrefix  enc.Name
+	kcUri       string
+	keyName     string
+	producerKey *audit.BlsPublicKey
+}
+
+func parseCsAuditAggregatesArgs(args []string) csAuditAggregatesArgs {
+	parsed := csAuditAggregatesArgs{
+		prefix:     enc.Name{},
+		depth:      1,
+		nodePrefix: enc.Name{enc.NewGenericComponent("minindn")},
+	}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "/") {
+			prefix, err := enc.NameFromStr(arg)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid prefix: %s\n", arg)
+				os.Exit(9)
+			}
+			parsed.prefix = prefix
+			continue
+		}
+
+		kv := strings.SplitN(arg, "=", 2)
+		if len(kv) != 2 {
+			fmt.Fprintf(os.Stderr, "Invalid argument: %s (should be key=value)\n", arg)
+			os.Exit(9)
+		}
+		switch key, val := kv[0], kv[1]; key {
+		case "depth":
+			depth, err := strconv.ParseUint(val, 10, 32)
+			if err != nil || depth > 8 {
+				fmt.Fprintf(os.Stderr, "Invalid depth: %s (should be 0-8)\n", val)
+				os.Exit(9)
+			}
+			parsed.depth = depth
+		case "node", "node-prefix":
+			name, err := enc.NameFromStr(val)
+			if err != nil || len(name) == 0 {
+				fmt.Fprintf(os.Stderr, "Invalid %s: %s\n", key, val)
+				os.Exit(9)
+			}
+			if key == "node" {
+				parsed.node = name
+			} else {
+				parsed.nodePrefix = name
+			}
+		case "nodes":
+			parsed.nodes = strings.Split(val, ",")
+		case "keychain":
+			parsed.kcUri = val
+		case "key":
+			parsed.keyName = val
+		case "producer-key":
+			b, err := hex.DecodeString(val)
+			if err == nil {
+				parsed.producerKey, err = audit.ParseBlsPublicKey(b)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid producer-key: %+v\n", err)
+				os.Exit(9)
+			}
+		default:
+			fmt.Fprintf(os.Stderr, "Unknown argument key: %s\n", key)
+			os.Exit(9)
+		}
+	}
+	return parsed
+}
+
+// fetchCsNatAggregates 获取节点 node 的前缀聚合数据集；node 为空时查询本机。
+// 远程请求以 sgn 签名，返回响应 Data 的签名者。
+func (t *Tool) fetchCsNatAggregates(node enc.Name, prefix enc.Name, depth uint64, sgn ndn.Signer) (
+	msg *mgmt.CsNatAggregateMsg, signedBy string, err error,
+) {
+	if len(node) == 0 {
+		name := t.Prefix().
+			Append(enc.NewGenericComponent("cs-audit")).
+			Append(enc.NewGenericComponent("aggregates")).
+			Append(enc.NewGenericComponent(strconv.FormatUint(depth, 10))).
+			Append(prefix...).
+			// 中文说明：追加 "_"，避免 WithVersion() 覆盖 prefix 末尾的版本组件。
+			Append(enc.NewGenericComponent("_"))
+
+		data, err := t.fetchDataset(name)
+		if err != nil {
+			return nil, "", err
+		}
+		msg, err = mgmt.ParseCsNatAggregateMsg(enc.NewWireView(data), true)
+		return msg, "", err
+	}
+
+	nonce := make([]byte, 16)
+	rand.Read(nonce)
+	name := node.
+		Append(enc.NewGenericComponent("cs-audit")).
+		Append(enc.NewGenericComponent("aggregates")).
+		Append(enc.NewGenericComponent(strconv.FormatUint(depth, 10))).
+		Append(prefix...).
+		Append(enc.NewGenericBytesComponent(nonce))
+
+	res := t.expressCsAuditRequest(name, nonce, sgn)
+	if res.Result != ndn.InterestResultData {
+		return nil, "", fmt.Errorf("%s %v", res.Result, res.Error)
+	}
+	if err := csAuditRejection(res.Data); err != nil {
+		return nil, "", fmt.Errorf("rejected: %w", err)
+	}
+	msg, err = mgmt.ParseCsNatAggregateMsg(enc.NewWireView(res.Data.Content()), true)
+	if err != nil {
+		return nil, "", err
+	}
+	if !bytes.Equal(msg.Nonce, nonce) {
+		return nil, "", fmt.Errorf("response nonce mismatch")
+	}
+	return msg, csAuditSignedBy(res.Data), nil
+}
+
+// cs-audit-aggregates [/prefix] [depth=N] [node=/node] [keychain=URI key=NAME]
+func (t *Tool) ExecCsAuditAggregates(_ *cobra.Command, args []string) {
+	parsed := parseCsAuditAggregatesArgs(args)
+	sgn, err := csAuditRequestSigner(parsed.kcUri, parsed.keyName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to load signing key: %+v\n", err)
+		os.Exit(1)
+		return
+	}
+
+	t.Start()
+	defer t.Stop()
+
+	msg, signedBy, err := t.fetchCsNatAggregates(parsed.node, parsed.prefix, parsed.depth, sgn)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching cs-audit aggregates: %+v\n", err)
+		os.Exit(1)
+		return
+	}
+
+	p := toolutils.StatusPrinter{File: os.Stdout, Padding: 10}
+	fmt.Println("CSNAT aggregates:")
+	p.Print("scheme", msg.Scheme)
+	if len(msg.PublicKey) > 0 {
+		p.Print("pubkey", hex.EncodeToString(msg.PublicKey))
+	}
+	if signedBy != "" {
+		p.Print("signedBy", signedBy)
+	}
+	if msg.Truncated {
+		p.Print("truncated", true)
+	}
+	for _, a := range msg.Aggregates {
+		fmt.Printf("  %s leafCount=%d cached=%d agg=%s tagAgg=%s\n",
+			a.Name, a.LeafCount, a.Cached, hex.EncodeToString(a.Agg), hex.EncodeToString(a.TagAgg))
+	}
+}
+
+// cs-audit-compare TOPOLOGY [/prefix] [depth=N] [nodes=a,b,c] [node-prefix=/minindn] [keychain=URI key=NAME] [producer-key=HEX]
+func (t *Tool) ExecCsAuditCompare(_ *cobra.Command, args []string) {
+	parsed := parseCsAuditAggregatesArgs(args[1:])
+	nodes, err := readTopologyNodes(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading topology: %+v\n", err)
+		os.Exit(1)
+		return
+	}
+	// 中文说明：nodes=a,b,c 只比较拓扑中的一条路径（按给定顺序输出）。
+	if len(parsed.nodes) > 0 {
+		for _, node := range parsed.nodes {
+			if !slices.Contains(nodes, node) {
+				fmt.Fprintf(os.Stderr, "Node %s is not in %s\n", node, args[0])
+				os.Exit(9)
+				return
+			}
+		}
+		nodes = parsed.nodes
+	}
+	sgn, err := csAuditRequestSigner(parsed.kcUri, parsed.keyName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to load signing key: %+v\n", err)
+		os.Exit(1)
+		return
+	}
+
+	t.Start()
+	defer t.Stop()
+
+	// 获取各节点的数据集；获取失败的节点不参与比较
+	msgs := make(map[string]*mgmt.CsNatAggregateMsg)
+	scheme := ""
+	failed := 0
+	for _, node := range nodes {
+		nodeName := parsed.nodePrefix.Append(enc.NewGenericComponent(node))
+		msg, signedBy, err := t.fetchCsNatAggregates(nodeName, parsed.prefix, parsed.depth, sgn)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error fetching aggregates from %s: %+v\n", nodeName, err)
+			failed++
+			continue
+		}
+		if scheme == "" {
+			scheme = msg.Scheme
+		} else if msg.Scheme != scheme {
+			fmt.Fprintf(os.Stderr, "Node %s uses scheme %s instead of %s, skipped\n", node, msg.Scheme, scheme)
+			failed++
+			continue
+		}
+		if msg.Truncated {
+			fmt.Fprintf(os.Stderr, "Aggregates of %s are truncated, use a longer prefix or smaller depth\n", node)
+		}
+		fmt.Printf("%-12s signedBy=%s\n", node, signedBy)
+		msgs[node] = msg
+	}
+
+	// 所有节点上出现过的前缀（按名字规范顺序）
+	prefixes := []enc.Name{}
+	byNode := make(map[string]map[string]*mgmt.CsNatAggregate)
+	seen := make(map[string]bool)
+	for node, msg := range msgs {
+		byNode[node] = make(map[string]*mgmt.CsNatAggregate)
+		for _, a := range msg.Aggregates {
+			key := a.Name.String()
+			if !seen[key] {
+				seen[key] = true
+				prefixes = append(prefixes, a.Name)
+			}
+			byNode[node][key] = a
+		}
+	}
+	slices.SortFunc(prefixes, func(a, b enc.Name) int { return a.Compare(b) })
+
+	verify := parsed.producerKey != nil && scheme == "bls12-381"
+	if parsed.producerKey != nil && !verify {
+		fmt.Fprintf(os.Stderr, "producer-key requires the bls12-381 scheme, not verifying\n")
+	}
+	digests := make(map[string][32]byte)
+
+	mismatched, unverified := 0, 0
+	for _, prefix := range prefixes {
+		key := prefix.String()
+		var ref []byte
+		var tags [][]byte
+		holders := []string{}
+		same, leaf := true, true
+
+		fmt.Printf("%s\n", prefix)
+		for _, node := range nodes {
+			if _, ok := msgs[node]; !ok {
+				continue
+			}
+			a, ok := byNode[node][key]
+			if !ok {
+				fmt.Printf("  %-12s (absent)\n", node)
+				continue
+			}
+			holders = append(holders, node)
+			tags = append(tags, a.TagAgg)
+			if ref == nil {
+				ref = a.Agg
+			} else if !bytes.Equal(ref, a.Agg) {
+				same = false
+			}
+			leaf = leaf && a.Cached > 0 && a.Cached == a.LeafCount
+			fmt.Printf("  %-12s leafCount=%d agg=%s\n", node, a.LeafCount, hex.EncodeToString(a.Agg))
+		}
+
+		result := fmt.Sprintf("nodes=%d/%d", len(holders), len(msgs))
+		switch {
+		case len(holders) < 2:
+			// 只有一个节点缓存了该前缀，没有可比较的对象
+		case leaf:
+			result += fmt.Sprintf(" match=%t", same)
+			if !same {
+				mismatched++
+			}
+		default:
+			result += fmt.Sprintf(" same=%t", same)
+		}
+
+		combined, err := combineCsAuditTags(scheme, tags)
+		if err != nil {
+			fmt.Printf("  %s combined=(%v)\n", result, err)
+			unverified++
+			continue
+		}
+		result += " combined=" + hex.EncodeToString(combined)
+
+		if verify {
+			ok, err := t.verifyCsAuditCombined(parsed.producerKey, prefix, holders, byNode, combined, digests)
+			if err != nil {
+				result += fmt.Sprintf(" verified=(%v)", err)
+				unverified++
+			} else {
+				result += fmt.Sprintf(" verified=%t", ok)
+				if !ok {
+					unverified++
+				}
+			}
+		}
+		fmt.Printf("  %s\n", result)
+	}
+
+	p := toolutils.StatusPrinter{File: os.Stdout, Padding: 12}
+	fmt.Println("Summary:")
+	p.Print("scheme", scheme)
+	p.Print("nodes", len(msgs))
+	p.Print("failed", failed)
+	p.Print("prefixes", len(prefixes))
+	p.Print("mismatched", mismatched)
+	if verify {
+		p.Print("unverified", unverified)
+	}
+
+	if failed > 0 || mismatched > 0 || (verify && unverified > 0) {
+		os.Exit(1)
+	}
+}
+
+// verifyCsAuditCombined 用生产者公钥验证 prefix 在 holders 上的组合证明。
+//
+// 中文说明：每个节点在 prefix 下的叶子必须全部出现在列表中（叶子数与 cached 之和一致），
+// 否则无法得到所有叶子的 TagDigest。同一个名字在一个节点上缓存多次时按次数计入。
+func (t *Tool) verifyCsAuditCombined(
+	pk *audit.BlsPublicKey,
+	prefix enc.Name,
+	holders []string,
+	byNode map[string]map[string]*mgmt.CsNatAggregate,
+	combined []byte,
+	digests map[string][32]byte,
+) (bool, error) {
+	var pks []*audit.BlsPublicKey
+	var ds [][32]byte
+	for _, node := range holders {
+		total := byNode[node][prefix.String()].LeafCount
+		listed := uint64(0)
+		for _, a := range byNode[node] {
+			if a.Cached == 0 || !prefix.IsPrefix(a.Name) {
+				continue
+			}
+			digest, ok := digests[a.Name.String()]
+			if !ok {
+				var err error
+				if digest, err = t.fetchCsAuditLeafDigest(pk, a.Name); err != nil {
+					return false, err
+				}
+				digests[a.Name.String()] = digest
+			}
+			for range a.Cached {
+				pks = append(pks, pk)
+				ds = append(ds, digest)
+			}
+			listed += a.Cached
+		}
+		if listed != total {
+			return false, fmt.Errorf("%s has leaves below the listed depth", node)
+		}
+	}
+	return audit.BlsVerifyAggregate(pks, ds, combined), nil
+}
+
+// fetchCsAuditLeafDigest 获取名字为 name 的 Data，校验其生产者标签并返回 TagDigest。
+func (t *Tool) fetchCsAuditLeafDigest(pk *audit.BlsPublicKey, name enc.Name) ([32]byte, error) {
+	ch := make(chan ndn.ExpressCallbackArgs, 1)
+	object.ExpressR(t.engine, ndn.ExpressRArgs{
+		Name: name,
+		Config: &ndn.InterestConfig{
+			Lifetime: optional.Some(4 * time.Second),
+		},
+		Retries:  2,
+		Callback: func(args ndn.ExpressCallbackArgs) { ch <- args },
+	})
+	res := <-ch
+	if res.Result != ndn.InterestResultData {
+		return [32]byte{}, fmt.Errorf("fetch %s: %s", name, res.Result)
+	}
+
+	covered, tag, err := audit.SplitTag(res.RawData.Join())
+	if err != nil || tag == nil {
+		return [32]byte{}, fmt.Errorf("%s has no producer audit tag", name)
+	}
+	digest := audit.TagDigest(name, covered)
+	if !audit.BlsVerify(pk, digest, tag.TagValue) {
+		return [32]byte{}, fmt.Errorf("%s is not tagged by the producer key", name)
+	}
+	return digest, nil
+}
+
+// combineCsAuditTags 把各节点的同态聚合标签相加（与 forwarder 中 CsAuditTagScheme.Add 相同）。
+func combineCsAuditTags(scheme string, tags [][]byte) ([]byte, error) {
+	switch scheme {
+	case "hmac-sha256":
+		out := make([]byte, 32)
+		for _, tag := range tags {
+			if len(tag) != len(out) {
+				return nil, fmt.Errorf("hmac-sha256 audit tag must be %d bytes", len(out))
+			}
+			for i := range out {
+				out[i] ^= tag[i]
+			}
+		}
+		return out, nil
+	case "bls12-381":
+		return audit.BlsAggregate(tags...)
+	default:
+		return nil, fmt.Errorf("unknown scheme %q", scheme)
+	}
+}
+
+// readTopologyNodes 读取 Mini-NDN 拓扑文件 [nodes] 节中的节点名（按文件中的顺序）。
+func readTopologyNodes(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var nodes []string
+	section := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = line[1 : len(line)-1]
+			continue
+		}
+		if section != "nodes" {
+			continue
+		}
+		if node, _, ok := strings.Cut(line, ":"); ok && strings.TrimSpace(node) != "" {
+			nodes = append(nodes, strings.TrimSpace(node))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("no nodes in %s", path)
+	}
+	return nodes, nil
+}
@@ -8,6 +8,12 @@ import (
 
 // (AI GENERATED DESCRIPTION): Fetches and returns the raw wire representation of a Data packet identified by the supplied suffix, using a consume‑only client to retrieve the content or report an error.
 func (t *Tool) fetchStatusDataset(suffix enc.Name) (enc.Wire, error) {
+	return t.fetchDataset(t.Prefix().Append(suffix...))
+}
+
+// fetchDataset fetches a segmented dataset with the given name prefix (without version),
+// which may also be served by a remote forwarder.
+func (t *Tool) fetchDataset(name enc.Name) (enc.Wire, error) {
 	// consume-only client, no need for a store
 	client := object.NewClient(t.engine, nil, nil)
 	client.Start()
@@ -15,7 +21,7 @@ func (t *Tool) fetchStatusDataset(suffix enc.Name) (enc.Wire, error) {
 
 	ch := make(chan ndn.ConsumeState)
 	client.ConsumeExt(ndn.ConsumeExtArgs{
-		Name:       t.Prefix().Append(suffix...),
+		Name:       name,
 		NoMetadata: true, // NFD has no RDR metadata
 		Callback:   func(status ndn.ConsumeState) { ch <- status },
 	})
@@ -7,7 +7,10 @@ import (
 	"time"
 
 	enc "github.com/named-data/ndnd/std/encoding"
+	"github.com/named-data/ndnd/std/ndn"
 	mgmt "github.com/named-data/ndnd/std/ndn/mgmt_2022"
+	"github.com/named-data/ndnd/std/object"
+	"github.com/named-data/ndnd/std/types/optional"
 	"github.com/spf13/cobra"
 )
 
@@ -57,12 +60,137 @@ func (t *Tool) ExecFaceList(_ *cobra.Command, args []string) {
 			info = append(info, fmt.Sprintf("mtu=%dB", mtu))
 		}
 
+		if rate, ok := entry.EgressRate.Get(); ok {
+			info = append(info, fmt.Sprintf("shaping={rate=%dbps burst=%dB}", rate, entry.EgressBurst.GetOr(0)))
+		}
+
 		info = append(info, fmt.Sprintf("counters={in={%di %dd %dn %dB} out={%di %dd %dn %dB}}",
 			entry.NInInterests, entry.NInData, entry.NInNacks, entry.NInBytes,
 			entry.NOutInterests, entry.NOutData, entry.NOutNacks, entry.NOutBytes))
 
+		lp := []string{}
+		if n := entry.NInLpInvalid.GetOr(0); n > 0 {
+			lp = append(lp, fmt.Sprintf("invalid=%d", n))
+		}
+		if n := entry.NReassemblyTimeouts.GetOr(0); n > 0 {
+			lp = append(lp, fmt.Sprintf("reassembly-timeouts=%d", n))
+		}
+		if n := entry.NReassemblyEvictions.GetOr(0); n > 0 {
+			lp = append(lp, fmt.Sprintf("reassembly-evictions=%d", n))
+		}
+		if len(lp) > 0 {
+			info = append(info, fmt.Sprintf("lp={%s}", strings.Join(lp, " ")))
+		}
+
+		queue := []string{}
+		if n := entry.NOutShaped.GetOr(0); n > 0 {
+			queue = append(queue, fmt.Sprintf("shaped=%d", n))
+		}
+		if n := entry.NOutDropped.GetOr(0); n > 0 {
+			queue = append(queue, fmt.Sprintf("dropped=%d", n))
+		}
+		if len(queue) > 0 {
+			info = append(info, fmt.Sprintf("queue={%s}", strings.Join(queue, " ")))
+		}
+
 		flags := []string{}
 		flags = append(flags, strings.ToLower(mgmt.Persistency(entry.FacePersistency).String()))
+		if entry.Flags&mgmt.FaceFlagLpReliabilityEnabled != 0 {
+			flags = append(flags, "reliability")
+		}
+		info = append(info, fmt.Sprintf("flags={%s}", strings.Join(flags, " ")))
+
+		fmt.Printf("%s\n", strings.Join(info, " "))
+	}
+}
+
+// ExecFaceEvents follows the face event notification stream and prints each event.
+func (t *Tool) ExecFaceEvents(_ *cobra.Command, _ []string) {
+	t.Start()
+	defer t.Stop()
+
+	prefix := t.Prefix().
+		Append(enc.NewGenericComponent("faces")).
+		Append(enc.NewGenericComponent("events"))
+
+	// The first Interest fetches the next notification, subsequent
+	// Interests follow the stream by sequence number.
+	var nextSeq optional.Optional[uint64]
+
+	for {
+		name := prefix
+		config := &ndn.InterestConfig{
+			CanBePrefix: true,
+			MustBeFresh: true,
+			Lifetime:    optional.Some(60 * time.Second),
+		}
+		if seq, ok := nextSeq.Get(); ok {
+			name = prefix.Append(enc.NewSequenceNumComponent(seq))
+			config = &ndn.InterestConfig{
+				Lifetime: optional.Some(60 * time.Second),
+			}
+		}
+
+		ch := make(chan ndn.ExpressCallbackArgs, 1)
+		object.ExpressR(t.engine, ndn.ExpressRArgs{
+			Name:     name,
+			Config:   config,
+			Callback: func(args ndn.ExpressCallbackArgs) { ch <- args },
+		})
+
+		res := <-ch
+		switch res.Result {
+		case ndn.InterestResultData:
+		case ndn.InterestResultTimeout:
+			continue // no events
+		case ndn.InterestResultNack:
+			time.Sleep(time.Second)
+			continue
+		default:
+			fmt.Fprintf(os.Stderr, "Error fetching face event: %s %+v\n", res.Result, res.Error)
+			os.Exit(1)
+			return
+		}
+
+		seqComp := res.Data.Name().At(-1)
+		if !seqComp.IsSequenceNum() {
+			fmt.Fprintf(os.Stderr, "Invalid face event name: %s\n", res.Data.Name())
+			os.Exit(1)
+			return
+		}
+		nextSeq = optional.Some(seqComp.NumberVal() + 1)
+
+		notif, err := mgmt.ParseFaceEventNotification(enc.NewWireView(res.Data.Content()), true)
+		if err != nil || notif.Val == nil {
+			fmt.Fprintf(os.Stderr, "Error parsing face event: %+v\n", err)
+			continue
+		}
+		event := notif.Val
+
+		kind := "unknown"
+		switch event.FaceEventKind {
+		case mgmt.FaceEventCreated:
+			kind = "created"
+		case mgmt.FaceEventDestroyed:
+			kind = "destroyed"
+		case mgmt.FaceEventUp:
+			kind = "up"
+		case mgmt.FaceEventDown:
+			kind = "down"
+		}
+
+		info := []string{}
+		info = append(info, fmt.Sprintf("seq=%d", seqComp.NumberVal()))
+		info = append(info, fmt.Sprintf("kind=%s", kind))
+		info = append(info, fmt.Sprintf("faceid=%d", event.FaceId))
+		info = append(info, fmt.Sprintf("remote=%s", event.Uri))
+		info = append(info, fmt.Sprintf("local=%s", event.LocalUri))
+
+		flags := []string{}
+		flags = append(flags, strings.ToLower(mgmt.Persistency(event.FacePersistency).String()))
+		if event.Flags&mgmt.FaceFlagLpReliabilityEnabled != 0 {
+			flags = append(flags, "reliability")
+		}
 		info = append(info, fmt.Sprintf("flags={%s}", strings.Join(flags, " ")))
 
 		fmt.Printf("%s\n", strings.Join(info, " "))
@@ -1,6 +1,7 @@
 package tools
 
 import (
+	"encoding/hex"
 	"io"
 	"os"
 	"os/signal"
@@ -12,11 +13,13 @@ import (
 	"github.com/named-data/ndnd/std/ndn"
 	"github.com/named-data/ndnd/std/object"
 	"github.com/named-data/ndnd/std/object/storage"
+	"github.com/named-data/ndnd/std/security/audit"
 	"github.com/spf13/cobra"
 )
 
 type PutChunks struct {
-	expose bool
+	expose   bool
+	auditKey string
 }
 
 // (AI GENERATED DESCRIPTION): Creates a Cobra command that publishes data chunks read from standard input under a specified name prefix, optionally registering the prefix with the client origin.
@@ -35,6 +38,7 @@ This tool expects data from the standard input.`,
 	}
 
 	cmd.Flags().BoolVar(&pc.expose, "expose", false, "Use client origin for prefix registration")
+	cmd.Flags().StringVar(&pc.auditKey, "audit-key", "", "Attach cache audit tags using this BLS secret key (64 hex chars)")
 	return cmd
 }
 
@@ -51,6 +55,23 @@ func (pc *PutChunks) run(_ *cobra.Command, args []string) {
 		return
 	}
 
+	// parse audit key before doing anything else
+	var tagger ndn.AuditTagger
+	if pc.auditKey != "" {
+		skBytes, err := hex.DecodeString(pc.auditKey)
+		if err != nil {
+			log.Fatal(pc, "Invalid audit key", "err", err)
+			return
+		}
+		sk, err := audit.ParseBlsSecretKey(skBytes)
+		if err != nil {
+			log.Fatal(pc, "Invalid audit key", "err", err)
+			return
+		}
+		tagger = audit.NewTagger(sk)
+		log.Info(pc, "Attaching cache audit tags", "pubkey", hex.EncodeToString(sk.Public().Bytes()))
+	}
+
 	// start face and engine
 	app := engine.NewBasicEngine(engine.NewDefaultFace())
 	err = app.Start()
@@ -84,8 +105,9 @@ func (pc *PutChunks) run(_ *cobra.Command, args []string) {
 
 	// produce object
 	vname, err := cli.Produce(ndn.ProduceArgs{
-		Name:    name.WithVersion(enc.VersionUnixMicro),
-		Content: content,
+		Name:        name.WithVersion(enc.VersionUnixMicro),
+		Content:     content,
+		AuditTagger: tagger,
 	})
 	if err != nil {
 		log.Fatal(pc, "Unable to produce object", "err", err)